@@ -2,15 +2,18 @@ package bridge
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math/big"
 	"path/filepath"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/crypto"
 	nodeutils "github.com/statechannels/go-nitro/internal/node"
-	"github.com/statechannels/go-nitro/internal/safesync"
 	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
 	p2pms "github.com/statechannels/go-nitro/node/engine/messageservice/p2p-message-service"
 	"github.com/statechannels/go-nitro/node/query"
 	"github.com/statechannels/go-nitro/protocols/bridgeddefund"
@@ -20,6 +23,7 @@ import (
 
 	"github.com/statechannels/go-nitro/node/engine/chainservice"
 	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/node/notifier"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
 )
@@ -29,18 +33,12 @@ const (
 	L2_DURABLE_STORE_SUB_DIR = "l2-node"
 )
 
-const RETRY_TX_LIMIT = 1
-
-type SentTx struct {
-	Tx                  protocols.ChainTransaction `json:"tx"`
-	NumOfRetries        uint                       `json:"num_of_retries"`
-	IsRetryLimitReached bool                       `json:"is_retry_limit_reached"`
-	IsL2                bool                       `json:"is_l2"`
-}
-
+// PendingTx is a transaction of a bridged channel which is held in the outbox of the L1 or L2 chain service and has not been confirmed
 type PendingTx struct {
-	SentTx
+	Tx chainservice.OutboxTx `json:"tx"`
+	// TxHash is the hash of the latest submission of the transaction, or empty if it has not been accepted by the chain
 	TxHash string `json:"tx_hash"`
+	IsL2   bool   `json:"is_l2"`
 }
 
 type Bridge struct {
@@ -58,7 +56,6 @@ type Bridge struct {
 	cancel                context.CancelFunc
 	mirrorChannelMap      map[types.Destination]MirrorChannelDetails
 	createdMirrorChannels chan types.Destination
-	sentTxs               safesync.Map[SentTx]
 }
 
 type BridgeConfig struct {
@@ -76,6 +73,15 @@ type BridgeConfig struct {
 	NodeL2ExtMultiAddr string
 	NodeL1MsgPort      int
 	NodeL2MsgPort      int
+
+	LaconicdUrl         string
+	LaconicdPK          string
+	LaconicdGasLimit    uint64
+	LaconicdGasPrice    *big.Int
+	LaconicdDenom       string
+	LaconicdStartHeight uint64
+	// LaconicdClient, if set, is used by node L2 instead of connecting to LaconicdUrl
+	LaconicdClient chainservice.LaconicdClient
 }
 
 func New() *Bridge {
@@ -88,9 +94,25 @@ func New() *Bridge {
 }
 
 func (b *Bridge) Start(configOpts BridgeConfig) (nodeL1 *node.Node, nodeL2 *node.Node, nodeL1MultiAddress string, nodeL2MultiAddress string, err error) {
+	laconicdClient := configOpts.LaconicdClient
+	if laconicdClient == nil {
+		laconicdClient, err = chainservice.NewCometLaconicdClient(context.Background(), chainservice.CometLaconicdClientOpts{
+			Url:       configOpts.LaconicdUrl,
+			AccountPk: common.Hex2Bytes(configOpts.LaconicdPK),
+			GasLimit:  configOpts.LaconicdGasLimit,
+			GasPrice:  configOpts.LaconicdGasPrice,
+			Denom:     configOpts.LaconicdDenom,
+		})
+		if err != nil {
+			return nil, nil, nodeL1MultiAddress, nodeL2MultiAddress, err
+		}
+	}
+
 	chainOptsL2 := chainservice.LaconicdChainOpts{
-		VpaAddress: common.HexToAddress(configOpts.VpaAddress),
-		CaAddress:  common.HexToAddress(configOpts.CaAddress),
+		Client:      laconicdClient,
+		StartHeight: configOpts.LaconicdStartHeight,
+		VpaAddress:  common.HexToAddress(configOpts.VpaAddress),
+		CaAddress:   common.HexToAddress(configOpts.CaAddress),
 	}
 
 	chainOptsL1 := chainservice.ChainOpts{
@@ -130,13 +152,18 @@ func (b *Bridge) Start(configOpts BridgeConfig) (nodeL1 *node.Node, nodeL2 *node
 		ExtMultiAddr: configOpts.NodeL2ExtMultiAddr,
 	}
 
+	signer, err := crypto.NewKeySigner(common.Hex2Bytes(configOpts.StateChannelPK))
+	if err != nil {
+		return nil, nil, nodeL1MultiAddress, nodeL2MultiAddress, err
+	}
+
 	// Initialize nodes
-	nodeL1, storeL1, msgServiceL1, chainServiceL1, err := nodeutils.InitializeNode(chainOptsL1, storeOptsL1, messageOptsL1, &NodeL1PermissivePolicy{})
+	nodeL1, storeL1, msgServiceL1, chainServiceL1, err := nodeutils.InitializeNode(chainOptsL1, storeOptsL1, messageOptsL1, signer, &NodeL1PermissivePolicy{}, engine.EngineOpts{})
 	if err != nil {
 		return nil, nil, nodeL1MultiAddress, nodeL2MultiAddress, err
 	}
 
-	nodeL2, storeL2, msgServiceL2, chainServiceL2, err := nodeutils.InitializeL2Node(chainOptsL2, storeOptsL2, messageOptsL2, &NodeL2PermissivePolicy{})
+	nodeL2, storeL2, msgServiceL2, chainServiceL2, err := nodeutils.InitializeL2Node(chainOptsL2, storeOptsL2, messageOptsL2, signer, &NodeL2PermissivePolicy{}, engine.EngineOpts{})
 	if err != nil {
 		return nil, nil, nodeL1MultiAddress, nodeL2MultiAddress, err
 	}
@@ -159,30 +186,47 @@ func (b *Bridge) Start(configOpts BridgeConfig) (nodeL1 *node.Node, nodeL2 *node
 
 	b.bridgeStore = ds
 
-	go b.listenForDroppedEvents(ctx)
 	go b.run(ctx)
 
 	return nodeL1, nodeL2, msgServiceL1.MultiAddr, msgServiceL2.MultiAddr, nil
 }
 
 func (b *Bridge) run(ctx context.Context) {
-	completedObjectivesInNodeL1 := b.nodeL1.CompletedObjectives()
-	completedObjectivesInNodeL2 := b.nodeL2.CompletedObjectives()
+	// Should the bridge fall behind, its subscriptions are disconnected rather than dropping completed objectives silently
+	opts := notifier.SubscriptionOpts{Overflow: notifier.Disconnect}
+	completedObjectivesInNodeL1 := b.nodeL1.SubscribeCompletedObjectives(opts)
+	completedObjectivesInNodeL2 := b.nodeL2.SubscribeCompletedObjectives(opts)
+	defer func() {
+		completedObjectivesInNodeL1.Close()
+		completedObjectivesInNodeL2.Close()
+	}()
 
 	for {
 		var err error
 		select {
-		case objId, ok := <-completedObjectivesInNodeL1:
-			if ok {
-				err = b.processCompletedObjectivesFromL1(objId)
-				b.checkError(err)
+		case objId, ok := <-completedObjectivesInNodeL1.Updates():
+			if !ok {
+				if !errors.Is(completedObjectivesInNodeL1.Err(), notifier.ErrSubscriptionOverflow) {
+					return
+				}
+				slog.Error("bridge fell behind and missed completed objectives of the L1 node, resubscribing")
+				completedObjectivesInNodeL1 = b.nodeL1.SubscribeCompletedObjectives(opts)
+				continue
 			}
-
-		case objId, ok := <-completedObjectivesInNodeL2:
-			if ok {
-				err = b.processCompletedObjectivesFromL2(objId)
-				b.checkError(err)
+			err = b.processCompletedObjectivesFromL1(objId)
+			b.checkError(err)
+
+		case objId, ok := <-completedObjectivesInNodeL2.Updates():
+			if !ok {
+				if !errors.Is(completedObjectivesInNodeL2.Err(), notifier.ErrSubscriptionOverflow) {
+					return
+				}
+				slog.Error("bridge fell behind and missed completed objectives of the L2 node, resubscribing")
+				completedObjectivesInNodeL2 = b.nodeL2.SubscribeCompletedObjectives(opts)
+				continue
 			}
+			err = b.processCompletedObjectivesFromL2(objId)
+			b.checkError(err)
 
 		case <-ctx.Done():
 			return
@@ -275,13 +319,11 @@ func (b *Bridge) processCompletedObjectivesFromL2(objId protocols.ObjectiveId) e
 
 		// Node B calls contract method to store L2ChannelId => L1ChannelId
 		setL2ToL1TxToSubmit := protocols.NewSetL2ToL1Transaction(mirrorChannelDetails.L1ChannelId, l2channelId)
-		setL2ToL1Tx, err := b.chainServiceL1.SendTransaction(setL2ToL1TxToSubmit)
+		err = b.chainServiceL1.SubmitTransaction(objId, setL2ToL1TxToSubmit)
 		if err != nil {
 			return fmt.Errorf("error in send transaction %w", err)
 		}
 
-		b.sentTxs.Store(setL2ToL1Tx.Hash().String(), SentTx{setL2ToL1TxToSubmit, 0, false, false})
-
 		// use a nonblocking send in case no one is listening
 		select {
 		case b.createdMirrorChannels <- l2channelId:
@@ -374,44 +416,6 @@ func (b *Bridge) CreatedMirrorChannels() <-chan types.Destination {
 	return b.createdMirrorChannels
 }
 
-func (b *Bridge) RetryObjectiveTx(objectiveId protocols.ObjectiveId) error {
-	if bridgedfund.IsBridgedFundObjective(objectiveId) {
-		b.nodeL2.RetryObjectiveTx(objectiveId)
-		return nil
-	}
-	if directfund.IsDirectFundObjective(objectiveId) {
-		b.nodeL1.RetryObjectiveTx(objectiveId)
-		return nil
-	}
-	return fmt.Errorf("objective with given Id is not supported for retrying")
-}
-
-func (b *Bridge) RetryTx(txHash common.Hash) error {
-	var chainService chainservice.ChainService
-
-	txToRetry, ok := b.sentTxs.Load(txHash.String())
-	if !ok {
-		return fmt.Errorf("tx with given hash %s was either complete or cannot be found", txHash)
-	}
-
-	if !txToRetry.IsRetryLimitReached {
-		return fmt.Errorf("tx with given hash %s is pending confirmation and connot be retried", txHash)
-	}
-
-	if txToRetry.IsL2 {
-		chainService = b.chainServiceL2
-	} else {
-		chainService = b.chainServiceL1
-	}
-
-	_, err := chainService.SendTransaction(txToRetry.Tx)
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (b *Bridge) Close() error {
 	b.cancel()
 	err := b.nodeL1.Close()
@@ -433,62 +437,39 @@ func (b *Bridge) checkError(err error) {
 	}
 }
 
-func (b *Bridge) listenForDroppedEvents(ctx context.Context) {
-	var err error
-	for {
-		select {
-		case l1DroppedEvent := <-b.chainServiceL1.DroppedEventFeed():
-			err = b.checkAndRetryDroppedTxs(l1DroppedEvent, b.chainServiceL1, false)
-
-		case l2DroppedEvent := <-b.chainServiceL2.DroppedEventFeed():
-			err = b.checkAndRetryDroppedTxs(l2DroppedEvent, b.chainServiceL2, true)
-
-		case l1ConfirmedEvent := <-b.chainServiceL1.EventFeed():
-			b.sentTxs.Delete(l1ConfirmedEvent.TxHash().String())
-
-		case l2ConfirmedEvent := <-b.chainServiceL2.EventFeed():
-			b.sentTxs.Delete(l2ConfirmedEvent.TxHash().String())
-
-		case <-ctx.Done():
-			return
-		}
-
-		b.checkError(err)
-	}
-}
-
-func (b *Bridge) checkAndRetryDroppedTxs(droppedEvent protocols.DroppedEventInfo, chainservice chainservice.ChainService, isL2 bool) error {
-	txToRetry, ok := b.sentTxs.Load(droppedEvent.TxHash.String())
-	if !ok {
-		return nil
+// GetPendingBridgeTxs returns the transactions of the channel which are held in the outboxes of the L1 and L2 chain services
+// and have not been confirmed. Failed transactions are included, since they are not resubmitted.
+func (b *Bridge) GetPendingBridgeTxs(channelId types.Destination) ([]PendingTx, error) {
+	l1PendingTxs, err := pendingTxs(b.storeL1, channelId, false)
+	if err != nil {
+		return nil, err
 	}
-
-	if txToRetry.NumOfRetries >= RETRY_TX_LIMIT {
-		txToRetry.IsRetryLimitReached = true
-		b.sentTxs.Store(droppedEvent.TxHash.String(), txToRetry)
-		return nil
+	l2PendingTxs, err := pendingTxs(b.storeL2, channelId, true)
+	if err != nil {
+		return nil, err
 	}
+	return append(l1PendingTxs, l2PendingTxs...), nil
+}
 
-	retriedTx, err := chainservice.SendTransaction(txToRetry.Tx)
+// pendingTxs returns the transactions of the channel in the outbox of the store which have not been confirmed
+func pendingTxs(s store.Store, channelId types.Destination, isL2 bool) ([]PendingTx, error) {
+	txs, err := s.GetOutboxTxs()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	b.sentTxs.Delete(droppedEvent.TxHash.String())
-	b.sentTxs.Store(retriedTx.Hash().String(), SentTx{txToRetry.Tx, txToRetry.NumOfRetries + 1, false, isL2})
-	return nil
-}
-
-func (b *Bridge) GetPendingBridgeTxs(channelId types.Destination) []PendingTx {
-	var foundPendingTx []PendingTx
-	b.sentTxs.Range(func(txHash string, sentTxInfo SentTx) bool {
-		if sentTxInfo.Tx.ChannelId() == channelId {
-			foundPendingTx = append(foundPendingTx, PendingTx{sentTxInfo, txHash})
+	pending := []PendingTx{}
+	for _, o := range txs {
+		if o.Tx.ChannelId() != channelId || o.Status == chainservice.TxConfirmed {
+			continue
 		}
-		return true
-	})
-
-	return foundPendingTx
+		pendingTx := PendingTx{Tx: o, IsL2: isL2}
+		if len(o.TxHashes) > 0 {
+			pendingTx.TxHash = o.TxHashes[len(o.TxHashes)-1].String()
+		}
+		pending = append(pending, pendingTx)
+	}
+	return pending, nil
 }
 
 func (b *Bridge) GetNodeInfo() types.NodeInfo {
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/channel/state/outcome"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/node/engine/chainservice"
 	"github.com/statechannels/go-nitro/types"
 )
@@ -22,6 +24,24 @@ type OnChainData struct {
 	ChannelMode              ChannelMode
 }
 
+// Clone returns a deep copy of the receiver.
+func (d OnChainData) Clone() OnChainData {
+	clone := d
+	if d.Holdings != nil {
+		clone.Holdings = make(types.Funds, len(d.Holdings))
+		for asset, amount := range d.Holdings {
+			clone.Holdings[asset] = new(big.Int).Set(amount)
+		}
+	}
+	if d.Outcome != nil {
+		clone.Outcome = d.Outcome.Clone()
+	}
+	if d.FinalizesAt != nil {
+		clone.FinalizesAt = new(big.Int).Set(d.FinalizesAt)
+	}
+	return clone
+}
+
 type OffChainData struct {
 	SignedStateForTurnNum       map[uint64]state.SignedState // Longer term, we should have a more efficient and smart mechanism to store states https://github.com/statechannels/go-nitro/issues/106
 	LatestSupportedStateTurnNum uint64                       // largest uint64 value reserved for "no supported state"
@@ -38,6 +58,10 @@ type Channel struct {
 	OffChain OffChainData
 
 	LastChainUpdate ChainUpdateData
+
+	// ChainHistory holds the on chain data of the channel before each chain event from the latest chainservice.MAX_REORG_DEPTH blocks
+	// was applied, oldest first, so that the events can be undone should a reorg remove their blocks
+	ChainHistory []ChainSnapshot
 }
 
 type ChainUpdateData struct {
@@ -45,6 +69,13 @@ type ChainUpdateData struct {
 	TxIndex  uint
 }
 
+// ChainSnapshot is the on chain data of a channel before a chain event from the block BlockNum was applied to it
+type ChainSnapshot struct {
+	BlockNum        uint64
+	OnChain         OnChainData
+	LastChainUpdate ChainUpdateData
+}
+
 // isNewChainEvent returns true if the event has a greater block number (or equal blocknumber but with greater tx index) than prior chain events process by the receiver.
 func (c *Channel) isNewChainEvent(event chainservice.Event) bool {
 	return event.Block().BlockNum > c.LastChainUpdate.BlockNum ||
@@ -100,6 +131,8 @@ type jsonChannel struct {
 	OnChain  OnChainData
 	OffChain OffChainData
 	Type     types.ChannelType
+
+	ChainHistory []ChainSnapshot `json:",omitempty"`
 }
 
 // MarshalJSON returns a JSON representation of the Channel
@@ -111,6 +144,8 @@ func (c Channel) MarshalJSON() ([]byte, error) {
 		OffChain:  c.OffChain,
 		FixedPart: c.FixedPart,
 		Type:      c.Type,
+
+		ChainHistory: c.ChainHistory,
 	}
 	return json.Marshal(jsonCh)
 }
@@ -131,6 +166,7 @@ func (c *Channel) UnmarshalJSON(data []byte) error {
 
 	c.FixedPart = jsonCh.FixedPart
 	c.Type = jsonCh.Type
+	c.ChainHistory = jsonCh.ChainHistory
 
 	return nil
 }
@@ -156,6 +192,7 @@ func (c *Channel) Clone() *Channel {
 	d.OnChain.ChannelMode = c.OnChain.ChannelMode
 	d.OnChain.StateHash = c.OnChain.StateHash
 	d.OnChain.IsChallengeInitiatedByMe = c.OnChain.IsChallengeInitiatedByMe
+	d.ChainHistory = slices.Clone(c.ChainHistory)
 	return d
 }
 
@@ -330,18 +367,18 @@ func (c *Channel) AddSignedState(ss state.SignedState) bool {
 }
 
 // SignAndAddPrefund signs and adds the prefund state for the channel, returning a state.SignedState suitable for sending to peers.
-func (c *Channel) SignAndAddPrefund(sk *[]byte) (state.SignedState, error) {
-	return c.SignAndAddState(c.PreFundState(), sk)
+func (c *Channel) SignAndAddPrefund(signer crypto.Signer) (state.SignedState, error) {
+	return c.SignAndAddState(c.PreFundState(), signer)
 }
 
 // SignAndAddPrefund signs and adds the postfund state for the channel, returning a state.SignedState suitable for sending to peers.
-func (c *Channel) SignAndAddPostfund(sk *[]byte) (state.SignedState, error) {
-	return c.SignAndAddState(c.PostFundState(), sk)
+func (c *Channel) SignAndAddPostfund(signer crypto.Signer) (state.SignedState, error) {
+	return c.SignAndAddState(c.PostFundState(), signer)
 }
 
 // SignAndAddState signs and adds the state to the channel, returning a state.SignedState suitable for sending to peers.
-func (c *Channel) SignAndAddState(s state.State, sk *[]byte) (state.SignedState, error) {
-	sig, err := s.Sign(*sk)
+func (c *Channel) SignAndAddState(s state.State, signer crypto.Signer) (state.SignedState, error) {
+	sig, err := s.SignWith(signer)
 	if err != nil {
 		return state.SignedState{}, fmt.Errorf("could not sign prefund %w", err)
 	}
@@ -362,6 +399,8 @@ func (c *Channel) UpdateWithChainEvent(event chainservice.Event) (*Channel, erro
 	if !c.isNewChainEvent(event) {
 		return nil, fmt.Errorf("chain event older than channel's last update")
 	}
+	c.recordChainSnapshot(event.Block().BlockNum)
+
 	// Process event
 	switch e := event.(type) {
 	case chainservice.AllocationUpdatedEvent:
@@ -414,6 +453,29 @@ func (c *Channel) UpdateWithChainEvent(event chainservice.Event) (*Channel, erro
 	return c, nil
 }
 
+// recordChainSnapshot records the on chain data of the channel before a chain event from the given block is applied,
+// and forgets the snapshots from blocks too deep to be removed by a reorg
+func (c *Channel) recordChainSnapshot(blockNum uint64) {
+	c.ChainHistory = slices.DeleteFunc(c.ChainHistory, func(s ChainSnapshot) bool {
+		return s.BlockNum+chainservice.MAX_REORG_DEPTH < blockNum
+	})
+	c.ChainHistory = append(c.ChainHistory, ChainSnapshot{BlockNum: blockNum, OnChain: c.OnChain.Clone(), LastChainUpdate: c.LastChainUpdate})
+}
+
+// RollbackChainUpdates restores the on chain data of the channel to what it was at the given block,
+// undoing the chain events applied from later blocks after a reorg removed those blocks.
+// It returns false if no chain event from a later block has been applied.
+func (c *Channel) RollbackChainUpdates(blockNum uint64) bool {
+	i := slices.IndexFunc(c.ChainHistory, func(s ChainSnapshot) bool { return s.BlockNum > blockNum })
+	if i == -1 {
+		return false
+	}
+	c.OnChain = c.ChainHistory[i].OnChain.Clone()
+	c.LastChainUpdate = c.ChainHistory[i].LastChainUpdate
+	c.ChainHistory = c.ChainHistory[:i]
+	return true
+}
+
 // UpdateChannelMode update channel mode based on the channel FinalizesAt timestamp and latest block timestamp
 func (c *Channel) UpdateChannelMode(latestBlockTime uint64) {
 	if c.OnChain.FinalizesAt.Cmp(big.NewInt(0)) == 0 {
@@ -424,3 +424,69 @@ func TestSerde(t *testing.T) {
 		t.Fatalf("incorrect json unmarshaling (-want +got):\n%s", diff)
 	}
 }
+
+func TestRollbackChainUpdates(t *testing.T) {
+	c, err := New(state.TestState.Clone(), 0, types.Ledger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	asset := common.Address{}
+	deposit := func(blockNum uint64, nowHeld int64) {
+		t.Helper()
+		event := chainservice.NewDepositedEvent(c.Id, chainservice.Block{BlockNum: blockNum}, 0, asset, big.NewInt(nowHeld), common.Hash{})
+		if _, err := c.UpdateWithChainEvent(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkHoldings := func(want int64) {
+		t.Helper()
+		if got := c.OnChain.Holdings[asset]; got.Cmp(big.NewInt(want)) != 0 {
+			t.Fatalf("expected holdings of %d, got %s", want, got)
+		}
+	}
+
+	deposit(10, 1)
+	deposit(11, 3)
+	deposit(12, 6)
+
+	// The events after the common ancestor are undone
+	if !c.RollbackChainUpdates(10) {
+		t.Fatal("expected the events after block 10 to be rolled back")
+	}
+	checkHoldings(1)
+	if c.LastChainUpdate.BlockNum != 10 {
+		t.Fatalf("expected the last chain update to be at block 10, got %d", c.LastChainUpdate.BlockNum)
+	}
+
+	// The events of the canonical chain are applied from the common ancestor
+	deposit(11, 5)
+	checkHoldings(5)
+	if c.RollbackChainUpdates(11) {
+		t.Fatal("expected no event after block 11 to be rolled back")
+	}
+
+	// The history is persisted along with the channel
+	data, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c = &Channel{}
+	if err := json.Unmarshal(data, c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.RollbackChainUpdates(0) {
+		t.Fatal("expected every event to be rolled back")
+	}
+	checkHoldings(0)
+
+	// Events too deep to be removed by a reorg are forgotten
+	deposit(10, 1)
+	deposit(10+chainservice.MAX_REORG_DEPTH+1, 2)
+	if !c.RollbackChainUpdates(10) {
+		t.Fatal("expected the latest event to be rolled back")
+	}
+	checkHoldings(1)
+	if c.RollbackChainUpdates(0) {
+		t.Fatal("expected the event deeper than the maximum reorg depth to be final")
+	}
+}
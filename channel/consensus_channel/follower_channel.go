@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/types"
 )
 
@@ -25,7 +26,7 @@ func NewFollowerChannel(fp state.FixedPart, turnNum uint64, outcome LedgerOutcom
 // SignNextProposal is called by the follower and inspects whether the
 // expected proposal matches the first proposal in the queue. If so,
 // the proposal is removed from the queue and integrated into the channel state.
-func (c *ConsensusChannel) SignNextProposal(expectedProposal Proposal, sk []byte) (SignedProposal, error) {
+func (c *ConsensusChannel) SignNextProposal(expectedProposal Proposal, signer crypto.Signer) (SignedProposal, error) {
 	if c.MyIndex != Follower {
 		return SignedProposal{}, ErrNotFollower
 	}
@@ -54,7 +55,7 @@ func (c *ConsensusChannel) SignNextProposal(expectedProposal Proposal, sk []byte
 		return SignedProposal{}, err
 	}
 
-	signature, err := c.sign(vars, sk)
+	signature, err := c.sign(vars, signer)
 	if err != nil {
 		return SignedProposal{}, fmt.Errorf("unable to sign state update: %f", err)
 	}
@@ -95,7 +95,7 @@ func TestFollowerChannel(t *testing.T) {
 	amountAdded := uint64(5)
 	proposal := Proposal{LedgerID: channel.Id, ToAdd: add(amountAdded, targetChannel, alice, bob)}
 
-	_, err = channel.SignNextProposal(proposal, bob.PrivateKey)
+	_, err = channel.SignNextProposal(proposal, bob.Signer())
 	if !errors.Is(ErrNoProposals, err) {
 		t.Fatalf("expected %v, but got %v", ErrNoProposals, err)
 	}
@@ -108,12 +108,12 @@ func TestFollowerChannel(t *testing.T) {
 	channel.proposalQueue = []SignedProposal{signedProposal}
 	proposal2 := Proposal{LedgerID: channel.Id, ToAdd: add(amountAdded+1, targetChannel, alice, bob)}
 
-	_, err = channel.SignNextProposal(proposal2, bob.PrivateKey)
+	_, err = channel.SignNextProposal(proposal2, bob.Signer())
 	if !errors.Is(ErrNonMatchingProposals, err) {
 		t.Fatalf("expected %v, but got %v", ErrNonMatchingProposals, err)
 	}
 
-	withMySig, err := channel.SignNextProposal(proposal, bob.PrivateKey)
+	withMySig, err := channel.SignNextProposal(proposal, bob.Signer())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -147,7 +147,7 @@ func TestRestrictedLeaderMethods(t *testing.T) {
 
 	channel, _ := NewFollowerChannel(fp(), 0, ledgerOutcome(), sigs)
 
-	if _, err := channel.Propose(Proposal{ToAdd: Add{}}, alice.PrivateKey); err != ErrNotLeader {
+	if _, err := channel.Propose(Proposal{ToAdd: Add{}}, alice.Signer()); err != ErrNotLeader {
 		t.Errorf("Expected error when calling Propose() as a follower, but found none")
 	}
 
@@ -165,7 +165,7 @@ func TestFollowerIncorrectlyAddressedProposals(t *testing.T) {
 	leaderCh, _ := NewLeaderChannel(fp(), 0, ledgerOutcome(), sigs)
 	followerCh, _ := NewFollowerChannel(fp(), 0, ledgerOutcome(), sigs)
 
-	someProposal, _ := leaderCh.Propose(Proposal{ToAdd: add(1, types.Destination{}, alice, bob)}, alice.PrivateKey)
+	someProposal, _ := leaderCh.Propose(Proposal{ToAdd: add(1, types.Destination{}, alice, bob)}, alice.Signer())
 	someProposal.Proposal.LedgerID = types.Destination{} // alter the ChannelID so that it doesn't match
 
 	err := followerCh.Receive(someProposal)
@@ -174,7 +174,7 @@ func TestFollowerIncorrectlyAddressedProposals(t *testing.T) {
 		t.Fatalf("expected error receiving proposal with incorrect ChannelID, but found none")
 	}
 
-	_, err = followerCh.SignNextProposal(someProposal.Proposal, bob.PrivateKey)
+	_, err = followerCh.SignNextProposal(someProposal.Proposal, bob.Signer())
 
 	if err != ErrIncorrectChannelID {
 		t.Fatalf("expected error receiving proposal with incorrect ChannelID, but found none")
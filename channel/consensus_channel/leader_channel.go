@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/types"
 )
 
@@ -21,7 +22,7 @@ func NewLeaderChannel(fp state.FixedPart, turnNum uint64, outcome LedgerOutcomes
 // Propose is called by the Leader and receives a proposal to add or remove a guarantee,
 // and generates and stores a SignedProposal in the queue, returning the
 // resulting SignedProposal
-func (c *ConsensusChannel) Propose(proposal Proposal, sk []byte) (SignedProposal, error) {
+func (c *ConsensusChannel) Propose(proposal Proposal, signer crypto.Signer) (SignedProposal, error) {
 	if c.MyIndex != Leader {
 		return SignedProposal{}, ErrNotLeader
 	}
@@ -39,7 +40,7 @@ func (c *ConsensusChannel) Propose(proposal Proposal, sk []byte) (SignedProposal
 		return SignedProposal{}, fmt.Errorf("propose could not add new state vars: %w", err)
 	}
 
-	signature, err := c.sign(vars, sk)
+	signature, err := c.sign(vars, signer)
 	if err != nil {
 		return SignedProposal{}, fmt.Errorf("unable to sign state update: %f", err)
 	}
@@ -134,6 +134,15 @@ func (s State) Sign(secretKey []byte) (Signature, error) {
 	return nc.SignEthereumMessage(hash.Bytes(), secretKey)
 }
 
+// SignWith generates an ECDSA signature on the state using the supplied signer, as Sign does
+func (s State) SignWith(signer nc.Signer) (Signature, error) {
+	hash, error := s.Hash()
+	if error != nil {
+		return Signature{}, error
+	}
+	return nc.SignEthereumMessageWith(hash.Bytes(), signer)
+}
+
 // RecoverSigner computes the Ethereum address which generated Signature sig on State state
 func (s State) RecoverSigner(sig Signature) (types.Address, error) {
 	stateHash, error := s.Hash()
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/cmd/utils"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+)
+
+const (
+	CONFIG               = "config"
+	PK                   = "pk"
+	ADDRESS              = "address"
+	KEYSTORE_FILE        = "keystorefile"
+	DURABLE_STORE_FOLDER = "durablestorefolder"
+	STORE_ENCRYPTION_KEY = "storeencryptionkey"
+	STORE_KEY_FILE       = "storekeyfile"
+	STORE_PASSPHRASE     = "storepassphrase"
+)
+
+func main() {
+	var pkString, address, keystoreFile, durableStoreFolder, storeEncryptionKey, storeKeyFile, storePassphrase string
+
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  CONFIG,
+			Usage: "Load config options from `config.toml`",
+		},
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        PK,
+			Usage:       "Specifies the private key of the node that owns the store. Only used to derive the node's address.",
+			Destination: &pkString,
+			EnvVars:     []string{"SC_PK"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        ADDRESS,
+			Usage:       "Specifies the address of the node that owns the store, e.g. a node which signs with a remote signer. Takes precedence over the keystore file and private key.",
+			Destination: &address,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        KEYSTORE_FILE,
+			Usage:       "Specifies the keystore file of the node that owns the store. Only its address is read, so no passphrase is needed. Takes precedence over the private key.",
+			Destination: &keystoreFile,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        DURABLE_STORE_FOLDER,
+			Usage:       "Specifies the folder for the durable store data storage.",
+			Destination: &durableStoreFolder,
+			Value:       "./data/nitro-store",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_ENCRYPTION_KEY,
+			Usage:       "Specifies a hex encoded 32 byte key to encrypt the store with.",
+			Destination: &storeEncryptionKey,
+			EnvVars:     []string{"STORE_ENCRYPTION_KEY"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_KEY_FILE,
+			Usage:       "Specifies a file containing a hex encoded 32 byte key to encrypt the store with.",
+			Destination: &storeKeyFile,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_PASSPHRASE,
+			Usage:       "Specifies a passphrase from which the store encryption key is derived.",
+			Destination: &storePassphrase,
+			EnvVars:     []string{"STORE_PASSPHRASE"},
+		}),
+	}
+
+	app := &cli.App{
+		Name:   "encrypt-store",
+		Usage:  "Encrypts an existing durable store in place. The node must not be running. An interrupted run can be resumed by running it again.",
+		Flags:  flags,
+		Before: altsrc.InitInputSourceWithContext(flags, altsrc.NewTomlSourceFromFlagFunc(CONFIG)),
+		Action: func(cCtx *cli.Context) error {
+			me, err := utils.StoreAddress(common.Hex2Bytes(utils.TrimHexPrefix(pkString)), address, keystoreFile)
+			if err != nil {
+				return fmt.Errorf("the node that owns the store must be identified with --%s, --%s or --%s: %w", ADDRESS, KEYSTORE_FILE, PK, err)
+			}
+
+			enc, err := utils.StoreEncryptionOpts(storeEncryptionKey, storeKeyFile, storePassphrase)
+			if err != nil {
+				return err
+			}
+			if !enc.Enabled() {
+				return fmt.Errorf("an encryption key must be provided with --%s, --%s or --%s", STORE_ENCRYPTION_KEY, STORE_KEY_FILE, STORE_PASSPHRASE)
+			}
+
+			// Matches the folder used by store.NewStore
+			dataFolder := filepath.Join(durableStoreFolder, me.String())
+			if _, err := os.Stat(dataFolder); err != nil {
+				return fmt.Errorf("no durable store found at %s: %w", dataFolder, err)
+			}
+
+			count, err := store.EncryptDurableStore(me, dataFolder, enc)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Encrypted %d records of the store at %s\n", count, dataFolder)
+			return nil
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/cmd/utils"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+)
+
+const (
+	CONFIG               = "config"
+	PK                   = "pk"
+	ADDRESS              = "address"
+	KEYSTORE_FILE        = "keystorefile"
+	DURABLE_STORE_FOLDER = "durablestorefolder"
+	DRY_RUN              = "dryrun"
+	STORE_ENCRYPTION_KEY = "storeencryptionkey"
+	STORE_KEY_FILE       = "storekeyfile"
+	STORE_PASSPHRASE     = "storepassphrase"
+)
+
+func main() {
+	var pkString, address, keystoreFile, durableStoreFolder, storeEncryptionKey, storeKeyFile, storePassphrase string
+	var dryRun bool
+
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  CONFIG,
+			Usage: "Load config options from `config.toml`",
+		},
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        PK,
+			Usage:       "Specifies the private key of the node that owns the store. Only used to derive the node's address.",
+			Destination: &pkString,
+			EnvVars:     []string{"SC_PK"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        ADDRESS,
+			Usage:       "Specifies the address of the node that owns the store, e.g. a node which signs with a remote signer. Takes precedence over the keystore file and private key.",
+			Destination: &address,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        KEYSTORE_FILE,
+			Usage:       "Specifies the keystore file of the node that owns the store. Only its address is read, so no passphrase is needed. Takes precedence over the private key.",
+			Destination: &keystoreFile,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        DURABLE_STORE_FOLDER,
+			Usage:       "Specifies the folder for the durable store data storage.",
+			Destination: &durableStoreFolder,
+			Value:       "./data/nitro-store",
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_ENCRYPTION_KEY,
+			Usage:       "Specifies the hex encoded key of an encrypted store.",
+			Destination: &storeEncryptionKey,
+			EnvVars:     []string{"STORE_ENCRYPTION_KEY"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_KEY_FILE,
+			Usage:       "Specifies a file containing the hex encoded key of an encrypted store.",
+			Destination: &storeKeyFile,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_PASSPHRASE,
+			Usage:       "Specifies the passphrase of an encrypted store.",
+			Destination: &storePassphrase,
+			EnvVars:     []string{"STORE_PASSPHRASE"},
+		}),
+		&cli.BoolFlag{
+			Name:        DRY_RUN,
+			Usage:       "Report the migrations that would be applied without changing the store.",
+			Destination: &dryRun,
+		},
+	}
+
+	app := &cli.App{
+		Name:   "migrate-store",
+		Usage:  "Migrates a durable store to the schema version of this version of go-nitro.",
+		Flags:  flags,
+		Before: altsrc.InitInputSourceWithContext(flags, altsrc.NewTomlSourceFromFlagFunc(CONFIG)),
+		Action: func(cCtx *cli.Context) error {
+			me, err := utils.StoreAddress(common.Hex2Bytes(utils.TrimHexPrefix(pkString)), address, keystoreFile)
+			if err != nil {
+				return fmt.Errorf("the node that owns the store must be identified with --%s, --%s or --%s: %w", ADDRESS, KEYSTORE_FILE, PK, err)
+			}
+
+			// Matches the folder used by store.NewStore
+			dataFolder := filepath.Join(durableStoreFolder, me.String())
+			if _, err := os.Stat(dataFolder); err != nil {
+				return fmt.Errorf("no durable store found at %s: %w", dataFolder, err)
+			}
+
+			enc, err := utils.StoreEncryptionOpts(storeEncryptionKey, storeKeyFile, storePassphrase)
+			if err != nil {
+				return err
+			}
+
+			report, err := store.MigrateDurableStore(me, dataFolder, enc, dryRun)
+			if err != nil {
+				return err
+			}
+
+			if len(report.Applied) == 0 {
+				fmt.Printf("Store is at schema version %d, nothing to migrate\n", report.FromVersion)
+				return nil
+			}
+
+			verb := "Migrated"
+			if dryRun {
+				verb = "Would migrate"
+			}
+			fmt.Printf("%s store from schema version %d to %d\n", verb, report.FromVersion, report.ToVersion)
+			for _, m := range report.Applied {
+				fmt.Printf("  %d: %s\n", m.Version, m.Description)
+			}
+			for _, c := range report.Changes {
+				fmt.Printf("  version %d rewrites %s/%s\n", c.Version, c.Table, c.Key)
+			}
+			return nil
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
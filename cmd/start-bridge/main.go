@@ -4,12 +4,14 @@ import (
 	"crypto/tls"
 	"log"
 	"log/slog"
+	"math/big"
 	"os"
 
 	"github.com/statechannels/go-nitro/bridge"
 	"github.com/statechannels/go-nitro/cmd/utils"
 	"github.com/statechannels/go-nitro/internal/logging"
 	"github.com/statechannels/go-nitro/internal/rpc"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
 	"github.com/statechannels/go-nitro/paymentsmanager"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
@@ -21,6 +23,13 @@ const (
 	L1_CHAIN_URL         = "l1chainurl"
 	L1_CHAIN_START_BLOCK = "l1chainstartblock"
 
+	LACONICD_URL          = "laconicdurl"
+	LACONICD_PK           = "laconicdpk"
+	LACONICD_GAS_LIMIT    = "laconicdgaslimit"
+	LACONICD_GAS_PRICE    = "laconicdgasprice"
+	LACONICD_DENOM        = "laconicddenom"
+	LACONICD_START_HEIGHT = "laconicdstartheight"
+
 	CHAIN_PK         = "chainpk"
 	STATE_CHANNEL_PK = "statechannelpk"
 
@@ -55,6 +64,8 @@ func main() {
 	var l1chainurl, chainpk, statechannelpk, naaddress, vpaaddress, caaddress, bridgeaddress, durableStoreDir, bridgepublicip, nodel1ExtMultiAddr, nodel2ExtMultiAddr string
 	var nodel1msgport, nodel2msgport, rpcport int
 	var l1chainstartblock uint64
+	var laconicdurl, laconicdpk, laconicddenom string
+	var laconicdgaslimit, laconicdgasprice, laconicdstartheight uint64
 
 	var tlscertfilepath, tlskeyfilepath string
 
@@ -156,6 +167,42 @@ func main() {
 			Value:       0,
 			Destination: &l1chainstartblock,
 		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        LACONICD_URL,
+			Usage:       "Specifies the CometBFT RPC url of the laconicd node used by nodeL2",
+			Value:       "http://127.0.0.1:26657",
+			Destination: &laconicdurl,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        LACONICD_PK,
+			Usage:       "Specifies the private key of the laconicd account which pays for the transactions of nodeL2",
+			Destination: &laconicdpk,
+			EnvVars:     []string{"LACONICD_PK"},
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        LACONICD_GAS_LIMIT,
+			Usage:       "Specifies the gas limit of laconicd transactions",
+			Value:       chainservice.LACONICD_GAS_LIMIT,
+			Destination: &laconicdgaslimit,
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        LACONICD_GAS_PRICE,
+			Usage:       "Specifies the fee paid per unit of gas for laconicd transactions",
+			Value:       0,
+			Destination: &laconicdgasprice,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        LACONICD_DENOM,
+			Usage:       "Specifies the denomination laconicd transaction fees are paid in",
+			Value:       chainservice.LACONICD_DENOM,
+			Destination: &laconicddenom,
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        LACONICD_START_HEIGHT,
+			Usage:       "Specifies the block height to start looking for nitro module events of nodeL2",
+			Value:       0,
+			Destination: &laconicdstartheight,
+		}),
 		altsrc.NewStringFlag(&cli.StringFlag{
 			Name:        TLS_CERT_FILEPATH,
 			Usage:       "Filepath to the TLS certificate. If not specified, TLS will not be used with the RPC transport.",
@@ -178,22 +225,29 @@ func main() {
 		Action: func(cCtx *cli.Context) error {
 			chainpk = utils.TrimHexPrefix(chainpk)
 			statechannelpk = utils.TrimHexPrefix(statechannelpk)
+			laconicdpk = utils.TrimHexPrefix(laconicdpk)
 
 			bridgeConfig := bridge.BridgeConfig{
-				L1ChainUrl:         l1chainurl,
-				L1ChainStartBlock:  l1chainstartblock,
-				ChainPK:            chainpk,
-				StateChannelPK:     statechannelpk,
-				NaAddress:          naaddress,
-				VpaAddress:         vpaaddress,
-				CaAddress:          caaddress,
-				BridgeAddress:      bridgeaddress,
-				DurableStoreDir:    durableStoreDir,
-				BridgePublicIp:     bridgepublicip,
-				NodeL1ExtMultiAddr: nodel1ExtMultiAddr,
-				NodeL2ExtMultiAddr: nodel2ExtMultiAddr,
-				NodeL1MsgPort:      nodel1msgport,
-				NodeL2MsgPort:      nodel2msgport,
+				L1ChainUrl:          l1chainurl,
+				L1ChainStartBlock:   l1chainstartblock,
+				ChainPK:             chainpk,
+				StateChannelPK:      statechannelpk,
+				NaAddress:           naaddress,
+				VpaAddress:          vpaaddress,
+				CaAddress:           caaddress,
+				BridgeAddress:       bridgeaddress,
+				DurableStoreDir:     durableStoreDir,
+				BridgePublicIp:      bridgepublicip,
+				NodeL1ExtMultiAddr:  nodel1ExtMultiAddr,
+				NodeL2ExtMultiAddr:  nodel2ExtMultiAddr,
+				NodeL1MsgPort:       nodel1msgport,
+				NodeL2MsgPort:       nodel2msgport,
+				LaconicdUrl:         laconicdurl,
+				LaconicdPK:          laconicdpk,
+				LaconicdGasLimit:    laconicdgaslimit,
+				LaconicdGasPrice:    new(big.Int).SetUint64(laconicdgasprice),
+				LaconicdDenom:       laconicddenom,
+				LaconicdStartHeight: laconicdstartheight,
 			}
 
 			logging.SetupDefaultLogger(os.Stdout, slog.LevelDebug)
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package main
+
+import (
+	"fmt"
+	"net"
+)
+
+// listen is only supported on unix, where the permissions of the socket can be restricted as it is created
+func listen(path string) (net.Listener, error) {
+	return nil, fmt.Errorf("cannot listen on %s: the signer socket is only supported on unix", path)
+}
@@ -0,0 +1,16 @@
+//go:build unix
+
+package main
+
+import (
+	"net"
+	"syscall"
+)
+
+// listen listens on the unix socket at path, which is created with no permissions for anyone but the owner.
+// The umask is restricted while the socket is created, since it could be connected to before its permissions were changed.
+func listen(path string) (net.Listener, error) {
+	oldMask := syscall.Umask(0o177)
+	defer syscall.Umask(oldMask)
+	return net.Listen("unix", path)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/statechannels/go-nitro/cmd/utils"
+	"github.com/statechannels/go-nitro/crypto"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+)
+
+const (
+	CONFIG              = "config"
+	KEYSTORE_FILE       = "keystorefile"
+	KEYSTORE_PASSPHRASE = "keystorepassphrase"
+	SOCKET              = "socket"
+)
+
+func main() {
+	var keystoreFile, keystorePassphrase, socket string
+
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  CONFIG,
+			Usage: "Load config options from `config.toml`",
+		},
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        KEYSTORE_FILE,
+			Usage:       "Specifies the encrypted keystore file holding the private key to sign with.",
+			Destination: &keystoreFile,
+			Required:    true,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        KEYSTORE_PASSPHRASE,
+			Usage:       "Specifies the passphrase of the keystore file.",
+			Destination: &keystorePassphrase,
+			EnvVars:     []string{"KEYSTORE_PASSPHRASE"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        SOCKET,
+			Usage:       "Specifies the unix socket to listen on. Pass the same path to the node's --remotesigner flag.",
+			Destination: &socket,
+			Value:       "./nitro-signer.sock",
+		}),
+	}
+
+	app := &cli.App{
+		Name:   "start-signer",
+		Usage:  "Signs on behalf of a nitro node, so that the node never loads its private key.",
+		Flags:  flags,
+		Before: altsrc.InitInputSourceWithContext(flags, altsrc.NewTomlSourceFromFlagFunc(CONFIG)),
+		Action: func(cCtx *cli.Context) error {
+			signer, err := crypto.NewKeystoreSigner(keystoreFile, keystorePassphrase)
+			if err != nil {
+				return err
+			}
+
+			listener, err := listen(socket)
+			if err != nil {
+				return err
+			}
+			// Only the owner of the signer process may connect to the socket
+			err = os.Chmod(socket, 0o600)
+			if err != nil {
+				listener.Close()
+				return err
+			}
+
+			go func() {
+				err := crypto.ServeSigner(listener, signer)
+				if err != nil {
+					log.Fatal(err)
+				}
+			}()
+			fmt.Printf("Signing for %s on %s\n", signer.Address(), socket)
+
+			utils.WaitForKillSignal()
+			return listener.Close()
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
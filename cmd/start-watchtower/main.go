@@ -0,0 +1,196 @@
+package main
+
+import (
+	"crypto/tls"
+	"log"
+	"log/slog"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/cmd/utils"
+	"github.com/statechannels/go-nitro/internal/logging"
+	"github.com/statechannels/go-nitro/internal/rpc"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/watchtower"
+	"github.com/tidwall/buntdb"
+	"github.com/urfave/cli/v2"
+	"github.com/urfave/cli/v2/altsrc"
+)
+
+const (
+	CONFIG = "config"
+
+	CHAIN_URL         = "chainurl"
+	CHAIN_START_BLOCK = "chainstartblock"
+	CHAIN_AUTH_TOKEN  = "chainauthtoken"
+	CHAIN_PK          = "chainpk"
+
+	NA_ADDRESS  = "naaddress"
+	VPA_ADDRESS = "vpaaddress"
+	CA_ADDRESS  = "caaddress"
+
+	DURABLE_STORE_DIR = "durablestorefolder"
+
+	RESPONSE_MARGIN = "responsemargin"
+
+	RPC_PORT = "rpcport"
+	USE_NATS = "usenats"
+
+	TLS_CERT_FILEPATH = "tlscertfilepath"
+	TLS_KEY_FILEPATH  = "tlskeyfilepath"
+)
+
+func main() {
+	var chainurl, chainauthtoken, chainpk, naaddress, vpaaddress, caaddress, durableStoreDir string
+	var rpcport int
+	var chainstartblock, responsemargin uint64
+	var usenats bool
+
+	var tlscertfilepath, tlskeyfilepath string
+
+	// urfave default precedence for flag value sources (highest to lowest):
+	// 1. Command line flag value
+	// 2. Environment variable (if specified)
+	// 3. Configuration file (if specified)
+	// 4. Default defined on the flag
+
+	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:  CONFIG,
+			Usage: "Load config options from `config.toml`",
+		},
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        CHAIN_URL,
+			Usage:       "Specifies the url of a RPC endpoint for the chain.",
+			Value:       "ws://127.0.0.1:8545",
+			Destination: &chainurl,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        CHAIN_AUTH_TOKEN,
+			Usage:       "The bearer token used for auth when making requests to the chain's RPC endpoint.",
+			Destination: &chainauthtoken,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        CHAIN_PK,
+			Usage:       "Specifies the chain private key used to pay for checkpoint and challenge transactions. It holds no channel funds.",
+			Destination: &chainpk,
+			EnvVars:     []string{"CHAIN_PK"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        NA_ADDRESS,
+			Usage:       "Specifies the nitro adjudicator contract address",
+			Destination: &naaddress,
+			EnvVars:     []string{"NA_ADDRESS"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        CA_ADDRESS,
+			Usage:       "Specifies the consensus app contract address",
+			Destination: &caaddress,
+			EnvVars:     []string{"CA_ADDRESS"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        VPA_ADDRESS,
+			Usage:       "Specifies the virtual payment app contract address",
+			Destination: &vpaaddress,
+			EnvVars:     []string{"VPA_ADDRESS"},
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        CHAIN_START_BLOCK,
+			Usage:       "Specifies the block number to start looking for nitro adjudicator events",
+			Value:       0,
+			Destination: &chainstartblock,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        DURABLE_STORE_DIR,
+			Usage:       "Specifies the location of the store of watched states",
+			Destination: &durableStoreDir,
+			Value:       "./data/watchtower-store",
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        RESPONSE_MARGIN,
+			Usage:       "Specifies the minimum number of seconds that must remain before a challenge finalizes for the watchtower to respond to it.",
+			Value:       0,
+			Destination: &responsemargin,
+		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:        RPC_PORT,
+			Usage:       "Specifies the tcp port for the rpc server.",
+			Value:       4008,
+			Destination: &rpcport,
+		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:        USE_NATS,
+			Usage:       "Specifies whether to use NATS or http/ws for the rpc server.",
+			Value:       false,
+			Destination: &usenats,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        TLS_CERT_FILEPATH,
+			Usage:       "Filepath to the TLS certificate. If not specified, TLS will not be used with the RPC transport.",
+			Value:       "./tls/statechannels.org.pem",
+			Destination: &tlscertfilepath,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        TLS_KEY_FILEPATH,
+			Usage:       "Filepath to the TLS private key. If not specified, TLS will not be used with the RPC transport.",
+			Value:       "./tls/statechannels.org_key.pem",
+			Destination: &tlskeyfilepath,
+		}),
+	}
+
+	app := &cli.App{
+		Name:   "watchtower",
+		Usage:  "Responds to challenges registered against stale states on behalf of nitro nodes.",
+		Flags:  flags,
+		Before: altsrc.InitInputSourceWithContext(flags, altsrc.NewTomlSourceFromFlagFunc(CONFIG)),
+		Action: func(cCtx *cli.Context) error {
+			chainpk = utils.TrimHexPrefix(chainpk)
+
+			logging.SetupDefaultLogger(os.Stdout, slog.LevelDebug)
+
+			store, err := watchtower.NewDurableStore(durableStoreDir, buntdb.Config{})
+			if err != nil {
+				return err
+			}
+
+			chainService, err := chainservice.NewEthChainService(chainservice.ChainOpts{
+				ChainUrl:           chainurl,
+				ChainStartBlockNum: chainstartblock,
+				ChainAuthToken:     chainauthtoken,
+				ChainPk:            chainpk,
+				NaAddress:          common.HexToAddress(naaddress),
+				VpaAddress:         common.HexToAddress(vpaaddress),
+				CaAddress:          common.HexToAddress(caaddress),
+				Outbox:             store,
+			})
+			if err != nil {
+				return err
+			}
+
+			w := watchtower.New(chainService, store, responsemargin)
+
+			var cert *tls.Certificate
+			if tlscertfilepath != "" && tlskeyfilepath != "" {
+				loadedCert, err := tls.LoadX509KeyPair(tlscertfilepath, tlskeyfilepath)
+				if err != nil {
+					panic(err)
+				}
+				cert = &loadedCert
+			}
+
+			rpcServer, err := rpc.InitializeWatchtowerRpcServer(w, rpcport, usenats, cert)
+			if err != nil {
+				return err
+			}
+
+			slog.Info("Watchtower started", "rpcPort", rpcport)
+			utils.WaitForKillSignal()
+
+			return rpcServer.Close()
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
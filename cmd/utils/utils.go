@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
@@ -13,7 +14,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/internal/testdata"
+	"github.com/statechannels/go-nitro/node/engine/store"
 	"github.com/statechannels/go-nitro/rpc"
 	"github.com/statechannels/go-nitro/types"
 )
@@ -92,3 +95,59 @@ func TrimHexPrefix(hex string) string {
 	}
 	return hex
 }
+
+// StoreEncryptionOpts builds the encryption options of a durable store from a hex encoded key, a file containing a hex encoded key, or a passphrase.
+// If none are supplied encryption is disabled.
+func StoreEncryptionOpts(keyHex, keyFile, passphrase string) (store.EncryptionOpts, error) {
+	if keyHex != "" && keyFile != "" {
+		return store.EncryptionOpts{}, errors.New("only one of a store encryption key and key file may be supplied")
+	}
+	if keyFile != "" {
+		contents, err := os.ReadFile(keyFile)
+		if err != nil {
+			return store.EncryptionOpts{}, fmt.Errorf("error reading store key file: %w", err)
+		}
+		keyHex = strings.TrimSpace(string(contents))
+	}
+	if keyHex == "" {
+		return store.EncryptionOpts{Passphrase: passphrase}, nil
+	}
+
+	key, err := hex.DecodeString(TrimHexPrefix(keyHex))
+	if err != nil {
+		return store.EncryptionOpts{}, fmt.Errorf("store encryption key is not valid hex: %w", err)
+	}
+	return store.EncryptionOpts{Key: key, Passphrase: passphrase}, nil
+}
+
+// StoreAddress returns the address of the node which owns a store, from which the folder of its durable store is derived.
+// An address takes precedence over a keystore file, which takes precedence over a private key.
+func StoreAddress(pk []byte, address, keystoreFile string) (types.Address, error) {
+	switch {
+	case address != "":
+		if !common.IsHexAddress(address) {
+			return types.Address{}, fmt.Errorf("%q is not an address", address)
+		}
+		return common.HexToAddress(address), nil
+	case keystoreFile != "":
+		return crypto.KeystoreAddress(keystoreFile)
+	case len(pk) > 0:
+		return crypto.GetAddressFromSecretKeyBytes(pk), nil
+	default:
+		return types.Address{}, errors.New("an address, keystore file or private key must be supplied")
+	}
+}
+
+// NewSigner returns the signer used by a node. A remote signer socket takes precedence over a keystore file, which takes precedence over a private key.
+func NewSigner(pk []byte, keystoreFile, keystorePassphrase, remoteSignerSocket string) (crypto.Signer, error) {
+	switch {
+	case remoteSignerSocket != "":
+		return crypto.NewRemoteSigner(remoteSignerSocket)
+	case keystoreFile != "":
+		return crypto.NewKeystoreSigner(keystoreFile, keystorePassphrase)
+	case len(pk) > 0:
+		return crypto.NewKeySigner(pk)
+	default:
+		return nil, errors.New("a private key, keystore file or remote signer must be supplied")
+	}
+}
@@ -0,0 +1,45 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// NewKeystoreSigner returns a Signer for the key held in an encrypted geth-style keystore file.
+// The key is decrypted with the passphrase when the signer is created.
+func NewKeystoreSigner(path, passphrase string) (*KeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keystore file: %w", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting keystore file: %w", err)
+	}
+	return NewKeySigner(crypto.FromECDSA(key.PrivateKey))
+}
+
+// KeystoreAddress returns the address of the key held in a geth-style keystore file. The address is stored unencrypted, so no passphrase is needed.
+func KeystoreAddress(path string) (types.Address, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return types.Address{}, fmt.Errorf("error reading keystore file: %w", err)
+	}
+	var key struct {
+		Address string `json:"address"`
+	}
+	err = json.Unmarshal(keyJSON, &key)
+	if err != nil {
+		return types.Address{}, fmt.Errorf("error decoding keystore file: %w", err)
+	}
+	if !common.IsHexAddress(key.Address) {
+		return types.Address{}, fmt.Errorf("keystore file does not hold a valid address: %q", key.Address)
+	}
+	return common.HexToAddress(key.Address), nil
+}
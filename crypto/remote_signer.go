@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/statechannels/go-nitro/types"
+)
+
+const (
+	remoteSignerAddressMethod = "address"
+	remoteSignerSignMethod    = "signDigest"
+	remoteSignerTimeout       = 10 * time.Second
+)
+
+// remoteSignerRequest and remoteSignerResponse are exchanged as newline delimited JSON
+type remoteSignerRequest struct {
+	Method string        `json:"method"`
+	Digest hexutil.Bytes `json:"digest,omitempty"`
+}
+
+type remoteSignerResponse struct {
+	Result hexutil.Bytes `json:"result,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+// RemoteSigner is a Signer which delegates signing to a signer process listening on a local (unix) socket,
+// so that the private key never enters this process. See ServeSigner.
+type RemoteSigner struct {
+	socketPath string
+	address    types.Address
+}
+
+// NewRemoteSigner connects to the signer listening on socketPath and fetches the address it signs for
+func NewRemoteSigner(socketPath string) (*RemoteSigner, error) {
+	rs := &RemoteSigner{socketPath: socketPath}
+	address, err := rs.call(remoteSignerRequest{Method: remoteSignerAddressMethod})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching address from remote signer: %w", err)
+	}
+	if len(address) != len(types.Address{}) {
+		return nil, fmt.Errorf("remote signer returned an invalid address %s", address)
+	}
+	rs.address = types.Address(address)
+	return rs, nil
+}
+
+func (rs *RemoteSigner) Address() types.Address {
+	return rs.address
+}
+
+func (rs *RemoteSigner) SignDigest(digest []byte) ([]byte, error) {
+	return rs.call(remoteSignerRequest{Method: remoteSignerSignMethod, Digest: digest})
+}
+
+// call sends a single request to the signer. A connection is made per request, so the signer may be restarted while the node is running.
+func (rs *RemoteSigner) call(req remoteSignerRequest) ([]byte, error) {
+	conn, err := net.DialTimeout("unix", rs.socketPath, remoteSignerTimeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(time.Now().Add(remoteSignerTimeout))
+	if err != nil {
+		return nil, err
+	}
+	err = json.NewEncoder(conn).Encode(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var res remoteSignerResponse
+	err = json.NewDecoder(bufio.NewReader(conn)).Decode(&res)
+	if err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, errors.New(res.Error)
+	}
+	return res.Result, nil
+}
+
+// ServeSigner answers RemoteSigner requests received on listener using signer, until the listener is closed.
+func ServeSigner(listener net.Listener, signer Signer) error {
+	for {
+		conn, err := listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		go serveSignerConn(conn, signer)
+	}
+}
+
+func serveSignerConn(conn net.Conn, signer Signer) {
+	defer conn.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(conn))
+	encoder := json.NewEncoder(conn)
+	for {
+		var req remoteSignerRequest
+		err := decoder.Decode(&req)
+		if err != nil {
+			return
+		}
+
+		var res remoteSignerResponse
+		switch req.Method {
+		case remoteSignerAddressMethod:
+			address := signer.Address()
+			res.Result = address.Bytes()
+		case remoteSignerSignMethod:
+			if len(req.Digest) != 32 {
+				res.Error = fmt.Sprintf("digest must be 32 bytes, got %d", len(req.Digest))
+				break
+			}
+			res.Result, err = signer.SignDigest(req.Digest)
+			if err != nil {
+				res.Error = err.Error()
+			}
+		default:
+			res.Error = fmt.Sprintf("unknown method %q", req.Method)
+		}
+
+		err = encoder.Encode(res)
+		if err != nil {
+			slog.Error("error responding to remote signer request", "err", err)
+			return
+		}
+	}
+}
@@ -26,19 +26,7 @@ type Signature struct {
 // "\x19Ethereum Signed Message:\n" + len(message).
 // See https://github.com/ethereum/go-ethereum/pull/2940 and EIPs 191, 721.
 func SignEthereumMessage(message []byte, secretKey []byte) (Signature, error) {
-	digest := computeEthereumSignedMessageDigest(message)
-	concatenatedSignature, error := secp256k1.Sign(digest, secretKey)
-	if error != nil {
-		return Signature{}, error
-	}
-	sig := SplitSignature(concatenatedSignature)
-
-	// This step is necessary to remain compatible with the ecrecover precompile
-	if int(sig.V) < 27 {
-		sig.V = byte(int(sig.V + 27))
-	}
-
-	return sig, nil
+	return SignEthereumMessageWith(message, &KeySigner{secretKey: secretKey})
 }
 
 // RecoverEthereumMessageSigner accepts a message (bytestring) and signature generated by SignEthereumMessage.
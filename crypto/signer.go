@@ -0,0 +1,59 @@
+package crypto
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto/secp256k1"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// Signer produces secp256k1 signatures on behalf of an Ethereum account.
+// Implementations need not hold the account's private key in process memory.
+type Signer interface {
+	// Address returns the Ethereum address of the signing account
+	Address() types.Address
+	// SignDigest returns the 65 byte [R || S || V] signature of a 32 byte digest, with V in {0, 1}
+	SignDigest(digest []byte) ([]byte, error)
+}
+
+// KeySigner is a Signer which holds a private key in memory
+type KeySigner struct {
+	secretKey []byte
+	address   types.Address
+}
+
+// NewKeySigner returns a Signer for the supplied private key
+func NewKeySigner(secretKey []byte) (*KeySigner, error) {
+	if len(secretKey) != 32 {
+		return nil, fmt.Errorf("private key must be 32 bytes, got %d", len(secretKey))
+	}
+	return &KeySigner{secretKey: secretKey, address: GetAddressFromSecretKeyBytes(secretKey)}, nil
+}
+
+func (ks *KeySigner) Address() types.Address {
+	return ks.address
+}
+
+func (ks *KeySigner) SignDigest(digest []byte) ([]byte, error) {
+	return secp256k1.Sign(digest, ks.secretKey)
+}
+
+// SignEthereumMessageWith signs the message as SignEthereumMessage does, using the supplied Signer.
+func SignEthereumMessageWith(message []byte, signer Signer) (Signature, error) {
+	digest := computeEthereumSignedMessageDigest(message)
+	concatenatedSignature, err := signer.SignDigest(digest)
+	if err != nil {
+		return Signature{}, err
+	}
+	if len(concatenatedSignature) != 65 {
+		return Signature{}, fmt.Errorf("signer returned a %d byte signature, expected 65 bytes", len(concatenatedSignature))
+	}
+	sig := SplitSignature(concatenatedSignature)
+
+	// This step is necessary to remain compatible with the ecrecover precompile
+	if int(sig.V) < 27 {
+		sig.V = byte(int(sig.V + 27))
+	}
+
+	return sig, nil
+}
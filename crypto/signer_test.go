@@ -0,0 +1,118 @@
+package crypto
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// from state/test-fixtures.go
+var (
+	signerTestKey     = common.Hex2Bytes("caab404f975b4620747174a75f08d98b4e5a7053b691b41bcfc0d839d48b7634")
+	signerTestAddress = common.HexToAddress("0xF5A1BB5607C9D079E46d1B3Dc33f257d937b43BD")
+)
+
+func assertSignsFor(t *testing.T, signer Signer) {
+	t.Helper()
+	if signer.Address() != signerTestAddress {
+		t.Fatalf("expected signer address %s, got %s", signerTestAddress, signer.Address())
+	}
+
+	msg := []byte("sign this")
+	sig, err := SignEthereumMessageWith(msg, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := RecoverEthereumMessageSigner(msg, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recovered != signerTestAddress {
+		t.Fatalf("expected to recover %s, got %s", signerTestAddress, recovered)
+	}
+
+	keySig, err := SignEthereumMessage(msg, signerTestKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sig.Equal(keySig) {
+		t.Fatalf("expected the signer to produce the same signature as the private key")
+	}
+}
+
+func TestKeySigner(t *testing.T) {
+	signer, err := NewKeySigner(signerTestKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSignsFor(t, signer)
+
+	if _, err := NewKeySigner([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected a short key to be rejected")
+	}
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	privateKey, err := crypto.ToECDSA(signerTestKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := &keystore.Key{Address: signerTestAddress, PrivateKey: privateKey}
+	keyJSON, err := keystore.EncryptKey(key, "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+	err = os.WriteFile(path, keyJSON, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewKeystoreSigner(path, "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSignsFor(t, signer)
+
+	if _, err := NewKeystoreSigner(path, "wrong"); err == nil {
+		t.Fatal("expected a wrong passphrase to be rejected")
+	}
+
+	address, err := KeystoreAddress(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if address != signerTestAddress {
+		t.Fatalf("expected keystore address %s, got %s", signerTestAddress, address)
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	keySigner, err := NewKeySigner(signerTestKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	socket := filepath.Join(t.TempDir(), "signer.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() { _ = ServeSigner(listener, keySigner) }()
+
+	signer, err := NewRemoteSigner(socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSignsFor(t, signer)
+
+	if _, err := signer.SignDigest([]byte("too short")); err == nil {
+		t.Fatal("expected the remote signer to reject a malformed digest")
+	}
+}
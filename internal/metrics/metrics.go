@@ -0,0 +1,183 @@
+// Package metrics defines the Prometheus metrics exported by a go-nitro node, and serves them over http.
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "nitro"
+
+// Registry holds every metric defined by this package, along with the standard Go runtime and process metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// ObjectivesStarted counts the objectives created by the engine, whether requested through the API or proposed by a peer
+	ObjectivesStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "engine",
+		Name:      "objectives_started_total",
+		Help:      "Number of objectives started, by objective type.",
+	}, []string{"type"})
+	// ObjectivesCompleted counts the objectives which completed
+	ObjectivesCompleted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "engine",
+		Name:      "objectives_completed_total",
+		Help:      "Number of objectives completed, by objective type.",
+	}, []string{"type"})
+	// ObjectivesFailed counts the objectives which failed or were rejected
+	ObjectivesFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "engine",
+		Name:      "objectives_failed_total",
+		Help:      "Number of objectives which failed or were rejected, by objective type and failure code.",
+	}, []string{"type", "code"})
+	// EngineTaskDuration observes the time from the engine receiving an input until it has been handled, including time spent queued
+	EngineTaskDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "engine",
+		Name:      "task_duration_seconds",
+		Help:      "Time taken by the engine to handle an input, from receipt to completion, by input kind.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"kind"})
+	// Vouchers counts the payment vouchers sent and received
+	Vouchers = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "payments",
+		Name:      "vouchers_total",
+		Help:      "Number of payment vouchers, by direction (sent or received).",
+	}, []string{"direction"})
+	// VoucherAmount sums the amounts paid by the vouchers sent and received
+	VoucherAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "payments",
+		Name:      "voucher_amount_total",
+		Help:      "Total amount paid by payment vouchers, by direction (sent or received).",
+	}, []string{"direction"})
+	// MessagesSent counts the messages delivered to each peer
+	MessagesSent = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "messages",
+		Name:      "sent_total",
+		Help:      "Number of messages delivered, by recipient address.",
+	}, []string{"peer"})
+	// MessagesReceived counts the messages received from each peer. Redelivered copies are not counted.
+	MessagesReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "messages",
+		Name:      "received_total",
+		Help:      "Number of messages received, by sender address.",
+	}, []string{"peer"})
+	// ChainEventsDispatched counts the chain events handed on once they have enough confirmations
+	ChainEventsDispatched = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "events_dispatched_total",
+		Help:      "Number of chain events dispatched, by event name.",
+	}, []string{"event"})
+	// ChainEventsDropped counts the chain events discarded because their block left the chain
+	ChainEventsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "events_dropped_total",
+		Help:      "Number of chain events dropped because their block is no longer in the chain, by event name.",
+	}, []string{"event"})
+	// ChainEventsRetracted counts the dispatched chain events retracted because a reorg removed their block from the chain
+	ChainEventsRetracted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "events_retracted_total",
+		Help:      "Number of dispatched chain events retracted because their block was removed by a reorg, by event name.",
+	}, []string{"event"})
+	// ChainTransactions counts transaction submissions by outcome
+	ChainTransactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "chain",
+		Name:      "transactions_total",
+		Help:      "Number of transaction submissions, by transaction type and outcome (submitted, failed or replaced).",
+	}, []string{"type", "outcome"})
+	// StoreOperationDuration observes the latency of store operations
+	StoreOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "store",
+		Name:      "operation_duration_seconds",
+		Help:      "Time taken by store operations, by operation.",
+		Buckets:   []float64{.0001, .00025, .0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation"})
+)
+
+// Directions of payment vouchers
+const (
+	Sent     = "sent"
+	Received = "received"
+)
+
+// Outcomes of transaction submissions
+const (
+	Submitted = "submitted"
+	Failed    = "failed"
+	Replaced  = "replaced"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		ObjectivesStarted,
+		ObjectivesCompleted,
+		ObjectivesFailed,
+		EngineTaskDuration,
+		Vouchers,
+		VoucherAmount,
+		MessagesSent,
+		MessagesReceived,
+		ChainEventsDispatched,
+		ChainEventsDropped,
+		ChainEventsRetracted,
+		ChainTransactions,
+		StoreOperationDuration,
+	)
+}
+
+// RecordVoucher counts a voucher sent or received, paying the given amount
+func RecordVoucher(direction string, amount *big.Int) {
+	Vouchers.WithLabelValues(direction).Inc()
+	if amount != nil {
+		f, _ := new(big.Float).SetInt(amount).Float64()
+		VoucherAmount.WithLabelValues(direction).Add(f)
+	}
+}
+
+// ObserveSince records the time elapsed since start against the given label of a histogram
+func ObserveSince(h *prometheus.HistogramVec, label string, start time.Time) {
+	h.WithLabelValues(label).Observe(time.Since(start).Seconds())
+}
+
+// Handler returns an http.Handler which serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// Serve starts an http server which serves the metrics at /metrics on the given port
+func Serve(port int) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		slog.Info("Serving metrics", "url", fmt.Sprintf("http://localhost:%d/metrics", port))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server failed", "err", err)
+		}
+	}()
+	return server
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/node"
 	"github.com/statechannels/go-nitro/node/engine"
 	"github.com/statechannels/go-nitro/node/engine/chainservice"
@@ -11,7 +12,8 @@ import (
 	"github.com/statechannels/go-nitro/node/engine/store"
 )
 
-func InitializeL2Node(chainOpts chainservice.LaconicdChainOpts, storeOpts store.StoreOpts, messageOpts p2pms.MessageOpts, policymaker engine.PolicyMaker) (*node.Node, *store.Store, *p2pms.P2PMessageService, chainservice.ChainService, error) {
+func InitializeL2Node(chainOpts chainservice.LaconicdChainOpts, storeOpts store.StoreOpts, messageOpts p2pms.MessageOpts, signer crypto.Signer, policymaker engine.PolicyMaker, engineOpts engine.EngineOpts) (*node.Node, *store.Store, *p2pms.P2PMessageService, chainservice.ChainService, error) {
+	storeOpts.Address = signer.Address()
 	ourStore, err := store.NewStore(storeOpts)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -19,9 +21,10 @@ func InitializeL2Node(chainOpts chainservice.LaconicdChainOpts, storeOpts store.
 
 	slog.Info("Initializing message service on port " + fmt.Sprint(messageOpts.TcpPort) + "...")
 	messageOpts.SCAddr = *ourStore.GetAddress()
+	messageOpts.Outbox = ourStore
 	messageService := p2pms.NewMessageService(messageOpts)
 
-	// TODO: Implement laconicd chain service
+	chainOpts.Outbox = ourStore
 	ourChain, err := chainservice.NewLaconicdChainService(chainOpts)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -31,7 +34,9 @@ func InitializeL2Node(chainOpts chainservice.LaconicdChainOpts, storeOpts store.
 		messageService,
 		ourChain,
 		ourStore,
+		signer,
 		policymaker,
+		engineOpts,
 	)
 
 	return &node, &ourStore, messageService, ourChain, nil
@@ -3,6 +3,7 @@ package node
 import (
 	"log/slog"
 
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/node"
 	"github.com/statechannels/go-nitro/node/engine"
 	"github.com/statechannels/go-nitro/node/engine/chainservice"
@@ -11,7 +12,8 @@ import (
 	p2pms "github.com/statechannels/go-nitro/node/engine/messageservice/p2p-message-service"
 )
 
-func InitializeNode(chainOpts chainservice.ChainOpts, storeOpts store.StoreOpts, messageOpts p2pms.MessageOpts, policymaker engine.PolicyMaker) (*node.Node, *store.Store, *p2pms.P2PMessageService, chainservice.ChainService, error) {
+func InitializeNode(chainOpts chainservice.ChainOpts, storeOpts store.StoreOpts, messageOpts p2pms.MessageOpts, signer crypto.Signer, policymaker engine.PolicyMaker, engineOpts engine.EngineOpts) (*node.Node, *store.Store, *p2pms.P2PMessageService, chainservice.ChainService, error) {
+	storeOpts.Address = signer.Address()
 	ourStore, err := store.NewStore(storeOpts)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -19,6 +21,7 @@ func InitializeNode(chainOpts chainservice.ChainOpts, storeOpts store.StoreOpts,
 
 	slog.Info("Initializing message service", "tcp port", messageOpts.TcpPort, "web socket port", messageOpts.WsMsgPort)
 	messageOpts.SCAddr = *ourStore.GetAddress()
+	messageOpts.Outbox = ourStore
 	messageService := p2pms.NewMessageService(messageOpts)
 
 	// Compare chainOpts.ChainStartBlock to lastBlockNum seen in store. The larger of the two
@@ -32,6 +35,7 @@ func InitializeNode(chainOpts chainservice.ChainOpts, storeOpts store.StoreOpts,
 	}
 
 	slog.Info("Initializing chain service...")
+	chainOpts.Outbox = ourStore
 	ourChain, err := chainservice.NewEthChainService(chainOpts)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -41,7 +45,9 @@ func InitializeNode(chainOpts chainservice.ChainOpts, storeOpts store.StoreOpts,
 		messageService,
 		ourChain,
 		ourStore,
+		signer,
 		policymaker,
+		engineOpts,
 	)
 
 	return &node, &ourStore, messageService, ourChain, nil
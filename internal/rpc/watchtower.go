@@ -0,0 +1,160 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/crypto"
+	"github.com/statechannels/go-nitro/node"
+	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
+	"github.com/statechannels/go-nitro/node/notifier"
+	"github.com/statechannels/go-nitro/rpc"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// WatchtowerPusher uploads the latest supported state of each of a node's ledger, payment and swap channels to a watchtower whenever it changes.
+type WatchtowerPusher struct {
+	node       *node.Node
+	client     *rpc.WatchtowerClient
+	challenger crypto.Signer
+	pushed     map[types.Destination]uint64 // the turn number last uploaded for each channel
+
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+// StartWatchtowerPusher connects to the watchtower at watchtowerUrl, uploads the states of all existing ledger channels and of the
+// payment and swap channels they fund, and keeps uploading new states as the channels are updated.
+// If challenger is not nil the uploads include a challenge signature, so the watchtower counter challenges rather than checkpoints.
+func StartWatchtowerPusher(n *node.Node, watchtowerUrl string, isSecure bool, challenger crypto.Signer) (*WatchtowerPusher, error) {
+	client, err := rpc.NewHttpWatchtowerClient(watchtowerUrl, isSecure)
+	if err != nil {
+		return nil, err
+	}
+
+	wp := &WatchtowerPusher{
+		node:       n,
+		client:     client,
+		challenger: challenger,
+		pushed:     make(map[types.Destination]uint64),
+		wg:         &sync.WaitGroup{},
+	}
+
+	// Subscribe before reading the existing channels so no update is missed
+	opts := notifier.SubscriptionOpts{Overflow: notifier.DropOldest}
+	ledgerUpdates := n.SubscribeLedgerUpdates(opts)
+	paymentUpdates := n.SubscribePaymentUpdates(opts)
+	swapUpdates := n.SubscribeSwapUpdates(opts)
+	closeSubscriptions := func() {
+		ledgerUpdates.Close()
+		paymentUpdates.Close()
+		swapUpdates.Close()
+	}
+
+	err = wp.pushExisting()
+	if err != nil {
+		closeSubscriptions()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wp.cancel = cancel
+
+	wp.wg.Add(1)
+	go func() {
+		defer wp.wg.Done()
+		defer closeSubscriptions()
+		for {
+			select {
+			// Dropped updates do no harm, as each push uploads the latest state of the channel
+			case lc, ok := <-ledgerUpdates.Updates():
+				if !ok {
+					return
+				}
+				wp.push(lc.ID)
+			case pc, ok := <-paymentUpdates.Updates():
+				if !ok {
+					return
+				}
+				wp.push(pc.ID)
+			case si, ok := <-swapUpdates.Updates():
+				if !ok {
+					return
+				}
+				wp.push(si.ChannelId)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	slog.Info("Pushing channel states to watchtower", "url", watchtowerUrl)
+	return wp, nil
+}
+
+// pushExisting uploads the states of all ledger channels, and of the payment and swap channels funded by them
+func (wp *WatchtowerPusher) pushExisting() error {
+	ledgerChannels, err := wp.node.GetAllLedgerChannels()
+	if err != nil {
+		return err
+	}
+	for _, lc := range ledgerChannels {
+		wp.push(lc.ID)
+
+		paymentChannels, err := wp.node.GetPaymentChannelsByLedger(lc.ID)
+		if err != nil {
+			return err
+		}
+		for _, pc := range paymentChannels {
+			wp.push(pc.ID)
+		}
+
+		swapChannels, err := wp.node.GetSwapChannelsByLedger(lc.ID)
+		if err != nil {
+			return err
+		}
+		for _, sc := range swapChannels {
+			wp.push(sc.ID)
+		}
+	}
+	return nil
+}
+
+// push uploads the supported state of the channel if it is newer than the state last uploaded.
+// Failures are logged rather than returned, as the next update of the channel will upload a newer state anyway.
+func (wp *WatchtowerPusher) push(channelId types.Destination) {
+	ss, err := wp.node.GetSignedState(channelId)
+	if err != nil {
+		slog.Debug("No supported state to push to watchtower", "channel", channelId, "err", err)
+		return
+	}
+
+	turnNum := ss.State().TurnNum
+	if lastPushed, ok := wp.pushed[channelId]; ok && lastPushed >= turnNum {
+		return
+	}
+
+	var challengerSig state.Signature
+	if wp.challenger != nil {
+		challengerSig, err = NitroAdjudicator.SignChallengeMessageWith(ss.State(), wp.challenger)
+		if err != nil {
+			slog.Error("Could not sign challenge message for watchtower", "channel", channelId, "err", err)
+			return
+		}
+	}
+
+	_, err = wp.client.WatchState(ss, challengerSig)
+	if err != nil {
+		slog.Error("Could not push state to watchtower", "channel", channelId, "turnNum", turnNum, "err", err)
+		return
+	}
+	wp.pushed[channelId] = turnNum
+}
+
+func (wp *WatchtowerPusher) Close() error {
+	wp.cancel()
+	wp.wg.Wait()
+	return wp.client.Close()
+}
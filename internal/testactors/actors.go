@@ -26,6 +26,15 @@ func (a Actor) Address() types.Address {
 	return crypto.GetAddressFromSecretKeyBytes(a.PrivateKey)
 }
 
+// Signer returns a signer for the actor's private key
+func (a Actor) Signer() crypto.Signer {
+	signer, err := crypto.NewKeySigner(a.PrivateKey)
+	if err != nil {
+		panic(err)
+	}
+	return signer
+}
+
 const (
 	START_PORT    = 3200
 	WS_START_PORT = 6200
@@ -0,0 +1,93 @@
+// Package tracing sets up OpenTelemetry tracing for a go-nitro node, and propagates trace context between peers.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer creates the spans of a go-nitro node. Spans are discarded unless Setup has been called.
+var Tracer = otel.Tracer("github.com/statechannels/go-nitro")
+
+// propagator encodes trace context using the W3C Trace Context format
+var propagator = propagation.TraceContext{}
+
+type Opts struct {
+	// CollectorEndpoint is the address of an OTLP collector which receives spans over gRPC, e.g. "localhost:4317"
+	CollectorEndpoint string
+	// File is the path of a file to which spans are written as JSON. It is used when no CollectorEndpoint is supplied.
+	File string
+	// ServiceName identifies the node in exported spans
+	ServiceName string
+}
+
+// Setup installs a tracer provider which exports spans to a collector or a file, and returns a function which flushes
+// and stops the exporter. If neither a collector nor a file is supplied, tracing stays disabled.
+func Setup(opts Opts) (shutdown func(context.Context) error, err error) {
+	var exporter sdktrace.SpanExporter
+	var file *os.File
+	switch {
+	case opts.CollectorEndpoint != "":
+		exporter, err = otlptracegrpc.New(context.Background(), otlptracegrpc.WithEndpoint(opts.CollectorEndpoint), otlptracegrpc.WithInsecure())
+	case opts.File != "":
+		file, err = os.OpenFile(opts.File, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o666)
+		if err != nil {
+			return nil, err
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(file))
+	default:
+		return func(context.Context) error { return nil }, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(opts.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// Inject returns the trace context of ctx in a form which can be carried by a message, or nil if ctx is not part of a trace
+func Inject(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier
+}
+
+// Extract returns a context holding the trace context carried by a message, to be used as the parent of the spans which handle it
+func Extract(carrier map[string]string) context.Context {
+	return propagator.Extract(context.Background(), propagation.MapCarrier(carrier))
+}
+
+// End ends a span, recording the error which ended the operation it covers, if any
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
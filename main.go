@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"log"
 	"log/slog"
+	"math/big"
 	"os"
 	"os/signal"
 	"strings"
@@ -12,14 +14,18 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/statechannels/go-nitro/cmd/utils"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/internal/logging"
+	"github.com/statechannels/go-nitro/internal/metrics"
 	nodeUtils "github.com/statechannels/go-nitro/internal/node"
 	"github.com/statechannels/go-nitro/internal/rpc"
+	"github.com/statechannels/go-nitro/internal/tracing"
 	"github.com/statechannels/go-nitro/node"
 	"github.com/statechannels/go-nitro/node/engine"
 	"github.com/statechannels/go-nitro/node/engine/chainservice"
 	p2pms "github.com/statechannels/go-nitro/node/engine/messageservice/p2p-message-service"
 	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/node/notifier"
 	"github.com/statechannels/go-nitro/paymentsmanager"
 	"github.com/urfave/cli/v2"
 	"github.com/urfave/cli/v2/altsrc"
@@ -49,26 +55,76 @@ func main() {
 		EXT_MULTIADDR         = "extMultiAddr"
 
 		// Keys
-		KEYS_CATEGORY = "Keys:"
-		PK            = "pk"
-		CHAIN_PK      = "chainpk"
+		KEYS_CATEGORY       = "Keys:"
+		PK                  = "pk"
+		KEYSTORE_FILE       = "keystorefile"
+		KEYSTORE_PASSPHRASE = "keystorepassphrase"
+		REMOTE_SIGNER       = "remotesigner"
+		CHAIN_PK            = "chainpk"
 
 		// Storage
 		STORAGE_CATEGORY     = "Storage:"
 		USE_DURABLE_STORE    = "usedurablestore"
 		DURABLE_STORE_FOLDER = "durablestorefolder"
+		SQL_DRIVER           = "sqldriver"
+		SQL_DATA_SOURCE      = "sqldatasource"
+		STORE_ENCRYPTION_KEY = "storeencryptionkey"
+		STORE_KEY_FILE       = "storekeyfile"
+		STORE_PASSPHRASE     = "storepassphrase"
+
+		// Policy
+		POLICY_CATEGORY = "Policy:"
+		MANUAL_APPROVAL = "manualapproval"
+
+		// Watchtower
+		WATCHTOWER_CATEGORY          = "Watchtower:"
+		WATCHTOWER_URL               = "watchtowerurl"
+		WATCHTOWER_TLS               = "watchtowertls"
+		WATCHTOWER_COUNTER_CHALLENGE = "watchtowercounterchallenge"
+		WATCHTOWER_RESPOND           = "watchtowerrespond"
+
+		// Observability
+		OBSERVABILITY_CATEGORY = "Observability:"
+		METRICS_PORT           = "metricsport"
+		TRACING_ENDPOINT       = "tracingendpoint"
+		TRACING_FILE           = "tracingfile"
+
+		// Notifications
+		NOTIFICATIONS_CATEGORY = "Notifications:"
+		WEBHOOK_URLS           = "webhookurls"
+		WEBHOOK_SECRET         = "webhooksecret"
+
+		// Fees
+		FEES_CATEGORY   = "Fees:"
+		MAX_GAS_TIP_CAP = "maxgastipcap"
+		MAX_GAS_FEE_CAP = "maxgasfeecap"
+		STUCK_TX_BLOCKS = "stucktxblocks"
+
+		// Laconicd
+		LACONICD_CATEGORY     = "Laconicd:"
+		LACONICD_URL          = "laconicdurl"
+		LACONICD_PK           = "laconicdpk"
+		LACONICD_GAS_LIMIT    = "laconicdgaslimit"
+		LACONICD_GAS_PRICE    = "laconicdgasprice"
+		LACONICD_DENOM        = "laconicddenom"
+		LACONICD_START_HEIGHT = "laconicdstartheight"
 
 		// TLS
 		TLS_CATEGORY      = "TLS:"
 		TLS_CERT_FILEPATH = "tlscertfilepath"
 		TLS_KEY_FILEPATH  = "tlskeyfilepath"
 	)
-	var pkString, chainUrl, chainAuthToken, naAddress, vpaAddress, caAddress, bridgeAddress, chainPk, durableStoreFolder, bootPeers, publicIp, extMultiAddr string
-	var msgPort, wsMsgPort, rpcPort, guiPort int
+	var pkString, chainUrl, chainAuthToken, naAddress, vpaAddress, caAddress, bridgeAddress, chainPk, durableStoreFolder, bootPeers, publicIp, extMultiAddr, watchtowerUrl, sqlDriver, sqlDataSource, storeEncryptionKey, storeKeyFile, storePassphrase, keystoreFile, keystorePassphrase, remoteSigner string
+	var msgPort, wsMsgPort, rpcPort, guiPort, metricsPort int
 	var chainStartBlock uint64
-	var useNats, useDurableStore, l2 bool
+	var maxGasTipCap, maxGasFeeCap, stuckTxBlocks uint64
+	var laconicdUrl, laconicdPk, laconicdDenom string
+	var laconicdGasLimit, laconicdGasPrice, laconicdStartHeight uint64
+	var useNats, useDurableStore, l2, manualApproval, watchtowerTls, watchtowerCounterChallenge, watchtowerRespond bool
 
 	var tlsCertFilepath, tlsKeyFilepath string
+	var tracingEndpoint, tracingFile string
+	var webhookUrls, webhookSecret string
 
 	// urfave default precedence for flag value sources (highest to lowest):
 	// 1. Command line flag value
@@ -111,6 +167,25 @@ func main() {
 			Destination: &pkString,
 			EnvVars:     []string{"SC_PK"},
 		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        KEYSTORE_FILE,
+			Usage:       "Specifies an encrypted keystore file holding the private key used by the nitro node. Takes precedence over the private key.",
+			Category:    KEYS_CATEGORY,
+			Destination: &keystoreFile,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        KEYSTORE_PASSPHRASE,
+			Usage:       "Specifies the passphrase of the keystore file.",
+			Category:    KEYS_CATEGORY,
+			Destination: &keystorePassphrase,
+			EnvVars:     []string{"KEYSTORE_PASSPHRASE"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        REMOTE_SIGNER,
+			Usage:       "Specifies the unix socket of a remote signer used by the nitro node, so that its private key is never loaded. Takes precedence over the keystore file and private key. The private key, if supplied, is only used as the message service identity.",
+			Category:    KEYS_CATEGORY,
+			Destination: &remoteSigner,
+		}),
 		altsrc.NewStringFlag(&cli.StringFlag{
 			Name:        CHAIN_URL,
 			Usage:       "Specifies the url of a RPC endpoint for the chain.",
@@ -209,6 +284,38 @@ func main() {
 			Category:    CONNECTIVITY_CATEGORY,
 			Destination: &guiPort,
 		}),
+		altsrc.NewIntFlag(&cli.IntFlag{
+			Name:        METRICS_PORT,
+			Usage:       "Specifies the tcp port on which Prometheus metrics are served at /metrics. Metrics are not served if 0.",
+			Value:       0,
+			Category:    OBSERVABILITY_CATEGORY,
+			Destination: &metricsPort,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        TRACING_ENDPOINT,
+			Usage:       "Specifies the address of an OTLP collector (e.g. localhost:4317) to which trace spans are exported over gRPC.",
+			Category:    OBSERVABILITY_CATEGORY,
+			Destination: &tracingEndpoint,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        TRACING_FILE,
+			Usage:       "Specifies a file to which trace spans are written as JSON, if no tracing endpoint is given.",
+			Category:    OBSERVABILITY_CATEGORY,
+			Destination: &tracingFile,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        WEBHOOK_URLS,
+			Usage:       "Comma-delimited list of urls to which signed JSON notifications of objectives, channel updates and received vouchers are POSTed.",
+			Value:       "",
+			Category:    NOTIFICATIONS_CATEGORY,
+			Destination: &webhookUrls,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        WEBHOOK_SECRET,
+			Usage:       "Specifies the secret with which notifications are signed, as a hex encoded HMAC-SHA256 of the body in the X-Nitro-Signature header. Required if webhook urls are given.",
+			Category:    NOTIFICATIONS_CATEGORY,
+			Destination: &webhookSecret,
+		}),
 		altsrc.NewStringFlag(&cli.StringFlag{
 			Name:        DURABLE_STORE_FOLDER,
 			Usage:       "Specifies the folder for the durable store data storage.",
@@ -216,6 +323,39 @@ func main() {
 			Destination: &durableStoreFolder,
 			Value:       "./data/nitro-store",
 		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        SQL_DRIVER,
+			Usage:       "Specifies the driver of a SQL store (\"sqlite\" or \"postgres\"). If specified, a SQL store is used instead of the durable or in-memory store.",
+			Category:    STORAGE_CATEGORY,
+			Destination: &sqlDriver,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        SQL_DATA_SOURCE,
+			Usage:       "Specifies the data source of the SQL store. For sqlite it defaults to a file in the durable store folder.",
+			Category:    STORAGE_CATEGORY,
+			Destination: &sqlDataSource,
+			EnvVars:     []string{"SQL_DATA_SOURCE"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_ENCRYPTION_KEY,
+			Usage:       "Specifies a hex encoded 32 byte key used to encrypt the durable store at rest.",
+			Category:    STORAGE_CATEGORY,
+			Destination: &storeEncryptionKey,
+			EnvVars:     []string{"STORE_ENCRYPTION_KEY"},
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_KEY_FILE,
+			Usage:       "Specifies a file containing a hex encoded 32 byte key used to encrypt the durable store at rest.",
+			Category:    STORAGE_CATEGORY,
+			Destination: &storeKeyFile,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        STORE_PASSPHRASE,
+			Usage:       "Specifies a passphrase from which the durable store encryption key is derived. Ignored if an encryption key is supplied.",
+			Category:    STORAGE_CATEGORY,
+			Destination: &storePassphrase,
+			EnvVars:     []string{"STORE_PASSPHRASE"},
+		}),
 		altsrc.NewStringFlag(&cli.StringFlag{
 			Name:        BOOT_PEERS,
 			Usage:       "Comma-delimited list of peer multiaddrs the messaging service will connect to when initialized.",
@@ -223,6 +363,41 @@ func main() {
 			Category:    CONNECTIVITY_CATEGORY,
 			Destination: &bootPeers,
 		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:        MANUAL_APPROVAL,
+			Usage:       "Specifies whether objectives proposed by peers must be approved by an operator via the RPC API before they progress.",
+			Value:       false,
+			Category:    POLICY_CATEGORY,
+			Destination: &manualApproval,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        WATCHTOWER_URL,
+			Usage:       "RPC url of a watchtower to upload ledger channel states to. If not specified, no states are uploaded.",
+			Value:       "",
+			Category:    WATCHTOWER_CATEGORY,
+			Destination: &watchtowerUrl,
+		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:        WATCHTOWER_TLS,
+			Usage:       "Specifies whether to use TLS when connecting to the watchtower.",
+			Value:       true,
+			Category:    WATCHTOWER_CATEGORY,
+			Destination: &watchtowerTls,
+		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:        WATCHTOWER_COUNTER_CHALLENGE,
+			Usage:       "Specifies whether uploaded states carry a challenge signature, allowing the watchtower to counter challenge rather than checkpoint.",
+			Value:       false,
+			Category:    WATCHTOWER_CATEGORY,
+			Destination: &watchtowerCounterChallenge,
+		}),
+		altsrc.NewBoolFlag(&cli.BoolFlag{
+			Name:        WATCHTOWER_RESPOND,
+			Usage:       "Specifies whether the node checkpoints the latest supported state of its channels when a challenge is registered against a stale state. The [watchtower] section of the config file configures the responses instead.",
+			Value:       false,
+			Category:    WATCHTOWER_CATEGORY,
+			Destination: &watchtowerRespond,
+		}),
 		altsrc.NewStringFlag(&cli.StringFlag{
 			Name:        TLS_CERT_FILEPATH,
 			Usage:       "Filepath to the TLS certificate. If not specified, TLS will not be used with the RPC transport.",
@@ -237,6 +412,69 @@ func main() {
 			Category:    TLS_CATEGORY,
 			Destination: &tlsKeyFilepath,
 		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        MAX_GAS_TIP_CAP,
+			Usage:       "Specifies the highest priority fee per gas, in wei, offered for chain transactions. If 0, the suggested priority fee is offered.",
+			Value:       0,
+			Category:    FEES_CATEGORY,
+			Destination: &maxGasTipCap,
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        MAX_GAS_FEE_CAP,
+			Usage:       "Specifies the highest fee per gas, in wei, offered for chain transactions. If 0, the fee per gas is not limited.",
+			Value:       0,
+			Category:    FEES_CATEGORY,
+			Destination: &maxGasFeeCap,
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        STUCK_TX_BLOCKS,
+			Usage:       "Specifies the number of blocks a chain transaction may stay pending before it is resubmitted with higher fees.",
+			Value:       chainservice.DEFAULT_STUCK_TX_BLOCKS,
+			Category:    FEES_CATEGORY,
+			Destination: &stuckTxBlocks,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        LACONICD_URL,
+			Usage:       "Specifies the CometBFT RPC url of the laconicd node used by an L2 node.",
+			Value:       "http://127.0.0.1:26657",
+			Category:    LACONICD_CATEGORY,
+			Destination: &laconicdUrl,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        LACONICD_PK,
+			Usage:       "Specifies the private key of the laconicd account which pays for the transactions of an L2 node.",
+			Category:    LACONICD_CATEGORY,
+			Destination: &laconicdPk,
+			EnvVars:     []string{"LACONICD_PK"},
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        LACONICD_GAS_LIMIT,
+			Usage:       "Specifies the gas limit of laconicd transactions.",
+			Value:       chainservice.LACONICD_GAS_LIMIT,
+			Category:    LACONICD_CATEGORY,
+			Destination: &laconicdGasLimit,
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        LACONICD_GAS_PRICE,
+			Usage:       "Specifies the fee paid per unit of gas for laconicd transactions.",
+			Value:       0,
+			Category:    LACONICD_CATEGORY,
+			Destination: &laconicdGasPrice,
+		}),
+		altsrc.NewStringFlag(&cli.StringFlag{
+			Name:        LACONICD_DENOM,
+			Usage:       "Specifies the denomination laconicd transaction fees are paid in.",
+			Value:       chainservice.LACONICD_DENOM,
+			Category:    LACONICD_CATEGORY,
+			Destination: &laconicdDenom,
+		}),
+		altsrc.NewUint64Flag(&cli.Uint64Flag{
+			Name:        LACONICD_START_HEIGHT,
+			Usage:       "Specifies the laconicd block height to start looking for nitro module events.",
+			Value:       0,
+			Category:    LACONICD_CATEGORY,
+			Destination: &laconicdStartHeight,
+		}),
 	}
 	app := &cli.App{
 		Name:   "go-nitro",
@@ -246,11 +484,40 @@ func main() {
 		Action: func(cCtx *cli.Context) error {
 			chainPk = utils.TrimHexPrefix(chainPk)
 			pkString = utils.TrimHexPrefix(pkString)
+			laconicdPk = utils.TrimHexPrefix(laconicdPk)
+
+			signer, err := utils.NewSigner(common.Hex2Bytes(pkString), keystoreFile, keystorePassphrase, remoteSigner)
+			if err != nil {
+				return err
+			}
+
+			shutdownTracing, err := tracing.Setup(tracing.Opts{
+				CollectorEndpoint: tracingEndpoint,
+				File:              tracingFile,
+				ServiceName:       "go-nitro " + signer.Address().String(),
+			})
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err := shutdownTracing(context.Background())
+				if err != nil {
+					slog.Error("failed to flush trace spans", "err", err)
+				}
+			}()
+
+			storeEncryption, err := utils.StoreEncryptionOpts(storeEncryptionKey, storeKeyFile, storePassphrase)
+			if err != nil {
+				return err
+			}
 
 			storeOpts := store.StoreOpts{
 				PkBytes:            common.Hex2Bytes(pkString),
 				UseDurableStore:    useDurableStore,
 				DurableStoreFolder: durableStoreFolder,
+				SQLDriver:          sqlDriver,
+				SQLDataSource:      sqlDataSource,
+				Encryption:         storeEncryption,
 			}
 
 			var peerSlice []string
@@ -267,14 +534,76 @@ func main() {
 				ExtMultiAddr: extMultiAddr,
 			}
 
+			var policymaker engine.PolicyMaker = &engine.PermissivePolicy{}
+			// Challenges are only responded to on chain when enabled by flag or configured in the [watchtower] section of the config file
+			var watchtowerRules *engine.WatchtowerRules
+			if watchtowerRespond {
+				defaultRules := engine.DefaultWatchtowerRules()
+				watchtowerRules = &defaultRules
+			}
+			if configPath := cCtx.String(CONFIG); configPath != "" {
+				rules, found, err := engine.LoadPolicyRules(configPath)
+				if err != nil {
+					return err
+				}
+				if found {
+					policymaker, err = engine.NewRulePolicy(rules)
+					if err != nil {
+						return err
+					}
+				}
+
+				configuredWatchtowerRules, found, err := engine.LoadWatchtowerRules(configPath)
+				if err != nil {
+					return err
+				}
+				if found {
+					watchtowerRules = &configuredWatchtowerRules
+				}
+			}
+			engineOpts := engine.EngineOpts{}
+			if watchtowerRules != nil {
+				engineOpts.Watchtower, err = engine.NewWatchtower(*watchtowerRules)
+				if err != nil {
+					return err
+				}
+			}
+			if configPath := cCtx.String(CONFIG); configPath != "" {
+				reaperRules, found, err := engine.LoadReaperRules(configPath)
+				if err != nil {
+					return err
+				}
+				if found {
+					engineOpts.Reaper, err = engine.NewReaper(reaperRules)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			if manualApproval {
+				policymaker = engine.NewApprovalQueue(policymaker, nil)
+			}
+
 			var node *node.Node
-			var err error
 			if l2 {
+				var client *chainservice.CometLaconicdClient
+				client, err = chainservice.NewCometLaconicdClient(context.Background(), chainservice.CometLaconicdClientOpts{
+					Url:       laconicdUrl,
+					AccountPk: common.Hex2Bytes(laconicdPk),
+					GasLimit:  laconicdGasLimit,
+					GasPrice:  new(big.Int).SetUint64(laconicdGasPrice),
+					Denom:     laconicdDenom,
+				})
+				if err != nil {
+					return err
+				}
 				chainOpts := chainservice.LaconicdChainOpts{
-					VpaAddress: common.HexToAddress(vpaAddress),
-					CaAddress:  common.HexToAddress(caAddress),
+					Client:      client,
+					StartHeight: laconicdStartHeight,
+					VpaAddress:  common.HexToAddress(vpaAddress),
+					CaAddress:   common.HexToAddress(caAddress),
 				}
-				node, _, _, _, err = nodeUtils.InitializeL2Node(chainOpts, storeOpts, messageOpts, &engine.PermissivePolicy{})
+				node, _, _, _, err = nodeUtils.InitializeL2Node(chainOpts, storeOpts, messageOpts, signer, policymaker, engineOpts)
 			} else {
 				chainOpts := chainservice.ChainOpts{
 					ChainUrl:           chainUrl,
@@ -284,9 +613,16 @@ func main() {
 					NaAddress:          common.HexToAddress(naAddress),
 					VpaAddress:         common.HexToAddress(vpaAddress),
 					CaAddress:          common.HexToAddress(caAddress),
+					FeeOpts:            chainservice.FeeOpts{StuckTxBlocks: stuckTxBlocks},
+				}
+				if maxGasTipCap > 0 {
+					chainOpts.FeeOpts.MaxGasTipCap = new(big.Int).SetUint64(maxGasTipCap)
+				}
+				if maxGasFeeCap > 0 {
+					chainOpts.FeeOpts.MaxGasFeeCap = new(big.Int).SetUint64(maxGasFeeCap)
 				}
 
-				node, _, _, _, err = nodeUtils.InitializeNode(chainOpts, storeOpts, messageOpts, &engine.PermissivePolicy{})
+				node, _, _, _, err = nodeUtils.InitializeNode(chainOpts, storeOpts, messageOpts, signer, policymaker, engineOpts)
 			}
 
 			logging.SetupDefaultLogger(os.Stdout, slog.LevelDebug)
@@ -295,6 +631,15 @@ func main() {
 				return err
 			}
 
+			if webhookUrls != "" {
+				for _, url := range strings.Split(webhookUrls, ",") {
+					err = node.AddWebhook(notifier.WebhookOpts{Url: url, Secret: webhookSecret})
+					if err != nil {
+						return err
+					}
+				}
+			}
+
 			paymentsManager, err := paymentsmanager.NewPaymentsManager(node)
 			if err != nil {
 				return err
@@ -325,6 +670,28 @@ func main() {
 				return err
 			}
 
+			if watchtowerUrl != "" {
+				var challenger crypto.Signer
+				if watchtowerCounterChallenge {
+					challenger = signer
+				}
+				watchtowerPusher, err := rpc.StartWatchtowerPusher(node, watchtowerUrl, watchtowerTls, challenger)
+				if err != nil {
+					return err
+				}
+				defer func() {
+					err := watchtowerPusher.Close()
+					if err != nil {
+						panic(err)
+					}
+				}()
+			}
+
+			if metricsPort != 0 {
+				metricsServer := metrics.Serve(metricsPort)
+				defer metricsServer.Close()
+			}
+
 			hostNitroUI(uint(guiPort), uint(rpcPort))
 
 			stopChan := make(chan os.Signal, 2)
@@ -18,6 +18,24 @@ func SignChallengeMessage(s state.State, privateKey []byte) (state.Signature, er
 	return nc.SignEthereumMessage(challengeHash[:], privateKey)
 }
 
+// SignChallengeMessageWith generates the challenge signature for s using the supplied signer, as SignChallengeMessage does.
+func SignChallengeMessageWith(s state.State, signer nc.Signer) (state.Signature, error) {
+	challengeHash, err := hashChallengeMessage(s)
+	if err != nil {
+		return state.Signature{}, err
+	}
+	return nc.SignEthereumMessageWith(challengeHash[:], signer)
+}
+
+// RecoverChallengeMessageSigner returns the address which produced the challenge signature for s.
+func RecoverChallengeMessageSigner(s state.State, sig state.Signature) (types.Address, error) {
+	challengeHash, err := hashChallengeMessage(s)
+	if err != nil {
+		return types.Address{}, err
+	}
+	return nc.RecoverEthereumMessageSigner(challengeHash[:], sig)
+}
+
 func hashChallengeMessage(s state.State) (types.Bytes32, error) {
 	digest, err := s.Hash()
 	if err != nil {
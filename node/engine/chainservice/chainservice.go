@@ -122,6 +122,11 @@ func (cr ChallengeRegisteredEvent) StateHash(fp state.FixedPart) (common.Hash, e
 	return state.StateFromFixedAndVariablePart(fp, cr.candidate).Hash()
 }
 
+// TurnNum returns the turn number of the state which was challenged.
+func (cr ChallengeRegisteredEvent) TurnNum() uint64 {
+	return cr.candidate.TurnNum
+}
+
 // Outcome returns the outcome which will have been stored on chain in the adjudicator after the ChallengeRegistered Event fires.
 func (cr ChallengeRegisteredEvent) Outcome() outcome.Exit {
 	return cr.candidate.Outcome
@@ -174,6 +179,24 @@ func (re ReclaimedEvent) String() string {
 	return "Reclaim event for Channel " + re.channelID.String() + " at Block " + fmt.Sprint(re.block.BlockNum)
 }
 
+// RetractedEvent reports that a reorg removed the blocks of chain events which were dispatched for a channel.
+// Its block is the common ancestor of the removed blocks and the canonical chain, to which the channel's on chain data is rolled back.
+// The events of the canonical chain after the common ancestor are dispatched again once they have enough confirmations.
+type RetractedEvent struct {
+	commonEvent
+	// Retracted holds the retracted events, oldest first
+	Retracted []Event
+}
+
+func (re RetractedEvent) String() string {
+	return fmt.Sprint(len(re.Retracted)) + " chain events retracted for Channel " + re.channelID.String() + " back to Block " + fmt.Sprint(re.block.BlockNum)
+}
+
+// NewRetractedEvent constructs a RetractedEvent for events which were dispatched after the common ancestor block
+func NewRetractedEvent(channelId types.Destination, commonAncestor Block, retracted []Event) RetractedEvent {
+	return RetractedEvent{commonEvent: commonEvent{channelID: channelId, block: commonAncestor}, Retracted: retracted}
+}
+
 type AssetMapUpdatedEvent struct {
 	commonEvent
 	L1AssetAddress, L2AssetAddress common.Address
@@ -198,16 +221,16 @@ type ChainEventHandler interface {
 }
 
 type ChainService interface {
-	// EventEngineFeed returns a chan for receiving events from the chain service
+	// EventEngineFeed returns a chan for receiving events from the chain service.
+	// Should a reorg remove the block of a dispatched event, a RetractedEvent is sent for its channel.
 	EventEngineFeed() <-chan Event
 	// EventFeed returns a chan for receiving bridge events from the chain service
 	EventFeed() <-chan Event
-	// Dropped event engine feed returns a chan for catching dropped events from chain service used by engine
-	DroppedEventEngineFeed() <-chan protocols.DroppedEventInfo
-	// TODO: Add comment
-	DroppedEventFeed() <-chan protocols.DroppedEventInfo
 	// SendTransaction is for sending transactions with the chain service
 	SendTransaction(protocols.ChainTransaction) (*ethTypes.Transaction, error)
+	// SubmitTransaction records a transaction submitted on behalf of an objective in the outbox, and submits it.
+	// The chain service resubmits the transaction should it be lost to a restart or a reorg before it is confirmed.
+	SubmitTransaction(objectiveId protocols.ObjectiveId, tx protocols.ChainTransaction) error
 	// GetConsensusAppAddress returns the address of a deployed ConsensusApp (for ledger channels)
 	GetConsensusAppAddress() types.Address
 	// GetVirtualPaymentAppAddress returns the address of a deployed VirtualPaymentApp
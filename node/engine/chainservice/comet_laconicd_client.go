@@ -0,0 +1,593 @@
+package chainservice
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/btcsuite/btcutil/bech32"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/statechannels/go-nitro/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	LACONICD_GAS_LIMIT      = 500_000
+	LACONICD_DENOM          = "alnt"
+	LACONICD_ADDRESS_PREFIX = "laconic"
+)
+
+// The protobuf type urls of the messages and queries used by the client
+const (
+	laconicdDepositMsgUrl     = "/cerc.nitro.v1.MsgDeposit"
+	laconicdConcludeMsgUrl    = "/cerc.nitro.v1.MsgConclude"
+	laconicdChallengeMsgUrl   = "/cerc.nitro.v1.MsgChallenge"
+	laconicdCheckpointMsgUrl  = "/cerc.nitro.v1.MsgCheckpoint"
+	laconicdTransferAllMsgUrl = "/cerc.nitro.v1.MsgTransferAll"
+	laconicdHoldingsQueryPath = "/cerc.nitro.v1.Query/Holdings"
+	laconicdAccountQueryPath  = "/cosmos.auth.v1beta1.Query/Account"
+	laconicdEthAccountUrl     = "/ethermint.types.v1.EthAccount"
+	laconicdPubKeyUrl         = "/ethermint.crypto.v1.ethsecp256k1.PubKey"
+	laconicdSignModeDirect    = 1
+)
+
+type CometLaconicdClientOpts struct {
+	// Url is the CometBFT RPC endpoint of a laconicd node, e.g. http://127.0.0.1:26657
+	Url string
+	// AccountPk is the eth_secp256k1 private key of the account which signs and pays for the client's transactions
+	AccountPk []byte
+	// GasLimit is the gas limit of each transaction. Defaults to LACONICD_GAS_LIMIT.
+	GasLimit uint64
+	// GasPrice is the fee paid per unit of gas, in Denom. Defaults to zero.
+	GasPrice *big.Int
+	// Denom is the denomination fees are paid in. Defaults to LACONICD_DENOM.
+	Denom string
+	// AddressPrefix is the bech32 prefix of account addresses. Defaults to LACONICD_ADDRESS_PREFIX.
+	AddressPrefix string
+}
+
+// CometLaconicdClient is a LaconicdClient which talks to a laconicd node over its CometBFT JSON-RPC endpoint.
+//
+// Messages are signed in SIGN_MODE_DIRECT with an Ethermint eth_secp256k1 key. Events are read from block results,
+// so the node must run CometBFT v0.37 or later, which reports event attributes as plain strings.
+type CometLaconicdClient struct {
+	url           string
+	httpClient    *http.Client
+	key           *ecdsa.PrivateKey
+	address       common.Address
+	accAddress    string
+	cosmosChainId string
+	chainId       *big.Int
+	gasLimit      uint64
+	gasPrice      *big.Int
+	denom         string
+	requestId     atomic.Uint64
+}
+
+// NewCometLaconicdClient creates a client for the laconicd node at opts.Url. It fails if the node cannot be reached.
+func NewCometLaconicdClient(ctx context.Context, opts CometLaconicdClientOpts) (*CometLaconicdClient, error) {
+	key, err := ethcrypto.ToECDSA(opts.AccountPk)
+	if err != nil {
+		return nil, fmt.Errorf("invalid laconicd account key: %w", err)
+	}
+	if opts.GasLimit == 0 {
+		opts.GasLimit = LACONICD_GAS_LIMIT
+	}
+	if opts.GasPrice == nil {
+		opts.GasPrice = big.NewInt(0)
+	}
+	if opts.Denom == "" {
+		opts.Denom = LACONICD_DENOM
+	}
+	if opts.AddressPrefix == "" {
+		opts.AddressPrefix = LACONICD_ADDRESS_PREFIX
+	}
+
+	address := ethcrypto.PubkeyToAddress(key.PublicKey)
+	words, err := bech32.ConvertBits(address.Bytes(), 8, 5, true)
+	if err != nil {
+		return nil, err
+	}
+	accAddress, err := bech32.Encode(opts.AddressPrefix, words)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &CometLaconicdClient{
+		url:        opts.Url,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		key:        key,
+		address:    address,
+		accAddress: accAddress,
+		gasLimit:   opts.GasLimit,
+		gasPrice:   opts.GasPrice,
+		denom:      opts.Denom,
+	}
+
+	status, err := c.status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach laconicd at %s: %w", opts.Url, err)
+	}
+	c.cosmosChainId = status.NodeInfo.Network
+	c.chainId, err = parseEthermintChainId(c.cosmosChainId)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// parseEthermintChainId returns the EIP-155 chain id of an Ethermint chain id, which has the form <name>_<EIP-155 id>-<version>
+func parseEthermintChainId(chainId string) (*big.Int, error) {
+	_, rest, ok := strings.Cut(chainId, "_")
+	if ok {
+		rest, _, ok = strings.Cut(rest, "-")
+	}
+	id, isNumber := new(big.Int).SetString(rest, 10)
+	if !ok || !isNumber {
+		return nil, fmt.Errorf("chain id %q is not an Ethermint chain id", chainId)
+	}
+	return id, nil
+}
+
+func (c *CometLaconicdClient) Address() common.Address {
+	return c.address
+}
+
+func (c *CometLaconicdClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.chainId), nil
+}
+
+func (c *CometLaconicdClient) LatestHeight(ctx context.Context) (uint64, error) {
+	status, err := c.status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(status.SyncInfo.LatestBlockHeight, 10, 64)
+}
+
+func (c *CometLaconicdClient) Block(ctx context.Context, height uint64) (LaconicdBlock, error) {
+	params := map[string]any{"height": strconv.FormatUint(height, 10)}
+
+	var block cometBlockResult
+	err := c.call(ctx, "block", params, &block)
+	if err != nil {
+		return LaconicdBlock{}, err
+	}
+	var results cometBlockResultsResult
+	err = c.call(ctx, "block_results", params, &results)
+	if err != nil {
+		return LaconicdBlock{}, err
+	}
+	if len(results.TxsResults) != len(block.Block.Data.Txs) {
+		return LaconicdBlock{}, fmt.Errorf("block %d has %d txs but %d tx results", height, len(block.Block.Data.Txs), len(results.TxsResults))
+	}
+
+	blockTime, err := time.Parse(time.RFC3339Nano, block.Block.Header.Time)
+	if err != nil {
+		return LaconicdBlock{}, err
+	}
+
+	lb := LaconicdBlock{Height: height, Time: uint64(blockTime.Unix())}
+	for i, result := range results.TxsResults {
+		// Failed transactions have no effect, even though their events may be reported
+		if result.Code != 0 {
+			continue
+		}
+		tx, err := base64.StdEncoding.DecodeString(block.Block.Data.Txs[i])
+		if err != nil {
+			return LaconicdBlock{}, err
+		}
+		txHash := common.Hash(sha256.Sum256(tx))
+		for _, event := range result.Events {
+			if _, ok := laconicdEventNames[event.Type]; !ok {
+				continue
+			}
+			attributes := make(map[string]string, len(event.Attributes))
+			for _, attribute := range event.Attributes {
+				attributes[attribute.Key] = attribute.Value
+			}
+			lb.Events = append(lb.Events, LaconicdEvent{Type: event.Type, Attributes: attributes, TxHash: txHash, TxIndex: uint(i)})
+		}
+	}
+	return lb, nil
+}
+
+func (c *CometLaconicdClient) Holdings(ctx context.Context, asset common.Address, channelId types.Destination) (*big.Int, error) {
+	var request []byte
+	request = appendProtoString(request, 1, channelId.String())
+	request = appendProtoString(request, 2, asset.Hex())
+
+	response, err := c.query(ctx, laconicdHoldingsQueryPath, request)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := parseProto(response)
+	if err != nil {
+		return nil, err
+	}
+	amount := string(fields.bytes(1))
+	if amount == "" {
+		return big.NewInt(0), nil
+	}
+	holdings, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("holdings %q is not a number", amount)
+	}
+	return holdings, nil
+}
+
+func (c *CometLaconicdClient) Broadcast(ctx context.Context, msg LaconicdMsg) (common.Hash, error) {
+	encodedMsg, err := c.encodeMsg(msg)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	accountNumber, sequence, err := c.account(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	tx := c.signTx(encodedMsg, accountNumber, sequence)
+
+	var result cometBroadcastResult
+	err = c.call(ctx, "broadcast_tx_commit", map[string]any{"tx": base64.StdEncoding.EncodeToString(tx)}, &result)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if result.CheckTx.Code != 0 {
+		return common.Hash{}, &LaconicdTxError{Code: result.CheckTx.Code, Log: result.CheckTx.Log}
+	}
+	// CometBFT v0.38 reports the result of executing the transaction as tx_result, and v0.37 as deliver_tx
+	txResult := result.TxResult
+	if txResult == nil {
+		txResult = result.DeliverTx
+	}
+	if txResult != nil && txResult.Code != 0 {
+		return common.Hash{}, &LaconicdTxError{Code: txResult.Code, Log: txResult.Log}
+	}
+	return common.HexToHash(result.Hash), nil
+}
+
+// encodeMsg encodes a message as a protobuf Any. Signed states and outcomes are carried as JSON, as they are in the module's events.
+func (c *CometLaconicdClient) encodeMsg(msg LaconicdMsg) ([]byte, error) {
+	var typeUrl string
+	var fields []byte
+	fields = appendProtoString(fields, 1, c.accAddress)
+
+	switch msg := msg.(type) {
+	case LaconicdDepositMsg:
+		typeUrl = laconicdDepositMsgUrl
+		fields = appendProtoString(fields, 2, msg.ChannelId.String())
+		fields = appendProtoString(fields, 3, msg.Asset.Hex())
+		fields = appendProtoString(fields, 4, msg.ExpectedHeld.String())
+		fields = appendProtoString(fields, 5, msg.Amount.String())
+	case LaconicdConcludeMsg:
+		typeUrl = laconicdConcludeMsgUrl
+		candidate, err := json.Marshal(msg.Candidate)
+		if err != nil {
+			return nil, err
+		}
+		fields = appendProtoBytes(fields, 2, candidate)
+	case LaconicdChallengeMsg:
+		typeUrl = laconicdChallengeMsgUrl
+		candidate, err := json.Marshal(msg.Candidate)
+		if err != nil {
+			return nil, err
+		}
+		proof, err := json.Marshal(msg.Proof)
+		if err != nil {
+			return nil, err
+		}
+		challengerSig, err := json.Marshal(msg.ChallengerSig)
+		if err != nil {
+			return nil, err
+		}
+		fields = appendProtoBytes(fields, 2, candidate)
+		fields = appendProtoBytes(fields, 3, proof)
+		fields = appendProtoBytes(fields, 4, challengerSig)
+	case LaconicdCheckpointMsg:
+		typeUrl = laconicdCheckpointMsgUrl
+		candidate, err := json.Marshal(msg.Candidate)
+		if err != nil {
+			return nil, err
+		}
+		proof, err := json.Marshal(msg.Proof)
+		if err != nil {
+			return nil, err
+		}
+		fields = appendProtoBytes(fields, 2, candidate)
+		fields = appendProtoBytes(fields, 3, proof)
+	case LaconicdTransferAllMsg:
+		typeUrl = laconicdTransferAllMsgUrl
+		exit, err := json.Marshal(msg.Outcome)
+		if err != nil {
+			return nil, err
+		}
+		fields = appendProtoString(fields, 2, msg.ChannelId.String())
+		fields = appendProtoBytes(fields, 3, exit)
+		fields = appendProtoString(fields, 4, msg.StateHash.Hex())
+	default:
+		return nil, fmt.Errorf("unexpected laconicd message %T", msg)
+	}
+	return protoAny(typeUrl, fields), nil
+}
+
+// signTx builds a TxRaw carrying a single message, signed in SIGN_MODE_DIRECT
+func (c *CometLaconicdClient) signTx(msg []byte, accountNumber uint64, sequence uint64) []byte {
+	body := appendProtoBytes(nil, 1, msg)
+
+	pubKey := appendProtoBytes(nil, 1, ethcrypto.CompressPubkey(&c.key.PublicKey))
+	modeInfo := appendProtoBytes(nil, 1, protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), laconicdSignModeDirect))
+	var signerInfo []byte
+	signerInfo = appendProtoBytes(signerInfo, 1, protoAny(laconicdPubKeyUrl, pubKey))
+	signerInfo = appendProtoBytes(signerInfo, 2, modeInfo)
+	signerInfo = appendProtoUint(signerInfo, 3, sequence)
+
+	var fee []byte
+	feeAmount := new(big.Int).Mul(c.gasPrice, new(big.Int).SetUint64(c.gasLimit))
+	if feeAmount.Sign() > 0 {
+		var coin []byte
+		coin = appendProtoString(coin, 1, c.denom)
+		coin = appendProtoString(coin, 2, feeAmount.String())
+		fee = appendProtoBytes(fee, 1, coin)
+	}
+	fee = appendProtoUint(fee, 2, c.gasLimit)
+
+	var authInfo []byte
+	authInfo = appendProtoBytes(authInfo, 1, signerInfo)
+	authInfo = appendProtoBytes(authInfo, 2, fee)
+
+	var signDoc []byte
+	signDoc = appendProtoBytes(signDoc, 1, body)
+	signDoc = appendProtoBytes(signDoc, 2, authInfo)
+	signDoc = appendProtoString(signDoc, 3, c.cosmosChainId)
+	signDoc = appendProtoUint(signDoc, 4, accountNumber)
+
+	// Ethermint keys sign the keccak256 hash of the sign doc. Signing a 32 byte digest with a valid key cannot fail.
+	signature, err := ethcrypto.Sign(ethcrypto.Keccak256(signDoc), c.key)
+	if err != nil {
+		panic(err)
+	}
+
+	var tx []byte
+	tx = appendProtoBytes(tx, 1, body)
+	tx = appendProtoBytes(tx, 2, authInfo)
+	tx = appendProtoBytes(tx, 3, signature)
+	return tx
+}
+
+// account returns the account number and next sequence of the client's account
+func (c *CometLaconicdClient) account(ctx context.Context) (accountNumber uint64, sequence uint64, err error) {
+	response, err := c.query(ctx, laconicdAccountQueryPath, appendProtoString(nil, 1, c.accAddress))
+	if err != nil {
+		return 0, 0, err
+	}
+	fields, err := parseProto(response)
+	if err != nil {
+		return 0, 0, err
+	}
+	anyFields, err := parseProto(fields.bytes(1))
+	if err != nil {
+		return 0, 0, err
+	}
+	baseAccount := anyFields.bytes(2)
+	// An EthAccount wraps the BaseAccount of an account
+	if string(anyFields.bytes(1)) == laconicdEthAccountUrl {
+		ethAccount, err := parseProto(baseAccount)
+		if err != nil {
+			return 0, 0, err
+		}
+		baseAccount = ethAccount.bytes(1)
+	}
+	accountFields, err := parseProto(baseAccount)
+	if err != nil {
+		return 0, 0, err
+	}
+	return accountFields.uint(3), accountFields.uint(4), nil
+}
+
+func (c *CometLaconicdClient) status(ctx context.Context) (cometStatusResult, error) {
+	var status cometStatusResult
+	err := c.call(ctx, "status", map[string]any{}, &status)
+	return status, err
+}
+
+// query makes an ABCI query and returns the value of the response
+func (c *CometLaconicdClient) query(ctx context.Context, path string, data []byte) ([]byte, error) {
+	var result cometAbciQueryResult
+	err := c.call(ctx, "abci_query", map[string]any{"path": path, "data": hex.EncodeToString(data)}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Response.Code != 0 {
+		return nil, fmt.Errorf("query %s failed: %s (code %d)", path, result.Response.Log, result.Response.Code)
+	}
+	return result.Response.Value, nil
+}
+
+// call makes a CometBFT JSON-RPC request
+func (c *CometLaconicdClient) call(ctx context.Context, method string, params map[string]any, result any) error {
+	request, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      c.requestId.Add(1),
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+
+	var response struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Data    string `json:"data"`
+		} `json:"error"`
+	}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return fmt.Errorf("%s: unexpected response (status %s): %w", method, httpResponse.Status, err)
+	}
+	if response.Error != nil {
+		return fmt.Errorf("%s: %s %s (code %d)", method, response.Error.Message, response.Error.Data, response.Error.Code)
+	}
+	return json.Unmarshal(response.Result, result)
+}
+
+type cometStatusResult struct {
+	NodeInfo struct {
+		Network string `json:"network"`
+	} `json:"node_info"`
+	SyncInfo struct {
+		LatestBlockHeight string `json:"latest_block_height"`
+	} `json:"sync_info"`
+}
+
+type cometBlockResult struct {
+	Block struct {
+		Header struct {
+			Time string `json:"time"`
+		} `json:"header"`
+		Data struct {
+			Txs []string `json:"txs"`
+		} `json:"data"`
+	} `json:"block"`
+}
+
+type cometBlockResultsResult struct {
+	TxsResults []cometTxResult `json:"txs_results"`
+}
+
+type cometTxResult struct {
+	Code   uint32 `json:"code"`
+	Log    string `json:"log"`
+	Events []struct {
+		Type       string `json:"type"`
+		Attributes []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"attributes"`
+	} `json:"events"`
+}
+
+type cometAbciQueryResult struct {
+	Response struct {
+		Code  uint32 `json:"code"`
+		Log   string `json:"log"`
+		Value []byte `json:"value"`
+	} `json:"response"`
+}
+
+type cometBroadcastResult struct {
+	CheckTx   cometTxResult  `json:"check_tx"`
+	TxResult  *cometTxResult `json:"tx_result"`
+	DeliverTx *cometTxResult `json:"deliver_tx"`
+	Hash      string         `json:"hash"`
+}
+
+// appendProtoString appends a string field to a protobuf message. As in proto3, empty values are omitted.
+func appendProtoString(b []byte, num protowire.Number, s string) []byte {
+	if s == "" {
+		return b
+	}
+	return appendProtoBytes(b, num, []byte(s))
+}
+
+// appendProtoBytes appends a bytes or embedded message field to a protobuf message
+func appendProtoBytes(b []byte, num protowire.Number, v []byte) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
+
+// appendProtoUint appends a uint64 field to a protobuf message. As in proto3, zero values are omitted.
+func appendProtoUint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+// protoAny encodes a google.protobuf.Any
+func protoAny(typeUrl string, value []byte) []byte {
+	b := appendProtoString(nil, 1, typeUrl)
+	return appendProtoBytes(b, 2, value)
+}
+
+// protoFields holds the last value of each bytes and varint field of a protobuf message
+type protoFields struct {
+	bytesFields  map[protowire.Number][]byte
+	varintFields map[protowire.Number]uint64
+}
+
+func (pf protoFields) bytes(num protowire.Number) []byte {
+	return pf.bytesFields[num]
+}
+
+func (pf protoFields) uint(num protowire.Number) uint64 {
+	return pf.varintFields[num]
+}
+
+// parseProto parses the top level fields of a protobuf message
+func parseProto(b []byte) (protoFields, error) {
+	pf := protoFields{bytesFields: map[protowire.Number][]byte{}, varintFields: map[protowire.Number]uint64{}}
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protoFields{}, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protoFields{}, protowire.ParseError(n)
+			}
+			pf.bytesFields[num] = v
+			b = b[n:]
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protoFields{}, protowire.ParseError(n)
+			}
+			pf.varintFields[num] = v
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protoFields{}, protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+	return pf, nil
+}
+
+var _ LaconicdClient = &CometLaconicdClient{}
@@ -0,0 +1,240 @@
+package chainservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// fakeComet answers the CometBFT JSON-RPC requests made by a CometLaconicdClient
+type fakeComet struct {
+	t          *testing.T
+	txs        [][]byte
+	txResults  []map[string]any
+	broadcasts [][]byte
+	// deliverCode is the result code of broadcast transactions
+	deliverCode uint32
+}
+
+func (fc *fakeComet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Id     uint64            `json:"id"`
+		Method string            `json:"method"`
+		Params map[string]string `json:"params"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		fc.t.Error(err)
+		return
+	}
+
+	var result any
+	switch request.Method {
+	case "status":
+		result = map[string]any{
+			"node_info": map[string]any{"network": "laconic_9000-1"},
+			"sync_info": map[string]any{"latest_block_height": "12"},
+		}
+	case "block":
+		txs := []string{}
+		for _, tx := range fc.txs {
+			txs = append(txs, base64.StdEncoding.EncodeToString(tx))
+		}
+		result = map[string]any{"block": map[string]any{
+			"header": map[string]any{"height": request.Params["height"], "time": "2024-05-01T10:00:00.123456789Z"},
+			"data":   map[string]any{"txs": txs},
+		}}
+	case "block_results":
+		result = map[string]any{"height": request.Params["height"], "txs_results": fc.txResults}
+	case "abci_query":
+		var value []byte
+		switch request.Params["path"] {
+		case laconicdAccountQueryPath:
+			var baseAccount []byte
+			baseAccount = appendProtoUint(baseAccount, 3, 7)
+			baseAccount = appendProtoUint(baseAccount, 4, 3)
+			ethAccount := appendProtoBytes(nil, 1, baseAccount)
+			value = appendProtoBytes(nil, 1, protoAny(laconicdEthAccountUrl, ethAccount))
+		case laconicdHoldingsQueryPath:
+			value = appendProtoString(nil, 1, "42")
+		default:
+			fc.t.Errorf("unexpected query %s", request.Params["path"])
+		}
+		result = map[string]any{"response": map[string]any{"code": 0, "value": value}}
+	case "broadcast_tx_commit":
+		tx, err := base64.StdEncoding.DecodeString(request.Params["tx"])
+		if err != nil {
+			fc.t.Error(err)
+		}
+		fc.broadcasts = append(fc.broadcasts, tx)
+		txHash := sha256.Sum256(tx)
+		result = map[string]any{
+			"check_tx":  map[string]any{"code": 0},
+			"tx_result": map[string]any{"code": fc.deliverCode, "log": "out of gas"},
+			"hash":      strings.ToUpper(hex.EncodeToString(txHash[:])),
+			"height":    "13",
+		}
+	default:
+		fc.t.Errorf("unexpected method %s", request.Method)
+	}
+
+	err = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.Id, "result": result})
+	if err != nil {
+		fc.t.Error(err)
+	}
+}
+
+func TestCometLaconicdClient(t *testing.T) {
+	channelId := types.Destination(common.HexToHash("0x01"))
+	depositedEvent := map[string]any{"type": LaconicdDepositedEvent, "attributes": []map[string]any{
+		{"key": LaconicdChannelIdAttribute, "value": channelId.String(), "index": true},
+		{"key": LaconicdAssetAttribute, "value": common.Address{}.Hex(), "index": true},
+	}}
+	transferEvent := map[string]any{"type": "transfer", "attributes": []map[string]any{}}
+
+	comet := &fakeComet{
+		t:   t,
+		txs: [][]byte{[]byte("tx0"), []byte("tx1"), []byte("tx2")},
+		txResults: []map[string]any{
+			{"code": 0, "events": []any{transferEvent, depositedEvent}},
+			// The events of failed transactions must be ignored
+			{"code": 5, "events": []any{depositedEvent}},
+			{"code": 0, "events": []any{depositedEvent}},
+		},
+	}
+	server := httptest.NewServer(comet)
+	defer server.Close()
+
+	client, err := NewCometLaconicdClient(context.Background(), CometLaconicdClientOpts{Url: server.URL, AccountPk: Alice.PrivateKey, GasPrice: big.NewInt(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Address() != Alice.Address() {
+		t.Fatalf("expected address %s, got %s", Alice.Address(), client.Address())
+	}
+
+	chainId, err := client.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chainId.Cmp(big.NewInt(9000)) != 0 {
+		t.Fatalf("expected chain id 9000, got %s", chainId)
+	}
+	height, err := client.LatestHeight(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 12 {
+		t.Fatalf("expected latest height 12, got %d", height)
+	}
+
+	block, err := client.Block(context.Background(), 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Height != 12 || block.Time != 1714557600 {
+		t.Fatalf("unexpected block height %d and time %d", block.Height, block.Time)
+	}
+	if len(block.Events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(block.Events))
+	}
+	for i, txIndex := range []uint{0, 2} {
+		event := block.Events[i]
+		if event.TxIndex != txIndex || event.TxHash != sha256.Sum256(comet.txs[txIndex]) {
+			t.Errorf("event %d: expected tx %d, got tx %d with hash %s", i, txIndex, event.TxIndex, event.TxHash)
+		}
+		if event.Type != LaconicdDepositedEvent || event.Attributes[LaconicdChannelIdAttribute] != channelId.String() {
+			t.Errorf("event %d: unexpected event %+v", i, event)
+		}
+	}
+
+	holdings, err := client.Holdings(context.Background(), common.Address{}, channelId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holdings.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("expected holdings 42, got %s", holdings)
+	}
+
+	msg := LaconicdDepositMsg{ChannelId: channelId, Asset: common.Address{}, ExpectedHeld: big.NewInt(0), Amount: big.NewInt(5)}
+	txHash, err := client.Broadcast(context.Background(), msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comet.broadcasts) != 1 {
+		t.Fatalf("expected 1 broadcast tx, got %d", len(comet.broadcasts))
+	}
+	tx := comet.broadcasts[0]
+	if txHash != sha256.Sum256(tx) {
+		t.Fatalf("expected tx hash %x, got %s", sha256.Sum256(tx), txHash)
+	}
+
+	txRaw, err := parseProto(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := parseProto(txRaw.bytes(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodedMsg, err := client.encodeMsg(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body.bytes(1)) != string(encodedMsg) {
+		t.Fatal("broadcast tx does not carry the deposit message")
+	}
+	authInfo, err := parseProto(txRaw.bytes(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signerInfo, err := parseProto(authInfo.bytes(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signerInfo.uint(3) != 3 {
+		t.Fatalf("expected sequence 3, got %d", signerInfo.uint(3))
+	}
+
+	// The signature must be over the sign doc of the account's number on this chain
+	var signDoc []byte
+	signDoc = appendProtoBytes(signDoc, 1, txRaw.bytes(1))
+	signDoc = appendProtoBytes(signDoc, 2, txRaw.bytes(2))
+	signDoc = appendProtoString(signDoc, 3, "laconic_9000-1")
+	signDoc = appendProtoUint(signDoc, 4, 7)
+	pubKey, err := ethcrypto.SigToPub(ethcrypto.Keccak256(signDoc), txRaw.bytes(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ethcrypto.PubkeyToAddress(*pubKey) != Alice.Address() {
+		t.Fatal("broadcast tx is not signed by the account")
+	}
+
+	comet.deliverCode = 11
+	_, err = client.Broadcast(context.Background(), msg)
+	var rejection *LaconicdTxError
+	if !errors.As(err, &rejection) || rejection.Code != 11 {
+		t.Fatalf("expected a failed transaction to return a rejection, got %v", err)
+	}
+}
+
+func TestParseEthermintChainId(t *testing.T) {
+	for _, chainId := range []string{"laconic-1", "laconic_abc-1", "laconic_9000"} {
+		_, err := parseEthermintChainId(chainId)
+		if err == nil {
+			t.Errorf("expected %q to be rejected", chainId)
+		}
+	}
+}
@@ -2,9 +2,11 @@ package chainservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -17,7 +19,7 @@ import (
 
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/internal/logging"
-	"github.com/statechannels/go-nitro/internal/safesync"
+	"github.com/statechannels/go-nitro/internal/metrics"
 	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
 	Token "github.com/statechannels/go-nitro/node/engine/chainservice/erc20"
 	chainutils "github.com/statechannels/go-nitro/node/engine/chainservice/utils"
@@ -33,6 +35,9 @@ type ChainOpts struct {
 	NaAddress          common.Address
 	VpaAddress         common.Address
 	CaAddress          common.Address
+	FeeOpts            FeeOpts
+	// Outbox persists submitted transactions until they are confirmed. If nil, transactions are held in memory.
+	Outbox TxOutbox
 }
 
 var (
@@ -75,6 +80,7 @@ type ethChain interface {
 	bind.ContractBackend
 	ethereum.TransactionReader
 	ethereum.ChainReader
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
 	ChainID(ctx context.Context) (*big.Int, error)
 	TransactionSender(ctx context.Context, tx *ethTypes.Transaction, block common.Hash, index uint) (common.Address, error)
 }
@@ -89,8 +95,6 @@ type EthChainService struct {
 	txSigner                 *bind.TransactOpts
 	eventEngineOut           chan Event
 	eventOut                 chan Event
-	droppedEventEngineOut    chan protocols.DroppedEventInfo
-	droppedEventOut          chan protocols.DroppedEventInfo
 	logger                   *slog.Logger
 	ctx                      context.Context
 	cancel                   context.CancelFunc
@@ -98,7 +102,20 @@ type EthChainService struct {
 	eventTracker             *eventTracker
 	eventSub                 ethereum.Subscription
 	newBlockSub              ethereum.Subscription
-	sentTxToChannelIdMap     *safesync.Map[types.Destination]
+	nonces                   *nonceManager
+	outbox                   TxOutbox
+	outboxIds                *outboxIds
+	// approveMu serializes the approval of token deposits with the submission of the deposits, since each approval overwrites the allowance.
+	// As nonces are used in order, a deposit submitted before the next approval spends the allowance before it is overwritten.
+	approveMu *sync.Mutex
+	// txMu protects pendingTxs
+	txMu        *sync.Mutex
+	feeOpts     FeeOpts
+	feeStrategy feeStrategy
+	// pendingTxs maps the nonce of each submitted transaction which has not been mined to the transaction
+	pendingTxs map[uint64]*pendingTx
+	// newBlocks passes new blocks to the pending transaction monitor
+	newBlocks chan Block
 }
 
 // MAX_QUERY_BLOCK_RANGE is the maximum range of blocks we query for events at once.
@@ -117,6 +134,10 @@ const RESUB_INTERVAL = 15 * time.Second
 // REQUIRED_BLOCK_CONFIRMATIONS is how many blocks must be mined before an emitted event is processed
 const REQUIRED_BLOCK_CONFIRMATIONS = 3
 
+// MAX_REORG_DEPTH is how many blocks deep a reorg can be detected.
+// Dispatched events are retracted should a reorg remove their block, unless it is deeper than this.
+const MAX_REORG_DEPTH = 64
+
 // MAX_EPOCHS is the maximum range of old epochs we can query with a single "FilterLogs" request
 // This is a restriction enforced by the rpc provider
 const MAX_EPOCHS = 60480
@@ -151,13 +172,13 @@ func NewEthChainService(chainOpts ChainOpts) (ChainService, error) {
 		panic(err)
 	}
 
-	return newEthChainService(ethClient, chainOpts.ChainStartBlockNum, na, chainOpts.NaAddress, chainOpts.CaAddress, chainOpts.VpaAddress, txSigner)
+	return newEthChainService(ethClient, chainOpts.ChainStartBlockNum, na, chainOpts.NaAddress, chainOpts.CaAddress, chainOpts.VpaAddress, txSigner, chainOpts.FeeOpts, chainOpts.Outbox)
 }
 
 // newEthChainService constructs a chain service that submits transactions to a NitroAdjudicator
 // and listens to events from an eventSource
 func newEthChainService(chain ethChain, startBlockNum uint64, na *NitroAdjudicator.NitroAdjudicator,
-	naAddress, caAddress, vpaAddress common.Address, txSigner *bind.TransactOpts,
+	naAddress, caAddress, vpaAddress common.Address, txSigner *bind.TransactOpts, feeOpts FeeOpts, outbox TxOutbox,
 ) (*EthChainService, error) {
 	ctx, cancelCtx := context.WithCancel(context.Background())
 
@@ -173,7 +194,12 @@ func newEthChainService(chain ethChain, startBlockNum uint64, na *NitroAdjudicat
 		Timestamp: block.Time(),
 	}
 	tracker := NewEventTracker(startBlock)
-	sentTxToChannelIdMap := safesync.Map[types.Destination]{}
+	if outbox == nil {
+		outbox = NewMemTxOutbox()
+	}
+	if feeOpts.StuckTxBlocks == 0 {
+		feeOpts.StuckTxBlocks = DEFAULT_STUCK_TX_BLOCKS
+	}
 
 	// Use a buffered channel so we don't have to worry about blocking on writing to the channel.
 	ecs := EthChainService{
@@ -185,13 +211,26 @@ func newEthChainService(chain ethChain, startBlockNum uint64, na *NitroAdjudicat
 		txSigner,
 		make(chan Event, 10),
 		make(chan Event, 10),
-		make(chan protocols.DroppedEventInfo, 10),
-		make(chan protocols.DroppedEventInfo, 10),
 		logger, ctx, cancelCtx, &sync.WaitGroup{},
 		tracker,
 		nil,
 		nil,
-		&sentTxToChannelIdMap,
+		newNonceManager(chain, txSigner.From),
+		outbox,
+		&outboxIds{},
+		&sync.Mutex{},
+		&sync.Mutex{},
+		feeOpts,
+		feeStrategy{feeOpts},
+		map[uint64]*pendingTx{},
+		make(chan Block, 1),
+	}
+
+	// The transactions left in the outbox by an earlier run are reconciled on startup
+	startupTxs, err := outbox.GetOutboxTxs()
+	if err != nil {
+		cancelCtx()
+		return nil, err
 	}
 
 	errChan, newBlockChan, eventChan, eventQuery, err := ecs.subscribeForLogs()
@@ -203,13 +242,14 @@ func newEthChainService(chain ethChain, startBlockNum uint64, na *NitroAdjudicat
 	ecs.eventTracker.mu.Lock()
 	defer ecs.eventTracker.mu.Unlock()
 
-	ecs.wg.Add(3)
+	ecs.wg.Add(4)
 	go ecs.listenForEventLogs(errChan, eventChan, eventQuery)
 	go ecs.listenForNewBlocks(errChan, newBlockChan)
 	go ecs.listenForErrors(errChan)
+	go ecs.monitorPendingTxs(startupTxs)
 
 	// Search for any missed events emitted while this node was offline
-	err = ecs.checkForMissedEvents(startBlock.BlockNum)
+	err = ecs.checkForMissedEvents(startBlock.BlockNum, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -217,7 +257,8 @@ func newEthChainService(chain ethChain, startBlockNum uint64, na *NitroAdjudicat
 	return &ecs, nil
 }
 
-func (ecs *EthChainService) checkForMissedEvents(startBlock uint64) error {
+// checkForMissedEvents queues the events emitted from startBlock onwards, other than those which are queued, dispatched or among the popped logs
+func (ecs *EthChainService) checkForMissedEvents(startBlock uint64, popped []ethTypes.Log) error {
 	// Fetch the latest block
 	latestBlock, err := ecs.chain.BlockByNumber(ecs.ctx, nil)
 	if err != nil {
@@ -254,6 +295,9 @@ func (ecs *EthChainService) checkForMissedEvents(startBlock uint64) error {
 		ecs.logger.Info("finished checking for missed chain events in range", "fromBlock", currentStart, "toBlock", currentEnd, "numMissedEvents", len(missedEvents))
 
 		for _, event := range missedEvents {
+			if slices.ContainsFunc(popped, func(l ethTypes.Log) bool { return isSameLog(l, event) }) {
+				continue
+			}
 			ecs.eventTracker.Push(event)
 		}
 
@@ -278,9 +322,10 @@ func (ecs *EthChainService) listenForErrors(errChan <-chan error) {
 	}
 }
 
-// defaultTxOpts returns transaction options suitable for most transaction submissions
+// defaultTxOpts returns transaction options suitable for most transaction submissions.
+// Unless the signer sets its own pricing, the fee strategy picks the tip and fee caps.
 func (ecs *EthChainService) defaultTxOpts() *bind.TransactOpts {
-	return &bind.TransactOpts{
+	txOpts := &bind.TransactOpts{
 		From:      ecs.txSigner.From,
 		Nonce:     ecs.txSigner.Nonce,
 		Signer:    ecs.txSigner.Signer,
@@ -289,6 +334,19 @@ func (ecs *EthChainService) defaultTxOpts() *bind.TransactOpts {
 		GasLimit:  ecs.txSigner.GasLimit,
 		GasPrice:  ecs.txSigner.GasPrice,
 	}
+	if txOpts.GasFeeCap != nil || txOpts.GasTipCap != nil || txOpts.GasPrice != nil {
+		return txOpts
+	}
+
+	tipCap, feeCap, err := ecs.feeStrategy.suggest(ecs.ctx, ecs.chain)
+	if err != nil {
+		// go-ethereum suggests fees itself when submitting the transaction
+		ecs.logger.Warn("failed to suggest transaction fees", "error", err)
+		return txOpts
+	}
+	txOpts.GasTipCap = tipCap
+	txOpts.GasFeeCap = feeCap
+	return txOpts
 }
 
 // defaultCallOpts provides options to fine-tune a contract call request
@@ -299,65 +357,154 @@ func (ecs *EthChainService) defaultCallOpts() *bind.CallOpts {
 	}
 }
 
-// SendTransaction sends the transaction and blocks until it has been submitted.
+// SendTransaction sends the transaction and blocks until it has been submitted. It is safe to call concurrently,
+// since every submission reserves its own nonce.
+//
+// A DepositTransaction is submitted as one transaction per asset, of which the last is returned.
 func (ecs *EthChainService) SendTransaction(tx protocols.ChainTransaction) (*ethTypes.Transaction, error) {
-	switch tx := tx.(type) {
-	case protocols.DepositTransaction:
-		var tokenApprovalLog ethTypes.Log
+	ethTxs, err := ecs.sendTransactions(tx)
+	if len(ethTxs) == 0 {
+		return nil, err
+	}
+	return ethTxs[len(ethTxs)-1], err
+}
 
-		for tokenAddress, amount := range tx.Deposit {
-			txOpts := ecs.defaultTxOpts()
-			ethTokenAddress := common.Address{}
-			if tokenAddress == ethTokenAddress {
-				txOpts.Value = amount
-			} else {
-				// TODO: Move Approve tx to a separate switch case so that Approval event parsing can go through dispatchEvents flow
-				// If custom token is used instead of ETH, we need to approve token amount to be transferred from
-				approvalLog, err := ecs.handleApproveTx(tokenAddress, amount)
-				if err != nil {
-					return nil, err
-				}
+// sendTransactions sends the transaction like SendTransaction, but returns every transaction submitted for it, so that each can be tracked.
+// Those submitted before an error are returned along with it.
+func (ecs *EthChainService) sendTransactions(tx protocols.ChainTransaction) ([]*ethTypes.Transaction, error) {
+	ethTxs, err := ecs.sendTransaction(tx)
+	outcome := metrics.Submitted
+	if err != nil {
+		outcome = metrics.Failed
+	}
+	metrics.ChainTransactions.WithLabelValues(strings.TrimPrefix(fmt.Sprintf("%T", tx), "protocols."), outcome).Inc()
+	return ethTxs, err
+}
 
-				tokenApprovalLog = approvalLog
-			}
+// deposit submits the deposit of a single asset into a channel. A token deposit is preceded by the approval of the amount,
+// whose log is returned.
+func (ecs *EthChainService) deposit(channelId types.Destination, tokenAddress common.Address, amount *big.Int, txType string) (*ethTypes.Transaction, ethTypes.Log, error) {
+	var tokenApprovalLog ethTypes.Log
+	txOpts := ecs.defaultTxOpts()
+	if tokenAddress == (common.Address{}) {
+		txOpts.Value = amount
+	} else {
+		ecs.approveMu.Lock()
+		defer ecs.approveMu.Unlock()
 
-			holdings, err := ecs.na.Holdings(&bind.CallOpts{}, tokenAddress, tx.ChannelId())
-			ecs.logger.Debug("existing holdings", "holdings", holdings)
+		// TODO: Move Approve tx to a separate switch case so that Approval event parsing can go through dispatchEvents flow
+		// If custom token is used instead of ETH, we need to approve token amount to be transferred from
+		approvalLog, err := ecs.handleApproveTx(tokenAddress, amount)
+		if err != nil {
+			return nil, tokenApprovalLog, err
+		}
 
-			if err != nil {
+		tokenApprovalLog = approvalLog
+	}
+
+	holdings, err := ecs.na.Holdings(&bind.CallOpts{}, tokenAddress, channelId)
+	ecs.logger.Debug("existing holdings", "holdings", holdings)
+
+	if err != nil {
+		return nil, tokenApprovalLog, err
+	}
+
+	depositTx, err := ecs.submit(txOpts, txType, channelId, func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+		return ecs.na.Deposit(opts, tokenAddress, channelId, holdings, amount)
+	})
+	return depositTx, tokenApprovalLog, err
+}
+
+// submit sends a transaction with the next nonce of the signing account, and tracks it until it is mined.
+// Should the nonce turn out to be used already, the nonces are resynced with the chain and the transaction is sent once more.
+// Should the transaction already be in the mempool, it is tracked as though it had just been sent.
+func (ecs *EthChainService) submit(txOpts *bind.TransactOpts, txType string, channelId types.Destination, send func(*bind.TransactOpts) (*ethTypes.Transaction, error)) (*ethTypes.Transaction, error) {
+	// The signed transaction is kept, since it is not returned along with an error
+	var signedTx *ethTypes.Transaction
+	signer := txOpts.Signer
+	txOpts.Signer = func(from common.Address, tx *ethTypes.Transaction) (*ethTypes.Transaction, error) {
+		signed, err := signer(from, tx)
+		signedTx = signed
+		return signed, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		nonce, err := ecs.nonces.reserve(ecs.ctx)
+		if err != nil {
+			return nil, err
+		}
+		txOpts.Nonce = new(big.Int).SetUint64(nonce)
+		signedTx = nil
+
+		ethTx, err := send(txOpts)
+		if err != nil && signedTx != nil && isAlreadyKnown(err) {
+			ecs.logger.Debug("transaction is already known to the chain", "txHash", signedTx.Hash(), "nonce", nonce)
+			ethTx, err = signedTx, nil
+		}
+		if err != nil {
+			ecs.nonces.resync()
+			if attempt > 0 {
+				return nil, err
+			}
+			used, nonceErr := ecs.isNonceUsed(nonce, err)
+			if nonceErr != nil {
+				ecs.logger.Warn("failed to check whether the nonce of a transaction has been used", "nonce", nonce, "error", nonceErr)
+			}
+			if !used {
 				return nil, err
 			}
+			ecs.logger.Warn("nonce was already used, resubmitting with a resynced nonce", "nonce", nonce, "error", err)
+			continue
+		}
 
-			depositTx, err := ecs.na.Deposit(txOpts, tokenAddress, tx.ChannelId(), holdings, amount)
-			if err != nil {
+		ecs.trackPendingTx(ethTx, txType, channelId)
+		return ethTx, nil
+	}
+}
+
+// sendTransaction submits a transaction of any supported type, and returns the transactions submitted for it
+func (ecs *EthChainService) sendTransaction(tx protocols.ChainTransaction) ([]*ethTypes.Transaction, error) {
+	txType := strings.TrimPrefix(fmt.Sprintf("%T", tx), "protocols.")
+	submit := func(send func(*bind.TransactOpts) (*ethTypes.Transaction, error)) ([]*ethTypes.Transaction, error) {
+		ethTx, err := ecs.submit(ecs.defaultTxOpts(), txType, tx.ChannelId(), send)
+		if err != nil {
+			return nil, err
+		}
+		return []*ethTypes.Transaction{ethTx}, nil
+	}
+
+	switch tx := tx.(type) {
+	case protocols.DepositTransaction:
+		depositTxs := []*ethTypes.Transaction{}
+
+		// Assets are deposited in a fixed order, so that the last deposit returned is always that of the same asset
+		assets := sortedAssets(tx.Deposit)
+
+		// The deposits submitted before an error are returned along with it, since they are already on their way to the chain
+		for _, tokenAddress := range assets {
+			depositTx, tokenApprovalLog, depositErr := ecs.deposit(tx.ChannelId(), tokenAddress, tx.Deposit[tokenAddress], txType)
+			if depositErr != nil {
 				// Check if `Approve` tx was confirmed when custom token is used
-				if tokenAddress != ethTokenAddress {
-					return nil, err
+				if tokenApprovalLog.BlockHash == (common.Hash{}) {
+					return depositTxs, depositErr
 				}
 
 				approvalBlock, err := ecs.GetBlockByNumber(big.NewInt(int64(tokenApprovalLog.BlockNumber)))
 				if err != nil {
-					return nil, err
+					return depositTxs, err
 				}
 
+				// The deposit is left to be submitted again once the approval is back in the chain
 				if approvalBlock.Hash() != tokenApprovalLog.BlockHash {
-					ecs.droppedEventEngineOut <- protocols.DroppedEventInfo{
-						TxHash:    tokenApprovalLog.TxHash,
-						ChannelId: tx.ChannelId(),
-						EventName: "Approval",
-					}
-
-					return nil, nil
+					ecs.logger.Warn("token approval was dropped by a reorg", "channelId", tx.ChannelId(), "txHash", tokenApprovalLog.TxHash)
+					return depositTxs, nil
 				}
-				// Return nil to not panic the node and log the error instead
-				return nil, err
+				return depositTxs, depositErr
 			}
-
-			ecs.sentTxToChannelIdMap.Store(depositTx.Hash().String(), tx.ChannelId())
+			depositTxs = append(depositTxs, depositTx)
 		}
 
-		// TODO: Handle multiple depositTx
-		return nil, nil
+		return depositTxs, nil
 	case protocols.WithdrawAllTransaction:
 		signedState := tx.SignedState.State()
 		signatures := tx.SignedState.Signatures()
@@ -370,20 +517,22 @@ func (ecs *EthChainService) SendTransaction(tx protocols.ChainTransaction) (*eth
 			Sigs:         nitroSignatures,
 		}
 
-		withdrawAllTx, err := ecs.na.ConcludeAndTransferAllAssets(ecs.defaultTxOpts(), nitroFixedPart, candidate)
-		ecs.sentTxToChannelIdMap.Store(withdrawAllTx.Hash().String(), tx.ChannelId())
-		return withdrawAllTx, err
+		return submit(func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+			return ecs.na.ConcludeAndTransferAllAssets(opts, nitroFixedPart, candidate)
+		})
 	case protocols.ChallengeTransaction:
 		fp, candidate := NitroAdjudicator.ConvertSignedStateToFixedPartAndSignedVariablePart(tx.Candidate)
 		proof := NitroAdjudicator.ConvertSignedStatesToProof(tx.Proof)
 		challengerSig := NitroAdjudicator.ConvertSignature(tx.ChallengerSig)
-		challengeTx, err := ecs.na.Challenge(ecs.defaultTxOpts(), fp, proof, candidate, challengerSig)
-		return challengeTx, err
+		return submit(func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+			return ecs.na.Challenge(opts, fp, proof, candidate, challengerSig)
+		})
 	case protocols.CheckpointTransaction:
 		fp, candidate := NitroAdjudicator.ConvertSignedStateToFixedPartAndSignedVariablePart(tx.Candidate)
 		proof := NitroAdjudicator.ConvertSignedStatesToProof(tx.Proof)
-		checkpointTx, err := ecs.na.Checkpoint(ecs.defaultTxOpts(), fp, proof, candidate)
-		return checkpointTx, err
+		return submit(func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+			return ecs.na.Checkpoint(opts, fp, proof, candidate)
+		})
 	case protocols.TransferAllTransaction:
 		transferState := tx.TransferState.State()
 		channelId := transferState.ChannelId()
@@ -394,11 +543,13 @@ func (ecs *EthChainService) SendTransaction(tx protocols.ChainTransaction) (*eth
 
 		nitroVariablePart := NitroAdjudicator.ConvertVariablePart(transferState.VariablePart())
 
-		transferAllTx, er := ecs.na.TransferAllAssets(ecs.defaultTxOpts(), channelId, nitroVariablePart.Outcome, stateHash)
-		return transferAllTx, er
+		return submit(func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+			return ecs.na.TransferAllAssets(opts, channelId, nitroVariablePart.Outcome, stateHash)
+		})
 	case protocols.ReclaimTransaction:
-		reclaimTx, err := ecs.na.Reclaim(ecs.defaultTxOpts(), tx.ReclaimArgs)
-		return reclaimTx, err
+		return submit(func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+			return ecs.na.Reclaim(opts, tx.ReclaimArgs)
+		})
 	case protocols.MirrorTransferAllTransaction:
 		transferState := tx.TransferState.State()
 		channelId := transferState.ChannelId()
@@ -409,16 +560,13 @@ func (ecs *EthChainService) SendTransaction(tx protocols.ChainTransaction) (*eth
 
 		nitroVariablePart := NitroAdjudicator.ConvertVariablePart(transferState.VariablePart())
 
-		mirrorTransferAllTx, err := ecs.na.MirrorTransferAllAssets(ecs.defaultTxOpts(), channelId, nitroVariablePart.Outcome, stateHash)
-		return mirrorTransferAllTx, err
+		return submit(func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+			return ecs.na.MirrorTransferAllAssets(opts, channelId, nitroVariablePart.Outcome, stateHash)
+		})
 	case protocols.SetL2ToL1Transaction:
-		setL2ToL1Tx, err := ecs.na.SetL2ToL1(ecs.defaultTxOpts(), tx.ChannelId(), tx.MirrorChannelId)
-		if err != nil {
-			return nil, err
-		}
-
-		ecs.sentTxToChannelIdMap.Store(setL2ToL1Tx.Hash().String(), tx.ChannelId())
-		return setL2ToL1Tx, nil
+		return submit(func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+			return ecs.na.SetL2ToL1(opts, tx.ChannelId(), tx.MirrorChannelId)
+		})
 
 	case protocols.MirrorWithdrawAllTransaction:
 		signedState := tx.SignedState.State()
@@ -431,8 +579,9 @@ func (ecs *EthChainService) SendTransaction(tx protocols.ChainTransaction) (*eth
 			VariablePart: nitroVariablePart,
 			Sigs:         nitroSignatures,
 		}
-		MirrorWithdrawAllTx, err := ecs.na.MirrorConcludeAndTransferAllAssets(ecs.defaultTxOpts(), nitroFixedPart, candidate)
-		return MirrorWithdrawAllTx, err
+		return submit(func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+			return ecs.na.MirrorConcludeAndTransferAllAssets(opts, nitroFixedPart, candidate)
+		})
 	default:
 		return nil, fmt.Errorf("unexpected transaction type %T", tx)
 	}
@@ -461,7 +610,7 @@ func (ecs *EthChainService) dispatchChainEvents(logs []ethTypes.Log) error {
 			}
 
 			event := NewDepositedEvent(nad.Destination, Block{BlockNum: l.BlockNumber, Timestamp: block.Time()}, l.TxIndex, nad.Asset, nad.DestinationHoldings, l.TxHash)
-			ecs.eventEngineOut <- event
+			ecs.dispatch(l, event)
 
 		case allocationUpdatedTopic:
 			ecs.logger.Debug("Processing AllocationUpdated event")
@@ -479,7 +628,7 @@ func (ecs *EthChainService) dispatchChainEvents(logs []ethTypes.Log) error {
 			}
 
 			event := NewAllocationUpdatedEvent(au.ChannelId, Block{BlockNum: l.BlockNumber, Timestamp: block.Time()}, l.TxIndex, au.Asset, au.FinalHoldings, l.TxHash)
-			ecs.eventEngineOut <- event
+			ecs.dispatch(l, event)
 
 		case concludedTopic:
 			ecs.logger.Debug("Processing Concluded event")
@@ -489,7 +638,7 @@ func (ecs *EthChainService) dispatchChainEvents(logs []ethTypes.Log) error {
 			}
 
 			event := ConcludedEvent{commonEvent: commonEvent{channelID: ce.ChannelId, block: Block{BlockNum: l.BlockNumber, Timestamp: block.Time()}, txIndex: l.TxIndex, txHash: l.TxHash}}
-			ecs.eventEngineOut <- event
+			ecs.dispatch(l, event)
 
 		case challengeRegisteredTopic:
 			ecs.logger.Debug("Processing Challenge Registered event")
@@ -528,7 +677,7 @@ func (ecs *EthChainService) dispatchChainEvents(logs []ethTypes.Log) error {
 				isInitiatedByMe,
 				l.TxHash,
 			)
-			ecs.eventEngineOut <- event
+			ecs.dispatch(l, event)
 		case challengeClearedTopic:
 			ecs.logger.Debug("Processing Challenge Cleared event")
 			cp, err := ecs.na.ParseChallengeCleared(l)
@@ -536,7 +685,7 @@ func (ecs *EthChainService) dispatchChainEvents(logs []ethTypes.Log) error {
 				return fmt.Errorf("error in ParseCheckpointed: %w", err)
 			}
 			event := NewChallengeClearedEvent(cp.ChannelId, Block{BlockNum: l.BlockNumber, Timestamp: block.Time()}, l.TxIndex, cp.NewTurnNumRecord, l.TxHash)
-			ecs.eventEngineOut <- event
+			ecs.dispatch(l, event)
 
 		case reclaimedTopic:
 			ecs.logger.Debug("Processing Reclaimed event")
@@ -546,7 +695,7 @@ func (ecs *EthChainService) dispatchChainEvents(logs []ethTypes.Log) error {
 			}
 
 			event := ReclaimedEvent{commonEvent: commonEvent{channelID: ce.ChannelId, block: Block{BlockNum: l.BlockNumber, Timestamp: block.Time()}, txIndex: l.TxIndex, txHash: l.TxHash}}
-			ecs.eventEngineOut <- event
+			ecs.dispatch(l, event)
 
 		case L2ToL1MapUpdatedTopic:
 			ecs.logger.Debug("Processing l2 to l1 map updated event")
@@ -566,12 +715,22 @@ func (ecs *EthChainService) dispatchChainEvents(logs []ethTypes.Log) error {
 
 		default:
 			ecs.logger.Info("Ignoring unknown chain event topic", "topic", l.Topics[0].String())
-
+			continue
 		}
+		metrics.ChainEventsDispatched.WithLabelValues(topicsToEventName[l.Topics[0]]).Inc()
 	}
 	return nil
 }
 
+// dispatch sends the event parsed from the log to the engine, and records it so that it can be retracted should a reorg remove its block
+func (ecs *EthChainService) dispatch(l ethTypes.Log, event Event) {
+	ecs.eventTracker.mu.Lock()
+	ecs.eventTracker.recordDispatched(l, event)
+	ecs.eventTracker.mu.Unlock()
+
+	ecs.eventEngineOut <- event
+}
+
 func (ecs *EthChainService) listenForEventLogs(errorChan chan<- error, eventChan chan ethTypes.Log, eventQuery ethereum.FilterQuery) {
 	for {
 		select {
@@ -608,7 +767,7 @@ func (ecs *EthChainService) listenForEventLogs(errorChan chan<- error, eventChan
 					ecs.logger.Debug("resubscribed to chain events")
 
 					ecs.eventTracker.mu.Lock()
-					err = ecs.checkForMissedEvents(latestBlockNum)
+					err = ecs.checkForMissedEvents(latestBlockNum, nil)
 					ecs.eventTracker.mu.Unlock()
 
 					if err != nil {
@@ -700,73 +859,89 @@ func (ecs *EthChainService) listenForNewBlocks(errorChan chan<- error, newBlockC
 		case newBlock := <-newBlockChan:
 			block := Block{BlockNum: newBlock.Number.Uint64(), Timestamp: newBlock.Time}
 			ecs.logger.Log(ecs.ctx, logging.LevelTrace, "detected new block", "block-num", block.BlockNum)
-			ecs.updateEventTracker(errorChan, &block, nil)
+			ecs.updateEventTracker(errorChan, newBlock, nil)
+
+			// If the monitor is still busy with an earlier block, it checks the pending transactions against that block instead
+			select {
+			case ecs.newBlocks <- block:
+			default:
+			}
 		}
 	}
 }
 
-// updateEventTracker accepts a new block number and/or new event and dispatches a chain event if there are enough block confirmations
-func (ecs *EthChainService) updateEventTracker(errorChan chan<- error, block *Block, chainEvent *ethTypes.Log) {
+// updateEventTracker accepts a new head block and/or new event and dispatches a chain event if there are enough block confirmations.
+// On a new block it checks whether a reorg has removed the blocks of dispatched events, in which case the events are retracted first.
+func (ecs *EthChainService) updateEventTracker(errorChan chan<- error, head *ethTypes.Header, chainEvent *ethTypes.Log) {
 	// lock the mutex for the shortest amount of time. The mutex only need to be locked to update the eventTracker data structure
 	ecs.eventTracker.mu.Lock()
 
-	if block != nil && block.BlockNum > ecs.eventTracker.latestBlock.BlockNum {
-		ecs.eventTracker.latestBlock = *block
+	var retractions []RetractedEvent
+	if head != nil {
+		block := Block{BlockNum: head.Number.Uint64(), Timestamp: head.Time}
+		if block.BlockNum > ecs.eventTracker.latestBlock.BlockNum {
+			ecs.eventTracker.latestBlock = block
+		}
+		ecs.eventTracker.recordHead(head)
+
+		var err error
+		retractions, err = ecs.checkForReorg(block)
+		if err != nil {
+			ecs.eventTracker.mu.Unlock()
+			errorChan <- fmt.Errorf("failed to check for reorg: %w", err)
+			return
+		}
 	}
 
-	if chainEvent != nil {
+	// Logs removed by a reorg are dropped when they are dequeued, or retracted if they have been dispatched
+	if chainEvent != nil && !chainEvent.Removed {
 		ecs.eventTracker.Push(*chainEvent)
 		ecs.logger.Debug("event added to queue", "updated-queue-length", ecs.eventTracker.events.Len())
 	}
 
 	eventsToDispatch := []ethTypes.Log{}
+	droppedFrom := uint64(0)
 	for ecs.eventTracker.events.Len() > 0 && ecs.eventTracker.latestBlock.BlockNum >= (ecs.eventTracker.events)[0].BlockNumber+REQUIRED_BLOCK_CONFIRMATIONS {
 		chainEvent := ecs.eventTracker.Pop()
 		ecs.logger.Debug("event popped from queue", "updated-queue-length", ecs.eventTracker.events.Len())
 
 		// Ensure event & associated tx is still in the chain before adding to eventsToDispatch
 		oldBlock, err := ecs.chain.BlockByNumber(context.Background(), new(big.Int).SetUint64(chainEvent.BlockNumber))
-		if err != nil {
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			ecs.eventTracker.mu.Unlock()
 			ecs.logger.Error("failed to fetch block", "err", err)
 			errorChan <- fmt.Errorf("failed to fetch block: %v", err)
 			return
 		}
 
-		if oldBlock.Hash() != chainEvent.BlockHash {
+		if oldBlock == nil || oldBlock.Hash() != chainEvent.BlockHash {
 			ecs.logger.Warn("dropping event because its block is no longer in the chain (possible re-org)", "blockNumber", chainEvent.BlockNumber, "blockHash", chainEvent.BlockHash)
-
-			// Send info of dropped event to engine
-			channelId, exists := ecs.sentTxToChannelIdMap.Load(chainEvent.TxHash.String())
-			if !exists {
-				continue
+			metrics.ChainEventsDropped.WithLabelValues(topicsToEventName[chainEvent.Topics[0]]).Inc()
+			if droppedFrom == 0 || chainEvent.BlockNumber < droppedFrom {
+				droppedFrom = chainEvent.BlockNumber
 			}
-
-			ecs.droppedEventEngineOut <- protocols.DroppedEventInfo{
-				TxHash:    chainEvent.TxHash,
-				ChannelId: channelId,
-				EventName: topicsToEventName[chainEvent.Topics[0]],
-			}
-
-			// Use non-blocking send incase no-one is listening
-			select {
-			case ecs.droppedEventOut <- protocols.DroppedEventInfo{
-				TxHash:    chainEvent.TxHash,
-				ChannelId: channelId,
-				EventName: topicsToEventName[chainEvent.Topics[0]],
-			}:
-			default:
-			}
-
-			ecs.sentTxToChannelIdMap.Delete(chainEvent.TxHash.String())
-
 			continue
 		}
 
-		ecs.sentTxToChannelIdMap.Delete(chainEvent.TxHash.String())
 		eventsToDispatch = append(eventsToDispatch, chainEvent)
 	}
+
+	// The events of the canonical chain which replaced the blocks of the dropped events are queued instead,
+	// other than those about to be dispatched
+	if droppedFrom != 0 {
+		err := ecs.checkForMissedEvents(droppedFrom, eventsToDispatch)
+		if err != nil {
+			ecs.eventTracker.mu.Unlock()
+			errorChan <- fmt.Errorf("failed to queue the events of the canonical chain: %w", err)
+			return
+		}
+	}
 	ecs.eventTracker.mu.Unlock()
 
+	for _, retraction := range retractions {
+		ecs.eventEngineOut <- retraction
+	}
+
 	err := ecs.dispatchChainEvents(eventsToDispatch)
 	if err != nil {
 		errorChan <- fmt.Errorf("failed dispatchChainEvents: %w", err)
@@ -774,6 +949,73 @@ func (ecs *EthChainService) updateEventTracker(errorChan chan<- error, block *Bl
 	}
 }
 
+// checkForReorg checks that the blocks of the dispatched events are still in the chain. Should a reorg have removed any of them,
+// the events dispatched from the removed blocks are retracted, and the events of the canonical chain after the common ancestor are queued.
+// It returns a retraction for each channel with retracted events. The event tracker must be locked by the caller.
+func (ecs *EthChainService) checkForReorg(head Block) ([]RetractedEvent, error) {
+	forkBlockNum, reorged, err := ecs.findForkBlock()
+	if err != nil || !reorged {
+		return nil, err
+	}
+
+	ancestor, err := ecs.chain.HeaderByNumber(ecs.ctx, new(big.Int).SetUint64(forkBlockNum-1))
+	if err != nil {
+		return nil, err
+	}
+	commonAncestor := Block{BlockNum: ancestor.Number.Uint64(), Timestamp: ancestor.Time}
+
+	retracted := ecs.eventTracker.retract(forkBlockNum)
+	ecs.logger.Warn("chain reorg removed the blocks of dispatched events", "commonAncestor", commonAncestor.BlockNum, "retractedEvents", len(retracted))
+
+	// Confirmations are counted on the canonical chain, which may be shorter than the chain it replaced
+	ecs.eventTracker.latestBlock = head
+
+	err = ecs.checkForMissedEvents(forkBlockNum, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	retractions := []RetractedEvent{}
+	byChannel := map[types.Destination]int{}
+	for _, d := range retracted {
+		metrics.ChainEventsRetracted.WithLabelValues(topicsToEventName[d.log.Topics[0]]).Inc()
+
+		channelId := d.event.ChannelID()
+		i, ok := byChannel[channelId]
+		if !ok {
+			i = len(retractions)
+			byChannel[channelId] = i
+			retractions = append(retractions, NewRetractedEvent(channelId, commonAncestor, nil))
+		}
+		retractions[i].Retracted = append(retractions[i].Retracted, d.event)
+	}
+	return retractions, nil
+}
+
+// findForkBlock returns the number of the oldest block of a dispatched event which is no longer in the chain, if any.
+// Block hashes are read from those recorded for recent heads, and only queried from the chain when they are not known.
+func (ecs *EthChainService) findForkBlock() (uint64, bool, error) {
+	for _, d := range ecs.eventTracker.dispatched {
+		hash, ok := ecs.eventTracker.blockHashes[d.log.BlockNumber]
+		if !ok {
+			header, err := ecs.chain.HeaderByNumber(ecs.ctx, new(big.Int).SetUint64(d.log.BlockNumber))
+			if errors.Is(err, ethereum.NotFound) {
+				return d.log.BlockNumber, true, nil
+			}
+			if err != nil {
+				return 0, false, err
+			}
+			hash = header.Hash()
+			ecs.eventTracker.blockHashes[d.log.BlockNumber] = hash
+		}
+
+		if hash != d.log.BlockHash {
+			return d.log.BlockNumber, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
 // subscribeForLogs subscribes for logs and pushes them to the out channel.
 // It relies on notifications being supported by the chain node.
 func (ecs *EthChainService) subscribeForLogs() (chan error, chan *ethTypes.Header, chan ethTypes.Log, ethereum.FilterQuery, error) {
@@ -805,10 +1047,6 @@ func (ecs *EthChainService) EventEngineFeed() <-chan Event {
 	return ecs.eventEngineOut
 }
 
-func (ecs *EthChainService) DroppedEventEngineFeed() <-chan protocols.DroppedEventInfo {
-	return ecs.droppedEventEngineOut
-}
-
 func (ecs *EthChainService) GetConsensusAppAddress() types.Address {
 	return ecs.consensusAppAddress
 }
@@ -899,7 +1137,10 @@ func (ecs *EthChainService) handleApproveTx(tokenAddress common.Address, amount
 		return ethTypes.Log{}, err
 	}
 
-	approveTx, err := token.Approve(ecs.defaultTxOpts(), ecs.naAddress, amount)
+	approve := func(opts *bind.TransactOpts) (*ethTypes.Transaction, error) {
+		return token.Approve(opts, ecs.naAddress, amount)
+	}
+	approveTx, err := ecs.submit(ecs.defaultTxOpts(), "Approve", types.Destination{}, approve)
 	if err != nil {
 		return ethTypes.Log{}, err
 	}
@@ -916,7 +1157,8 @@ func (ecs *EthChainService) handleApproveTx(tokenAddress common.Address, amount
 	for {
 		select {
 		case log := <-approvalLogsChan:
-			if log.Owner == ecs.txSigner.From {
+			// Approvals submitted concurrently for other deposits of the same token are told apart by their hash
+			if log.Raw.TxHash == approveTx.Hash() || (isApproveTxRetried && log.Raw.TxHash == retryApproveTxHash) {
 				approvalSubscription.Unsubscribe()
 				return log.Raw, nil
 			}
@@ -941,7 +1183,7 @@ func (ecs *EthChainService) handleApproveTx(tokenAddress common.Address, amount
 
 				// Multiply estimated gas limit with set multiplier
 				approveTxOpts.GasLimit = uint64(float64(estimatedGasLimit) * GAS_LIMIT_MULTIPLIER)
-				reApproveTx, err := token.Approve(approveTxOpts, ecs.naAddress, amount)
+				reApproveTx, err := ecs.submit(approveTxOpts, "Approve", types.Destination{}, approve)
 				if err != nil {
 					return ethTypes.Log{}, err
 				}
@@ -960,10 +1202,6 @@ func (ecs *EthChainService) GetChain() ethChain {
 	return ecs.chain
 }
 
-func (ecs *EthChainService) DroppedEventFeed() <-chan protocols.DroppedEventInfo {
-	return ecs.droppedEventOut
-}
-
 func (ecs *EthChainService) EventFeed() <-chan Event {
 	return ecs.eventOut
 }
@@ -2,17 +2,31 @@ package chainservice
 
 import (
 	"container/heap"
+	"slices"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type eventTracker struct {
 	latestBlock Block
 	events      eventQueue
+	// dispatched holds the events dispatched from the latest MAX_REORG_DEPTH blocks, oldest first,
+	// so that they can be retracted should a reorg remove their blocks
+	dispatched []dispatchedEvent
+	// blockHashes holds the hashes of the canonical blocks seen within MAX_REORG_DEPTH of the latest block,
+	// so that the blocks of dispatched events are checked without querying the chain on every new block
+	blockHashes map[uint64]common.Hash
 	mu          sync.Mutex
 }
 
+// dispatchedEvent is a dispatched chain event, along with the log it was parsed from
+type dispatchedEvent struct {
+	log   types.Log
+	event Event
+}
+
 type Block struct {
 	BlockNum  uint64
 	Timestamp uint64
@@ -21,10 +35,14 @@ type Block struct {
 func NewEventTracker(startBlock Block) *eventTracker {
 	eventQueue := eventQueue{}
 	heap.Init(&eventQueue)
-	return &eventTracker{latestBlock: startBlock, events: eventQueue}
+	return &eventTracker{latestBlock: startBlock, events: eventQueue, blockHashes: map[uint64]common.Hash{}}
 }
 
+// Push queues the log, unless it is already queued or has been dispatched
 func (eT *eventTracker) Push(l types.Log) {
+	if eT.isKnown(l) {
+		return
+	}
 	heap.Push(&eT.events, (l))
 }
 
@@ -32,6 +50,60 @@ func (eT *eventTracker) Pop() types.Log {
 	return heap.Pop(&eT.events).(types.Log)
 }
 
+// isKnown returns true if the log is queued or has been dispatched
+func (eT *eventTracker) isKnown(l types.Log) bool {
+	sameLog := func(other types.Log) bool { return isSameLog(other, l) }
+	return slices.ContainsFunc(eT.events, sameLog) ||
+		slices.ContainsFunc(eT.dispatched, func(d dispatchedEvent) bool { return sameLog(d.log) })
+}
+
+// isSameLog returns true if the logs were emitted at the same position of the same block
+func isSameLog(a, b types.Log) bool {
+	return a.BlockHash == b.BlockHash && a.Index == b.Index
+}
+
+// recordDispatched records that the event parsed from the log was dispatched,
+// and forgets the events dispatched from blocks too deep to be removed by a reorg
+func (eT *eventTracker) recordDispatched(l types.Log, event Event) {
+	eT.dispatched = append(eT.dispatched, dispatchedEvent{l, event})
+	eT.dispatched = slices.DeleteFunc(eT.dispatched, func(d dispatchedEvent) bool {
+		return d.log.BlockNumber+MAX_REORG_DEPTH < eT.latestBlock.BlockNum
+	})
+}
+
+// retract removes and returns the events dispatched from blocks from fromBlock onwards
+func (eT *eventTracker) retract(fromBlock uint64) []dispatchedEvent {
+	i := slices.IndexFunc(eT.dispatched, func(d dispatchedEvent) bool { return d.log.BlockNumber >= fromBlock })
+	if i == -1 {
+		return nil
+	}
+	retracted := slices.Clone(eT.dispatched[i:])
+	eT.dispatched = eT.dispatched[:i]
+	return retracted
+}
+
+// recordHead records the hashes of a new head block and its parent. Should the head not extend the recorded blocks, because a reorg
+// replaced them, the recorded hashes are forgotten so that they are read from the chain again. Hashes of blocks too deep to be
+// removed by a reorg are forgotten too.
+func (eT *eventTracker) recordHead(head *types.Header) {
+	num := head.Number.Uint64()
+	if hash, ok := eT.blockHashes[num]; ok && hash == head.Hash() {
+		return
+	}
+	if num > 0 {
+		if parentHash, ok := eT.blockHashes[num-1]; !ok || parentHash != head.ParentHash {
+			clear(eT.blockHashes)
+		}
+		eT.blockHashes[num-1] = head.ParentHash
+	}
+	for n := range eT.blockHashes {
+		if n >= num || n+MAX_REORG_DEPTH < num {
+			delete(eT.blockHashes, n)
+		}
+	}
+	eT.blockHashes[num] = head.Hash()
+}
+
 type eventQueue []types.Log
 
 func (q eventQueue) Len() int { return len(q) }
@@ -0,0 +1,60 @@
+package chainservice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// chainOf returns n headers, each a child of the one before, starting from the child of parent.
+// Chains with different tags have different hashes.
+func chainOf(parent *types.Header, n int, tag byte) []*types.Header {
+	headers := []*types.Header{}
+	for i := 0; i < n; i++ {
+		h := &types.Header{Number: new(big.Int).Add(parent.Number, big.NewInt(1)), ParentHash: parent.Hash(), Extra: []byte{tag}}
+		headers = append(headers, h)
+		parent = h
+	}
+	return headers
+}
+
+func TestRecordHead(t *testing.T) {
+	genesis := &types.Header{Number: big.NewInt(0)}
+	eT := NewEventTracker(Block{})
+
+	canonical := chainOf(genesis, MAX_REORG_DEPTH+10, 0)
+	for _, h := range canonical {
+		eT.recordHead(h)
+	}
+	head := canonical[len(canonical)-1].Number.Uint64()
+	if len(eT.blockHashes) != MAX_REORG_DEPTH+1 {
+		t.Fatalf("expected the hashes of the latest %d blocks to be recorded, got %d", MAX_REORG_DEPTH+1, len(eT.blockHashes))
+	}
+	for _, h := range canonical[len(canonical)-MAX_REORG_DEPTH-1:] {
+		if eT.blockHashes[h.Number.Uint64()] != h.Hash() {
+			t.Fatalf("expected the hash of block %d to be recorded", h.Number.Uint64())
+		}
+	}
+
+	// A reorg replaces the latest 3 blocks with a side chain of 2 blocks
+	ancestor := canonical[len(canonical)-4]
+	side := chainOf(ancestor, 2, 1)
+	eT.recordHead(side[0])
+	if _, ok := eT.blockHashes[head]; ok {
+		t.Fatal("expected the hashes of the replaced blocks to be forgotten")
+	}
+	eT.recordHead(side[1])
+	for _, h := range append([]*types.Header{ancestor}, side...) {
+		if eT.blockHashes[h.Number.Uint64()] != h.Hash() {
+			t.Fatalf("expected the hash of block %d of the canonical chain to be recorded", h.Number.Uint64())
+		}
+	}
+
+	// A head which does not extend the recorded blocks leaves only its own hashes to be trusted
+	eT.recordHead(&types.Header{Number: big.NewInt(int64(head + 5)), ParentHash: common.Hash{1}})
+	if len(eT.blockHashes) != 2 || eT.blockHashes[head+4] != (common.Hash{1}) {
+		t.Fatalf("expected the recorded hashes to be forgotten, got %v", eT.blockHashes)
+	}
+}
@@ -0,0 +1,310 @@
+package chainservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/statechannels/go-nitro/internal/metrics"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// DEFAULT_STUCK_TX_BLOCKS is the number of blocks a transaction may stay pending before it is replaced, unless FeeOpts.StuckTxBlocks is set
+const DEFAULT_STUCK_TX_BLOCKS = 5
+
+// FEE_BUMP_PERCENT is how much higher the fees of a replacement transaction are than those of the transaction it replaces
+const FEE_BUMP_PERCENT = 20
+
+// MIN_FEE_BUMP_PERCENT is the smallest increase in fees with which nodes accept a replacement transaction
+const MIN_FEE_BUMP_PERCENT = 10
+
+// BASE_FEE_MULTIPLIER is applied to the base fee when computing the fee cap of a transaction, so that it stays includable while the base fee rises
+const BASE_FEE_MULTIPLIER = 2
+
+// FeeOpts configures the EIP-1559 fees offered for transactions, and the replacement of transactions stuck in the mempool
+type FeeOpts struct {
+	// MaxGasTipCap is the highest priority fee per gas offered, in wei. If nil, the suggested priority fee is offered.
+	MaxGasTipCap *big.Int
+	// MaxGasFeeCap is the highest fee per gas offered, in wei. If nil, the fee cap is not limited.
+	MaxGasFeeCap *big.Int
+	// StuckTxBlocks is the number of blocks a transaction may stay pending before it is resubmitted with higher fees.
+	// Defaults to DEFAULT_STUCK_TX_BLOCKS.
+	StuckTxBlocks uint64
+}
+
+// feeStrategy picks the fees offered for transactions
+type feeStrategy struct {
+	opts FeeOpts
+}
+
+// suggest returns the tip and fee caps for a new transaction. It returns nil caps if the chain does not support EIP-1559.
+func (fs feeStrategy) suggest(ctx context.Context, chain ethChain) (tipCap, feeCap *big.Int, err error) {
+	head, err := chain.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if head.BaseFee == nil {
+		return nil, nil, nil
+	}
+	tipCap, err = chain.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	feeCap = new(big.Int).Mul(head.BaseFee, big.NewInt(BASE_FEE_MULTIPLIER))
+	feeCap.Add(feeCap, tipCap)
+
+	tipCap, feeCap = fs.clamp(tipCap, feeCap)
+	return tipCap, feeCap, nil
+}
+
+// bump returns the caps for a transaction replacing one offered the previous caps: the previous caps raised by FEE_BUMP_PERCENT,
+// or the suggested caps if they are higher. It returns false if the ceilings leave no room for a replacement to be accepted.
+func (fs feeStrategy) bump(prevTipCap, prevFeeCap, suggestedTipCap, suggestedFeeCap *big.Int) (tipCap, feeCap *big.Int, ok bool) {
+	tipCap = types.Max(percentOf(prevTipCap, 100+FEE_BUMP_PERCENT), suggestedTipCap)
+	feeCap = types.Max(percentOf(prevFeeCap, 100+FEE_BUMP_PERCENT), suggestedFeeCap)
+	tipCap, feeCap = fs.clamp(tipCap, feeCap)
+
+	ok = tipCap.Cmp(percentOf(prevTipCap, 100+MIN_FEE_BUMP_PERCENT)) >= 0 && feeCap.Cmp(percentOf(prevFeeCap, 100+MIN_FEE_BUMP_PERCENT)) >= 0
+	return tipCap, feeCap, ok
+}
+
+// clamp limits the caps to the configured ceilings. The tip cap never exceeds the fee cap.
+func (fs feeStrategy) clamp(tipCap, feeCap *big.Int) (*big.Int, *big.Int) {
+	if fs.opts.MaxGasTipCap != nil {
+		tipCap = types.Min(tipCap, fs.opts.MaxGasTipCap)
+	}
+	if fs.opts.MaxGasFeeCap != nil {
+		feeCap = types.Min(feeCap, fs.opts.MaxGasFeeCap)
+	}
+	return types.Min(tipCap, feeCap), feeCap
+}
+
+// percentOf returns percent% of n, rounded up
+func percentOf(n *big.Int, percent int64) *big.Int {
+	result := new(big.Int).Mul(n, big.NewInt(percent))
+	result.Add(result, big.NewInt(99))
+	return result.Div(result, big.NewInt(100))
+}
+
+// TransactionReplacedEvent is emitted when a transaction which stayed pending for too long is resubmitted with higher fees.
+// Its block is the head at the time of the replacement, rather than the block of a confirmed log.
+type TransactionReplacedEvent struct {
+	commonEvent
+	// ReplacedTxHash is the hash of the transaction which was replaced
+	ReplacedTxHash common.Hash
+	Nonce          uint64
+	GasTipCap      *big.Int
+	GasFeeCap      *big.Int
+}
+
+func (tre TransactionReplacedEvent) String() string {
+	return "Transaction " + tre.ReplacedTxHash.String() + " replaced by " + tre.txHash.String() + " at Block " + fmt.Sprint(tre.block.BlockNum)
+}
+
+// pendingTx is a transaction which has been submitted but not yet mined
+type pendingTx struct {
+	// tx is the latest submission of the transaction
+	tx        *ethTypes.Transaction
+	txType    string
+	channelId types.Destination
+	// hashes holds the hash of every submission of the transaction, any one of which may be mined
+	hashes []common.Hash
+	// submittedAt is the number of the latest block when the transaction was last submitted
+	submittedAt uint64
+}
+
+// trackPendingTx records a submitted transaction, so that it is replaced if it stays pending for too long
+func (ecs *EthChainService) trackPendingTx(tx *ethTypes.Transaction, txType string, channelId types.Destination) {
+	latestBlockNum := ecs.latestBlockNum()
+
+	ecs.txMu.Lock()
+	defer ecs.txMu.Unlock()
+	ecs.pendingTxs[tx.Nonce()] = &pendingTx{tx: tx, txType: txType, channelId: channelId, hashes: []common.Hash{tx.Hash()}, submittedAt: latestBlockNum}
+}
+
+// latestBlockNum returns the number of the latest block seen by the chain service
+func (ecs *EthChainService) latestBlockNum() uint64 {
+	ecs.eventTracker.mu.Lock()
+	defer ecs.eventTracker.mu.Unlock()
+	return ecs.eventTracker.latestBlock.BlockNum
+}
+
+// monitorPendingTxs reconciles the transactions left in the outbox by an earlier run with the chain, and then checks the pending
+// transactions and reconciles the outbox whenever a new block is seen
+func (ecs *EthChainService) monitorPendingTxs(startupTxs []OutboxTx) {
+	defer ecs.wg.Done()
+
+	latest, err := ecs.chain.HeaderByNumber(ecs.ctx, nil)
+	if err != nil {
+		ecs.logger.Error("failed to fetch the latest block to reconcile the transaction outbox", "error", err)
+	} else {
+		ecs.reconcileOutboxTxs(startupTxs, latest.Number.Uint64(), true)
+	}
+
+	for {
+		select {
+		case <-ecs.ctx.Done():
+			return
+		case block := <-ecs.newBlocks:
+			ecs.checkPendingTxs(block)
+			ecs.reconcileOutbox(block.BlockNum)
+		}
+	}
+}
+
+// checkPendingTxs forgets pending transactions which have been mined, and replaces those which have been pending for StuckTxBlocks blocks.
+// The chain is queried without holding txMu, so that transactions can be submitted meanwhile.
+func (ecs *EthChainService) checkPendingTxs(block Block) {
+	ecs.txMu.Lock()
+	pending := make([]*pendingTx, 0, len(ecs.pendingTxs))
+	for _, p := range ecs.pendingTxs {
+		snapshot := *p
+		snapshot.hashes = slices.Clone(p.hashes)
+		pending = append(pending, &snapshot)
+	}
+	ecs.txMu.Unlock()
+
+	for _, p := range pending {
+		mined, err := ecs.isMined(p)
+		if err != nil {
+			ecs.logger.Warn("failed to check whether transaction was mined", "txHash", p.tx.Hash(), "error", err)
+			continue
+		}
+		if mined {
+			ecs.forgetPendingTx(p.tx)
+			continue
+		}
+		if block.BlockNum < p.submittedAt+ecs.feeOpts.StuckTxBlocks {
+			continue
+		}
+
+		err = ecs.replacePendingTx(p, block)
+		if err == nil {
+			continue
+		}
+		used, nonceErr := ecs.isNonceUsed(p.tx.Nonce(), err)
+		if nonceErr != nil {
+			ecs.logger.Warn("failed to check whether the nonce of a pending transaction has been used", "txHash", p.tx.Hash(), "error", nonceErr)
+		}
+		if used {
+			// The nonce has been used by a transaction which is not tracked, so none of the submissions can be mined
+			ecs.logger.Warn("dropping pending transaction whose nonce has been used", "txHash", p.tx.Hash(), "nonce", p.tx.Nonce())
+			ecs.forgetPendingTx(p.tx)
+			continue
+		}
+		ecs.logger.Error("failed to replace pending transaction", "txHash", p.tx.Hash(), "error", err)
+	}
+}
+
+// isNonceUsed reports whether a transaction with the nonce has been mined from the signing account, given the error with which a replacement was refused
+func (ecs *EthChainService) isNonceUsed(nonce uint64, err error) (bool, error) {
+	if errors.Is(err, core.ErrNonceTooLow) {
+		return true, nil
+	}
+	// Errors returned over RPC do not wrap the errors of the node, so the nonce is checked against the chain
+	mined, err := ecs.chain.NonceAt(ecs.ctx, ecs.txSigner.From, nil)
+	if err != nil {
+		return false, err
+	}
+	return nonce < mined, nil
+}
+
+// forgetPendingTx stops tracking a pending transaction, unless its nonce has since been taken by another transaction
+func (ecs *EthChainService) forgetPendingTx(tx *ethTypes.Transaction) {
+	ecs.txMu.Lock()
+	defer ecs.txMu.Unlock()
+	if p, ok := ecs.pendingTxs[tx.Nonce()]; ok && p.tx.Hash() == tx.Hash() {
+		delete(ecs.pendingTxs, tx.Nonce())
+	}
+}
+
+// recordPendingTxReplacement records the submission of a replacement for a pending transaction, unless the transaction has since been forgotten
+func (ecs *EthChainService) recordPendingTxReplacement(old, replacement *ethTypes.Transaction, submittedAt uint64) {
+	ecs.txMu.Lock()
+	defer ecs.txMu.Unlock()
+	if p, ok := ecs.pendingTxs[old.Nonce()]; ok && p.tx.Hash() == old.Hash() {
+		p.tx = replacement
+		p.hashes = append(p.hashes, replacement.Hash())
+		p.submittedAt = submittedAt
+	}
+}
+
+// isMined reports whether any submission of a pending transaction has been mined
+func (ecs *EthChainService) isMined(p *pendingTx) (bool, error) {
+	for _, hash := range p.hashes {
+		_, err := ecs.chain.TransactionReceipt(ecs.ctx, hash)
+		if err == nil {
+			return true, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// replacePendingTx resubmits a pending transaction with the same nonce and higher fees. p is a snapshot of the tracked transaction.
+func (ecs *EthChainService) replacePendingTx(p *pendingTx, block Block) error {
+	suggestedTipCap, suggestedFeeCap, err := ecs.feeStrategy.suggest(ecs.ctx, ecs.chain)
+	if err != nil {
+		return err
+	}
+	if suggestedFeeCap == nil {
+		// Without EIP-1559, the gas price is offered as both caps
+		gasPrice, err := ecs.chain.SuggestGasPrice(ecs.ctx)
+		if err != nil {
+			return err
+		}
+		suggestedTipCap, suggestedFeeCap = gasPrice, gasPrice
+	}
+
+	old := p.tx
+	tipCap, feeCap, ok := ecs.feeStrategy.bump(old.GasTipCap(), old.GasFeeCap(), suggestedTipCap, suggestedFeeCap)
+	if !ok {
+		ecs.logger.Warn("not replacing pending transaction, since its fees are at the configured ceiling", "txHash", old.Hash(), "gasTipCap", old.GasTipCap(), "gasFeeCap", old.GasFeeCap())
+		return nil
+	}
+
+	var replacement ethTypes.TxData
+	if old.Type() == ethTypes.LegacyTxType {
+		replacement = &ethTypes.LegacyTx{Nonce: old.Nonce(), GasPrice: feeCap, Gas: old.Gas(), To: old.To(), Value: old.Value(), Data: old.Data()}
+	} else {
+		replacement = &ethTypes.DynamicFeeTx{ChainID: old.ChainId(), Nonce: old.Nonce(), GasTipCap: tipCap, GasFeeCap: feeCap, Gas: old.Gas(), To: old.To(), Value: old.Value(), Data: old.Data(), AccessList: old.AccessList()}
+	}
+	signedTx, err := ecs.txSigner.Signer(ecs.txSigner.From, ethTypes.NewTx(replacement))
+	if err != nil {
+		return err
+	}
+	err = ecs.chain.SendTransaction(ecs.ctx, signedTx)
+	if err != nil {
+		return err
+	}
+
+	ecs.recordPendingTxReplacement(old, signedTx, block.BlockNum)
+	err = ecs.recordOutboxReplacement(old.Hash(), signedTx.Hash())
+	if err != nil {
+		ecs.logger.Error("failed to record replacement in the transaction outbox", "txHash", signedTx.Hash(), "error", err)
+	}
+	metrics.ChainTransactions.WithLabelValues(p.txType, metrics.Replaced).Inc()
+	ecs.logger.Info("replaced pending transaction", "replacedTxHash", old.Hash(), "txHash", signedTx.Hash(), "nonce", signedTx.Nonce(), "gasTipCap", signedTx.GasTipCap(), "gasFeeCap", signedTx.GasFeeCap())
+
+	event := TransactionReplacedEvent{
+		commonEvent:    commonEvent{channelID: p.channelId, block: block, txHash: signedTx.Hash()},
+		ReplacedTxHash: old.Hash(),
+		Nonce:          signedTx.Nonce(),
+		GasTipCap:      signedTx.GasTipCap(),
+		GasFeeCap:      signedTx.GasFeeCap(),
+	}
+	select {
+	case ecs.eventEngineOut <- event:
+	case <-ecs.ctx.Done():
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package chainservice
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestFeeStrategyBump(t *testing.T) {
+	testCases := []struct {
+		name                             string
+		opts                             FeeOpts
+		prevTipCap, prevFeeCap           int64
+		suggestedTipCap, suggestedFeeCap int64
+		wantTipCap, wantFeeCap           int64
+		wantOk                           bool
+	}{
+		{"bumps the previous caps", FeeOpts{}, 100, 1000, 50, 500, 120, 1200, true},
+		{"prefers higher suggested caps", FeeOpts{}, 100, 1000, 300, 2000, 300, 2000, true},
+		{"rounds up", FeeOpts{}, 1, 1, 0, 0, 2, 2, true},
+		{"clamps to the ceilings", FeeOpts{MaxGasTipCap: big.NewInt(115), MaxGasFeeCap: big.NewInt(1500)}, 100, 1000, 50, 500, 115, 1200, true},
+		{"keeps the tip cap below the fee cap", FeeOpts{MaxGasFeeCap: big.NewInt(1100)}, 1000, 1000, 0, 0, 1100, 1100, true},
+		{"refuses a replacement below the minimum bump", FeeOpts{MaxGasTipCap: big.NewInt(105)}, 100, 1000, 50, 500, 105, 1200, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fs := feeStrategy{tc.opts}
+			tipCap, feeCap, ok := fs.bump(big.NewInt(tc.prevTipCap), big.NewInt(tc.prevFeeCap), big.NewInt(tc.suggestedTipCap), big.NewInt(tc.suggestedFeeCap))
+			if tipCap.Int64() != tc.wantTipCap || feeCap.Int64() != tc.wantFeeCap || ok != tc.wantOk {
+				t.Fatalf("expected (%d, %d, %t), got (%d, %d, %t)", tc.wantTipCap, tc.wantFeeCap, tc.wantOk, tipCap, feeCap, ok)
+			}
+		})
+	}
+}
+
+func TestStuckTransactionIsReplaced(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txSigner := ethAccounts[0]
+
+	cs, err := newEthChainService(sim, 0, bindings.Adjudicator.Contract, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, txSigner, FeeOpts{StuckTxBlocks: 2}, nil)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A fee cap far below the base fee keeps the transaction from being mined
+	ctx := context.Background()
+	nonce, err := sim.PendingNonceAt(ctx, txSigner.From)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1234")
+	stuckTx, err := txSigner.Signer(txSigner.From, ethTypes.NewTx(&ethTypes.DynamicFeeTx{
+		ChainID:   big.NewInt(TEST_CHAIN_ID),
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = sim.SendTransaction(ctx, stuckTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs.trackPendingTx(stuckTx, "Transfer", types.Destination{})
+
+	// Blocks are mined one at a time, giving the monitor the chance to check each of them. The replacement is reported to the engine.
+	var replacedEvent TransactionReplacedEvent
+	for i := 0; replacedEvent.ReplacedTxHash == (common.Hash{}); i++ {
+		if i == 20 {
+			t.Fatal("expected the stuck transaction to be replaced")
+		}
+		sim.Commit()
+		select {
+		case event := <-cs.EventEngineFeed():
+			if replaced, ok := event.(TransactionReplacedEvent); ok {
+				replacedEvent = replaced
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	if replacedEvent.ReplacedTxHash != stuckTx.Hash() || replacedEvent.Nonce != nonce {
+		t.Fatalf("unexpected transaction replaced event %+v", replacedEvent)
+	}
+	if replacedEvent.GasFeeCap.Cmp(stuckTx.GasFeeCap()) <= 0 || replacedEvent.GasTipCap.Cmp(stuckTx.GasTipCap()) <= 0 {
+		t.Fatalf("expected the replacement to offer higher fees, got %+v", replacedEvent)
+	}
+
+	sim.Commit()
+	receipt, err := sim.TransactionReceipt(ctx, replacedEvent.TxHash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != ethTypes.ReceiptStatusSuccessful {
+		t.Fatal("expected the replacement transaction to succeed")
+	}
+}
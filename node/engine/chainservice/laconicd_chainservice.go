@@ -1,49 +1,426 @@
 package chainservice
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"math/big"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
 
+	"github.com/statechannels/go-nitro/internal/logging"
+	"github.com/statechannels/go-nitro/internal/metrics"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
 )
 
+// LACONICD_POLL_INTERVAL is how often the chain is polled for new blocks, unless LaconicdChainOpts.PollInterval is set
+const LACONICD_POLL_INTERVAL = 1 * time.Second
+
 type LaconicdChainOpts struct {
-	VpaAddress common.Address
-	CaAddress  common.Address
+	// Client connects the chain service to laconicd. It is required.
+	Client LaconicdClient
+	// StartHeight is the height of the first block whose events are dispatched
+	StartHeight uint64
+	// PollInterval is how often the chain is polled for new blocks. Defaults to LACONICD_POLL_INTERVAL.
+	PollInterval time.Duration
+	VpaAddress   common.Address
+	CaAddress    common.Address
+	// Outbox persists submitted transactions. If nil, transactions are held in memory.
+	Outbox TxOutbox
 }
+
+// LaconicdChainService submits transactions to the nitro module of a laconicd chain, and relays the events emitted by it.
+//
+// Blocks committed by laconicd are final, so events are dispatched as soon as their block is seen and every seen block is confirmed.
 type LaconicdChainService struct {
+	client                   LaconicdClient
+	chainId                  *big.Int
 	consensusAppAddress      common.Address
 	virtualPaymentAppAddress common.Address
+	pollInterval             time.Duration
+	eventEngineOut           chan Event
+	eventOut                 chan Event
+	logger                   *slog.Logger
+	ctx                      context.Context
+	cancel                   context.CancelFunc
+	wg                       *sync.WaitGroup
+	// txMu serializes broadcasts, since each takes the next sequence number of the signing account
+	txMu      *sync.Mutex
+	outbox    TxOutbox
+	outboxIds *outboxIds
+
+	// blockMu protects latestBlock, the latest block whose events have been dispatched
+	blockMu     *sync.Mutex
+	latestBlock Block
 }
 
+// NewLaconicdChainService constructs a chain service which uses the supplied client. Events emitted from chainOpts.StartHeight
+// onwards are dispatched, including those emitted while this node was offline.
 func NewLaconicdChainService(chainOpts LaconicdChainOpts) (*LaconicdChainService, error) {
-	return &LaconicdChainService{
-		chainOpts.CaAddress,
-		chainOpts.VpaAddress,
-	}, nil
+	if chainOpts.Client == nil {
+		return nil, fmt.Errorf("a laconicd client must be supplied")
+	}
+	if chainOpts.PollInterval == 0 {
+		chainOpts.PollInterval = LACONICD_POLL_INTERVAL
+	}
+	if chainOpts.Outbox == nil {
+		chainOpts.Outbox = NewMemTxOutbox()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	chainId, err := chainOpts.Client.ChainID(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("could not get laconicd chain id: %w", err)
+	}
+
+	lcs := LaconicdChainService{
+		client:                   chainOpts.Client,
+		chainId:                  chainId,
+		consensusAppAddress:      chainOpts.CaAddress,
+		virtualPaymentAppAddress: chainOpts.VpaAddress,
+		pollInterval:             chainOpts.PollInterval,
+		// Use buffered channels so we don't have to worry about blocking on writing to the channel.
+		eventEngineOut: make(chan Event, 10),
+		eventOut:       make(chan Event, 10),
+		logger:         logging.LoggerWithAddress(slog.Default(), chainOpts.Client.Address()),
+		ctx:            ctx,
+		cancel:         cancel,
+		wg:             &sync.WaitGroup{},
+		txMu:           &sync.Mutex{},
+		outbox:         chainOpts.Outbox,
+		outboxIds:      &outboxIds{},
+		blockMu:        &sync.Mutex{},
+	}
+	if chainOpts.StartHeight > 0 {
+		lcs.latestBlock.BlockNum = chainOpts.StartHeight - 1
+	}
+
+	// The transactions left in the outbox by an earlier run are reconciled on startup
+	startupTxs, err := lcs.outbox.GetOutboxTxs()
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	lcs.wg.Add(1)
+	go lcs.listenForNewBlocks(startupTxs)
+
+	return &lcs, nil
 }
 
-func (lcs *LaconicdChainService) SendTransaction(tx protocols.ChainTransaction) (*ethTypes.Transaction, error) {
-	return nil, nil
+// listenForNewBlocks resubmits the transactions whose submission was interrupted by a restart, and then polls the chain for new blocks
+// until the chain service is closed
+func (lcs *LaconicdChainService) listenForNewBlocks(startupTxs []OutboxTx) {
+	defer lcs.wg.Done()
+	for _, o := range startupTxs {
+		if o.Status != TxQueued {
+			continue
+		}
+		lcs.logger.Info("submitting transaction queued before a restart", "id", o.Id, "objective", o.ObjectiveId)
+		err := lcs.submitOutboxTx(o)
+		if err != nil {
+			lcs.logger.Error("failed to submit transaction queued before a restart", "id", o.Id, "error", err)
+		}
+	}
+
+	ticker := time.NewTicker(lcs.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := lcs.checkForNewBlocks()
+		if err != nil && !errors.Is(err, context.Canceled) {
+			// The blocks will be fetched again on the next poll
+			lcs.logger.Warn("failed to fetch laconicd blocks", "error", err)
+		}
+		err = lcs.retryQueuedTxs()
+		if err != nil {
+			lcs.logger.Error("failed to read the transaction outbox", "error", err)
+		}
+
+		select {
+		case <-lcs.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
-func (lcs *LaconicdChainService) DroppedEventEngineFeed() <-chan protocols.DroppedEventInfo {
+// checkForNewBlocks dispatches the events of every block committed since the latest block seen
+func (lcs *LaconicdChainService) checkForNewBlocks() error {
+	latestHeight, err := lcs.client.LatestHeight(lcs.ctx)
+	if err != nil {
+		return err
+	}
+
+	for height := lcs.GetLastConfirmedBlockNum() + 1; height <= latestHeight; height++ {
+		block, err := lcs.client.Block(lcs.ctx, height)
+		if err != nil {
+			return fmt.Errorf("could not get laconicd block %d: %w", height, err)
+		}
+		err = lcs.dispatchChainEvents(block)
+		if err != nil {
+			return err
+		}
+
+		lcs.blockMu.Lock()
+		lcs.latestBlock = Block{BlockNum: block.Height, Timestamp: block.Time}
+		lcs.blockMu.Unlock()
+		lcs.logger.Log(lcs.ctx, logging.LevelTrace, "detected new block", "block-num", block.Height)
+	}
+	return lcs.pruneOutbox(latestHeight)
+}
+
+// pruneOutbox removes the transactions from the outbox which are OUTBOX_RETENTION_BLOCKS deep. Committed laconicd blocks are final,
+// so the transactions are only kept for inspection.
+func (lcs *LaconicdChainService) pruneOutbox(latestHeight uint64) error {
+	txs, err := lcs.outbox.GetOutboxTxs()
+	if err != nil {
+		return err
+	}
+	for _, o := range txs {
+		if o.Status == TxQueued || latestHeight < o.BlockNum+OUTBOX_RETENTION_BLOCKS {
+			continue
+		}
+		err := lcs.outbox.RemoveOutboxTx(o.Id)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (lcs *LaconicdChainService) DroppedEventFeed() <-chan protocols.DroppedEventInfo {
+// dispatchChainEvents translates the events of a block into chain service events, and dispatches them to the engine.
+// Events which cannot be translated are logged and skipped.
+func (lcs *LaconicdChainService) dispatchChainEvents(block LaconicdBlock) error {
+	for _, le := range block.Events {
+		event, err := lcs.translateEvent(Block{BlockNum: block.Height, Timestamp: block.Time}, le)
+		if err != nil {
+			lcs.logger.Error("failed to translate laconicd event", "type", le.Type, "block-num", block.Height, "error", err)
+			continue
+		}
+		if event == nil {
+			lcs.logger.Info("Ignoring unknown laconicd event", "type", le.Type)
+			continue
+		}
+
+		select {
+		case lcs.eventEngineOut <- event:
+		case <-lcs.ctx.Done():
+			return lcs.ctx.Err()
+		}
+		metrics.ChainEventsDispatched.WithLabelValues(laconicdEventNames[le.Type]).Inc()
+	}
 	return nil
 }
 
-func (lcs *LaconicdChainService) EventEngineFeed() <-chan Event {
+// translateEvent converts an event emitted by the nitro module into a chain service event. It returns nil for unknown event types.
+func (lcs *LaconicdChainService) translateEvent(block Block, le LaconicdEvent) (Event, error) {
+	if _, ok := laconicdEventNames[le.Type]; !ok {
+		return nil, nil
+	}
+	channelId, err := le.destination(LaconicdChannelIdAttribute)
+	if err != nil {
+		return nil, err
+	}
+
+	switch le.Type {
+	case LaconicdDepositedEvent:
+		asset, err := le.address(LaconicdAssetAttribute)
+		if err != nil {
+			return nil, err
+		}
+		nowHeld, err := le.bigInt(LaconicdDestinationHoldingsAttribute)
+		if err != nil {
+			return nil, err
+		}
+		return NewDepositedEvent(channelId, block, le.TxIndex, asset, nowHeld, le.TxHash), nil
+
+	case LaconicdAllocationUpdatedEvent:
+		asset, err := le.address(LaconicdAssetAttribute)
+		if err != nil {
+			return nil, err
+		}
+		finalHoldings, err := le.bigInt(LaconicdFinalHoldingsAttribute)
+		if err != nil {
+			return nil, err
+		}
+		return NewAllocationUpdatedEvent(channelId, block, le.TxIndex, asset, finalHoldings, le.TxHash), nil
+
+	case LaconicdConcludedEvent:
+		return ConcludedEvent{commonEvent: commonEvent{channelID: channelId, block: block, txIndex: le.TxIndex, txHash: le.TxHash}}, nil
+
+	case LaconicdChallengeRegisteredEvent:
+		sender, err := le.address(LaconicdSenderAttribute)
+		if err != nil {
+			return nil, err
+		}
+		candidate, err := le.signedState(LaconicdCandidateAttribute)
+		if err != nil {
+			return nil, err
+		}
+		finalizesAt, err := le.bigInt(LaconicdFinalizesAtAttribute)
+		if err != nil {
+			return nil, err
+		}
+		return NewChallengeRegisteredEvent(
+			channelId,
+			block,
+			le.TxIndex,
+			candidate.State().VariablePart(),
+			candidate.Signatures(),
+			finalizesAt,
+			sender == lcs.client.Address(),
+			le.TxHash,
+		), nil
+
+	case LaconicdChallengeClearedEvent:
+		newTurnNumRecord, err := le.bigInt(LaconicdNewTurnNumRecordAttribute)
+		if err != nil {
+			return nil, err
+		}
+		return NewChallengeClearedEvent(channelId, block, le.TxIndex, newTurnNumRecord, le.TxHash), nil
+
+	default:
+		return nil, nil
+	}
+}
+
+// SendTransaction broadcasts the nitro module messages for a transaction, and blocks until they have been committed.
+// laconicd transactions are not Ethereum transactions, so the returned transaction is always nil.
+func (lcs *LaconicdChainService) SendTransaction(tx protocols.ChainTransaction) (*ethTypes.Transaction, error) {
+	_, err := lcs.broadcast(tx)
+	return nil, err
+}
+
+// SubmitTransaction records the transaction in the outbox and broadcasts it, blocking until it has been committed.
+// Committed laconicd blocks are final, so only a transaction whose broadcast was interrupted by a restart, or which could not reach laconicd,
+// is broadcast again.
+func (lcs *LaconicdChainService) SubmitTransaction(objectiveId protocols.ObjectiveId, tx protocols.ChainTransaction) error {
+	return submitToOutbox(lcs.outbox, lcs.outboxIds, objectiveId, tx, lcs.submitOutboxTx)
+}
+
+// retryQueuedTxs broadcasts again the transactions in the outbox which could not reach laconicd
+func (lcs *LaconicdChainService) retryQueuedTxs() error {
+	txs, err := lcs.outbox.GetOutboxTxs()
+	if err != nil {
+		return err
+	}
+	for _, o := range txs {
+		// A queued transaction without an error is still being broadcast
+		if o.Status != TxQueued || o.Error == "" {
+			continue
+		}
+		lcs.logger.Info("retrying transaction which could not be broadcast", "id", o.Id, "objective", o.ObjectiveId, "error", o.Error)
+		err := lcs.submitOutboxTx(o)
+		if err != nil {
+			lcs.logger.Error("failed to retry transaction", "id", o.Id, "error", err)
+		}
+	}
 	return nil
 }
 
+// submitOutboxTx broadcasts a transaction held in the outbox and records the outcome. A transaction rejected by laconicd is marked as failed,
+// while one which could not reach laconicd stays queued to be broadcast again on the next poll, until MAX_OUTBOX_TX_ATTEMPTS broadcasts have failed.
+// Since the hash of the block in which the transaction was committed is not known, a confirmed transaction only records the latest height at the time.
+func (lcs *LaconicdChainService) submitOutboxTx(o OutboxTx) error {
+	txHash, err := lcs.broadcast(o.Tx)
+	var rejection *LaconicdTxError
+	switch {
+	case errors.As(err, &rejection):
+		o.Status, o.Error = TxFailed, err.Error()
+	case err != nil && o.Attempts+1 >= MAX_OUTBOX_TX_ATTEMPTS:
+		o.Attempts++
+		o.Status, o.Error = TxFailed, fmt.Sprintf("gave up after %d attempts: %s", o.Attempts, err)
+		err = fmt.Errorf("gave up after %d attempts: %w", o.Attempts, err)
+	case err != nil:
+		// The error is recorded, so that the transaction is retried. It is not returned, since the transaction has not failed.
+		o.Attempts++
+		lcs.logger.Warn("could not broadcast transaction, it will be retried", "id", o.Id, "objective", o.ObjectiveId, "attempts", o.Attempts, "error", err)
+		o.Status, o.Error, o.UpdatedAt = TxQueued, err.Error(), time.Now()
+		return lcs.outbox.SetOutboxTx(o)
+	default:
+		o.Status, o.Error, o.Attempts = TxConfirmed, "", 0
+		o.TxHashes = append(o.TxHashes, txHash)
+	}
+	o.BlockNum, o.UpdatedAt = lcs.GetLastConfirmedBlockNum(), time.Now()
+	if height, heightErr := lcs.client.LatestHeight(lcs.ctx); heightErr == nil {
+		o.BlockNum = height
+	}
+
+	setErr := lcs.outbox.SetOutboxTx(o)
+	if err != nil {
+		return err
+	}
+	return setErr
+}
+
+// broadcast broadcasts the messages for a transaction, and returns the hash of the last of them
+func (lcs *LaconicdChainService) broadcast(tx protocols.ChainTransaction) (common.Hash, error) {
+	lcs.txMu.Lock()
+	defer lcs.txMu.Unlock()
+
+	txHash, err := lcs.sendTransaction(tx)
+	outcome := metrics.Submitted
+	if err != nil {
+		outcome = metrics.Failed
+	}
+	metrics.ChainTransactions.WithLabelValues(strings.TrimPrefix(fmt.Sprintf("%T", tx), "protocols."), outcome).Inc()
+	return txHash, err
+}
+
+// sendTransaction broadcasts the messages for a transaction of any supported type
+func (lcs *LaconicdChainService) sendTransaction(tx protocols.ChainTransaction) (common.Hash, error) {
+	switch tx := tx.(type) {
+	case protocols.DepositTransaction:
+		// Assets are deposited in a fixed order, so that the hash returned is always that of the deposit of the same asset
+		var txHash common.Hash
+		for _, asset := range sortedAssets(tx.Deposit) {
+			amount := tx.Deposit[asset]
+			holdings, err := lcs.client.Holdings(lcs.ctx, asset, tx.ChannelId())
+			if err != nil {
+				return common.Hash{}, err
+			}
+			lcs.logger.Debug("existing holdings", "holdings", holdings)
+
+			txHash, err = lcs.client.Broadcast(lcs.ctx, LaconicdDepositMsg{ChannelId: tx.ChannelId(), Asset: asset, ExpectedHeld: holdings, Amount: amount})
+			if err != nil {
+				return common.Hash{}, err
+			}
+		}
+		return txHash, nil
+	case protocols.WithdrawAllTransaction:
+		return lcs.client.Broadcast(lcs.ctx, LaconicdConcludeMsg{Candidate: tx.SignedState})
+	case protocols.ChallengeTransaction:
+		return lcs.client.Broadcast(lcs.ctx, LaconicdChallengeMsg{Candidate: tx.Candidate, Proof: tx.Proof, ChallengerSig: tx.ChallengerSig})
+	case protocols.CheckpointTransaction:
+		return lcs.client.Broadcast(lcs.ctx, LaconicdCheckpointMsg{Candidate: tx.Candidate, Proof: tx.Proof})
+	case protocols.TransferAllTransaction:
+		transferState := tx.TransferState.State()
+		stateHash, err := transferState.Hash()
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return lcs.client.Broadcast(lcs.ctx, LaconicdTransferAllMsg{ChannelId: transferState.ChannelId(), Outcome: transferState.Outcome, StateHash: stateHash})
+	default:
+		return common.Hash{}, fmt.Errorf("unexpected transaction type %T for laconicd", tx)
+	}
+}
+
+// EventEngineFeed returns the out chan, and narrows the type so that external consumers may only receive on it.
+func (lcs *LaconicdChainService) EventEngineFeed() <-chan Event {
+	return lcs.eventEngineOut
+}
+
+// EventFeed returns a chan for bridge events. The nitro module does not emit any, so nothing is sent on it.
 func (lcs *LaconicdChainService) EventFeed() <-chan Event {
-	return nil
+	return lcs.eventOut
 }
 
 func (lcs *LaconicdChainService) GetConsensusAppAddress() common.Address {
@@ -55,21 +432,42 @@ func (lcs *LaconicdChainService) GetVirtualPaymentAppAddress() common.Address {
 }
 
 func (lcs *LaconicdChainService) GetChainId() (*big.Int, error) {
-	return nil, nil
+	return new(big.Int).Set(lcs.chainId), nil
 }
 
+// GetLastConfirmedBlockNum returns the height of the latest block whose events have been dispatched
 func (lcs *LaconicdChainService) GetLastConfirmedBlockNum() uint64 {
-	return 0
+	lcs.blockMu.Lock()
+	defer lcs.blockMu.Unlock()
+	return lcs.latestBlock.BlockNum
 }
 
+// GetBlockByNumber returns a block holding the number and timestamp of the laconicd block at the given height, or nil if no block has been seen yet
 func (lcs *LaconicdChainService) GetBlockByNumber(blockNum *big.Int) (*ethTypes.Block, error) {
-	return &ethTypes.Block{}, nil
+	lcs.blockMu.Lock()
+	latestBlock := lcs.latestBlock
+	lcs.blockMu.Unlock()
+
+	if latestBlock.Timestamp == 0 && blockNum.Uint64() == latestBlock.BlockNum {
+		return nil, nil
+	}
+	if blockNum.Uint64() != latestBlock.BlockNum {
+		block, err := lcs.client.Block(lcs.ctx, blockNum.Uint64())
+		if err != nil {
+			return nil, err
+		}
+		latestBlock = Block{BlockNum: block.Height, Timestamp: block.Time}
+	}
+	return ethTypes.NewBlockWithHeader(&ethTypes.Header{Number: new(big.Int).SetUint64(latestBlock.BlockNum), Time: latestBlock.Timestamp}), nil
 }
 
+// GetL1ChannelFromL2 returns the zero destination, since the nitro module does not map L2 channels to L1 channels
 func (lcs *LaconicdChainService) GetL1ChannelFromL2(l2Channel types.Destination) (types.Destination, error) {
 	return types.Destination{}, nil
 }
 
 func (lcs *LaconicdChainService) Close() error {
+	lcs.cancel()
+	lcs.wg.Wait()
 	return nil
 }
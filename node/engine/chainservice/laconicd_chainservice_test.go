@@ -0,0 +1,257 @@
+package chainservice
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/channel/state"
+	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestLaconicdChainService(t *testing.T) {
+	chain := NewLocalLaconicd(LocalLaconicdOpts{})
+	defer chain.Close()
+
+	newChainService := func(account common.Address) *LaconicdChainService {
+		cs, err := NewLaconicdChainService(LaconicdChainOpts{Client: chain.Client(account), PollInterval: 10 * time.Millisecond})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cs
+	}
+	csA := newChainService(Alice.Address())
+	defer closeChainService(t, csA)
+	csB := newChainService(Bob.Address())
+	defer closeChainService(t, csB)
+
+	signedState := func(channelNonce uint64, turnNum uint64, isFinal bool) state.SignedState {
+		s := state.State{
+			Participants:      []types.Address{Alice.Address(), Bob.Address()},
+			ChannelNonce:      channelNonce,
+			ChallengeDuration: CHALLENGE_DURATION,
+			AppData:           []byte{},
+			Outcome:           concludeOutcome,
+			TurnNum:           turnNum,
+			IsFinal:           isFinal,
+		}
+		ss := state.NewSignedState(s)
+		for _, pk := range [][]byte{Alice.PrivateKey, Bob.PrivateKey} {
+			sig, err := s.Sign(pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ss.AddSignature(sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		return ss
+	}
+	challengeTx := func(ss state.SignedState) protocols.ChallengeTransaction {
+		challengerSig, err := NitroAdjudicator.SignChallengeMessage(ss.State(), Alice.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return protocols.NewChallengeTransaction(ss.ChannelId(), ss, []state.SignedState{}, challengerSig)
+	}
+	send := func(tx protocols.ChainTransaction) {
+		t.Helper()
+		if _, err := csA.SendTransaction(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	receive := func(cs *LaconicdChainService) Event {
+		t.Helper()
+		select {
+		case event := <-cs.EventEngineFeed():
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for a chain event")
+			return nil
+		}
+	}
+
+	challenged := signedState(1, 2, false)
+	cId := challenged.ChannelId()
+	token := common.HexToAddress("0x1234")
+
+	// Deposits are made one asset at a time, in order of asset
+	testDeposit := types.Funds{common.Address{}: big.NewInt(3), token: big.NewInt(1)}
+	send(protocols.NewDepositTransaction(cId, testDeposit))
+	for _, asset := range []common.Address{{}, token} {
+		dEvent := receive(csA).(DepositedEvent)
+		if dEvent.ChannelID() != cId || dEvent.Asset != asset || dEvent.NowHeld.Cmp(testDeposit[asset]) != 0 {
+			t.Fatalf("unexpected deposited event %+v", dEvent)
+		}
+	}
+
+	send(challengeTx(challenged))
+	crEvent := receive(csA).(ChallengeRegisteredEvent)
+	if crEvent.TurnNum() != 2 || !crEvent.IsInitiatedByMe {
+		t.Fatalf("unexpected challenge registered event %+v", crEvent)
+	}
+	if expected := crEvent.Block().Timestamp + uint64(CHALLENGE_DURATION); crEvent.FinalizesAt.Uint64() != expected {
+		t.Fatalf("expected the challenge to finalize at %d, got %d", expected, crEvent.FinalizesAt)
+	}
+	challengedSignedState, err := crEvent.SignedState(challenged.State().FixedPart())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !challengedSignedState.HasAllSignatures() {
+		t.Fatal("expected the challenge registered event to carry the signatures of the candidate")
+	}
+
+	send(protocols.NewCheckpointTransaction(cId, signedState(1, 3, false), []state.SignedState{}))
+	if _, ok := receive(csA).(ChallengeClearedEvent); !ok {
+		t.Fatal("expected a challenge cleared event")
+	}
+
+	// Concluding pays out the two units allocated, leaving one unit of the native asset
+	send(protocols.NewWithdrawAllTransaction(cId, signedState(1, 4, true)))
+	if _, ok := receive(csA).(ConcludedEvent); !ok {
+		t.Fatal("expected a concluded event")
+	}
+	auEvent := receive(csA).(AllocationUpdatedEvent)
+	if auEvent.AssetAddress != (common.Address{}) || auEvent.AssetAmount.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("unexpected allocation updated event %+v", auEvent)
+	}
+	if _, err := csA.SendTransaction(protocols.NewDepositTransaction(cId, testDeposit)); err == nil {
+		t.Fatal("expected a deposit into a finalized channel to be rejected")
+	}
+
+	// A channel finalized by a challenge is paid out by a TransferAllTransaction once the challenge has expired
+	timedOut := signedState(2, 2, false)
+	send(protocols.NewDepositTransaction(timedOut.ChannelId(), types.Funds{common.Address{}: big.NewInt(2)}))
+	receive(csA)
+	send(challengeTx(timedOut))
+	receive(csA)
+	transferTx := protocols.NewTransferAllTransaction(timedOut.ChannelId(), timedOut)
+	if _, err := csA.SendTransaction(transferTx); err == nil {
+		t.Fatal("expected the outcome of a channel which is not finalized to be kept")
+	}
+	chain.AdvanceTime(uint64(CHALLENGE_DURATION))
+	send(transferTx)
+	auEvent = receive(csA).(AllocationUpdatedEvent)
+	if auEvent.ChannelID() != timedOut.ChannelId() || auEvent.AssetAmount.Sign() != 0 {
+		t.Fatalf("unexpected allocation updated event %+v", auEvent)
+	}
+
+	// Bob's chain service relays the same events, but did not initiate the challenges
+	for i := 0; i < 9; i++ {
+		event := receive(csB)
+		if cr, ok := event.(ChallengeRegisteredEvent); ok && cr.IsInitiatedByMe {
+			t.Fatal("expected Bob's chain service not to report Alice's challenge as its own")
+		}
+	}
+
+	latestHeight, err := chain.Client(Alice.Address()).LatestHeight(csA.ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The block is recorded as confirmed once all of its events have been dispatched
+	deadline := time.Now().Add(time.Second)
+	for csA.GetLastConfirmedBlockNum() != latestHeight && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if blockNum := csA.GetLastConfirmedBlockNum(); blockNum != latestHeight {
+		t.Fatalf("expected the last confirmed block to be %d, got %d", latestHeight, blockNum)
+	}
+	block, err := csA.GetBlockByNumber(new(big.Int).SetUint64(latestHeight))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.NumberU64() != latestHeight {
+		t.Fatalf("expected block %d, got %d", latestHeight, block.NumberU64())
+	}
+
+	// A chain service started later relays the events emitted while it was offline
+	csLate := newChainService(Bob.Address())
+	defer closeChainService(t, csLate)
+	if _, ok := receive(csLate).(DepositedEvent); !ok {
+		t.Fatal("expected the first event to be a deposited event")
+	}
+}
+
+// unreachableLaconicdClient fails to broadcast until failures have been returned, as though laconicd could not be reached.
+// When reject is set, laconicd instead rejects every message.
+type unreachableLaconicdClient struct {
+	LaconicdClient
+	failures atomic.Int32
+	reject   atomic.Bool
+}
+
+func (c *unreachableLaconicdClient) Broadcast(ctx context.Context, msg LaconicdMsg) (common.Hash, error) {
+	if c.reject.Load() {
+		return common.Hash{}, &LaconicdTxError{Code: 5, Log: "insufficient funds"}
+	}
+	if c.failures.Add(-1) >= 0 {
+		return common.Hash{}, errors.New("connection refused")
+	}
+	return c.LaconicdClient.Broadcast(ctx, msg)
+}
+
+func TestLaconicdOutboxRetriesUnreachableTransactions(t *testing.T) {
+	chain := NewLocalLaconicd(LocalLaconicdOpts{})
+	defer chain.Close()
+
+	client := &unreachableLaconicdClient{LaconicdClient: chain.Client(Alice.Address())}
+	client.failures.Store(1)
+	outbox := NewMemTxOutbox()
+	cs, err := NewLaconicdChainService(LaconicdChainOpts{Client: client, PollInterval: 10 * time.Millisecond, Outbox: outbox})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer closeChainService(t, cs)
+
+	outboxTx := func() OutboxTx {
+		t.Helper()
+		txs, err := outbox.GetOutboxTxs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(txs) != 1 {
+			t.Fatalf("expected 1 transaction in the outbox, got %d", len(txs))
+		}
+		return txs[0]
+	}
+
+	// A transaction which could not reach laconicd stays queued, and is broadcast again on a later poll
+	channelId := types.Destination(common.HexToHash("0x01"))
+	err = cs.SubmitTransaction("objective", protocols.NewDepositTransaction(channelId, types.Funds{common.Address{}: big.NewInt(1)}))
+	if err != nil {
+		t.Fatalf("expected a transaction which could not reach laconicd not to fail, got %v", err)
+	}
+	select {
+	case event := <-cs.EventEngineFeed():
+		if _, ok := event.(DepositedEvent); !ok {
+			t.Fatalf("expected a deposited event, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the deposit to be retried")
+	}
+	if o := outboxTx(); o.Status != TxConfirmed || o.Error != "" || len(o.TxHashes) != 1 {
+		t.Fatalf("expected the retried transaction to be confirmed, got %+v", o)
+	}
+
+	// A transaction rejected by laconicd fails
+	err = outbox.RemoveOutboxTx(outboxTx().Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.reject.Store(true)
+	err = cs.SubmitTransaction("objective", protocols.NewDepositTransaction(channelId, types.Funds{common.Address{}: big.NewInt(1)}))
+	var rejection *LaconicdTxError
+	if !errors.As(err, &rejection) {
+		t.Fatalf("expected the deposit to be rejected, got %v", err)
+	}
+	if o := outboxTx(); o.Status != TxFailed {
+		t.Fatalf("expected the rejected transaction to fail, got %+v", o)
+	}
+}
@@ -0,0 +1,187 @@
+package chainservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/channel/state/outcome"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// LaconicdClient is the interface to the nitro module of a laconicd chain. It broadcasts the module's messages from a single account,
+// and reads back the events emitted by committed blocks.
+type LaconicdClient interface {
+	// Address returns the account which signs the messages broadcast by the client
+	Address() common.Address
+	// ChainID returns the EIP-155 chain id of the chain, to which the channels adjudicated by it are bound
+	ChainID(ctx context.Context) (*big.Int, error)
+	// LatestHeight returns the height of the latest committed block
+	LatestHeight(ctx context.Context) (uint64, error)
+	// Block returns the committed block at the given height, along with the events emitted by the nitro module in that block
+	Block(ctx context.Context, height uint64) (LaconicdBlock, error)
+	// Holdings returns the amount of an asset held against a channel by the nitro module
+	Holdings(ctx context.Context, asset common.Address, channelId types.Destination) (*big.Int, error)
+	// Broadcast signs and broadcasts a message, and waits for it to be committed. It returns the hash of the transaction.
+	// A message rejected by laconicd returns a *LaconicdTxError, while any other error means the message may not have reached laconicd.
+	Broadcast(ctx context.Context, msg LaconicdMsg) (common.Hash, error)
+}
+
+// LaconicdTxError is returned when laconicd rejects a transaction, either when checking it or when executing it
+type LaconicdTxError struct {
+	Code uint32
+	Log  string
+}
+
+func (e *LaconicdTxError) Error() string {
+	return fmt.Sprintf("laconicd rejected transaction: %s (code %d)", e.Log, e.Code)
+}
+
+// LaconicdMsg is a message handled by the nitro module of laconicd
+type LaconicdMsg interface {
+	isLaconicdMsg()
+}
+
+// LaconicdDepositMsg deposits Amount of Asset against a channel, provided ExpectedHeld is already held against it
+type LaconicdDepositMsg struct {
+	ChannelId    types.Destination
+	Asset        common.Address
+	ExpectedHeld *big.Int
+	Amount       *big.Int
+}
+
+// LaconicdConcludeMsg finalizes a channel with a final state signed by every participant, and pays out its outcome
+type LaconicdConcludeMsg struct {
+	Candidate state.SignedState
+}
+
+// LaconicdChallengeMsg registers a challenge with a supported candidate state
+type LaconicdChallengeMsg struct {
+	Candidate     state.SignedState
+	Proof         []state.SignedState
+	ChallengerSig state.Signature
+}
+
+// LaconicdCheckpointMsg records a supported candidate state, clearing any challenge registered with an earlier state
+type LaconicdCheckpointMsg struct {
+	Candidate state.SignedState
+	Proof     []state.SignedState
+}
+
+// LaconicdTransferAllMsg pays out the outcome of a channel which was finalized by a challenge
+type LaconicdTransferAllMsg struct {
+	ChannelId types.Destination
+	Outcome   outcome.Exit
+	StateHash common.Hash
+}
+
+func (LaconicdDepositMsg) isLaconicdMsg()     {}
+func (LaconicdConcludeMsg) isLaconicdMsg()    {}
+func (LaconicdChallengeMsg) isLaconicdMsg()   {}
+func (LaconicdCheckpointMsg) isLaconicdMsg()  {}
+func (LaconicdTransferAllMsg) isLaconicdMsg() {}
+
+// LaconicdBlock is a committed block of a laconicd chain
+type LaconicdBlock struct {
+	Height uint64
+	// Time is the unix timestamp of the block, in seconds
+	Time   uint64
+	Events []LaconicdEvent
+}
+
+// LaconicdEvent is an event emitted by the nitro module. As with any Cosmos SDK event, its attributes are strings.
+type LaconicdEvent struct {
+	Type       string
+	Attributes map[string]string
+	TxHash     common.Hash
+	TxIndex    uint
+}
+
+// The types of event emitted by the nitro module
+const (
+	LaconicdDepositedEvent           = "deposited"
+	LaconicdAllocationUpdatedEvent   = "allocation_updated"
+	LaconicdConcludedEvent           = "concluded"
+	LaconicdChallengeRegisteredEvent = "challenge_registered"
+	LaconicdChallengeClearedEvent    = "challenge_cleared"
+)
+
+// The attributes of the events emitted by the nitro module. Channel ids and addresses are hex encoded, amounts and numbers are decimal,
+// and the candidate of a challenge is the JSON encoding of a state.SignedState.
+const (
+	LaconicdChannelIdAttribute           = "channel_id"
+	LaconicdAssetAttribute               = "asset"
+	LaconicdDestinationHoldingsAttribute = "destination_holdings"
+	LaconicdFinalHoldingsAttribute       = "final_holdings"
+	LaconicdSenderAttribute              = "sender"
+	LaconicdCandidateAttribute           = "candidate"
+	LaconicdFinalizesAtAttribute         = "finalizes_at"
+	LaconicdNewTurnNumRecordAttribute    = "new_turn_num_record"
+)
+
+// laconicdEventNames maps the nitro module's event types to the names of the corresponding NitroAdjudicator events
+var laconicdEventNames = map[string]string{
+	LaconicdDepositedEvent:           "Deposited",
+	LaconicdAllocationUpdatedEvent:   "AllocationUpdated",
+	LaconicdConcludedEvent:           "Concluded",
+	LaconicdChallengeRegisteredEvent: "ChallengeRegistered",
+	LaconicdChallengeClearedEvent:    "ChallengeCleared",
+}
+
+func (le LaconicdEvent) attribute(key string) (string, error) {
+	value, ok := le.Attributes[key]
+	if !ok {
+		return "", fmt.Errorf("%s event has no %s attribute", le.Type, key)
+	}
+	return value, nil
+}
+
+func (le LaconicdEvent) destination(key string) (types.Destination, error) {
+	value, err := le.attribute(key)
+	if err != nil {
+		return types.Destination{}, err
+	}
+	if len(common.FromHex(value)) != common.HashLength {
+		return types.Destination{}, fmt.Errorf("%s attribute %q is not a channel id", key, value)
+	}
+	return types.Destination(common.HexToHash(value)), nil
+}
+
+func (le LaconicdEvent) address(key string) (common.Address, error) {
+	value, err := le.attribute(key)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("%s attribute %q is not an address", key, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+func (le LaconicdEvent) bigInt(key string) (*big.Int, error) {
+	value, err := le.attribute(key)
+	if err != nil {
+		return nil, err
+	}
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("%s attribute %q is not a number", key, value)
+	}
+	return n, nil
+}
+
+func (le LaconicdEvent) signedState(key string) (state.SignedState, error) {
+	value, err := le.attribute(key)
+	if err != nil {
+		return state.SignedState{}, err
+	}
+	var ss state.SignedState
+	err = json.Unmarshal([]byte(value), &ss)
+	if err != nil {
+		return state.SignedState{}, fmt.Errorf("%s attribute is not a signed state: %w", key, err)
+	}
+	return ss, nil
+}
@@ -0,0 +1,379 @@
+package chainservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/statechannels/go-nitro/channel/state/outcome"
+	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
+	"github.com/statechannels/go-nitro/types"
+)
+
+type LocalLaconicdOpts struct {
+	// ChainId is the chain id reported to clients. Defaults to TEST_CHAIN_ID.
+	ChainId *big.Int
+	// BlockInterval is how often an empty block is committed, so that time moves on while no messages are broadcast.
+	// If it is zero, blocks are only committed by broadcasts and AdvanceTime.
+	BlockInterval time.Duration
+}
+
+// LocalLaconicd is an in-process stand-in for a laconicd chain, for running nodes against without a live chain.
+// Like MockChain, it keeps its state in memory. Unlike MockChain, it emits the events of laconicd's nitro module,
+// so the LaconicdChainService translating them can be tested.
+//
+// Each broadcast message is committed in a block of its own. Messages are checked much as the NitroAdjudicator checks the
+// corresponding transactions, except that candidate states must be signed by every participant: the proof of support
+// required by the VirtualPaymentApp is not checked.
+type LocalLaconicd struct {
+	chainId *big.Int
+
+	mu sync.Mutex
+	// blocks holds the committed blocks. The block at height h is blocks[h-1].
+	blocks   []LaconicdBlock
+	holdings map[types.Destination]types.Funds
+	channels map[types.Destination]localLaconicdChannel
+	// timeOffset is added to the wall clock to give the time of the next block
+	timeOffset uint64
+
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+}
+
+// localLaconicdChannel is the adjudication status of a channel
+type localLaconicdChannel struct {
+	turnNumRecord uint64
+	// finalizesAt is the time at which the channel's outcome can be paid out, or zero if no challenge is ongoing
+	finalizesAt uint64
+	stateHash   common.Hash
+	// transferred is set once the outcome has been paid out
+	transferred bool
+}
+
+// NewLocalLaconicd constructs a LocalLaconicd
+func NewLocalLaconicd(opts LocalLaconicdOpts) *LocalLaconicd {
+	if opts.ChainId == nil {
+		opts.ChainId = big.NewInt(TEST_CHAIN_ID)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ll := LocalLaconicd{
+		chainId:  opts.ChainId,
+		holdings: map[types.Destination]types.Funds{},
+		channels: map[types.Destination]localLaconicdChannel{},
+		cancel:   cancel,
+		wg:       &sync.WaitGroup{},
+	}
+
+	if opts.BlockInterval > 0 {
+		ll.wg.Add(1)
+		go func() {
+			defer ll.wg.Done()
+			ticker := time.NewTicker(opts.BlockInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					ll.AdvanceTime(0)
+				}
+			}
+		}()
+	}
+	return &ll
+}
+
+// Client returns a client which broadcasts messages from the given account
+func (ll *LocalLaconicd) Client(account common.Address) LaconicdClient {
+	return &localLaconicdClient{chain: ll, account: account}
+}
+
+// AdvanceTime moves the clock of the chain on by the given number of seconds, and commits an empty block
+func (ll *LocalLaconicd) AdvanceTime(seconds uint64) {
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+	ll.timeOffset += seconds
+	ll.commit(nil)
+}
+
+// Close stops committing empty blocks
+func (ll *LocalLaconicd) Close() error {
+	ll.cancel()
+	ll.wg.Wait()
+	return nil
+}
+
+// now returns the time of the next block. Block times never decrease.
+func (ll *LocalLaconicd) now() uint64 {
+	t := uint64(time.Now().Unix()) + ll.timeOffset
+	if len(ll.blocks) > 0 {
+		t = max(t, ll.blocks[len(ll.blocks)-1].Time)
+	}
+	return t
+}
+
+// commit appends a block holding the given events
+func (ll *LocalLaconicd) commit(events []LaconicdEvent) LaconicdBlock {
+	block := LaconicdBlock{Height: uint64(len(ll.blocks)) + 1, Time: ll.now(), Events: events}
+	ll.blocks = append(ll.blocks, block)
+	return block
+}
+
+// broadcast applies a message and commits the events it emits in a new block
+func (ll *LocalLaconicd) broadcast(sender common.Address, msg LaconicdMsg) (common.Hash, error) {
+	ll.mu.Lock()
+	defer ll.mu.Unlock()
+
+	height := uint64(len(ll.blocks)) + 1
+	encodedMsg, err := json.Marshal(msg)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	txHash := ethcrypto.Keccak256Hash(sender.Bytes(), new(big.Int).SetUint64(height).Bytes(), encodedMsg)
+	blockTime := ll.now()
+
+	var events []LaconicdEvent
+	switch msg := msg.(type) {
+	case LaconicdDepositMsg:
+		events, err = ll.deposit(msg, blockTime)
+	case LaconicdConcludeMsg:
+		events, err = ll.conclude(msg, blockTime)
+	case LaconicdChallengeMsg:
+		events, err = ll.challenge(sender, msg, blockTime)
+	case LaconicdCheckpointMsg:
+		events, err = ll.checkpoint(msg, blockTime)
+	case LaconicdTransferAllMsg:
+		events, err = ll.transferAll(msg, blockTime)
+	default:
+		err = fmt.Errorf("unexpected message type %T", msg)
+	}
+	if err != nil {
+		return common.Hash{}, &LaconicdTxError{Code: 1, Log: err.Error()}
+	}
+
+	for i := range events {
+		events[i].TxHash = txHash
+	}
+	ll.commit(events)
+	return txHash, nil
+}
+
+func (ll *LocalLaconicd) isFinalized(channelId types.Destination, blockTime uint64) bool {
+	c := ll.channels[channelId]
+	return c.finalizesAt != 0 && c.finalizesAt <= blockTime
+}
+
+func (ll *LocalLaconicd) deposit(msg LaconicdDepositMsg, blockTime uint64) ([]LaconicdEvent, error) {
+	if ll.isFinalized(msg.ChannelId, blockTime) {
+		return nil, fmt.Errorf("channel %s is finalized", msg.ChannelId)
+	}
+	held := new(big.Int)
+	if h, ok := ll.holdings[msg.ChannelId][msg.Asset]; ok {
+		held.Set(h)
+	}
+	if held.Cmp(msg.ExpectedHeld) < 0 {
+		return nil, fmt.Errorf("holdings < expectedHeld")
+	}
+	nowHeld := new(big.Int).Add(msg.ExpectedHeld, msg.Amount)
+	if held.Cmp(nowHeld) >= 0 {
+		return nil, fmt.Errorf("holdings already sufficient")
+	}
+
+	ll.holdings[msg.ChannelId] = ll.holdings[msg.ChannelId].Add(types.Funds{msg.Asset: new(big.Int).Sub(nowHeld, held)})
+	return []LaconicdEvent{{
+		Type: LaconicdDepositedEvent,
+		Attributes: map[string]string{
+			LaconicdChannelIdAttribute:           msg.ChannelId.String(),
+			LaconicdAssetAttribute:               msg.Asset.String(),
+			LaconicdDestinationHoldingsAttribute: nowHeld.String(),
+		},
+	}}, nil
+}
+
+func (ll *LocalLaconicd) conclude(msg LaconicdConcludeMsg, blockTime uint64) ([]LaconicdEvent, error) {
+	s := msg.Candidate.State()
+	channelId := s.ChannelId()
+	if !s.IsFinal {
+		return nil, fmt.Errorf("state must be final")
+	}
+	if !msg.Candidate.HasAllSignatures() {
+		return nil, fmt.Errorf("state must be signed by every participant")
+	}
+	if ll.isFinalized(channelId, blockTime) {
+		return nil, fmt.Errorf("channel %s is finalized", channelId)
+	}
+	stateHash, err := s.Hash()
+	if err != nil {
+		return nil, err
+	}
+
+	ll.channels[channelId] = localLaconicdChannel{turnNumRecord: s.TurnNum, finalizesAt: blockTime, stateHash: stateHash}
+	concluded := LaconicdEvent{
+		Type:       LaconicdConcludedEvent,
+		Attributes: map[string]string{LaconicdChannelIdAttribute: channelId.String()},
+	}
+	return append([]LaconicdEvent{concluded}, ll.payOut(channelId, s.Outcome)...), nil
+}
+
+func (ll *LocalLaconicd) challenge(sender common.Address, msg LaconicdChallengeMsg, blockTime uint64) ([]LaconicdEvent, error) {
+	s := msg.Candidate.State()
+	channelId := s.ChannelId()
+	if !msg.Candidate.HasAllSignatures() {
+		return nil, fmt.Errorf("state must be signed by every participant")
+	}
+	if ll.isFinalized(channelId, blockTime) {
+		return nil, fmt.Errorf("channel %s is finalized", channelId)
+	}
+	c := ll.channels[channelId]
+	if s.TurnNum < c.turnNumRecord {
+		return nil, fmt.Errorf("turnNum < turnNumRecord")
+	}
+	challenger, err := NitroAdjudicator.RecoverChallengeMessageSigner(s, msg.ChallengerSig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid challenger signature: %w", err)
+	}
+	if !slices.Contains(s.Participants, challenger) {
+		return nil, fmt.Errorf("challenger is not a participant")
+	}
+	stateHash, err := s.Hash()
+	if err != nil {
+		return nil, err
+	}
+	candidate, err := json.Marshal(msg.Candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	finalizesAt := blockTime + uint64(s.ChallengeDuration)
+	ll.channels[channelId] = localLaconicdChannel{turnNumRecord: s.TurnNum, finalizesAt: finalizesAt, stateHash: stateHash}
+	return []LaconicdEvent{{
+		Type: LaconicdChallengeRegisteredEvent,
+		Attributes: map[string]string{
+			LaconicdChannelIdAttribute:   channelId.String(),
+			LaconicdSenderAttribute:      sender.String(),
+			LaconicdCandidateAttribute:   string(candidate),
+			LaconicdFinalizesAtAttribute: fmt.Sprint(finalizesAt),
+		},
+	}}, nil
+}
+
+func (ll *LocalLaconicd) checkpoint(msg LaconicdCheckpointMsg, blockTime uint64) ([]LaconicdEvent, error) {
+	s := msg.Candidate.State()
+	channelId := s.ChannelId()
+	if !msg.Candidate.HasAllSignatures() {
+		return nil, fmt.Errorf("state must be signed by every participant")
+	}
+	if ll.isFinalized(channelId, blockTime) {
+		return nil, fmt.Errorf("channel %s is finalized", channelId)
+	}
+	c := ll.channels[channelId]
+	if s.TurnNum <= c.turnNumRecord {
+		return nil, fmt.Errorf("turnNum must increase")
+	}
+
+	ll.channels[channelId] = localLaconicdChannel{turnNumRecord: s.TurnNum}
+	if c.finalizesAt == 0 {
+		return nil, nil
+	}
+	return []LaconicdEvent{{
+		Type: LaconicdChallengeClearedEvent,
+		Attributes: map[string]string{
+			LaconicdChannelIdAttribute:        channelId.String(),
+			LaconicdNewTurnNumRecordAttribute: fmt.Sprint(s.TurnNum),
+		},
+	}}, nil
+}
+
+func (ll *LocalLaconicd) transferAll(msg LaconicdTransferAllMsg, blockTime uint64) ([]LaconicdEvent, error) {
+	c := ll.channels[msg.ChannelId]
+	if !ll.isFinalized(msg.ChannelId, blockTime) {
+		return nil, fmt.Errorf("channel %s is not finalized", msg.ChannelId)
+	}
+	if c.transferred {
+		return nil, fmt.Errorf("outcome of channel %s has already been paid out", msg.ChannelId)
+	}
+	if c.stateHash != msg.StateHash {
+		return nil, fmt.Errorf("incorrect fingerprint")
+	}
+	return ll.payOut(msg.ChannelId, msg.Outcome), nil
+}
+
+// payOut releases the holdings of a finalized channel to the destinations of its outcome, in order of priority
+func (ll *LocalLaconicd) payOut(channelId types.Destination, exit outcome.Exit) []LaconicdEvent {
+	c := ll.channels[channelId]
+	c.transferred = true
+	ll.channels[channelId] = c
+
+	events := []LaconicdEvent{}
+	holdings := ll.holdings[channelId].Clone()
+	for _, assetExit := range exit {
+		remaining := new(big.Int)
+		if held, ok := holdings[assetExit.Asset]; ok {
+			remaining.Set(held)
+		}
+		for _, allocation := range assetExit.Allocations {
+			remaining.Sub(remaining, types.Min(remaining, allocation.Amount))
+		}
+		holdings[assetExit.Asset] = remaining
+
+		events = append(events, LaconicdEvent{
+			Type: LaconicdAllocationUpdatedEvent,
+			Attributes: map[string]string{
+				LaconicdChannelIdAttribute:     channelId.String(),
+				LaconicdAssetAttribute:         assetExit.Asset.String(),
+				LaconicdFinalHoldingsAttribute: remaining.String(),
+			},
+		})
+	}
+	ll.holdings[channelId] = holdings
+	return events
+}
+
+// localLaconicdClient is a LaconicdClient for a LocalLaconicd
+type localLaconicdClient struct {
+	chain   *LocalLaconicd
+	account common.Address
+}
+
+func (c *localLaconicdClient) Address() common.Address {
+	return c.account
+}
+
+func (c *localLaconicdClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.chain.chainId), nil
+}
+
+func (c *localLaconicdClient) LatestHeight(ctx context.Context) (uint64, error) {
+	c.chain.mu.Lock()
+	defer c.chain.mu.Unlock()
+	return uint64(len(c.chain.blocks)), nil
+}
+
+func (c *localLaconicdClient) Block(ctx context.Context, height uint64) (LaconicdBlock, error) {
+	c.chain.mu.Lock()
+	defer c.chain.mu.Unlock()
+	if height == 0 || height > uint64(len(c.chain.blocks)) {
+		return LaconicdBlock{}, fmt.Errorf("no block at height %d", height)
+	}
+	return c.chain.blocks[height-1], nil
+}
+
+func (c *localLaconicdClient) Holdings(ctx context.Context, asset common.Address, channelId types.Destination) (*big.Int, error) {
+	c.chain.mu.Lock()
+	defer c.chain.mu.Unlock()
+	held := new(big.Int)
+	if h, ok := c.chain.holdings[channelId][asset]; ok {
+		held.Set(h)
+	}
+	return held, nil
+}
+
+func (c *localLaconicdClient) Broadcast(ctx context.Context, msg LaconicdMsg) (common.Hash, error) {
+	return c.chain.broadcast(c.account, msg)
+}
@@ -28,6 +28,12 @@ func (mc *MockChainService) SendTransaction(tx protocols.ChainTransaction) (*eth
 	return nil, err
 }
 
+// SubmitTransaction responds to the given tx. The mock chain never drops transactions, so no outbox is kept.
+func (mc *MockChainService) SubmitTransaction(objectiveId protocols.ObjectiveId, tx protocols.ChainTransaction) error {
+	_, err := mc.SendTransaction(tx)
+	return err
+}
+
 // GetConsensusAppAddress returns the zero address, since the mock chain will not run any application logic.
 func (mc *MockChainService) GetConsensusAppAddress() types.Address {
 	return types.Address{}
@@ -46,14 +52,6 @@ func (mc *MockChainService) EventEngineFeed() <-chan Event {
 	return mc.eventFeed
 }
 
-func (mc *MockChainService) DroppedEventEngineFeed() <-chan protocols.DroppedEventInfo {
-	return make(<-chan protocols.DroppedEventInfo)
-}
-
-func (mc *MockChainService) DroppedEventFeed() <-chan protocols.DroppedEventInfo {
-	return make(<-chan protocols.DroppedEventInfo)
-}
-
 func (mc *MockChainService) EventFeed() <-chan Event {
 	return nil
 }
@@ -0,0 +1,57 @@
+package chainservice
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/txpool"
+)
+
+// nonceManager hands out the nonces of the signing account, so that several transactions can be submitted without
+// waiting for each other. Nonces are reserved locally, and resynced with the chain whenever a submission fails.
+type nonceManager struct {
+	mu      sync.Mutex
+	chain   ethChain
+	account common.Address
+	// next is the nonce handed out by the next reservation. It is only meaningful when synced is true.
+	next   uint64
+	synced bool
+}
+
+func newNonceManager(chain ethChain, account common.Address) *nonceManager {
+	return &nonceManager{chain: chain, account: account}
+}
+
+// reserve returns the next nonce of the account. Unless the nonces need resyncing, no call is made to the chain.
+func (nm *nonceManager) reserve(ctx context.Context) (uint64, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	if !nm.synced {
+		next, err := nm.chain.PendingNonceAt(ctx, nm.account)
+		if err != nil {
+			return 0, err
+		}
+		nm.next = next
+		nm.synced = true
+	}
+	nonce := nm.next
+	nm.next++
+	return nonce, nil
+}
+
+// resync makes the next reservation take the pending nonce of the account from the chain. It is called when a submission fails,
+// since the reserved nonce may or may not have been used. Should it be unused, the chain's pending nonce fills the gap it leaves.
+func (nm *nonceManager) resync() {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	nm.synced = false
+}
+
+// isAlreadyKnown reports whether a transaction was refused because the same transaction is already in the mempool, in which case
+// it was submitted after all. Errors returned over RPC do not wrap the errors of the node, so the message is compared as well.
+func isAlreadyKnown(err error) bool {
+	return errors.Is(err, txpool.ErrAlreadyKnown) || err.Error() == txpool.ErrAlreadyKnown.Error()
+}
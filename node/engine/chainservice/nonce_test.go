@@ -0,0 +1,217 @@
+package chainservice
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestNonceManager(t *testing.T) {
+	sim, _, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	account := ethAccounts[0].From
+
+	pendingNonce, err := sim.PendingNonceAt(ctx, account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nm := newNonceManager(sim, account)
+	reserve := func() uint64 {
+		t.Helper()
+		nonce, err := nm.reserve(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return nonce
+	}
+
+	// Nonces are reserved locally, even though no transaction has been sent with them
+	for i := uint64(0); i < 3; i++ {
+		if nonce := reserve(); nonce != pendingNonce+i {
+			t.Fatalf("expected nonce %d, got %d", pendingNonce+i, nonce)
+		}
+	}
+
+	// Resyncing hands out the unused nonces again
+	nm.resync()
+	if nonce := reserve(); nonce != pendingNonce {
+		t.Fatalf("expected the resynced nonce to be %d, got %d", pendingNonce, nonce)
+	}
+}
+
+func TestConcurrentSendTransaction(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txSigner := ethAccounts[0]
+
+	cs, err := newEthChainService(sim, 0, bindings.Adjudicator.Contract, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, txSigner, FeeOpts{}, nil)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Blocks are mined continually, since token deposits wait for their approval to be mined
+	mined := make(chan struct{})
+	var miner sync.WaitGroup
+	miner.Add(1)
+	go func() {
+		defer miner.Done()
+		for {
+			select {
+			case <-mined:
+				return
+			case <-time.After(10 * time.Millisecond):
+				sim.Commit()
+			}
+		}
+	}()
+
+	// Use a nonce behind the back of the chain service, which must then resync its nonces
+	ctx := context.Background()
+	_, err = bindings.Token.Contract.Transfer(&bind.TransactOpts{From: txSigner.From, Signer: txSigner.Signer}, common.HexToAddress("0x1234"), big.NewInt(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = cs.nonces.reserve(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs.nonces.resync()
+
+	deposit := types.Funds{common.Address{}: big.NewInt(3), bindings.Token.Address: big.NewInt(2)}
+	channelIds := []types.Destination{{1}, {2}, {3}, {4}}
+	depositTxs := make([]*ethTypes.Transaction, len(channelIds))
+	errs := make([]error, len(channelIds))
+	var senders sync.WaitGroup
+	for i, channelId := range channelIds {
+		senders.Add(1)
+		go func() {
+			defer senders.Done()
+			depositTxs[i], errs[i] = cs.SendTransaction(protocols.NewDepositTransaction(channelId, deposit))
+		}()
+	}
+	senders.Wait()
+
+	for i := range channelIds {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if depositTxs[i] == nil {
+			t.Fatal("expected the last deposit transaction to be returned")
+		}
+	}
+
+	// Every deposit is mined, so no two transactions were given the same nonce
+	waitFor := func(hash common.Hash) *ethTypes.Receipt {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			receipt, err := sim.TransactionReceipt(ctx, hash)
+			if err == nil {
+				return receipt
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("transaction %s was not mined", hash)
+		return nil
+	}
+	for _, depositTx := range depositTxs {
+		if receipt := waitFor(depositTx.Hash()); receipt.Status != ethTypes.ReceiptStatusSuccessful {
+			t.Fatalf("deposit transaction %s failed", depositTx.Hash())
+		}
+	}
+	close(mined)
+	miner.Wait()
+
+	for _, channelId := range channelIds {
+		for asset, amount := range deposit {
+			holdings, err := bindings.Adjudicator.Contract.Holdings(&bind.CallOpts{}, asset, channelId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if holdings.Cmp(amount) != 0 {
+				t.Fatalf("expected %s of %s to be held by channel %s, got %s", amount, asset, channelId, holdings)
+			}
+		}
+	}
+}
+
+// alreadyKnownChain passes transactions on to the chain, but refuses them as a node does when they are already in its mempool
+type alreadyKnownChain struct {
+	SimulatedChain
+	sent []*ethTypes.Transaction
+}
+
+func (c *alreadyKnownChain) SendTransaction(ctx context.Context, tx *ethTypes.Transaction) error {
+	c.sent = append(c.sent, tx)
+	err := c.SimulatedChain.SendTransaction(ctx, tx)
+	if err != nil {
+		return err
+	}
+	// Errors returned over RPC carry only the message of the node's error
+	return errors.New("already known")
+}
+
+func TestAlreadyKnownTransactionIsNotResent(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &alreadyKnownChain{SimulatedChain: sim}
+	na, err := NitroAdjudicator.NewNitroAdjudicator(bindings.Adjudicator.Address, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs, err := newEthChainService(chain, 0, na, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, ethAccounts[0], FeeOpts{}, nil)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channelId := types.Destination{1}
+	depositTx, err := cs.SendTransaction(protocols.NewDepositTransaction(channelId, types.Funds{common.Address{}: big.NewInt(1)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chain.sent) != 1 {
+		t.Fatalf("expected one transaction to be sent, got %d", len(chain.sent))
+	}
+	if depositTx == nil || depositTx.Hash() != chain.sent[0].Hash() {
+		t.Fatalf("expected the sent transaction to be returned, got %v", depositTx)
+	}
+
+	sim.Commit()
+	receipt, err := sim.TransactionReceipt(context.Background(), depositTx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != ethTypes.ReceiptStatusSuccessful {
+		t.Fatal("expected the deposit transaction to succeed")
+	}
+	holdings, err := bindings.Adjudicator.Contract.Holdings(&bind.CallOpts{}, common.Address{}, channelId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holdings.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("expected the deposit to be made once, got holdings of %s", holdings)
+	}
+}
@@ -0,0 +1,437 @@
+package chainservice
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/statechannels/go-nitro/internal/safesync"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// OUTBOX_RETENTION_BLOCKS is how many blocks deep a confirmed or failed transaction is before it is removed from the outbox.
+// Until then, a reorg which drops the transaction from the chain gets it resubmitted.
+const OUTBOX_RETENTION_BLOCKS = 64
+
+// MAX_OUTBOX_TX_ATTEMPTS is how many submissions of a transaction may fail in a row, without the transaction being rejected, before it is marked as failed
+const MAX_OUTBOX_TX_ATTEMPTS = 10
+
+// TxStatus is the confirmation state of a transaction in the outbox
+type TxStatus string
+
+const (
+	TxQueued    TxStatus = "queued"    // recorded, but not yet accepted by the chain, possibly because it could not be reached
+	TxSubmitted TxStatus = "submitted" // accepted by the chain, but not yet mined
+	TxConfirmed TxStatus = "confirmed" // mined in the block recorded alongside it
+	TxFailed    TxStatus = "failed"    // rejected by the chain, reverted when mined, or not submitted after MAX_OUTBOX_TX_ATTEMPTS attempts
+)
+
+// OutboxTx is a transaction submitted on behalf of an objective, which is held in the outbox until it is confirmed
+type OutboxTx struct {
+	// Id orders the transactions in the outbox by the time they were submitted
+	Id          string
+	ObjectiveId protocols.ObjectiveId
+	Tx          protocols.ChainTransaction
+	// TxHashes holds the hash of every submission of the transaction, latest last.
+	// A deposit of several assets has the hashes of the deposits of each asset.
+	TxHashes []common.Hash
+	Status   TxStatus
+	// BlockNum and BlockHash identify the block in which the transaction was confirmed. When it failed, BlockNum is the latest block at the time.
+	BlockNum  uint64
+	BlockHash common.Hash
+	// Error explains why the transaction failed, or why a queued transaction could not be submitted
+	Error string `json:",omitempty"`
+	// Attempts counts the submissions in a row which failed without the transaction being rejected
+	Attempts  uint `json:",omitempty"`
+	UpdatedAt time.Time
+}
+
+// outboxTxJSON is the JSON encoding of an OutboxTx, whose transaction is encoded by protocols.MarshalChainTransaction
+type outboxTxJSON struct {
+	Id          string
+	ObjectiveId protocols.ObjectiveId
+	Tx          json.RawMessage
+	TxHashes    []common.Hash
+	Status      TxStatus
+	BlockNum    uint64
+	BlockHash   common.Hash
+	Error       string `json:",omitempty"`
+	Attempts    uint   `json:",omitempty"`
+	UpdatedAt   time.Time
+}
+
+func (o OutboxTx) MarshalJSON() ([]byte, error) {
+	tx, err := protocols.MarshalChainTransaction(o.Tx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(outboxTxJSON{o.Id, o.ObjectiveId, tx, o.TxHashes, o.Status, o.BlockNum, o.BlockHash, o.Error, o.Attempts, o.UpdatedAt})
+}
+
+func (o *OutboxTx) UnmarshalJSON(b []byte) error {
+	var j outboxTxJSON
+	err := json.Unmarshal(b, &j)
+	if err != nil {
+		return err
+	}
+	tx, err := protocols.UnmarshalChainTransaction(j.Tx)
+	if err != nil {
+		return err
+	}
+	*o = OutboxTx{j.Id, j.ObjectiveId, tx, j.TxHashes, j.Status, j.BlockNum, j.BlockHash, j.Error, j.Attempts, j.UpdatedAt}
+	return nil
+}
+
+// hasHash returns true if the hash is that of any submission of the transaction
+func (o OutboxTx) hasHash(hash common.Hash) bool {
+	return slices.Contains(o.TxHashes, hash)
+}
+
+// TxOutbox persists the transactions submitted by a chain service until they are confirmed, so that the chain service can resubmit them
+// after a restart or a reorg.
+type TxOutbox interface {
+	SetOutboxTx(OutboxTx) error
+	GetOutboxTxs() ([]OutboxTx, error) // Returns the transactions in the outbox, sorted by Id
+	RemoveOutboxTx(id string) error
+}
+
+// SortOutboxTxs sorts transactions by Id
+func SortOutboxTxs(txs []OutboxTx) {
+	slices.SortFunc(txs, func(a, b OutboxTx) int { return strings.Compare(a.Id, b.Id) })
+}
+
+// memTxOutbox is a TxOutbox which holds transactions in memory
+type memTxOutbox struct {
+	txs safesync.Map[OutboxTx]
+}
+
+// NewMemTxOutbox returns a TxOutbox which holds transactions in memory, for use when no store is supplied
+func NewMemTxOutbox() TxOutbox {
+	return &memTxOutbox{}
+}
+
+func (mo *memTxOutbox) SetOutboxTx(o OutboxTx) error {
+	mo.txs.Store(o.Id, o)
+	return nil
+}
+
+func (mo *memTxOutbox) GetOutboxTxs() ([]OutboxTx, error) {
+	txs := []OutboxTx{}
+	mo.txs.Range(func(_ string, o OutboxTx) bool {
+		txs = append(txs, o)
+		return true
+	})
+	SortOutboxTxs(txs)
+	return txs, nil
+}
+
+func (mo *memTxOutbox) RemoveOutboxTx(id string) error {
+	mo.txs.Delete(id)
+	return nil
+}
+
+// outboxIds hands out outbox ids. Ids increase monotonically, so that the outbox returns transactions in the order they were submitted.
+type outboxIds struct {
+	mu     sync.Mutex
+	lastId uint64
+}
+
+func (oi *outboxIds) next() string {
+	oi.mu.Lock()
+	defer oi.mu.Unlock()
+
+	id := uint64(time.Now().UnixNano())
+	if id <= oi.lastId {
+		id = oi.lastId + 1
+	}
+	oi.lastId = id
+	return fmt.Sprintf("%020d", id)
+}
+
+// splitDeposit splits a deposit of several assets into one deposit per asset, each of which is held in the outbox separately.
+// A deposit which was mined is then never resubmitted along with a deposit of another asset which was not.
+func splitDeposit(tx protocols.ChainTransaction) []protocols.ChainTransaction {
+	deposit, ok := tx.(protocols.DepositTransaction)
+	if !ok || len(deposit.Deposit) <= 1 {
+		return []protocols.ChainTransaction{tx}
+	}
+
+	assets := sortedAssets(deposit.Deposit)
+	txs := make([]protocols.ChainTransaction, 0, len(assets))
+	for _, asset := range assets {
+		txs = append(txs, protocols.NewDepositTransaction(deposit.ChannelId(), types.Funds{asset: deposit.Deposit[asset]}))
+	}
+	return txs
+}
+
+// sortedAssets returns the assets of funds in a fixed order, so that deposits of several assets are always made in the same order
+func sortedAssets(funds types.Funds) []common.Address {
+	assets := make([]common.Address, 0, len(funds))
+	for asset := range funds {
+		assets = append(assets, asset)
+	}
+	slices.SortFunc(assets, func(a, b common.Address) int { return a.Cmp(b) })
+	return assets
+}
+
+// submitToOutbox records a transaction in the outbox and then submits it with submit, which records the outcome.
+// A deposit of several assets is recorded as one transaction per asset.
+func submitToOutbox(outbox TxOutbox, ids *outboxIds, objectiveId protocols.ObjectiveId, tx protocols.ChainTransaction, submit func(OutboxTx) error) error {
+	// Every part of the transaction is recorded before any is submitted, so that none is lost should the node stop in between
+	queued := []OutboxTx{}
+	for _, tx := range splitDeposit(tx) {
+		o := OutboxTx{Id: ids.next(), ObjectiveId: objectiveId, Tx: tx, Status: TxQueued, UpdatedAt: time.Now()}
+		err := outbox.SetOutboxTx(o)
+		if err != nil {
+			return err
+		}
+		queued = append(queued, o)
+	}
+	// A part which fails does not stop the others from being submitted
+	var errs []error
+	for _, o := range queued {
+		errs = append(errs, submit(o))
+	}
+	return errors.Join(errs...)
+}
+
+// SubmitTransaction records the transaction in the outbox before submitting it, so that it is resubmitted should it be lost
+// to a restart or a reorg before it is confirmed. A deposit of several assets is recorded as one transaction per asset.
+func (ecs *EthChainService) SubmitTransaction(objectiveId protocols.ObjectiveId, tx protocols.ChainTransaction) error {
+	return submitToOutbox(ecs.outbox, ecs.outboxIds, objectiveId, tx, ecs.submitOutboxTx)
+}
+
+// submitOutboxTx submits a transaction held in the outbox and records the outcome. A transaction rejected by the chain is marked as failed,
+// while one which could not reach the chain, or whose nonce or gas price was refused, stays queued to be submitted again.
+// After MAX_OUTBOX_TX_ATTEMPTS such submissions in a row, the transaction is marked as failed with the last error.
+// The hash of every transaction submitted for it is recorded, so that each is tracked until it is mined.
+func (ecs *EthChainService) submitOutboxTx(o OutboxTx) error {
+	ethTxs, err := ecs.sendTransactions(o.Tx)
+	for _, ethTx := range ethTxs {
+		o.TxHashes = append(o.TxHashes, ethTx.Hash())
+	}
+	if err != nil && !isRejection(err) {
+		o.Attempts++
+	}
+	switch {
+	case err != nil && isRejection(err):
+		o.Status = TxFailed
+		o.Error = err.Error()
+		o.BlockNum = ecs.latestBlockNum()
+	case err != nil && o.Attempts >= MAX_OUTBOX_TX_ATTEMPTS:
+		o.Status = TxFailed
+		o.Error = fmt.Sprintf("gave up after %d attempts: %s", o.Attempts, err)
+		o.BlockNum = ecs.latestBlockNum()
+		err = fmt.Errorf("gave up after %d attempts: %w", o.Attempts, err)
+	case err != nil:
+		if isNonceRefusal(err) {
+			// Another transaction took the nonce, or is pending under it, so the nonces are resynced before the transaction is retried
+			ecs.nonces.resync()
+		}
+		// The error is recorded, so that the transaction is submitted again when the outbox is next reconciled.
+		// It is not returned, since the transaction has not failed.
+		ecs.logger.Warn("could not submit transaction, it will be retried", "id", o.Id, "objective", o.ObjectiveId, "attempts", o.Attempts, "error", err)
+		o.Status = TxQueued
+		o.Error = err.Error()
+		return ecs.setOutboxTx(o)
+	case len(ethTxs) == 0:
+		// The approval of a token deposit was dropped by a reorg. The deposit is retried like one which could not reach the chain.
+		o.Status = TxQueued
+		o.Error = "token approval dropped by reorg"
+		return ecs.setOutboxTx(o)
+	default:
+		o.Status = TxSubmitted
+		o.Error = ""
+		o.Attempts = 0
+	}
+
+	setErr := ecs.setOutboxTx(o)
+	if err != nil {
+		return err
+	}
+	return setErr
+}
+
+// isRejection returns true if a submission failed because the transaction would revert, rather than because the chain could not be reached
+// or refused its nonce or gas price. Nodes return reverts as JSON-RPC errors with code 3, while the simulated backend may return the error
+// of the EVM, so the message is compared as well.
+func isRejection(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == 3 {
+		return true
+	}
+	return errors.Is(err, vm.ErrExecutionReverted) || strings.Contains(err.Error(), vm.ErrExecutionReverted.Error())
+}
+
+// isNonceRefusal returns true if a submission was refused because its nonce was already used, or because it did not pay enough to replace
+// the transaction pending under its nonce. Errors returned over RPC do not wrap the errors of the node, so the message is compared as well.
+func isNonceRefusal(err error) bool {
+	for _, refusal := range []error{core.ErrNonceTooLow, txpool.ErrReplaceUnderpriced, txpool.ErrUnderpriced} {
+		if errors.Is(err, refusal) || strings.Contains(err.Error(), refusal.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// resubmitOutboxTx submits a transaction held in the outbox again. The nonces are resynced first, since a transaction dropped by the chain
+// leaves its nonce unused.
+func (ecs *EthChainService) resubmitOutboxTx(o OutboxTx) error {
+	ecs.nonces.resync()
+	return ecs.submitOutboxTx(o)
+}
+
+func (ecs *EthChainService) setOutboxTx(o OutboxTx) error {
+	o.UpdatedAt = time.Now()
+	return ecs.outbox.SetOutboxTx(o)
+}
+
+// outboxTxByHash returns the transaction in the outbox with a submission of the given hash
+func (ecs *EthChainService) outboxTxByHash(hash common.Hash) (OutboxTx, bool) {
+	txs, err := ecs.outbox.GetOutboxTxs()
+	if err != nil {
+		ecs.logger.Error("failed to read the transaction outbox", "error", err)
+		return OutboxTx{}, false
+	}
+	for _, o := range txs {
+		if o.hasHash(hash) {
+			return o, true
+		}
+	}
+	return OutboxTx{}, false
+}
+
+// recordOutboxReplacement adds the hash of a replacement to the transaction in the outbox which it replaces
+func (ecs *EthChainService) recordOutboxReplacement(replacedTxHash, txHash common.Hash) error {
+	o, ok := ecs.outboxTxByHash(replacedTxHash)
+	if !ok {
+		return nil
+	}
+	o.TxHashes = append(o.TxHashes, txHash)
+	return ecs.setOutboxTx(o)
+}
+
+// reconcileOutbox brings the transactions in the outbox up to date with the chain:
+//
+//   - a submitted transaction which has been mined is confirmed, or failed if it reverted
+//   - a confirmed transaction whose block is no longer in the chain, following a reorg, is checked again as a submitted transaction
+//   - a submitted transaction which is neither mined nor pending is resubmitted
+//   - queued transactions which could not reach the chain are resubmitted, unless an earlier submission has been mined
+//   - on startup, queued transactions, whose submission was interrupted, are resubmitted, and pending transactions are tracked so that they are replaced if they get stuck
+//   - confirmed and failed transactions are removed once they are OUTBOX_RETENTION_BLOCKS deep
+func (ecs *EthChainService) reconcileOutbox(latestBlockNum uint64) {
+	txs, err := ecs.outbox.GetOutboxTxs()
+	if err != nil {
+		ecs.logger.Error("failed to read the transaction outbox", "error", err)
+		return
+	}
+	ecs.reconcileOutboxTxs(txs, latestBlockNum, false)
+}
+
+// reconcileOutboxTxs reconciles the given transactions from the outbox. On startup, they are those left in the outbox by an earlier run.
+func (ecs *EthChainService) reconcileOutboxTxs(txs []OutboxTx, latestBlockNum uint64, startup bool) {
+	for _, o := range txs {
+		err := ecs.reconcileOutboxTx(o, latestBlockNum, startup)
+		if err != nil {
+			ecs.logger.Error("failed to reconcile outbox transaction", "id", o.Id, "objective", o.ObjectiveId, "error", err)
+		}
+	}
+}
+
+func (ecs *EthChainService) reconcileOutboxTx(o OutboxTx, latestBlockNum uint64, startup bool) error {
+	reorged := false
+	switch o.Status {
+	case TxQueued:
+		if !startup && o.Error == "" {
+			// The transaction is still being submitted
+			return nil
+		}
+		if len(o.TxHashes) == 0 {
+			if startup {
+				ecs.logger.Info("submitting transaction queued before a restart", "id", o.Id, "objective", o.ObjectiveId)
+			} else {
+				ecs.logger.Info("retrying transaction which could not be submitted", "id", o.Id, "objective", o.ObjectiveId, "error", o.Error)
+			}
+			return ecs.resubmitOutboxTx(o)
+		}
+		// An earlier submission may have been mined, so it is looked for before the transaction is submitted again
+	case TxFailed:
+		return ecs.pruneOutboxTx(o, latestBlockNum)
+	case TxConfirmed:
+		header, err := ecs.chain.HeaderByNumber(ecs.ctx, new(big.Int).SetUint64(o.BlockNum))
+		if err != nil {
+			return err
+		}
+		if header.Hash() == o.BlockHash {
+			return ecs.pruneOutboxTx(o, latestBlockNum)
+		}
+		ecs.logger.Warn("confirmed transaction was dropped by a reorg", "id", o.Id, "objective", o.ObjectiveId, "blockNum", o.BlockNum)
+		o.Status = TxSubmitted
+		o.BlockNum, o.BlockHash = 0, common.Hash{}
+		reorged = true
+	}
+
+	// The transaction may have been mined under the hash of any of its submissions
+	for _, hash := range o.TxHashes {
+		receipt, err := ecs.chain.TransactionReceipt(ecs.ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		o.Status, o.Error = TxConfirmed, ""
+		o.BlockNum, o.BlockHash = receipt.BlockNumber.Uint64(), receipt.BlockHash
+		if receipt.Status != ethTypes.ReceiptStatusSuccessful {
+			o.Status, o.Error = TxFailed, "transaction reverted"
+		}
+		return ecs.setOutboxTx(o)
+	}
+
+	for _, hash := range o.TxHashes {
+		tx, isPending, err := ecs.chain.TransactionByHash(ecs.ctx, hash)
+		if errors.Is(err, ethereum.NotFound) || (err == nil && !isPending) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if startup && hash == o.TxHashes[len(o.TxHashes)-1] {
+			ecs.trackPendingTx(tx, protocols.ChainTransactionType(o.Tx), o.Tx.ChannelId())
+		}
+		if reorged {
+			// The reorg returned the transaction to the mempool
+			return ecs.setOutboxTx(o)
+		}
+		if o.Status == TxQueued {
+			// An earlier submission is still pending
+			o.Status, o.Error = TxSubmitted, ""
+			return ecs.setOutboxTx(o)
+		}
+		return nil
+	}
+
+	ecs.logger.Warn("resubmitting transaction which is no longer known to the chain", "id", o.Id, "objective", o.ObjectiveId, "txHashes", o.TxHashes)
+	return ecs.resubmitOutboxTx(o)
+}
+
+// pruneOutboxTx removes a confirmed or failed transaction from the outbox once it is OUTBOX_RETENTION_BLOCKS deep
+func (ecs *EthChainService) pruneOutboxTx(o OutboxTx, latestBlockNum uint64) error {
+	if latestBlockNum < o.BlockNum+OUTBOX_RETENTION_BLOCKS {
+		return nil
+	}
+	return ecs.outbox.RemoveOutboxTx(o.Id)
+}
@@ -0,0 +1,297 @@
+package chainservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// mineUntil mines blocks one at a time, giving the monitor the chance to reconcile the outbox with each of them, until done returns true
+func mineUntil(t *testing.T, sim SimulatedChain, outbox TxOutbox, done func([]OutboxTx) bool) []OutboxTx {
+	t.Helper()
+	for i := 0; i < 50; i++ {
+		sim.Commit()
+		time.Sleep(50 * time.Millisecond)
+		txs, err := outbox.GetOutboxTxs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if done(txs) {
+			return txs
+		}
+	}
+	t.Fatal("the outbox did not reach the expected state")
+	return nil
+}
+
+func allConfirmed(txs []OutboxTx) bool {
+	for _, o := range txs {
+		if o.Status != TxConfirmed {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSubmitTransactionIsConfirmedAndPruned(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outbox := NewMemTxOutbox()
+	cs, err := newEthChainService(sim, 0, bindings.Adjudicator.Contract, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, ethAccounts[0], FeeOpts{}, outbox)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	channelId := types.Destination{1}
+	err = cs.SubmitTransaction("DirectFunding-0x01", protocols.NewDepositTransaction(channelId, types.Funds{common.Address{}: big.NewInt(1)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs, err := outbox.GetOutboxTxs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].Status != TxSubmitted || len(txs[0].TxHashes) != 1 || txs[0].ObjectiveId != "DirectFunding-0x01" {
+		t.Fatalf("expected the deposit to be recorded as submitted, got %+v", txs)
+	}
+
+	txs = mineUntil(t, sim, outbox, allConfirmed)
+	if txs[0].BlockHash == (common.Hash{}) {
+		t.Fatal("expected the block of the confirmed transaction to be recorded")
+	}
+
+	// The confirmed transaction is removed once it is deep enough
+	for i := 0; i < OUTBOX_RETENTION_BLOCKS; i++ {
+		sim.Commit()
+	}
+	mineUntil(t, sim, outbox, func(txs []OutboxTx) bool { return len(txs) == 0 })
+}
+
+func TestSubmitOutboxTxRecordsEveryDeposit(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outbox := NewMemTxOutbox()
+	cs, err := newEthChainService(sim, 0, bindings.Adjudicator.Contract, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, ethAccounts[0], FeeOpts{}, outbox)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A deposit of several assets which was recorded in the outbox unsplit is submitted as one deposit per asset
+	deposit := protocols.NewDepositTransaction(types.Destination{1}, types.Funds{common.Address{}: big.NewInt(1), bindings.Token.Address: big.NewInt(2)})
+	submitted := make(chan error)
+	go func() {
+		submitted <- cs.submitOutboxTx(OutboxTx{Id: "1", ObjectiveId: "DirectFunding-0x01", Tx: deposit, Status: TxQueued})
+	}()
+	// The token deposit waits for its approval to be mined
+	for done := false; !done; {
+		select {
+		case err := <-submitted:
+			if err != nil {
+				t.Fatal(err)
+			}
+			done = true
+		case <-time.After(50 * time.Millisecond):
+			sim.Commit()
+		}
+	}
+
+	txs, err := outbox.GetOutboxTxs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].Status != TxSubmitted || len(txs[0].TxHashes) != len(deposit.Deposit) {
+		t.Fatalf("expected the hash of the deposit of each asset to be recorded, got %+v", txs)
+	}
+	mineUntil(t, sim, outbox, allConfirmed)
+}
+
+func TestOutboxIsReconciledOnStartup(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The outbox is left by an earlier run with a transaction which was never submitted, one which was dropped from the mempool,
+	// and one whose block was dropped by a reorg
+	queued := protocols.NewDepositTransaction(types.Destination{1}, types.Funds{common.Address{}: big.NewInt(1)})
+	dropped := protocols.NewDepositTransaction(types.Destination{2}, types.Funds{common.Address{}: big.NewInt(2)})
+	reorged := protocols.NewDepositTransaction(types.Destination{3}, types.Funds{common.Address{}: big.NewInt(3)})
+	outbox := NewMemTxOutbox()
+	for _, o := range []OutboxTx{
+		{Id: "1", ObjectiveId: "DirectFunding-0x01", Tx: queued, Status: TxQueued},
+		{Id: "2", ObjectiveId: "DirectFunding-0x02", Tx: dropped, TxHashes: []common.Hash{{2}}, Status: TxSubmitted},
+		{Id: "3", ObjectiveId: "DirectFunding-0x03", Tx: reorged, TxHashes: []common.Hash{{3}}, Status: TxConfirmed, BlockNum: 1, BlockHash: common.Hash{3}},
+	} {
+		if err := outbox.SetOutboxTx(o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cs, err := newEthChainService(sim, 0, bindings.Adjudicator.Contract, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, ethAccounts[0], FeeOpts{}, outbox)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every deposit is submitted again and mined
+	mineUntil(t, sim, outbox, allConfirmed)
+	for _, tx := range []protocols.DepositTransaction{queued, dropped, reorged} {
+		holdings, err := bindings.Adjudicator.Contract.Holdings(&bind.CallOpts{}, common.Address{}, tx.ChannelId())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := tx.Deposit[common.Address{}]; holdings.Cmp(want) != 0 {
+			t.Fatalf("expected %s to be held by channel %s, got %s", want, tx.ChannelId(), holdings)
+		}
+	}
+}
+
+type testRPCError struct {
+	msg  string
+	code int
+}
+
+func (e testRPCError) Error() string  { return e.msg }
+func (e testRPCError) ErrorCode() int { return e.code }
+
+func TestIsRejection(t *testing.T) {
+	testCases := []struct {
+		err  error
+		want bool
+	}{
+		{testRPCError{"execution reverted", 3}, true},
+		{fmt.Errorf("could not deposit: %w", testRPCError{"execution reverted", 3}), true},
+		{vm.ErrExecutionReverted, true},
+		{errors.New("execution reverted: Deposit | holdings[asset] is not equal to expectedHeld"), true},
+		{testRPCError{"nonce too low: next nonce 4, tx nonce 3", -32000}, false},
+		{testRPCError{"replacement transaction underpriced", -32000}, false},
+		{errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), false},
+		{context.DeadlineExceeded, false},
+	}
+	for _, tc := range testCases {
+		if got := isRejection(tc.err); got != tc.want {
+			t.Errorf("isRejection(%v): expected %v, got %v", tc.err, tc.want, got)
+		}
+	}
+}
+
+func TestIsNonceRefusal(t *testing.T) {
+	testCases := []struct {
+		err  error
+		want bool
+	}{
+		{core.ErrNonceTooLow, true},
+		{testRPCError{"nonce too low: next nonce 4, tx nonce 3", -32000}, true},
+		{testRPCError{"replacement transaction underpriced", -32000}, true},
+		{fmt.Errorf("could not deposit: %w", txpool.ErrUnderpriced), true},
+		{testRPCError{"execution reverted", 3}, false},
+		{errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), false},
+	}
+	for _, tc := range testCases {
+		if got := isNonceRefusal(tc.err); got != tc.want {
+			t.Errorf("isNonceRefusal(%v): expected %v, got %v", tc.err, tc.want, got)
+		}
+	}
+}
+
+// unreachableChain fails to send transactions, as though the chain could not be reached
+type unreachableChain struct {
+	SimulatedChain
+}
+
+func (unreachableChain) SendTransaction(ctx context.Context, tx *ethTypes.Transaction) error {
+	return errors.New("connection refused")
+}
+
+func TestTransactionWhichCannotBeSubmittedFailsAfterMaxAttempts(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain := unreachableChain{sim}
+	na, err := NitroAdjudicator.NewNitroAdjudicator(bindings.Adjudicator.Address, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outbox := NewMemTxOutbox()
+	cs, err := newEthChainService(chain, 0, na, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, ethAccounts[0], FeeOpts{}, outbox)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The transaction has not failed yet, so no error is returned
+	err = cs.SubmitTransaction("DirectFunding-0x01", protocols.NewDepositTransaction(types.Destination{1}, types.Funds{common.Address{}: big.NewInt(1)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs, err := outbox.GetOutboxTxs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 1 || txs[0].Status != TxQueued || txs[0].Attempts != 1 {
+		t.Fatalf("expected the deposit to be queued after one attempt, got %+v", txs)
+	}
+
+	txs = mineUntil(t, chain, outbox, func(txs []OutboxTx) bool { return len(txs) == 1 && txs[0].Status == TxFailed })
+	if txs[0].Attempts != MAX_OUTBOX_TX_ATTEMPTS {
+		t.Errorf("expected the deposit to fail after %d attempts, got %d", MAX_OUTBOX_TX_ATTEMPTS, txs[0].Attempts)
+	}
+	if !strings.Contains(txs[0].Error, "connection refused") {
+		t.Errorf("expected the last error to be recorded, got %q", txs[0].Error)
+	}
+}
+
+func TestTransactionWhichCouldNotBeSubmittedIsRetried(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outbox := NewMemTxOutbox()
+	cs, err := newEthChainService(sim, 0, bindings.Adjudicator.Contract, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, ethAccounts[0], FeeOpts{}, outbox)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An earlier submission could not reach the chain, so the transaction was left queued with the error
+	tx := protocols.NewDepositTransaction(types.Destination{1}, types.Funds{common.Address{}: big.NewInt(1)})
+	if err := outbox.SetOutboxTx(OutboxTx{Id: "1", ObjectiveId: "DirectFunding-0x01", Tx: tx, Status: TxQueued, Error: "connection refused"}); err != nil {
+		t.Fatal(err)
+	}
+
+	txs := mineUntil(t, sim, outbox, func(txs []OutboxTx) bool { return len(txs) == 1 && txs[0].Status == TxConfirmed })
+	if txs[0].Error != "" {
+		t.Fatalf("expected the error to be cleared once the transaction was confirmed, got %q", txs[0].Error)
+	}
+}
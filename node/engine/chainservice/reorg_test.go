@@ -0,0 +1,202 @@
+package chainservice
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// mineUntilEvent mines blocks one at a time until the chain service dispatches an event for which match returns true
+func mineUntilEvent(t *testing.T, sim SimulatedChain, cs ChainService, match func(Event) bool) Event {
+	t.Helper()
+	for i := 0; i < 50; i++ {
+		sim.Commit()
+		timeout := time.After(50 * time.Millisecond)
+		for {
+			select {
+			case event := <-cs.EventEngineFeed():
+				if match(event) {
+					return event
+				}
+				continue
+			case <-timeout:
+			}
+			break
+		}
+	}
+	t.Fatal("the expected chain event was not dispatched")
+	return nil
+}
+
+func TestDispatchedEventsAreRetractedOnReorg(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs, err := newEthChainService(sim, 0, bindings.Adjudicator.Contract, bindings.Adjudicator.Address, bindings.ConsensusApp.Address, bindings.VirtualPaymentApp.Address, ethAccounts[0], FeeOpts{}, nil)
+	defer closeChainService(t, cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ancestor, err := sim.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	channelId := types.Destination{1}
+	err = cs.SubmitTransaction("DirectFunding-0x01", protocols.NewDepositTransaction(channelId, types.Funds{common.Address{}: big.NewInt(1)}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isDeposit := func(e Event) bool {
+		_, ok := e.(DepositedEvent)
+		return ok && e.ChannelID() == channelId
+	}
+	deposited := mineUntilEvent(t, sim, cs, isDeposit)
+	if deposited.Block().BlockNum != ancestor.Number.Uint64()+1 {
+		t.Fatalf("expected the deposit to be mined in block %d, got %d", ancestor.Number.Uint64()+1, deposited.Block().BlockNum)
+	}
+
+	// A reorg replaces the blocks mined since the ancestor with a side chain
+	err = sim.Fork(ancestor.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := mineUntilEvent(t, sim, cs, func(e Event) bool {
+		_, ok := e.(RetractedEvent)
+		return ok
+	})
+	retraction := event.(RetractedEvent)
+	if retraction.ChannelID() != channelId || retraction.Block().BlockNum != ancestor.Number.Uint64() || retraction.Block().Timestamp != ancestor.Time {
+		t.Fatalf("expected the events of channel %s to be retracted back to block %d, got %s", channelId, ancestor.Number.Uint64(), retraction)
+	}
+	if len(retraction.Retracted) != 1 || !isDeposit(retraction.Retracted[0]) {
+		t.Fatalf("expected the deposit to be retracted, got %+v", retraction.Retracted)
+	}
+
+	// The deposit is mined again on the canonical chain, and dispatched once it has enough confirmations
+	redeposited := mineUntilEvent(t, sim, cs, isDeposit).(DepositedEvent)
+	if redeposited.NowHeld.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("expected 1 to be held by the channel after the reorg, got %s", redeposited.NowHeld)
+	}
+	holdings, err := bindings.Adjudicator.Contract.Holdings(&bind.CallOpts{}, common.Address{}, channelId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holdings.Cmp(big.NewInt(1)) != 0 {
+		t.Fatalf("expected the deposit to be made once, got holdings of %s", holdings)
+	}
+}
+
+func TestCanonicalEventConfirmedWithDroppedEventIsDispatchedOnce(t *testing.T) {
+	sim, bindings, ethAccounts, err := SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	txSigner := ethAccounts[0]
+
+	ancestor, err := sim.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deposit := func(channelId types.Destination) {
+		t.Helper()
+		opts := *txSigner
+		opts.Value = big.NewInt(1)
+		_, err := bindings.Adjudicator.Contract.Deposit(&opts, common.Address{}, channelId, big.NewInt(0), big.NewInt(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	logsSince := func(blockNum uint64) []ethTypes.Log {
+		t.Helper()
+		logs, err := sim.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(blockNum),
+			Addresses: []common.Address{bindings.Adjudicator.Address},
+			Topics:    [][]common.Hash{topicsToWatch},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return logs
+	}
+
+	// A deposit is mined, and then removed by a reorg before it is confirmed
+	deposit(types.Destination{1})
+	sim.Commit()
+	dropped := logsSince(ancestor.Number.Uint64() + 1)
+	if len(dropped) != 1 {
+		t.Fatalf("expected one deposit to be mined, got %d", len(dropped))
+	}
+	err = sim.Fork(ancestor.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The canonical chain holds another deposit, and both deposits are confirmed by the same head
+	sim.Commit()
+	deposit(types.Destination{2})
+	sim.Commit()
+	for i := 0; i < REQUIRED_BLOCK_CONFIRMATIONS; i++ {
+		sim.Commit()
+	}
+	canonical := logsSince(ancestor.Number.Uint64() + 1)
+	if len(canonical) == 0 {
+		t.Fatal("expected a deposit to be mined on the canonical chain")
+	}
+	head, err := sim.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The chain service is not started, so that the tracker is only updated by the test
+	cs := &EthChainService{
+		chain:          sim,
+		na:             bindings.Adjudicator.Contract,
+		naAddress:      bindings.Adjudicator.Address,
+		eventEngineOut: make(chan Event, 10),
+		logger:         slog.Default(),
+		ctx:            ctx,
+		eventTracker:   NewEventTracker(Block{BlockNum: ancestor.Number.Uint64(), Timestamp: ancestor.Time}),
+	}
+	cs.eventTracker.Push(dropped[0])
+	for _, l := range canonical {
+		cs.eventTracker.Push(l)
+	}
+
+	errChan := make(chan error, 1)
+	cs.updateEventTracker(errChan, head, nil)
+	cs.updateEventTracker(errChan, head, nil)
+	select {
+	case err := <-errChan:
+		t.Fatal(err)
+	default:
+	}
+
+	if cs.eventTracker.events.Len() != 0 {
+		t.Fatalf("expected no event to be queued again, got %d", cs.eventTracker.events.Len())
+	}
+	if len(cs.eventTracker.dispatched) != len(canonical) || len(cs.eventEngineOut) != len(canonical) {
+		t.Fatalf("expected each of the %d canonical events to be dispatched once, got %d", len(canonical), len(cs.eventEngineOut))
+	}
+	for _, d := range cs.eventTracker.dispatched {
+		if d.log.BlockHash == dropped[0].BlockHash {
+			t.Fatal("expected the dropped event not to be dispatched")
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -51,6 +52,8 @@ type Bindings struct {
 type SimulatedChain interface {
 	ethChain
 	Commit() common.Hash
+	// Fork rewinds the chain to the block with the given hash, so that the blocks committed afterwards replace the rewound blocks as in a reorg
+	Fork(parentHash common.Hash) error
 	Close() error
 }
 
@@ -58,6 +61,21 @@ type SimulatedChain interface {
 type BackendWrapper struct {
 	*simulated.Backend
 	simulated.Client
+	mu sync.Mutex // serializes block production, which deadlocks the simulated backend when run concurrently
+}
+
+// Commit seals a block containing the pending transactions
+func (b *BackendWrapper) Commit() common.Hash {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.Backend.Commit()
+}
+
+// Fork rewinds the chain to the block with the given hash
+func (b *BackendWrapper) Fork(parentHash common.Hash) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.Backend.Fork(parentHash)
 }
 
 func (b *BackendWrapper) ChainID(ctx context.Context) (*big.Int, error) {
@@ -84,7 +102,9 @@ func NewSimulatedBackendChainService(sim SimulatedChain, bindings Bindings,
 		bindings.Adjudicator.Address,
 		bindings.ConsensusApp.Address,
 		bindings.VirtualPaymentApp.Address,
-		txSigner)
+		txSigner,
+		FeeOpts{},
+		nil)
 	if err != nil {
 		return &SimulatedBackendChainService{}, err
 	}
@@ -108,6 +128,22 @@ func (sbcs *SimulatedBackendChainService) SendTransaction(tx protocols.ChainTran
 	return nil, nil
 }
 
+// SubmitTransaction records the transaction in the outbox and submits it, and blocks until it has been mined.
+func (sbcs *SimulatedBackendChainService) SubmitTransaction(objectiveId protocols.ObjectiveId, tx protocols.ChainTransaction) error {
+	err := sbcs.EthChainService.SubmitTransaction(objectiveId, tx)
+	if err != nil {
+		return err
+	}
+	sbcs.sim.Commit()
+
+	// Mint additional blocks to satisfy REQUIRED_BLOCK_CONFIRMATIONS.
+	for i := 0; i < REQUIRED_BLOCK_CONFIRMATIONS; i++ {
+		sbcs.sim.Commit()
+	}
+
+	return nil
+}
+
 // SetupSimulatedBackend creates a new SimulatedBackend with the supplied number of transacting accounts, deploys the Nitro Adjudicator and returns both.
 func SetupSimulatedBackend(numAccounts uint64) (SimulatedChain, Bindings, []*bind.TransactOpts, error) {
 	accounts := make([]*bind.TransactOpts, numAccounts)
@@ -181,7 +217,7 @@ func SetupSimulatedBackend(numAccounts uint64) (SimulatedChain, Bindings, []*bin
 	}
 	sim.Commit()
 
-	return &BackendWrapper{sim, simulatedClient}, contractBindings, accounts, nil
+	return &BackendWrapper{Backend: sim, Client: simulatedClient}, contractBindings, accounts, nil
 }
 
 func (sbcs *SimulatedBackendChainService) GetConsensusAppAddress() types.Address {
@@ -193,10 +229,6 @@ func (sbcs *SimulatedBackendChainService) GetVirtualPaymentAppAddress() types.Ad
 	return sbcs.virtualPaymentAppAddress
 }
 
-func (sbcs *SimulatedBackendChainService) DroppedEventFeed() <-chan protocols.DroppedEventInfo {
-	return nil
-}
-
 func (sbcs *SimulatedBackendChainService) EventFeed() <-chan Event {
 	return nil
 }
@@ -9,18 +9,22 @@ import (
 	"fmt"
 	"log/slog"
 	"math/big"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
-	ethTypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto/secp256k1"
 	"github.com/statechannels/go-nitro/channel"
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/internal/logging"
+	"github.com/statechannels/go-nitro/internal/metrics"
+	"github.com/statechannels/go-nitro/internal/safesync"
+	"github.com/statechannels/go-nitro/internal/tracing"
 	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
 	"github.com/statechannels/go-nitro/node/engine/messageservice"
 	p2pms "github.com/statechannels/go-nitro/node/engine/messageservice/p2p-message-service"
 	"github.com/statechannels/go-nitro/node/engine/store"
@@ -32,17 +36,22 @@ import (
 	"github.com/statechannels/go-nitro/protocols/directdefund"
 	"github.com/statechannels/go-nitro/protocols/directfund"
 	"github.com/statechannels/go-nitro/protocols/mirrorbridgeddefund"
+	"github.com/statechannels/go-nitro/protocols/registry"
 	"github.com/statechannels/go-nitro/protocols/swap"
 	"github.com/statechannels/go-nitro/protocols/swapdefund"
 	"github.com/statechannels/go-nitro/protocols/swapfund"
 	"github.com/statechannels/go-nitro/protocols/virtualdefund"
 	"github.com/statechannels/go-nitro/protocols/virtualfund"
 	"github.com/statechannels/go-nitro/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
-	errEmptyDroppedEvent   error = errors.New("no dropped events yet")
-	errSwapObjectiveExists error = errors.New("swap objective already exists")
+	errSwapObjectiveExists     error = errors.New("swap objective already exists")
+	errObjectiveNotPending     error = errors.New("objective is not pending approval")
+	errObjectiveNotCancellable error = errors.New("objective has already finished")
+	errChallengeNotResponded   error = errors.New("could not respond to challenge")
 )
 
 // ErrUnhandledChainEvent is an engine error when the the engine cannot process a chain event
@@ -73,8 +82,10 @@ var nonFatalErrors = []error{
 	virtualfund.ErrUpdatingLedgerFunding,
 	swapfund.ErrUpdatingLedgerFunding,
 	swapfund.ErrZeroFunds,
-	errEmptyDroppedEvent,
 	errSwapObjectiveExists,
+	errObjectiveNotPending,
+	errObjectiveNotCancellable,
+	errChallengeNotResponded,
 	swap.ErrInvalidSwap,
 	types.ErrLeftLedgerChannelNotFound,
 	types.ErrRightLedgerChannelNotFound,
@@ -85,32 +96,50 @@ type Engine struct {
 	// inbound go channels
 
 	// From API
-	ObjectiveRequestsFromAPI        chan protocols.ObjectiveRequest
+	ObjectiveRequestsFromAPI        chan ObjectiveRequest
 	PaymentRequestsFromAPI          chan PaymentRequest
 	CounterChallengeRequestsFromAPI chan CounterChallengeRequest
-	RetryObjectiveTxRequestFromAPI  chan types.RetryObjectiveTxRequest
 	ConfirmSwapRequestFromAPI       chan types.ConfirmSwapRequest
+	ApprovalDecisionsFromAPI        chan ApprovalDecision
+	CancelObjectiveRequestsFromAPI  chan CancelObjectiveRequest
 
-	fromChain             <-chan chainservice.Event
-	droppedEventFromChain <-chan protocols.DroppedEventInfo
-	fromMsg               <-chan protocols.Message
-	fromLedger            chan consensus_channel.Proposal
-	signRequests          <-chan p2pms.SignatureRequest
+	fromChain    <-chan chainservice.Event
+	fromMsg      <-chan protocols.Message
+	fromLedger   chan consensus_channel.Proposal
+	signRequests <-chan p2pms.SignatureRequest
 
 	eventHandler func(EngineEvent)
 
 	msg   messageservice.MessageService
 	chain chainservice.ChainService
 
-	store       store.Store // A Store for persisting and restoring important data
-	policymaker PolicyMaker // A PolicyMaker decides whether to approve or reject objectives
-	logger      *slog.Logger
-	vm          *payments.VoucherManager
+	store         store.Store    // A Store for persisting and restoring important data
+	signer        crypto.Signer  // A Signer signs states, vouchers, swaps and DHT records on behalf of the node
+	policymaker   PolicyMaker    // A PolicyMaker decides whether to approve or reject objectives
+	watchtower    *Watchtower    // A Watchtower decides how to respond to challenges registered against stale states. May be nil.
+	reaper        *Reaper        // A Reaper decides how to act on objectives which have missed their deadline. May be nil.
+	approvalQueue *ApprovalQueue // An ApprovalQueue parks approved objectives until an operator decides on them. May be nil.
+	logger        *slog.Logger
+	vm            *payments.VoucherManager
+
+	// progress records what each in-flight objective is waiting for, and since when. It is shared by concurrently running tasks, so is guarded by progressMu.
+	progress   map[protocols.ObjectiveId]objectiveProgress
+	progressMu *sync.Mutex
+	blockNumMu *sync.Mutex // blockNumMu guards updates to the last block number seen
+
+	// traces holds the span context which the next crank of each in-flight objective continues, keyed by objective id
+	traces *safesync.Map[trace.SpanContext]
 
 	wg     *sync.WaitGroup
 	cancel context.CancelFunc
 }
 
+// objectiveProgress records what an objective is waiting for, and since when
+type objectiveProgress struct {
+	waitingFor protocols.WaitingFor
+	since      time.Time
+}
+
 // PaymentRequest represents a request from the API to make a payment using a channel
 type PaymentRequest struct {
 	ChannelId types.Destination
@@ -124,12 +153,38 @@ type CounterChallengeRequest struct {
 	Payload   state.SignedState
 }
 
+// ApprovalDecision represents an operator's decision on an objective parked by an ApprovalQueue
+type ApprovalDecision struct {
+	ObjectiveId protocols.ObjectiveId
+	Approve     bool
+}
+
+// EngineOpts holds the optional components of an engine
+type EngineOpts struct {
+	// Watchtower decides how to respond to challenges registered against stale states. If nil, the engine does not respond to them.
+	Watchtower *Watchtower
+	// Reaper decides how to act on objectives which have missed their deadline. If nil, objectives may wait indefinitely.
+	Reaper *Reaper
+}
+
+// ObjectiveRequest represents a request from the API to create an objective.
+// Ctx is the context of the API call, whose trace the objective continues. It may be nil.
+type ObjectiveRequest struct {
+	protocols.ObjectiveRequest
+	Ctx context.Context
+}
+
+// CancelObjectiveRequest represents a request from the API to cancel an objective which has not finished
+type CancelObjectiveRequest struct {
+	ObjectiveId protocols.ObjectiveId
+}
+
 // EngineEvent is a struct that contains a list of changes caused by handling a message/chain event/api event
 type EngineEvent struct {
 	// These are objectives that are now completed
 	CompletedObjectives []protocols.Objective
-	// These are objectives that have failed
-	FailedObjectives []protocols.ObjectiveId
+	// These are objectives that have failed, or have missed a deadline set by a Reaper, along with the reason
+	FailedObjectives []protocols.FailedObjective
 	// ReceivedVouchers are vouchers we've received from other participants
 	ReceivedVouchers []payments.Voucher
 
@@ -139,6 +194,10 @@ type EngineEvent struct {
 	PaymentChannelUpdates []query.PaymentChannelInfo
 	// SwapUpdates contains info for updates in swap
 	SwapUpdates []query.SwapInfo
+	// ChallengeResponses are checkpoints and counter challenges submitted in response to challenges registered against stale states
+	ChallengeResponses []types.ChallengeResponse
+	// TransactionReplacements are chain transactions resubmitted with higher fees after staying pending for too long
+	TransactionReplacements []types.TransactionReplacement
 }
 
 // IsEmpty returns true if the EngineEvent contains no changes
@@ -148,7 +207,9 @@ func (ee *EngineEvent) IsEmpty() bool {
 		len(ee.ReceivedVouchers) == 0 &&
 		len(ee.LedgerChannelUpdates) == 0 &&
 		len(ee.PaymentChannelUpdates) == 0 &&
-		len(ee.SwapUpdates) == 0
+		len(ee.SwapUpdates) == 0 &&
+		len(ee.ChallengeResponses) == 0 &&
+		len(ee.TransactionReplacements) == 0
 }
 
 func (ee *EngineEvent) Merge(other EngineEvent) {
@@ -158,6 +219,8 @@ func (ee *EngineEvent) Merge(other EngineEvent) {
 	ee.LedgerChannelUpdates = append(ee.LedgerChannelUpdates, other.LedgerChannelUpdates...)
 	ee.PaymentChannelUpdates = append(ee.PaymentChannelUpdates, other.PaymentChannelUpdates...)
 	ee.SwapUpdates = append(ee.SwapUpdates, other.SwapUpdates...)
+	ee.ChallengeResponses = append(ee.ChallengeResponses, other.ChallengeResponses...)
+	ee.TransactionReplacements = append(ee.TransactionReplacements, other.TransactionReplacements...)
 }
 
 type CompletedObjectiveEvent struct {
@@ -168,21 +231,26 @@ type CompletedObjectiveEvent struct {
 type Response struct{}
 
 // NewEngine is the constructor for an Engine
-func New(vm *payments.VoucherManager, msg messageservice.MessageService, chain chainservice.ChainService, store store.Store, policymaker PolicyMaker, eventHandler func(EngineEvent)) Engine {
+func New(vm *payments.VoucherManager, msg messageservice.MessageService, chain chainservice.ChainService, store store.Store, signer crypto.Signer, policymaker PolicyMaker, opts EngineOpts, eventHandler func(EngineEvent)) Engine {
+	if signer.Address() != *store.GetAddress() {
+		panic(fmt.Sprintf("signer address %s does not match store address %s", signer.Address(), store.GetAddress()))
+	}
+
 	e := Engine{}
 	e.logger = logging.LoggerWithAddress(slog.Default(), *store.GetAddress())
 	e.store = store
+	e.signer = signer
 
 	e.fromLedger = make(chan consensus_channel.Proposal, 100)
 	// bind to inbound chans
-	e.ObjectiveRequestsFromAPI = make(chan protocols.ObjectiveRequest)
+	e.ObjectiveRequestsFromAPI = make(chan ObjectiveRequest)
 	e.PaymentRequestsFromAPI = make(chan PaymentRequest)
 	e.CounterChallengeRequestsFromAPI = make(chan CounterChallengeRequest)
-	e.RetryObjectiveTxRequestFromAPI = make(chan types.RetryObjectiveTxRequest)
 	e.ConfirmSwapRequestFromAPI = make(chan types.ConfirmSwapRequest)
+	e.ApprovalDecisionsFromAPI = make(chan ApprovalDecision)
+	e.CancelObjectiveRequestsFromAPI = make(chan CancelObjectiveRequest)
 
 	e.fromChain = chain.EventEngineFeed()
-	e.droppedEventFromChain = chain.DroppedEventEngineFeed()
 	e.fromMsg = msg.P2PMessages()
 	e.signRequests = msg.SignRequests()
 
@@ -192,6 +260,13 @@ func New(vm *payments.VoucherManager, msg messageservice.MessageService, chain c
 	e.eventHandler = eventHandler
 
 	e.policymaker = policymaker
+	e.watchtower = opts.Watchtower
+	e.reaper = opts.Reaper
+	e.approvalQueue, _ = FindPolicyMaker[*ApprovalQueue](policymaker)
+	e.progress = make(map[protocols.ObjectiveId]objectiveProgress)
+	e.progressMu = &sync.Mutex{}
+	e.blockNumMu = &sync.Mutex{}
+	e.traces = &safesync.Map[trace.SpanContext]{}
 
 	e.vm = vm
 
@@ -218,94 +293,195 @@ func (e *Engine) Close() error {
 	return e.chain.Close()
 }
 
-// run kicks of an infinite loop that waits for communications on the supplied channels, and handles them accordingly
+// run kicks of an infinite loop that waits for communications on the supplied channels, and hands each of them to a task.
+// Tasks on unrelated channels run concurrently, while tasks on the same channel or ledger run in the order they arrived (see task).
+// The results of tasks are handled by the loop itself, so the event handler is never called concurrently.
 // The loop exits when the context is cancelled.
 func (e *Engine) run(ctx context.Context) {
-	for {
-		var res EngineEvent
-		var err error
+	defer e.wg.Done()
 
-		var blockTicker <-chan time.Time
-		var ticker *time.Ticker
-		_, isEthChainService := e.chain.(*chainservice.EthChainService)
-		if isEthChainService {
-			ticker = time.NewTicker(5 * time.Second)
-			blockTicker = ticker.C
+	recovered, err := e.recoverObjectives()
+	e.checkError(err)
+	if !recovered.IsEmpty() {
+		e.eventHandler(recovered)
+	}
+
+	// Unapproved objectives are decided on after the approved ones are recovered, so that none is cranked twice,
+	// and before the loop starts, so that those parked before a restart are parked again before any decision can arrive
+	e.handleResult(e.restoreUnapprovedObjectives())
+
+	var reaperTicker <-chan time.Time
+	if e.reaper != nil {
+		ticker := time.NewTicker(e.reaper.Interval())
+		defer ticker.Stop()
+		reaperTicker = ticker.C
+	}
+
+	var blockTicker <-chan time.Time
+	switch e.chain.(type) {
+	case *chainservice.EthChainService, *chainservice.LaconicdChainService:
+		ticker := time.NewTicker(5 * time.Second)
+		defer ticker.Stop()
+		blockTicker = ticker.C
+	}
+
+	s := newScheduler()
+	finished := make(chan finishedTask)
+
+	for {
+		// Stop accepting API requests and messages while the queue is full. Feeds which running tasks may be blocked on are always accepted.
+		objectiveRequests, paymentRequests, messages := e.ObjectiveRequestsFromAPI, e.PaymentRequestsFromAPI, e.fromMsg
+		if len(s.pending) >= maxPendingTasks {
+			objectiveRequests, paymentRequests, messages = nil, nil, nil
 		}
 
+		var t *task
+
 		select {
 
-		case or := <-e.ObjectiveRequestsFromAPI:
-			res, err = e.handleObjectiveRequest(or)
-		case pr := <-e.PaymentRequestsFromAPI:
-			res, err = e.handlePaymentRequest(pr)
+		case or := <-objectiveRequests:
+			lanes, ok := e.objectiveRequestLanes(or.ObjectiveRequest)
+			t = newTask("objective_request", lanes, ok, func() (EngineEvent, error) { return e.handleObjectiveRequest(or) })
+		case pr := <-paymentRequests:
+			t = newTask("payment_request", []string{pr.ChannelId.String()}, true, func() (EngineEvent, error) { return e.handlePaymentRequest(pr) })
 		case chainEvent := <-e.fromChain:
-			res, err = e.handleChainEvent(chainEvent)
-		case droppedEventTxInfo := <-e.droppedEventFromChain:
-			err = e.handleDroppedChainEvent(droppedEventTxInfo)
-		case message := <-e.fromMsg:
-			res, err = e.handleMessage(message)
+			lanes, ok := e.chainEventLanes(chainEvent)
+			t = newTask("chain_event", lanes, ok, func() (EngineEvent, error) { return e.handleChainEvent(chainEvent) })
+		case message := <-messages:
+			lanes, ok := e.messageLanes(message)
+			t = newTask("message", lanes, ok, func() (EngineEvent, error) {
+				ee, err := e.handleMessage(message)
+				if err == nil || isNonFatal(err) {
+					e.acknowledgeMessage(message)
+				}
+				return ee, err
+			})
 		case proposal := <-e.fromLedger:
-			res, err = e.handleProposal(proposal)
+			lanes, ok := e.proposalLanes(proposal)
+			t = newTask("proposal", lanes, ok, func() (EngineEvent, error) { return e.handleProposal(proposal) })
 		case signReq := <-e.signRequests:
-			err = e.handleSignRequest(signReq)
+			// Signing touches no channel
+			t = newTask("sign_request", nil, true, func() (EngineEvent, error) { return EngineEvent{}, e.handleSignRequest(signReq) })
 		case counterChallengeReq := <-e.CounterChallengeRequestsFromAPI:
-			err = e.handleCounterChallengeRequest(counterChallengeReq)
-		case retryObjectiveTxReq := <-e.RetryObjectiveTxRequestFromAPI:
-			err = e.handleRetryObjectiveTxRequest(retryObjectiveTxReq)
+			lanes, ok := e.channelLanes(counterChallengeReq.ChannelId)
+			t = newTask("counter_challenge_request", lanes, ok, func() (EngineEvent, error) {
+				return EngineEvent{}, e.handleCounterChallengeRequest(counterChallengeReq)
+			})
 		case confirmSwapReq := <-e.ConfirmSwapRequestFromAPI:
-			res, err = e.handleConfirmSwapRequest(confirmSwapReq)
+			t = exclusiveTask("confirm_swap_request", func() (EngineEvent, error) { return e.handleConfirmSwapRequest(confirmSwapReq) })
+		case approvalDecision := <-e.ApprovalDecisionsFromAPI:
+			lanes, ok := e.objectiveLanes(approvalDecision.ObjectiveId)
+			t = newTask("approval_decision", lanes, ok, func() (EngineEvent, error) { return e.handleApprovalDecision(approvalDecision) })
+		case cancelReq := <-e.CancelObjectiveRequestsFromAPI:
+			lanes, ok := e.objectiveLanes(cancelReq.ObjectiveId)
+			t = newTask("cancel_objective_request", lanes, ok, func() (EngineEvent, error) { return e.handleCancelObjectiveRequest(cancelReq) })
+		case <-reaperTicker:
+			t = exclusiveTask("reaper_tick", e.reapStalledObjectives)
 		case <-blockTicker:
-			blockNum := e.chain.GetLastConfirmedBlockNum()
-			err = e.store.SetLastBlockNumSeen(blockNum)
-			e.checkError(err)
-
-			var block *ethTypes.Block
+			t = exclusiveTask("block_tick", func() (EngineEvent, error) { return EngineEvent{}, e.handleNewBlock() })
+		case f := <-finished:
+			s.finish(f.task)
+			metrics.ObserveSince(metrics.EngineTaskDuration, f.task.kind, f.task.queued)
+			e.handleResult(f.res, f.err)
+		case <-ctx.Done():
+			return
+		}
 
-			block, err = e.chain.GetBlockByNumber(big.NewInt(int64(blockNum)))
-			if err != nil {
-				e.logger.Error(err.Error())
-				err = nil
-			}
+		if t != nil {
+			s.add(t)
+		}
+		for _, t := range s.start() {
+			e.wg.Add(1)
+			go e.runTask(ctx, t, finished)
+		}
+	}
+}
 
-			if block != nil {
-				chainServiceBlock := chainservice.Block{
-					BlockNum:  block.NumberU64(),
-					Timestamp: block.Time(),
-				}
+// runTask runs a task and hands its result back to the run loop
+func (e *Engine) runTask(ctx context.Context, t *task, finished chan<- finishedTask) {
+	defer e.wg.Done()
+	res, err := t.handle()
+	select {
+	case finished <- finishedTask{task: t, res: res, err: err}:
+	case <-ctx.Done():
+	}
+}
 
-				err = e.processStoreChannels(chainServiceBlock)
-			}
-		case <-ctx.Done():
-			if ticker != nil {
-				ticker.Stop()
-			}
+// handleResult checks the error returned by a task, and sends out an event if the task made any changes
+func (e *Engine) handleResult(res EngineEvent, err error) {
+	e.checkError(err)
 
-			e.wg.Done()
-			return
+	if !res.IsEmpty() {
+		for _, obj := range res.CompletedObjectives {
+			e.logger.Info("Objective is complete & returned to API", logging.WithObjectiveIdAttribute(obj.Id()))
+			metrics.ObjectivesCompleted.WithLabelValues(objectiveTypeName(obj.Id())).Inc()
+			e.traces.Delete(string(obj.Id()))
+		}
+		for _, failed := range res.FailedObjectives {
+			metrics.ObjectivesFailed.WithLabelValues(objectiveTypeName(failed.ObjectiveId), string(failed.Reason.Code)).Inc()
+			e.traces.Delete(string(failed.ObjectiveId))
 		}
+		e.eventHandler(res)
+	}
+}
 
-		// Handle errors
-		e.checkError(err)
+// handleNewBlock records the latest confirmed block, and updates the mode of stored channels according to its timestamp
+func (e *Engine) handleNewBlock() error {
+	blockNum := e.chain.GetLastConfirmedBlockNum()
+	err := e.setLastBlockNumSeen(blockNum)
+	e.checkError(err)
 
-		// Only send out an event if there are changes
-		if !res.IsEmpty() {
+	block, err := e.chain.GetBlockByNumber(big.NewInt(int64(blockNum)))
+	if err != nil {
+		e.logger.Error(err.Error())
+		return nil
+	}
+	if block == nil {
+		return nil
+	}
 
-			for _, obj := range res.CompletedObjectives {
-				e.logger.Info("Objective is complete & returned to API", logging.WithObjectiveIdAttribute(obj.Id()))
-			}
-			e.eventHandler(res)
-		}
+	return e.processStoreChannels(chainservice.Block{
+		BlockNum:  block.NumberU64(),
+		Timestamp: block.Time(),
+	})
+}
 
+// setLastBlockNumSeen records that a block has been seen, unless a later block already has been.
+// Chain events on different channels are handled concurrently, so may finish out of order.
+func (e *Engine) setLastBlockNumSeen(blockNum uint64) error {
+	e.blockNumMu.Lock()
+	defer e.blockNumMu.Unlock()
+	seen, err := e.store.GetLastBlockNumSeen()
+	if err != nil || seen >= blockNum {
+		return err
+	}
+	return e.store.SetLastBlockNumSeen(blockNum)
+}
+
+// rollbackLastBlockNumSeen records that blocks after the given block have been removed by a reorg, unless an earlier block was the last seen
+func (e *Engine) rollbackLastBlockNumSeen(blockNum uint64) error {
+	e.blockNumMu.Lock()
+	defer e.blockNumMu.Unlock()
+	seen, err := e.store.GetLastBlockNumSeen()
+	if err != nil || seen <= blockNum {
+		return err
 	}
+	return e.store.SetLastBlockNumSeen(blockNum)
 }
 
 // handleProposal handles a Proposal returned to the engine from
 // a running ledger channel by pulling its corresponding objective
 // from the store and attempting progress.
 func (e *Engine) handleProposal(proposal consensus_channel.Proposal) (EngineEvent, error) {
+	if _, isRollback := e.getRolledBackObjective(proposal); isRollback {
+		return EngineEvent{}, e.countersignRollbackProposals(proposal.LedgerID)
+	}
+
 	c, _ := e.store.GetChannelById(proposal.Target())
-	id := getProposalObjectiveId(proposal, c.Type)
+	id, err := getProposalObjectiveId(proposal, c.Type)
+	if err != nil {
+		return EngineEvent{}, err
+	}
 
 	obj, err := e.store.GetObjectiveById(id)
 	if err != nil {
@@ -325,8 +501,7 @@ func (e *Engine) handleSignRequest(sigReq p2pms.SignatureRequest) error {
 	}
 
 	hash := sha256.Sum256(recordDataBytes) // Hash the data before signing it
-	secretKey := e.store.GetChannelSecretKey()
-	signature, err := secp256k1.Sign(hash[:], *secretKey)
+	signature, err := e.signer.SignDigest(hash[:])
 	if err != nil {
 		return err
 	}
@@ -341,9 +516,15 @@ func (e *Engine) handleSignRequest(sigReq p2pms.SignatureRequest) error {
 //   - generates an updated objective,
 //   - attempts progress on the target Objective,
 //   - attempts progress on related objectives which may have become unblocked.
-func (e *Engine) handleMessage(message protocols.Message) (EngineEvent, error) {
+func (e *Engine) handleMessage(message protocols.Message) (ee EngineEvent, err error) {
+	ctx, span := tracing.Tracer.Start(tracing.Extract(message.TraceContext), "handleMessage", trace.WithAttributes(attribute.String("from", message.From.String())))
+	defer func() { tracing.End(span, err) }()
+
 	e.logMessage(message, Incoming)
 	allCompleted := EngineEvent{}
+	for _, payload := range message.ObjectivePayloads {
+		e.setObjectiveTrace(payload.ObjectiveId, ctx)
+	}
 
 	for _, payload := range message.ObjectivePayloads {
 
@@ -353,49 +534,32 @@ func (e *Engine) handleMessage(message protocols.Message) (EngineEvent, error) {
 		}
 
 		if objective.GetStatus() == protocols.Unapproved {
-			e.logger.Info("Policymaker for objective", "policy-maker", e.policymaker, logging.WithObjectiveIdAttribute(objective.Id()))
-			if e.policymaker.ShouldApprove(objective) {
-				objective = objective.Approve()
-
-				ddfo, ok := objective.(*directdefund.Objective)
-				if ok {
-					// If we just approved a direct defund objective, destroy the consensus channel to prevent it being used (a Channel will now take over governance)
-					err := e.store.DestroyConsensusChannel(ddfo.C.Id)
-					if err != nil {
-						return EngineEvent{}, err
-					}
-				}
-
-				so, ok := objective.(*swap.Objective)
-				if ok {
-					rejectedObjective, err := e.rejectSwapIfPendingExists(so)
-					if err != nil {
-						return EngineEvent{}, err
-					}
-
-					if rejectedObjective != nil {
-						allCompleted.CompletedObjectives = append(allCompleted.CompletedObjectives, rejectedObjective)
-
-						if rejectedObjective.Id() == so.Id() {
-							return allCompleted, nil
-						}
-					}
-				}
-
-			} else {
-				objective, sideEffects := objective.Reject()
-				err = e.store.SetObjective(objective)
+			if e.approvalQueue != nil && e.approvalQueue.IsPending(objective.Id()) {
+				err = e.updatePendingObjective(objective, payload)
 				if err != nil {
 					return EngineEvent{}, err
 				}
+				continue
+			}
 
-				allCompleted.CompletedObjectives = append(allCompleted.CompletedObjectives, objective)
-
-				err = e.executeSideEffects(sideEffects)
-				// An error would mean we failed to send a message. But the objective is still "completed".
+			decided, parked, ee, err := e.decideUnapprovedObjective(objective)
+			allCompleted.Merge(ee)
+			if err != nil {
+				// An error rejecting the objective would mean we failed to send a message. But the objective is still "completed".
 				// So, we should return allCompleted even if there was an error.
 				return allCompleted, err
 			}
+			if parked {
+				err = e.updatePendingObjective(objective, payload)
+				if err != nil {
+					return EngineEvent{}, err
+				}
+				continue
+			}
+			if isCompleted(ee, objective.Id()) {
+				return allCompleted, nil
+			}
+			objective = decided
 		}
 
 		if objective.GetStatus() == protocols.Completed {
@@ -437,8 +601,19 @@ func (e *Engine) handleMessage(message protocols.Message) (EngineEvent, error) {
 			slog.Debug("DEBUG: engine.go-handleMessage proposal received from message", "proposal", string(marshalledProposal))
 		}
 
+		if _, isRollback := e.getRolledBackObjective(entry.Proposal); isRollback {
+			err := e.receiveRollbackProposal(entry)
+			if err != nil {
+				return EngineEvent{}, err
+			}
+			continue
+		}
+
 		c, _ := e.store.GetChannelById(entry.Proposal.Target())
-		id := getProposalObjectiveId(entry.Proposal, c.Type)
+		id, err := getProposalObjectiveId(entry.Proposal, c.Type)
+		if err != nil {
+			return EngineEvent{}, err
+		}
 
 		o, err := e.store.GetObjectiveById(id)
 		if err != nil {
@@ -456,7 +631,26 @@ func (e *Engine) handleMessage(message protocols.Message) (EngineEvent, error) {
 
 		updatedObjective, err := objective.ReceiveProposal(entry)
 		if err != nil {
-			return EngineEvent{}, err
+			reason := failureReason(err)
+			if reason.Code != protocols.InvalidSignature {
+				return EngineEvent{}, err
+			}
+			e.logger.Warn("Failing objective after receiving an incorrectly signed proposal", logging.WithObjectiveIdAttribute(id))
+			failed, err := e.failObjective(objective, reason)
+			if err != nil {
+				return EngineEvent{}, err
+			}
+			allCompleted.Merge(failedEvent(failed, reason))
+			continue
+		}
+
+		// Objectives awaiting approval keep the proposal, but must not progress until approved
+		if updatedObjective.GetStatus() == protocols.Unapproved {
+			err = e.store.SetObjective(updatedObjective)
+			if err != nil {
+				return EngineEvent{}, err
+			}
+			continue
 		}
 
 		progressEvent, err := e.attemptProgress(updatedObjective)
@@ -482,24 +676,35 @@ func (e *Engine) handleMessage(message protocols.Message) (EngineEvent, error) {
 		// we are rejecting due to a counterparty message notifying us of their rejection. We
 		// do not need to send a message back to that counterparty, and furthermore we assume that
 		// counterparty has already notified all other interested parties. We can therefore ignore the side effects
-		objective, _ = objective.Reject()
-		err = e.store.SetObjective(objective)
+		reason, ok := message.RejectionReasons[entry]
+		if !ok {
+			reason = protocols.FailureReason{Code: protocols.OtherFailure, Message: "rejected by a peer which gave no reason"}
+		}
+		objective, _, err = e.rejectWithReason(objective, reason)
+		if err != nil {
+			return EngineEvent{}, err
+		}
+		if e.approvalQueue != nil {
+			e.approvalQueue.Remove(objective.Id())
+		}
+		err = e.rollbackLedgerFunding(objective)
 		if err != nil {
 			return EngineEvent{}, err
 		}
 
-		allCompleted.CompletedObjectives = append(allCompleted.CompletedObjectives, objective)
+		allCompleted.Merge(failedEvent(objective, reason))
 	}
 
 	for _, voucher := range message.Payments {
 
 		// TODO: return the amount we paid?
-		_, _, err := e.vm.Receive(voucher)
+		_, delta, err := e.vm.Receive(voucher)
 
 		allCompleted.ReceivedVouchers = append(allCompleted.ReceivedVouchers, voucher)
 		if err != nil {
 			return EngineEvent{}, fmt.Errorf("error accepting payment voucher: %w", err)
 		}
+		metrics.RecordVoucher(metrics.Received, delta)
 		c, ok := e.store.GetChannelById(voucher.ChannelId)
 		if !ok {
 			return EngineEvent{}, fmt.Errorf("could not fetch channel for voucher %+v", voucher)
@@ -523,14 +728,189 @@ func (e *Engine) handleMessage(message protocols.Message) (EngineEvent, error) {
 	return allCompleted, nil
 }
 
+// updatePendingObjective applies the payload to an objective that is awaiting approval and stores it, without cranking it.
+func (e *Engine) updatePendingObjective(objective protocols.Objective, payload protocols.ObjectivePayload) error {
+	updatedObjective, err := objective.Update(payload)
+	if err != nil {
+		return err
+	}
+	return e.store.SetObjective(updatedObjective)
+}
+
+// decideUnapprovedObjective asks the policy maker whether an unapproved objective received from a peer should be approved, and records the decision.
+// An approved objective which requires an operator decision is parked, and parked is returned as true. Any other approved objective is approved
+// and returned. A rejected objective is rejected with the policy's reason and the other participants are notified.
+// Objectives rejected as a result are reported as completed in the returned event.
+func (e *Engine) decideUnapprovedObjective(objective protocols.Objective) (decided protocols.Objective, parked bool, ee EngineEvent, err error) {
+	e.logger.Info("Policymaker for objective", "policy-maker", e.policymaker, logging.WithObjectiveIdAttribute(objective.Id()))
+	approved := e.policymaker.ShouldApprove(objective)
+	decision, err := e.recordPolicyDecision(objective.Id())
+	if err != nil {
+		return nil, false, EngineEvent{}, err
+	}
+
+	if !approved {
+		// Tell the counterparty which rule rejected the objective, if the policy maker recorded it
+		reason := "rejected by policy"
+		if decision.Reason != "" {
+			reason = decision.Reason
+		}
+		ee, err := e.rejectObjective(objective, protocols.FailureReason{Code: protocols.PolicyRejection, Message: reason})
+		return objective, false, ee, err
+	}
+
+	if e.approvalQueue != nil && e.approvalQueue.RequiresApproval(objective) {
+		e.logger.Info("Parking objective until an operator approves or rejects it", logging.WithObjectiveIdAttribute(objective.Id()))
+		e.approvalQueue.Park(objective)
+		return objective, true, EngineEvent{}, nil
+	}
+
+	objective = objective.Approve()
+	rejectedObjective, err := e.handleApprovedObjective(objective)
+	if err != nil {
+		return nil, false, EngineEvent{}, err
+	}
+	if rejectedObjective != nil {
+		ee.CompletedObjectives = append(ee.CompletedObjectives, rejectedObjective)
+	}
+	return objective, false, ee, nil
+}
+
+// isCompleted returns true if the event reports the objective with the given id as completed
+func isCompleted(ee EngineEvent, id protocols.ObjectiveId) bool {
+	return slices.ContainsFunc(ee.CompletedObjectives, func(o protocols.Objective) bool { return o.Id() == id })
+}
+
+// handleApprovedObjective performs the bookkeeping required when an objective received from a peer is approved.
+// If approving a swap objective causes a swap objective to be rejected, the rejected objective is returned.
+func (e *Engine) handleApprovedObjective(objective protocols.Objective) (protocols.Objective, error) {
+	switch o := objective.(type) {
+	case *directdefund.Objective:
+		// If we just approved a direct defund objective, destroy the consensus channel to prevent it being used (a Channel will now take over governance)
+		return nil, e.store.DestroyConsensusChannel(o.C.Id)
+	case *swap.Objective:
+		return e.rejectSwapIfPendingExists(o)
+	default:
+		return nil, nil
+	}
+}
+
+// rejectObjective rejects the objective for the given reason, stores it and notifies the other participants.
+func (e *Engine) rejectObjective(objective protocols.Objective, reason protocols.FailureReason) (EngineEvent, error) {
+	objective, sideEffects, err := e.rejectWithReason(objective, reason)
+	if err != nil {
+		return EngineEvent{}, err
+	}
+	return failedEvent(objective, reason), e.executeSideEffects(e.objectiveTrace(objective.Id()), objective.Id(), sideEffects)
+}
+
+// rejectWithReason rejects and stores the objective, recording why it was rejected.
+// The reason is added to the rejection notices declared by the returned side effects, which the caller must execute.
+func (e *Engine) rejectWithReason(objective protocols.Objective, reason protocols.FailureReason) (protocols.Objective, protocols.SideEffects, error) {
+	objective, sideEffects := objective.Reject()
+	protocols.AddRejectionReason(sideEffects.MessagesToSend, objective.Id(), reason)
+	err := e.store.SetObjective(objective)
+	if err != nil {
+		return nil, protocols.SideEffects{}, err
+	}
+	err = e.store.SetFailureReason(objective.Id(), reason)
+	if err != nil {
+		return nil, protocols.SideEffects{}, err
+	}
+	err = e.recordProgress(objective, "", sideEffects)
+	if err != nil {
+		return nil, protocols.SideEffects{}, err
+	}
+	e.untrackProgress(objective.Id())
+	return objective, sideEffects, nil
+}
+
+// failedEvent reports the objective as completed, so that anyone waiting on it is released, and as failed for the given reason.
+func failedEvent(objective protocols.Objective, reason protocols.FailureReason) EngineEvent {
+	return EngineEvent{
+		CompletedObjectives: []protocols.Objective{objective},
+		FailedObjectives:    []protocols.FailedObjective{{ObjectiveId: objective.Id(), Reason: reason}},
+	}
+}
+
+// failureReason classifies an error which caused an objective to fail
+func failureReason(err error) protocols.FailureReason {
+	code := protocols.OtherFailure
+	switch {
+	case errors.Is(err, consensus_channel.ErrInsufficientFunds):
+		code = protocols.InsufficientLedgerFunds
+	case errors.Is(err, consensus_channel.ErrInvalidProposalSignature), errors.Is(err, consensus_channel.ErrWrongSigner):
+		code = protocols.InvalidSignature
+	}
+	return protocols.FailureReason{Code: code, Message: err.Error()}
+}
+
+// handleApprovalDecision handles an operator's decision (triggered by a client API call) on an objective parked by an ApprovalQueue.
+// An approved objective is cranked straight away, and a rejected objective is rejected with the other participants notified.
+func (e *Engine) handleApprovalDecision(decision ApprovalDecision) (EngineEvent, error) {
+	if e.approvalQueue == nil || !e.approvalQueue.IsPending(decision.ObjectiveId) {
+		return EngineEvent{}, fmt.Errorf("%w: %s", errObjectiveNotPending, decision.ObjectiveId)
+	}
+	e.approvalQueue.Remove(decision.ObjectiveId)
+
+	objective, err := e.store.GetObjectiveById(decision.ObjectiveId)
+	if err != nil {
+		return EngineEvent{}, &ErrGetObjective{err, decision.ObjectiveId}
+	}
+	if objective.GetStatus() != protocols.Unapproved {
+		return EngineEvent{}, fmt.Errorf("%w: %s has status %v", errObjectiveNotPending, decision.ObjectiveId, objective.GetStatus())
+	}
+
+	if !decision.Approve {
+		e.logger.Info("Operator rejected objective", logging.WithObjectiveIdAttribute(objective.Id()))
+		return e.rejectObjective(objective, protocols.FailureReason{Code: protocols.PolicyRejection, Message: "rejected by operator"})
+	}
+
+	e.logger.Info("Operator approved objective", logging.WithObjectiveIdAttribute(objective.Id()))
+	objective = objective.Approve()
+	ee := EngineEvent{}
+
+	rejectedObjective, err := e.handleApprovedObjective(objective)
+	if err != nil {
+		return EngineEvent{}, err
+	}
+	if rejectedObjective != nil {
+		ee.CompletedObjectives = append(ee.CompletedObjectives, rejectedObjective)
+
+		if rejectedObjective.Id() == objective.Id() {
+			return ee, nil
+		}
+	}
+
+	progressEvent, err := e.attemptProgress(objective)
+	ee.Merge(progressEvent)
+	return ee, err
+}
+
 // handleChainEvent handles a Chain Event from the blockchain.
 // It:
 //   - reads an objective from the store,
 //   - generates an updated objective, and
 //   - attempts progress.
 func (e *Engine) handleChainEvent(chainEvent chainservice.Event) (EngineEvent, error) {
+	if retraction, ok := chainEvent.(chainservice.RetractedEvent); ok {
+		return e.handleRetractedChainEvents(retraction)
+	}
+	// A replacement is reported as it is, without recording its block as seen, since the block is the head rather than that of a confirmed log
+	if replaced, ok := chainEvent.(chainservice.TransactionReplacedEvent); ok {
+		e.logger.Info("Chain transaction replaced", "event", replaced)
+		return EngineEvent{TransactionReplacements: []types.TransactionReplacement{{
+			ChannelId:      replaced.ChannelID(),
+			ReplacedTxHash: replaced.ReplacedTxHash,
+			TxHash:         replaced.TxHash(),
+			Nonce:          replaced.Nonce,
+			GasTipCap:      replaced.GasTipCap,
+			GasFeeCap:      replaced.GasFeeCap,
+		}}}, nil
+	}
+
 	e.logger.Info("Handling chain event", "blockNum", chainEvent.Block().BlockNum, "event", chainEvent)
-	err := e.store.SetLastBlockNumSeen(chainEvent.Block().BlockNum)
+	err := e.setLastBlockNumSeen(chainEvent.Block().BlockNum)
 	if err != nil {
 		return EngineEvent{}, err
 	}
@@ -577,6 +957,7 @@ func (e *Engine) handleChainEvent(chainEvent chainservice.Event) (EngineEvent, e
 				return EngineEvent{}, err
 			}
 			c = ddfo.C
+			ddfo.SpawnedAtBlock = chainEvent.Block().BlockNum
 			err = e.store.SetObjective(&ddfo)
 			if err != nil {
 				return EngineEvent{}, err
@@ -600,10 +981,20 @@ func (e *Engine) handleChainEvent(chainEvent chainservice.Event) (EngineEvent, e
 		return EngineEvent{}, err
 	}
 
+	ee := EngineEvent{}
+	if challenge, isChallengeRegistered := chainEvent.(chainservice.ChallengeRegisteredEvent); isChallengeRegistered {
+		ee, err = e.respondToChallenge(challenge, updatedChannel)
+		if err != nil {
+			return EngineEvent{}, err
+		}
+	}
+
 	objective, ok := e.store.GetObjectiveByChannelId(updatedChannel.Id)
 
 	if ok {
-		return e.attemptProgress(objective)
+		progress, err := e.attemptProgress(objective)
+		ee.Merge(progress)
+		return ee, err
 	}
 
 	// When a challenge is registered on a virtual channel, identify the related ledger channel and its associated objective, and then process it.
@@ -629,68 +1020,193 @@ func (e *Engine) handleChainEvent(chainEvent chainservice.Event) (EngineEvent, e
 		}
 	}
 
-	return EngineEvent{}, nil
+	return ee, nil
 }
 
-func (e *Engine) handleDroppedChainEvent(droppedEventInfo protocols.DroppedEventInfo) error {
-	obj, ok := e.store.GetObjectiveByChannelId(droppedEventInfo.ChannelId)
+// handleRetractedChainEvents handles chain events retracted because a reorg removed their blocks.
+// The on chain data of the channel is rolled back to the common ancestor of the removed blocks and the canonical chain, and its objective
+// is progressed from there. A direct defund objective spawned by a retracted challenge is undone instead.
+// The chain service then dispatches the events of the canonical chain, which are applied as usual.
+func (e *Engine) handleRetractedChainEvents(retraction chainservice.RetractedEvent) (EngineEvent, error) {
+	commonAncestor := retraction.Block()
+	e.logger.Warn("Rolling back retracted chain events", "channelId", retraction.ChannelID(), "commonAncestor", commonAncestor.BlockNum, "retracted", len(retraction.Retracted))
 
-	if !ok {
-		slog.Info("Could not find objective with given channel ID", "channelId", droppedEventInfo.ChannelId)
-		return nil
+	// The chain service resumes from the common ancestor should the node restart before the canonical events are dispatched
+	err := e.rollbackLastBlockNumSeen(commonAncestor.BlockNum)
+	if err != nil {
+		return EngineEvent{}, err
 	}
 
-	switch objective := obj.(type) {
-	case *directfund.Objective:
-		objective.SetDroppedEvent(droppedEventInfo)
-		err := e.store.SetObjective(objective)
-		if err != nil {
-			return err
-		}
-	case *directdefund.Objective:
-		objective.SetDroppedEvent(droppedEventInfo)
-		err := e.store.SetObjective(objective)
-		if err != nil {
-			return err
-		}
+	c, ok := e.store.GetChannelById(retraction.ChannelID())
+	if !ok || !c.RollbackChainUpdates(commonAncestor.BlockNum) {
+		return EngineEvent{}, nil
 	}
+	c.UpdateChannelMode(commonAncestor.Timestamp)
 
-	return nil
+	err = e.store.SetChannel(c)
+	if err != nil {
+		return EngineEvent{}, err
+	}
+
+	objective, ok := e.store.GetObjectiveByChannelId(c.Id)
+	if !ok {
+		return EngineEvent{}, nil
+	}
+	if ddfo, isDdfo := objective.(*directdefund.Objective); isDdfo && ddfo.SpawnedAtBlock > commonAncestor.BlockNum {
+		return EngineEvent{}, e.undoSpawnedDirectDefund(ddfo)
+	}
+	return e.attemptProgress(objective)
 }
 
-// checkAndProcessL2Channel checks if the chain event corresponds to an L2 channel and retrieves its L1 channel ID.
-// If the L1 channel doesn't exist, it creates a mirror bridged defund objective.
-func (e *Engine) checkAndProcessL2Channel(chainEvent chainservice.Event, isChallengeRegistered bool) (types.Destination, error) {
-	// Check whether a challenge has been registered / cleared for the L2 channel, and then retrieve its L1 channel using an eth call to NitroAdjudicator contract
-	l1ChannelId, err := e.chain.GetL1ChannelFromL2(chainEvent.ChannelID())
+// undoSpawnedDirectDefund undoes a direct defund objective spawned by a challenge which a reorg removed from the chain.
+// The consensus channel destroyed when the objective was spawned is restored from the rolled back channel, and the objective
+// and channel are destroyed. Should the canonical chain include the challenge, its event spawns the objective again.
+func (e *Engine) undoSpawnedDirectDefund(ddfo *directdefund.Objective) error {
+	e.logger.Warn("Undoing direct defund objective spawned by a retracted challenge", "objective", ddfo.Id(), "spawnedAtBlock", ddfo.SpawnedAtBlock)
+
+	cc, err := ddfo.CreateConsensusChannelFromChannel()
 	if err != nil {
-		return types.Destination{}, err
+		return fmt.Errorf("could not restore consensus channel for objective %s: %w", ddfo.Id(), err)
+	}
+	err = e.store.SetConsensusChannel(cc)
+	if err != nil {
+		return fmt.Errorf("could not store consensus channel for objective %s: %w", ddfo.Id(), err)
 	}
 
-	if l1ChannelId.IsZero() {
-		return types.Destination{}, nil
+	err = e.store.DestroyObjective(ddfo.Id())
+	if err != nil {
+		return fmt.Errorf("could not destroy objective %s: %w", ddfo.Id(), err)
+	}
+	err = e.store.DestroyChannel(cc.Id)
+	if err != nil {
+		return fmt.Errorf("could not destroy channel for objective %s: %w", ddfo.Id(), err)
 	}
+	return nil
+}
 
-	_, ok := e.store.GetChannelById(l1ChannelId)
-	if ok {
-		return l1ChannelId, nil
+// respondToChallenge responds to a challenge registered by someone else against a state older than the latest supported state of c.
+// The watchtower decides whether to checkpoint or counter challenge with the latest supported state.
+// Ledger channels respond through their direct defund objective, other channels submit the transaction directly.
+func (e *Engine) respondToChallenge(challenge chainservice.ChallengeRegisteredEvent, c *channel.Channel) (EngineEvent, error) {
+	// Challenges registered on an L2 channel are handled by the mirror bridged defund objective of the L1 channel
+	if e.watchtower == nil || challenge.IsInitiatedByMe || challenge.ChannelID() != c.Id {
+		return EngineEvent{}, nil
 	}
 
-	// If channel doesn't exist and chain event is ChallengeRegistered on L2 then create a new mirror bridged defund objective
-	// This doesn't occur for actor who registered the challenge on L2
-	if isChallengeRegistered && !ok {
-		mbdfo, err := mirrorbridgeddefund.NewObjective(mirrorbridgeddefund.NewObjectiveRequest(l1ChannelId, state.SignedState{}, false), true, e.store.GetConsensusChannelById, true)
-		if err != nil {
-			return types.Destination{}, err
+	latestSupportedSignedState, err := c.LatestSupportedSignedState()
+	if err != nil {
+		e.logger.Error("Cannot respond to challenge without a supported state", "channel", c.Id, "challengedTurnNum", challenge.TurnNum(), "error", err)
+		return EngineEvent{}, fmt.Errorf("%w on channel %s: %w", errChallengeNotResponded, c.Id, err)
+	}
+	latestSupportedTurnNum := latestSupportedSignedState.State().TurnNum
+	latestBlockTime := e.latestBlockTime(challenge.Block())
+
+	action, ok := e.watchtower.Response(c.Type, challenge.TurnNum(), latestSupportedTurnNum, challenge.FinalizesAt, latestBlockTime)
+	if !ok {
+		if latestSupportedTurnNum > challenge.TurnNum() {
+			e.logger.Warn("Not responding to challenge registered against a stale state", "channel", c.Id, "challengedTurnNum", challenge.TurnNum(), "latestSupportedTurnNum", latestSupportedTurnNum, "finalizesAt", challenge.FinalizesAt, "latestBlockTime", latestBlockTime)
 		}
+		return EngineEvent{}, nil
+	}
 
-		// Destroy the consensus channel to prevent it being used (Channel will now take over governance)
-		err = e.store.DestroyConsensusChannel(mbdfo.C.Id)
-		if err != nil {
-			return types.Destination{}, err
+	switch c.Type {
+	case types.Ledger:
+		obj, ok := e.store.GetObjectiveByChannelId(c.Id)
+		if !ok {
+			return EngineEvent{}, fmt.Errorf("objective to respond to the challenge on channel %s could not be found", c.Id)
+		}
+		objective, ok := obj.(*directdefund.Objective)
+		if !ok {
+			e.logger.Warn("Cannot respond to challenge without a direct defund objective", "channel", c.Id, "objective", obj.Id())
+			return EngineEvent{}, nil
 		}
+		objective.IsCheckpoint = action == types.Checkpoint
+		objective.IsChallenge = action == types.Challenge
 
-		err = e.store.SetObjective(&mbdfo)
+		// The transaction is declared when the objective is next cranked
+		err = e.store.SetObjective(objective)
+		if err != nil {
+			return EngineEvent{}, err
+		}
+	default:
+		var tx protocols.ChainTransaction = protocols.NewCheckpointTransaction(c.Id, latestSupportedSignedState, make([]state.SignedState, 0))
+		if action == types.Challenge {
+			challengerSig, err := NitroAdjudicator.SignChallengeMessageWith(latestSupportedSignedState.State(), e.signer)
+			if err != nil {
+				return EngineEvent{}, err
+			}
+			tx = protocols.NewChallengeTransaction(c.Id, latestSupportedSignedState, make([]state.SignedState, 0), challengerSig)
+		}
+
+		// The transaction is submitted on behalf of the objective which owns the channel, if any
+		var objectiveId protocols.ObjectiveId
+		if obj, ok := e.store.GetObjectiveByChannelId(c.Id); ok {
+			objectiveId = obj.Id()
+		}
+		err = e.executeSideEffects(context.Background(), objectiveId, protocols.SideEffects{TransactionsToSubmit: []protocols.ChainTransaction{tx}})
+		if err != nil {
+			return EngineEvent{}, err
+		}
+	}
+
+	e.logger.Info("Responded to challenge registered against a stale state", "channel", c.Id, "action", action, "challengedTurnNum", challenge.TurnNum(), "latestSupportedTurnNum", latestSupportedTurnNum)
+
+	return EngineEvent{ChallengeResponses: []types.ChallengeResponse{{
+		ChannelId:         c.Id,
+		Action:            action,
+		ChallengedTurnNum: challenge.TurnNum(),
+		ResponseTurnNum:   latestSupportedTurnNum,
+		FinalizesAt:       challenge.FinalizesAt.Uint64(),
+	}}}, nil
+}
+
+// latestBlockTime returns the timestamp of the latest confirmed block, falling back to the timestamp of the supplied block.
+func (e *Engine) latestBlockTime(block chainservice.Block) uint64 {
+	lastConfirmedBlockNum := e.chain.GetLastConfirmedBlockNum()
+	if lastConfirmedBlockNum <= block.BlockNum {
+		return block.Timestamp
+	}
+
+	latestBlock, err := e.chain.GetBlockByNumber(new(big.Int).SetUint64(lastConfirmedBlockNum))
+	if err != nil || latestBlock == nil {
+		return block.Timestamp
+	}
+	return max(latestBlock.Time(), block.Timestamp)
+}
+
+// checkAndProcessL2Channel checks if the chain event corresponds to an L2 channel and retrieves its L1 channel ID.
+// If the L1 channel doesn't exist, it creates a mirror bridged defund objective.
+func (e *Engine) checkAndProcessL2Channel(chainEvent chainservice.Event, isChallengeRegistered bool) (types.Destination, error) {
+	// Check whether a challenge has been registered / cleared for the L2 channel, and then retrieve its L1 channel using an eth call to NitroAdjudicator contract
+	l1ChannelId, err := e.chain.GetL1ChannelFromL2(chainEvent.ChannelID())
+	if err != nil {
+		return types.Destination{}, err
+	}
+
+	if l1ChannelId.IsZero() {
+		return types.Destination{}, nil
+	}
+
+	_, ok := e.store.GetChannelById(l1ChannelId)
+	if ok {
+		return l1ChannelId, nil
+	}
+
+	// If channel doesn't exist and chain event is ChallengeRegistered on L2 then create a new mirror bridged defund objective
+	// This doesn't occur for actor who registered the challenge on L2
+	if isChallengeRegistered && !ok {
+		mbdfo, err := mirrorbridgeddefund.NewObjective(mirrorbridgeddefund.NewObjectiveRequest(l1ChannelId, state.SignedState{}, false), true, e.store.GetConsensusChannelById, true)
+		if err != nil {
+			return types.Destination{}, err
+		}
+
+		// Destroy the consensus channel to prevent it being used (Channel will now take over governance)
+		err = e.store.DestroyConsensusChannel(mbdfo.C.Id)
+		if err != nil {
+			return types.Destination{}, err
+		}
+
+		err = e.store.SetObjective(&mbdfo)
 		if err != nil {
 			return types.Destination{}, err
 		}
@@ -703,7 +1219,8 @@ func (e *Engine) checkAndProcessL2Channel(chainEvent chainservice.Event, isChall
 
 // handleObjectiveRequest handles an ObjectiveRequest (triggered by a client API call).
 // It will attempt to spawn a new, approved objective.
-func (e *Engine) handleObjectiveRequest(or protocols.ObjectiveRequest) (EngineEvent, error) {
+func (e *Engine) handleObjectiveRequest(request ObjectiveRequest) (ee EngineEvent, err error) {
+	or := request.ObjectiveRequest
 	myAddress := *e.store.GetAddress()
 
 	chainId, err := e.chain.GetChainId()
@@ -712,34 +1229,47 @@ func (e *Engine) handleObjectiveRequest(or protocols.ObjectiveRequest) (EngineEv
 	}
 
 	objectiveId := or.Id(myAddress, chainId)
-	failedEngineEvent := EngineEvent{FailedObjectives: []protocols.ObjectiveId{objectiveId}}
+
+	ctx := request.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, span := tracing.Tracer.Start(ctx, "handleObjectiveRequest", trace.WithAttributes(attribute.String("objective.id", string(objectiveId))))
+	defer func() { tracing.End(span, err) }()
+	e.setObjectiveTrace(objectiveId, ctx)
+
+	// fail reports the objective as failed with a reason derived from err
+	fail := func(err error) (EngineEvent, error) {
+		return EngineEvent{FailedObjectives: []protocols.FailedObjective{{ObjectiveId: objectiveId, Reason: failureReason(err)}}}, err
+	}
 	e.logger.Info("handling new objective request", logging.WithObjectiveIdAttribute(objectiveId))
+	metrics.ObjectivesStarted.WithLabelValues(objectiveTypeName(objectiveId)).Inc()
 	defer or.SignalObjectiveStarted()
 	switch request := or.(type) {
 
 	case virtualfund.ObjectiveRequest:
 		vfo, err := virtualfund.NewObjective(request, true, myAddress, chainId, e.store.GetConsensusChannel)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create virtualfund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create virtualfund objective for %+v: %w", request, err))
 		}
 		// Only Alice or Bob care about registering the objective and keeping track of vouchers
 		lastParticipant := uint(len(vfo.V.Participants) - 1)
 		if vfo.MyRole == lastParticipant || vfo.MyRole == payments.PAYER_INDEX {
-			err = e.registerPaymentChannel(vfo)
+			err = vfo.RegisterPaymentChannel(e.vm)
 			if err != nil {
-				return failedEngineEvent, fmt.Errorf("could not register channel with payment/receipt manager: %w", err)
+				return fail(fmt.Errorf("could not register channel with payment/receipt manager: %w", err))
 			}
 		}
 
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("could not register channel with payment/receipt manager: %w", err)
+			return fail(fmt.Errorf("could not register channel with payment/receipt manager: %w", err))
 		}
 		return e.attemptProgress(&vfo)
 
 	case swap.ObjectiveRequest:
 		so, err := swap.NewObjective(request, true, true, e.store.GetChannelById)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create swap objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create swap objective for %+v: %w", request, err))
 		}
 
 		pendingSwap, err := e.store.GetPendingSwapByChannelId(so.C.Id)
@@ -748,18 +1278,18 @@ func (e *Engine) handleObjectiveRequest(or protocols.ObjectiveRequest) (EngineEv
 		}
 
 		if pendingSwap != nil {
-			return failedEngineEvent, errSwapObjectiveExists
+			return fail(errSwapObjectiveExists)
 		}
 		return e.attemptProgress(&so)
 
 	case swapfund.ObjectiveRequest:
 		sfo, err := swapfund.NewObjective(request, true, myAddress, chainId, e.store.GetConsensusChannel)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create swapfund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create swapfund objective for %+v: %w", request, err))
 		}
 
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("could not register channel with swap manager: %w", err)
+			return fail(fmt.Errorf("could not register channel with swap manager: %w", err))
 		}
 		return e.attemptProgress(&sfo)
 
@@ -768,41 +1298,41 @@ func (e *Engine) handleObjectiveRequest(or protocols.ObjectiveRequest) (EngineEv
 		if e.vm.ChannelRegistered(request.ChannelId) {
 			paid, err := e.vm.Paid(request.ChannelId)
 			if err != nil {
-				return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create virtualdefund objective for %+v: %w", request, err)
+				return fail(fmt.Errorf("handleAPIEvent: Could not create virtualdefund objective for %+v: %w", request, err))
 			}
 			minAmount = paid
 		}
 		vdfo, err := virtualdefund.NewObjective(request, true, myAddress, minAmount, e.store.GetChannelById, e.store.GetConsensusChannel)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create virtualdefund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create virtualdefund objective for %+v: %w", request, err))
 		}
 		return e.attemptProgress(&vdfo)
 
 	case swapdefund.ObjectiveRequest:
 		sdfo, err := swapdefund.NewObjective(request, true, myAddress, e.store.GetChannelById, e.store.GetConsensusChannel)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create swapdefund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create swapdefund objective for %+v: %w", request, err))
 		}
 		return e.attemptProgress(&sdfo)
 
 	case directfund.ObjectiveRequest:
 		dfo, err := directfund.NewObjective(request, true, myAddress, chainId, e.store.GetChannelsByParticipant, e.store.GetConsensusChannel)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create directfund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create directfund objective for %+v: %w", request, err))
 		}
 		return e.attemptProgress(&dfo)
 
 	case directdefund.ObjectiveRequest:
 		ddfo, err := directdefund.NewObjective(request, true, e.store.GetConsensusChannelById, e.store.GetChannelById, e.vm.GetVoucherIfAmountPresent, false)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create directdefund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create directdefund objective for %+v: %w", request, err))
 		}
 
 		// Retaining the consensus channel only if ddfo is with challenge since it's needed to process a challenge-registered event for a virtual channel and obtain the ledger channel ID.
 		if !ddfo.IsChallenge {
 			err = e.store.DestroyConsensusChannel(request.ChannelId)
 			if err != nil {
-				return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not destroy consensus channel for %+v: %w", request, err)
+				return fail(fmt.Errorf("handleAPIEvent: Could not destroy consensus channel for %+v: %w", request, err))
 			}
 		}
 
@@ -810,39 +1340,39 @@ func (e *Engine) handleObjectiveRequest(or protocols.ObjectiveRequest) (EngineEv
 	case bridgedfund.ObjectiveRequest:
 		bfo, err := bridgedfund.NewObjective(request, true, myAddress, chainId, e.store.GetChannelsByParticipant, e.store.GetConsensusChannel)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create bridgedfund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create bridgedfund objective for %+v: %w", request, err))
 		}
 		return e.attemptProgress(&bfo)
 
 	case bridgeddefund.ObjectiveRequest:
 		bdfo, err := bridgeddefund.NewObjective(request, true, e.store.GetConsensusChannelById)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create bridgeddefund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create bridgeddefund objective for %+v: %w", request, err))
 		}
 
 		// Destroy the consensus channel to prevent it being used (Channel will now take over governance)
 		err = e.store.DestroyConsensusChannel(bdfo.C.Id)
 		if err != nil {
-			return failedEngineEvent, err
+			return fail(err)
 		}
 
 		return e.attemptProgress(&bdfo)
 	case mirrorbridgeddefund.ObjectiveRequest:
 		mbdfo, err := mirrorbridgeddefund.NewObjective(request, true, e.store.GetConsensusChannelById, true)
 		if err != nil {
-			return failedEngineEvent, fmt.Errorf("handleAPIEvent: Could not create mirrorbridgeddefund objective for %+v: %w", request, err)
+			return fail(fmt.Errorf("handleAPIEvent: Could not create mirrorbridgeddefund objective for %+v: %w", request, err))
 		}
 
 		// Destroy the consensus channel to prevent it being used (Channel will now take over governance)
 		err = e.store.DestroyConsensusChannel(mbdfo.C.Id)
 		if err != nil {
-			return failedEngineEvent, err
+			return fail(err)
 		}
 
 		return e.attemptProgress(&mbdfo)
 
 	default:
-		return failedEngineEvent, fmt.Errorf("handleAPIEvent: Unknown objective type %T", request)
+		return fail(fmt.Errorf("handleAPIEvent: Unknown objective type %T", request))
 	}
 }
 
@@ -857,10 +1387,11 @@ func (e *Engine) handlePaymentRequest(request PaymentRequest) (EngineEvent, erro
 	voucher, err := e.vm.Pay(
 		cId,
 		request.Amount,
-		*e.store.GetChannelSecretKey())
+		e.signer)
 	if err != nil {
 		return ee, fmt.Errorf("handleAPIEvent: Error making payment: %w", err)
 	}
+	metrics.RecordVoucher(metrics.Sent, request.Amount)
 	c, ok := e.store.GetChannelById(cId)
 	if !ok {
 		return ee, fmt.Errorf("handleAPIEvent: Could not get channel from the store %s", cId)
@@ -876,7 +1407,7 @@ func (e *Engine) handlePaymentRequest(request PaymentRequest) (EngineEvent, erro
 	ee.PaymentChannelUpdates = append(ee.PaymentChannelUpdates, info)
 
 	se := protocols.SideEffects{MessagesToSend: protocols.CreateVoucherMessage(voucher, payee)}
-	return ee, e.executeSideEffects(se)
+	return ee, e.executeSideEffects(context.Background(), "", se)
 }
 
 // handleCounterChallengeRequest handles a counter challenge request for the given channel.
@@ -940,40 +1471,6 @@ func (e *Engine) handleConfirmSwapRequest(request types.ConfirmSwapRequest) (Eng
 	return e.attemptProgress(o)
 }
 
-func (e *Engine) handleRetryObjectiveTxRequest(request types.RetryObjectiveTxRequest) error {
-	// Get objective from objective id
-	obj, err := e.store.GetObjectiveById(protocols.ObjectiveId(request.ObjectiveId))
-	if err != nil {
-		return &ErrGetObjective{wrappedError: err, objectiveId: protocols.ObjectiveId(request.ObjectiveId)}
-	}
-
-	// Based on objective type, send appropriate tx
-	switch objective := obj.(type) {
-	case *directfund.Objective:
-		droppedEvent := objective.GetDroppedEvent()
-		if droppedEvent.ChannelId.IsZero() {
-			return errEmptyDroppedEvent
-		}
-
-		objective.ResetTxSubmitted()
-		_, err = e.attemptProgress(objective)
-
-	case *directdefund.Objective:
-		droppedEvent := objective.GetDroppedEvent()
-		if droppedEvent.ChannelId.IsZero() {
-			return errEmptyDroppedEvent
-		}
-		objective.ResetWithDrawAllTxSubmitted()
-		_, err = e.attemptProgress(objective)
-	}
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // sendMessages sends out the messages and records the metrics.
 func (e *Engine) sendMessages(msgs []protocols.Message) {
 	defer e.wg.Done()
@@ -991,7 +1488,17 @@ func (e *Engine) sendMessages(msgs []protocols.Message) {
 }
 
 // executeSideEffects executes the SideEffects declared by cranking an Objective or handling a payment request.
-func (e *Engine) executeSideEffects(sideEffects protocols.SideEffects) error {
+// Outgoing messages carry the trace of ctx, so that the recipient's handling of them joins the same trace.
+// Transactions are submitted on behalf of the objective with the given id, which is empty if there is none.
+func (e *Engine) executeSideEffects(ctx context.Context, objectiveId protocols.ObjectiveId, sideEffects protocols.SideEffects) (err error) {
+	ctx, span := tracing.Tracer.Start(ctx, "executeSideEffects")
+	defer func() { tracing.End(span, err) }()
+
+	traceContext := tracing.Inject(ctx)
+	for i := range sideEffects.MessagesToSend {
+		sideEffects.MessagesToSend[i].TraceContext = traceContext
+	}
+
 	e.wg.Add(1)
 	// Send messages in a go routine so that we don't block on message delivery
 	go e.sendMessages(sideEffects.MessagesToSend)
@@ -999,7 +1506,7 @@ func (e *Engine) executeSideEffects(sideEffects protocols.SideEffects) error {
 	for _, tx := range sideEffects.TransactionsToSubmit {
 		e.logger.Info("Sending chain transaction", "channel", tx.ChannelId().String())
 
-		_, err := e.chain.SendTransaction(tx)
+		err := e.chain.SubmitTransaction(objectiveId, tx)
 		if err != nil {
 			return err
 		}
@@ -1013,19 +1520,28 @@ func (e *Engine) executeSideEffects(sideEffects protocols.SideEffects) error {
 
 // attemptProgress takes a "live" objective in memory and performs the following actions:
 //
-//  1. It pulls the secret key from the store
-//  2. It cranks the objective with that key
-//  3. It commits the cranked objective to the store
-//  4. It executes any side effects that were declared during cranking
-//  5. It updates progress metadata in the store
+//  1. It cranks the objective with the engine's signer
+//  2. It commits the cranked objective to the store
+//  3. It executes any side effects that were declared during cranking
+//  4. It updates progress metadata in the store
 func (e *Engine) attemptProgress(objective protocols.Objective) (outgoing EngineEvent, err error) {
-	secretKey := e.store.GetChannelSecretKey()
 	var crankedObjective protocols.Objective
 	var sideEffects protocols.SideEffects
 	var waitingFor protocols.WaitingFor
 
-	crankedObjective, sideEffects, waitingFor, err = objective.Crank(secretKey)
+	ctx, span := tracing.Tracer.Start(e.objectiveTrace(objective.Id()), "Crank", trace.WithAttributes(attribute.String("objective.id", string(objective.Id()))))
+	defer func() { tracing.End(span, err) }()
+
+	crankedObjective, sideEffects, waitingFor, err = objective.Crank(e.signer)
 	if err != nil {
+		if reason := failureReason(err); reason.Code == protocols.InsufficientLedgerFunds {
+			e.logger.Warn("Failing objective which cannot be funded", logging.WithObjectiveIdAttribute(objective.Id()), "err", err)
+			failed, err := e.failObjective(objective, reason)
+			if err != nil {
+				return EngineEvent{}, err
+			}
+			return failedEvent(failed, reason), nil
+		}
 		return
 	}
 
@@ -1033,6 +1549,10 @@ func (e *Engine) attemptProgress(objective protocols.Objective) (outgoing Engine
 	if err != nil {
 		return EngineEvent{}, err
 	}
+	err = e.recordProgress(crankedObjective, waitingFor, sideEffects)
+	if err != nil {
+		return EngineEvent{}, err
+	}
 
 	notifEvents, err := e.generateNotifications(crankedObjective)
 	if err != nil {
@@ -1041,6 +1561,7 @@ func (e *Engine) attemptProgress(objective protocols.Objective) (outgoing Engine
 	outgoing.Merge(notifEvents)
 
 	e.logger.Info("Objective cranked", logging.WithObjectiveIdAttribute(objective.Id()), "waiting-for", string(waitingFor))
+	span.SetAttributes(attribute.String("waiting-for", string(waitingFor)))
 
 	// If our protocol is waiting for nothing then we know the objective is complete
 	// TODO: If attemptProgress is called on a completed objective CompletedObjectives would include that objective id
@@ -1072,10 +1593,498 @@ func (e *Engine) attemptProgress(objective protocols.Objective) (outgoing Engine
 			return
 		}
 	}
-	err = e.executeSideEffects(sideEffects)
+	completed := waitingFor == "WaitingForNothing"
+	e.trackProgress(crankedObjective.Id(), waitingFor, completed)
+	err = e.recordPendingSideEffects(crankedObjective.Id(), sideEffects, completed)
+	if err != nil {
+		return
+	}
+
+	err = e.executeSideEffects(ctx, crankedObjective.Id(), sideEffects)
+	if err != nil {
+		return
+	}
+
+	if len(sideEffects.TransactionsToSubmit) > 0 && !completed {
+		err = e.recordTransactionsSubmitted(crankedObjective.Id())
+	}
 	return
 }
 
+// recordPendingSideEffects records the side effects declared by cranking an objective, so that recoverObjectives can replay them
+// if the node restarts before the objective completes. The messages of earlier cranks are kept until a crank declares new ones.
+// The record is removed once the objective completes.
+func (e *Engine) recordPendingSideEffects(id protocols.ObjectiveId, sideEffects protocols.SideEffects, completed bool) error {
+	if completed {
+		return e.store.RemovePendingSideEffects(id)
+	}
+	if len(sideEffects.MessagesToSend) == 0 && len(sideEffects.TransactionsToSubmit) == 0 {
+		return nil
+	}
+
+	pending, _, err := e.store.GetPendingSideEffects(id)
+	if err != nil {
+		return err
+	}
+	if len(sideEffects.MessagesToSend) > 0 {
+		pending.Messages = sideEffects.MessagesToSend
+	}
+	pending.UnsubmittedTransactions = len(sideEffects.TransactionsToSubmit) > 0
+	return e.store.SetPendingSideEffects(id, pending)
+}
+
+// recordTransactionsSubmitted records that the transactions declared by an objective's latest crank have been handed to the chain service
+func (e *Engine) recordTransactionsSubmitted(id protocols.ObjectiveId) error {
+	pending, ok, err := e.store.GetPendingSideEffects(id)
+	if err != nil || !ok {
+		return err
+	}
+	pending.UnsubmittedTransactions = false
+	return e.store.SetPendingSideEffects(id, pending)
+}
+
+// hasOutboxTxs returns true if the outbox holds any transaction submitted on behalf of the objective
+func (e *Engine) hasOutboxTxs(id protocols.ObjectiveId) bool {
+	txs, err := e.store.GetOutboxTxs()
+	if err != nil {
+		e.logger.Error("could not read the transaction outbox", logging.WithObjectiveIdAttribute(id), "err", err)
+		return false
+	}
+	return slices.ContainsFunc(txs, func(o chainservice.OutboxTx) bool { return o.ObjectiveId == id })
+}
+
+// recoverObjectives picks up the objectives left incomplete by a previous run of the node. For each objective it:
+//
+//  1. resends the messages declared by its latest crank, in case they were lost in the restart
+//  2. clears the objective's record of submitted transactions if they were never handed to the chain service, so that they are declared again.
+//     Transactions which reached the outbox are resubmitted by the chain service instead.
+//  3. cranks the objective
+//
+// An objective which cannot be recovered is logged and skipped, so that it does not prevent the node from starting.
+func (e *Engine) recoverObjectives() (EngineEvent, error) {
+	objectives, err := e.store.GetIncompleteObjectives()
+	if err != nil {
+		return EngineEvent{}, err
+	}
+
+	outgoing := EngineEvent{}
+	for _, objective := range objectives {
+		e.logger.Info("Recovering objective", logging.WithObjectiveIdAttribute(objective.Id()))
+
+		pending, ok, err := e.store.GetPendingSideEffects(objective.Id())
+		if err != nil {
+			return EngineEvent{}, err
+		}
+		if ok && len(pending.Messages) > 0 {
+			e.resendMessages(pending.Messages)
+		}
+		if ok && pending.UnsubmittedTransactions && !e.hasOutboxTxs(objective.Id()) {
+			resetTransactionsSubmitted(objective)
+		}
+
+		ee, err := e.attemptProgress(objective)
+		if err != nil {
+			e.logger.Error("could not recover objective", logging.WithObjectiveIdAttribute(objective.Id()), "err", err)
+			continue
+		}
+		outgoing.Merge(ee)
+	}
+	return outgoing, nil
+}
+
+// restoreUnapprovedObjectives decides again on the stored unapproved objectives, since pending approvals are only held in memory
+// and the policy may have changed since they were received. Each is approved and cranked, rejected, or parked until an operator
+// decides on it, as if it had just been received.
+// An objective which cannot be restored is logged and skipped, so that it does not prevent the node from starting.
+func (e *Engine) restoreUnapprovedObjectives() (EngineEvent, error) {
+	objectives, err := e.store.GetObjectives(store.ObjectiveFilter{Statuses: []protocols.ObjectiveStatus{protocols.Unapproved}})
+	if err != nil {
+		return EngineEvent{}, err
+	}
+
+	outgoing := EngineEvent{}
+	for _, objective := range objectives {
+		e.logger.Info("Restoring unapproved objective", logging.WithObjectiveIdAttribute(objective.Id()))
+
+		decided, parked, ee, err := e.decideUnapprovedObjective(objective)
+		outgoing.Merge(ee)
+		if err != nil {
+			e.logger.Error("could not restore objective", logging.WithObjectiveIdAttribute(objective.Id()), "err", err)
+			continue
+		}
+		if parked || isCompleted(ee, objective.Id()) {
+			continue
+		}
+
+		ee, err = e.attemptProgress(decided)
+		if err != nil {
+			e.logger.Error("could not restore objective", logging.WithObjectiveIdAttribute(objective.Id()), "err", err)
+			continue
+		}
+		outgoing.Merge(ee)
+	}
+	return outgoing, nil
+}
+
+// resendMessages sends the messages declared by an earlier crank of an objective again
+func (e *Engine) resendMessages(msgs []protocols.Message) {
+	e.wg.Add(1)
+	go e.sendMessages(msgs)
+}
+
+// recordProgress adds an entry to the progress history of the objective if its status or what it is waiting for has changed,
+// or if side effects were declared.
+func (e *Engine) recordProgress(objective protocols.Objective, waitingFor protocols.WaitingFor, sideEffects protocols.SideEffects) error {
+	entry := store.ObjectiveProgress{Time: time.Now(), Status: objective.GetStatus(), WaitingFor: waitingFor}
+	for _, msg := range sideEffects.MessagesToSend {
+		entry.MessagesTo = append(entry.MessagesTo, msg.To)
+	}
+	for _, tx := range sideEffects.TransactionsToSubmit {
+		entry.Transactions = append(entry.Transactions, strings.TrimPrefix(fmt.Sprintf("%T", tx), "protocols."))
+	}
+
+	if len(entry.MessagesTo) == 0 && len(entry.Transactions) == 0 {
+		history, err := e.store.GetObjectiveProgress(objective.Id())
+		if err != nil {
+			return err
+		}
+		if len(history) > 0 && history[len(history)-1].Status == entry.Status && history[len(history)-1].WaitingFor == entry.WaitingFor {
+			return nil
+		}
+	}
+	return e.store.AddObjectiveProgress(objective.Id(), entry)
+}
+
+// trackProgress records what an objective is waiting for after a crank. The deadline set by a Reaper restarts whenever this changes.
+func (e *Engine) trackProgress(id protocols.ObjectiveId, waitingFor protocols.WaitingFor, completed bool) {
+	if completed {
+		e.untrackProgress(id)
+		return
+	}
+	e.progressMu.Lock()
+	defer e.progressMu.Unlock()
+	if p, ok := e.progress[id]; ok && p.waitingFor == waitingFor {
+		return
+	}
+	e.progress[id] = objectiveProgress{waitingFor: waitingFor, since: time.Now()}
+}
+
+// untrackProgress stops tracking an objective which has finished
+func (e *Engine) untrackProgress(id protocols.ObjectiveId) {
+	e.progressMu.Lock()
+	defer e.progressMu.Unlock()
+	delete(e.progress, id)
+}
+
+// restartDeadline restarts the deadline of an objective, which keeps waiting for the same thing
+func (e *Engine) restartDeadline(id protocols.ObjectiveId) {
+	e.progressMu.Lock()
+	defer e.progressMu.Unlock()
+	if p, ok := e.progress[id]; ok {
+		e.progress[id] = objectiveProgress{waitingFor: p.waitingFor, since: time.Now()}
+	}
+}
+
+// reapStalledObjectives acts on every objective which has waited on the same thing for longer than the deadline set by the Reaper.
+// Each objective which missed its deadline is reported in FailedObjectives, whatever the action taken.
+func (e *Engine) reapStalledObjectives() (EngineEvent, error) {
+	now := time.Now()
+	stalled := map[protocols.ObjectiveId]protocols.WaitingFor{}
+	e.progressMu.Lock()
+	for id, p := range e.progress {
+		deadline, ok := e.reaper.Deadline(id)
+		if ok && now.Sub(p.since) >= deadline.Timeout {
+			stalled[id] = p.waitingFor
+		}
+	}
+	e.progressMu.Unlock()
+
+	outgoing := EngineEvent{}
+	for id, waitingFor := range stalled {
+		deadline, _ := e.reaper.Deadline(id)
+		e.logger.Warn("Objective missed its deadline", logging.WithObjectiveIdAttribute(id), "waiting-for", string(waitingFor), "action", string(deadline.Action))
+
+		reason := protocols.FailureReason{
+			Code:    protocols.Timeout,
+			Message: fmt.Sprintf("waited for %s for longer than %v, action taken: %s", waitingFor, deadline.Timeout, deadline.Action),
+		}
+		ee, err := e.reapObjective(id, deadline.Action, reason)
+		if err != nil {
+			// The other stalled objectives are still reaped, and retried on a later tick should they stay stalled
+			e.logger.Error("Could not reap objective", logging.WithObjectiveIdAttribute(id), "error", err)
+			continue
+		}
+		outgoing.Merge(ee)
+		if !slices.ContainsFunc(ee.FailedObjectives, func(f protocols.FailedObjective) bool { return f.ObjectiveId == id }) {
+			outgoing.FailedObjectives = append(outgoing.FailedObjectives, protocols.FailedObjective{ObjectiveId: id, Reason: reason})
+		}
+	}
+	return outgoing, nil
+}
+
+// reapObjective takes the supplied action on an objective which has missed its deadline.
+// A directdefund objective which is already challenging has its messages resent instead of being escalated again.
+// If the objective is failed, the reason is recorded and sent to the other participants.
+func (e *Engine) reapObjective(id protocols.ObjectiveId, action ReaperAction, reason protocols.FailureReason) (EngineEvent, error) {
+	objective, err := e.store.GetObjectiveById(id)
+	if err != nil {
+		return EngineEvent{}, &ErrGetObjective{err, id}
+	}
+	if objective.GetStatus() != protocols.Approved {
+		e.untrackProgress(id)
+		return EngineEvent{}, nil
+	}
+
+	switch action {
+	case ReaperFail:
+		failed, err := e.failObjective(objective, reason)
+		if err != nil {
+			return EngineEvent{}, err
+		}
+		return failedEvent(failed, reason), nil
+	case ReaperChallenge:
+		if objectiveType, ok := registry.Lookup(id); ok && objectiveType.Policy.Challenge != nil {
+			if challenging, escalated := objectiveType.Policy.Challenge(objective); escalated {
+				return e.attemptProgress(challenging)
+			}
+		}
+	}
+
+	pending, ok, err := e.store.GetPendingSideEffects(id)
+	if err != nil {
+		return EngineEvent{}, err
+	}
+	if ok && len(pending.Messages) > 0 {
+		e.resendMessages(pending.Messages)
+	}
+	e.restartDeadline(id)
+	return EngineEvent{}, nil
+}
+
+// failObjective abandons an objective: it is rejected for the given reason with the other participants notified, its channel is released
+// and any ledger funding it has added or proposed is rolled back. The rejected objective is returned.
+func (e *Engine) failObjective(objective protocols.Objective, reason protocols.FailureReason) (protocols.Objective, error) {
+	objective, sideEffects, err := e.rejectWithReason(objective, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if channelId := objective.OwnsChannel(); !channelId.IsZero() {
+		err = e.store.ReleaseChannelFromOwnership(channelId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = e.store.RemovePendingSideEffects(objective.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.executeSideEffects(e.objectiveTrace(objective.Id()), objective.Id(), sideEffects)
+	if err != nil {
+		return nil, err
+	}
+	return objective, e.rollbackLedgerFunding(objective)
+}
+
+// handleCancelObjectiveRequest handles a request (triggered by a client API call) to cancel an objective which has not finished.
+// The objective is failed as if it had missed a deadline, and reported as completed so that anyone waiting on it is released.
+func (e *Engine) handleCancelObjectiveRequest(request CancelObjectiveRequest) (EngineEvent, error) {
+	objective, err := e.store.GetObjectiveById(request.ObjectiveId)
+	if err != nil {
+		return EngineEvent{}, &ErrGetObjective{err, request.ObjectiveId}
+	}
+	if status := objective.GetStatus(); status != protocols.Unapproved && status != protocols.Approved {
+		return EngineEvent{}, fmt.Errorf("%w: %s has status %v", errObjectiveNotCancellable, request.ObjectiveId, status)
+	}
+	if e.approvalQueue != nil {
+		e.approvalQueue.Remove(request.ObjectiveId)
+	}
+
+	e.logger.Info("Cancelling objective", logging.WithObjectiveIdAttribute(objective.Id()))
+	reason := protocols.FailureReason{Code: protocols.Cancellation, Message: "cancelled by operator"}
+	cancelled, err := e.failObjective(objective, reason)
+	if err != nil {
+		return EngineEvent{}, err
+	}
+	return failedEvent(cancelled, reason), nil
+}
+
+// rollbackLedgerFunding reverses the ledger funding of a rejected virtualfund or swapfund objective, freeing the ledger funds it has locked up.
+// On each ledger we lead, the removal of every guarantee the objective has added or proposed is proposed.
+// On each ledger we follow, the leader's rollback proposals are countersigned.
+func (e *Engine) rollbackLedgerFunding(objective protocols.Objective) error {
+	rollbacks := [][]consensus_channel.Proposal{}
+	switch o := objective.(type) {
+	case *virtualfund.Objective:
+		for _, c := range []*virtualfund.Connection{o.ToMyLeft, o.ToMyRight} {
+			if c != nil {
+				rollbacks = append(rollbacks, c.RollbackProposals())
+			}
+		}
+	case *swapfund.Objective:
+		for _, c := range []*swapfund.Connection{o.ToMyLeft, o.ToMyRight} {
+			if c != nil {
+				rollbacks = append(rollbacks, c.RollbackProposals())
+			}
+		}
+	}
+
+	for _, removals := range rollbacks {
+		if len(removals) == 0 {
+			continue
+		}
+		ledgerId := removals[0].LedgerID
+		cc, err := e.store.GetConsensusChannelById(ledgerId)
+		if err != nil {
+			return err
+		}
+
+		if cc.IsFollower() {
+			err = e.countersignRollbackProposals(ledgerId)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		proposed := false
+		for _, removal := range removals {
+			_, err = cc.Propose(removal, e.signer)
+			if errors.Is(err, consensus_channel.ErrGuaranteeNotFound) {
+				// The guarantee was never proposed, or its removal has been proposed already
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			proposed = true
+		}
+		if !proposed {
+			continue
+		}
+
+		err = e.store.SetConsensusChannel(cc)
+		if err != nil {
+			return err
+		}
+		e.logger.Info("Proposing ledger funding rollback", logging.WithObjectiveIdAttribute(objective.Id()), "ledger", ledgerId.String())
+		message := protocols.CreateSignedProposalMessage(cc.Follower(), cc.ProposalQueue()...)
+		err = e.executeSideEffects(e.objectiveTrace(objective.Id()), objective.Id(), protocols.SideEffects{MessagesToSend: []protocols.Message{message}})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getRolledBackObjective returns the funding objective whose guarantee the proposal adds or removes, if the proposal is rolling back that funding
+// rather than being made by a running virtualfund, swapfund, virtualdefund or swapdefund objective.
+// That is the case for an Add targeting a rejected objective, and for a Remove targeting an unfinished objective without a defunding objective.
+func (e *Engine) getRolledBackObjective(p consensus_channel.Proposal) (protocols.Objective, bool) {
+	c, ok := e.store.GetChannelById(p.Target())
+	if !ok {
+		return nil, false
+	}
+
+	fundingId, ok := registry.ProposalObjectiveId(consensus_channel.AddProposal, p.Target(), c.Type)
+	if !ok {
+		return nil, false
+	}
+	funding, err := e.store.GetObjectiveById(fundingId)
+	if err != nil {
+		return nil, false
+	}
+
+	if p.Type() == consensus_channel.AddProposal {
+		return funding, funding.GetStatus() == protocols.Rejected
+	}
+	if funding.GetStatus() == protocols.Completed {
+		return nil, false
+	}
+	defundingId, err := getProposalObjectiveId(p, c.Type)
+	if err != nil {
+		return funding, true
+	}
+	_, err = e.store.GetObjectiveById(defundingId)
+	return funding, err != nil
+}
+
+// receiveRollbackProposal applies a proposal rolling back ledger funding to the ledger, and countersigns it if we are the follower and it is next in turn.
+func (e *Engine) receiveRollbackProposal(sp consensus_channel.SignedProposal) error {
+	cc, err := e.store.GetConsensusChannelById(sp.Proposal.LedgerID)
+	if err != nil {
+		return err
+	}
+
+	err = cc.Receive(sp)
+	// Leaders resend their whole proposal queue, so proposals we have already received are ignored
+	if err != nil && !errors.Is(err, consensus_channel.ErrInvalidTurnNum) {
+		return err
+	}
+	err = e.store.SetConsensusChannel(cc)
+	if err != nil {
+		return err
+	}
+
+	if cc.IsLeader() {
+		return nil
+	}
+	return e.countersignRollbackProposals(cc.Id)
+}
+
+// countersignRollbackProposals countersigns the proposals at the front of a ledger's queue which roll back the funding of rejected objectives.
+// An Add is only countersigned once the Remove reversing it has been proposed too, so that the ledger funds are never locked up by a cancelled objective.
+func (e *Engine) countersignRollbackProposals(ledgerId types.Destination) error {
+	cc, err := e.store.GetConsensusChannelById(ledgerId)
+	if err != nil {
+		return err
+	}
+
+	signed := []consensus_channel.SignedProposal{}
+	for len(cc.ProposalQueue()) > 0 {
+		next := cc.ProposalQueue()[0].Proposal
+		funding, isRollback := e.getRolledBackObjective(next)
+		if !isRollback || funding.GetStatus() != protocols.Rejected {
+			break
+		}
+		if next.Type() == consensus_channel.AddProposal && !cc.HasRemovalBeenProposed(next.Target(), next.ToAdd.AssetAddress) {
+			break
+		}
+
+		sp, err := cc.SignNextProposal(next, e.signer)
+		if err != nil {
+			return err
+		}
+		signed = append(signed, sp)
+	}
+	if len(signed) == 0 {
+		return nil
+	}
+
+	err = e.store.SetConsensusChannel(cc)
+	if err != nil {
+		return err
+	}
+
+	sideEffects := protocols.SideEffects{MessagesToSend: []protocols.Message{protocols.CreateSignedProposalMessage(cc.Leader(), signed...)}}
+	// Proposals queued behind the rollback belong to other objectives, which may now be able to progress
+	if proposals := cc.ProposalQueue(); len(proposals) != 0 {
+		sideEffects.ProposalsToProcess = append(sideEffects.ProposalsToProcess, proposals[0].Proposal)
+	}
+	return e.executeSideEffects(context.Background(), "", sideEffects)
+}
+
+// resetTransactionsSubmitted clears an objective's record of having submitted its transactions, so that the next crank declares them again
+func resetTransactionsSubmitted(objective protocols.Objective) {
+	switch o := objective.(type) {
+	case *directfund.Objective:
+		o.ResetTxSubmitted()
+	case *directdefund.Objective:
+		o.ResetWithDrawAllTxSubmitted()
+	}
+}
+
 // generateNotifications takes an objective and constructs notifications for any related channels for that objective.
 func (e *Engine) generateNotifications(o protocols.Objective) (EngineEvent, error) {
 	outgoing := EngineEvent{}
@@ -1133,15 +2142,6 @@ func (e *Engine) generateNotifications(o protocols.Objective) (EngineEvent, erro
 	return outgoing, nil
 }
 
-func (e Engine) registerPaymentChannel(vfo virtualfund.Objective) error {
-	postfund := vfo.V.PostFundState()
-	startingBalance := big.NewInt(0)
-	// TODO: Assumes one asset for now
-	startingBalance.Set(postfund.Outcome[0].Allocations[0].Amount)
-
-	return e.vm.Register(vfo.V.Id, payments.GetPayer(postfund.Participants), payments.GetPayee(postfund.Participants), startingBalance)
-}
-
 // spawnConsensusChannel will attempt to create and store a ConsensusChannel derived from the supplied Objective if it is a directfund.Objective or bridgedfund.Objective.
 // The associated Channel will remain in the store.
 func (e Engine) spawnConsensusChannel(crankedObjective protocols.Objective, createChannelFunc func() (*consensus_channel.ConsensusChannel, error)) error {
@@ -1251,6 +2251,7 @@ func (e *Engine) getOrCreateObjective(p protocols.ObjectivePayload) (protocols.O
 			return nil, fmt.Errorf("error setting objective in store: %w", err)
 		}
 		e.logger.Info("Created new objective from message", "id", id)
+		metrics.ObjectivesStarted.WithLabelValues(objectiveTypeName(id)).Inc()
 
 		return newObj, nil
 
@@ -1262,87 +2263,45 @@ func (e *Engine) getOrCreateObjective(p protocols.ObjectivePayload) (protocols.O
 // constructObjectiveFromMessage Constructs a new objective (of the appropriate concrete type) from the supplied payload.
 func (e *Engine) constructObjectiveFromMessage(id protocols.ObjectiveId, p protocols.ObjectivePayload) (protocols.Objective, error) {
 	e.logger.Info("Constructing objective from message", logging.WithObjectiveIdAttribute(id))
-	switch {
-	case directfund.IsDirectFundObjective(id):
-
-		dfo, err := directfund.ConstructFromPayload(false, p, *e.store.GetAddress())
-		return &dfo, err
-	case virtualfund.IsVirtualFundObjective(id):
-		vfo, err := virtualfund.ConstructObjectiveFromPayload(p, false, *e.store.GetAddress(), e.store.GetConsensusChannel)
-		if err != nil {
-			return &virtualfund.Objective{}, fromMsgErr(id, err)
-		}
-		err = e.registerPaymentChannel(vfo)
-		if err != nil {
-			return &virtualfund.Objective{}, fmt.Errorf("could not register channel with payment/receipt manager.\n\ttarget channel: %s\n\terr: %w", id, err)
-		}
-		return &vfo, nil
-	case swapfund.IsSwapFundObjective(id):
-		sfo, err := swapfund.ConstructObjectiveFromPayload(p, false, *e.store.GetAddress(), e.store.GetConsensusChannel)
-		if err != nil {
-			return &swapfund.Objective{}, fromMsgErr(id, err)
-		}
-
-		return &sfo, nil
-	case swap.IsSwapObjective(id):
-		so, err := swap.ConstructObjectiveFromPayload(p, false, e.store.GetChannelById, *e.store.GetAddress())
-		return &so, err
-	case virtualdefund.IsVirtualDefundObjective(id):
-		vId, err := virtualdefund.GetVirtualChannelFromObjectiveId(id)
-		if err != nil {
-			return &virtualdefund.Objective{}, fmt.Errorf("could not determine virtual channel id from objective %s: %w", id, err)
-		}
-		minAmount := big.NewInt(0)
-		if e.vm.ChannelRegistered(vId) {
-			paid, err := e.vm.Paid(vId)
-			if err != nil {
-				return &virtualdefund.Objective{}, fmt.Errorf("could not determine virtual channel id from objective %s: %w", id, err)
-			}
-			minAmount = paid
-		}
-
-		vdfo, err := virtualdefund.ConstructObjectiveFromPayload(p, false, *e.store.GetAddress(), e.store.GetChannelById, e.store.GetConsensusChannel, minAmount)
-		if err != nil {
-			return &virtualfund.Objective{}, fromMsgErr(id, err)
-		}
-		return &vdfo, nil
-	case swapdefund.IsSwapDefundObjective(id):
-		sdfo, err := swapdefund.ConstructObjectiveFromPayload(p, false, *e.store.GetAddress(), e.store.GetChannelById, e.store.GetConsensusChannel)
-		if err != nil {
-			return &virtualfund.Objective{}, fromMsgErr(id, err)
-		}
-		return &sdfo, nil
-	case directdefund.IsDirectDefundObjective(id):
-		ddfo, err := directdefund.ConstructObjectiveFromPayload(p, false, e.store.GetConsensusChannelById, e.store.GetChannelById, e.vm.GetVoucherIfAmountPresent)
-		if err != nil {
-			return &directdefund.Objective{}, fromMsgErr(id, err)
-		}
-		return &ddfo, nil
+	objectiveType, ok := registry.Lookup(id)
+	if !ok {
+		return nil, errors.New("cannot handle unimplemented objective type")
+	}
 
-	case bridgedfund.IsBridgedFundObjective(id):
-		bfo, err := bridgedfund.ConstructFromPayload(false, p, *e.store.GetAddress())
-		if err != nil {
-			return &bridgedfund.Objective{}, fromMsgErr(id, err)
-		}
-		return &bfo, err
+	objective, err := objectiveType.ConstructFromPayload(p, registry.Dependencies{
+		Address:                 *e.store.GetAddress(),
+		GetChannelById:          e.store.GetChannelById,
+		GetConsensusChannel:     e.store.GetConsensusChannel,
+		GetConsensusChannelById: e.store.GetConsensusChannelById,
+		Vouchers:                e.vm,
+	})
+	if err != nil {
+		return nil, fromMsgErr(id, err)
+	}
+	return objective, nil
+}
 
-	case bridgeddefund.IsBridgedDefundObjective(id):
-		bdfo, err := bridgeddefund.ConstructObjectiveFromPayload(p, false, e.store.GetConsensusChannelById)
-		if err != nil {
-			return &bridgeddefund.Objective{}, fromMsgErr(id, err)
-		}
-		return &bdfo, nil
+// objectiveTypeName returns the name of the type of an objective, e.g. "DirectFunding", for use as a metric label
+func objectiveTypeName(id protocols.ObjectiveId) string {
+	if objectiveType, ok := registry.Lookup(id); ok {
+		return objectiveType.Name()
+	}
+	return "Unknown"
+}
 
-	case mirrorbridgeddefund.IsMirrorBridgedDefundObjective(id):
-		mbdfo, err := mirrorbridgeddefund.ConstructObjectiveFromPayload(p, false, e.store.GetConsensusChannelById)
-		if err != nil {
-			return &mirrorbridgeddefund.Objective{}, fromMsgErr(id, err)
-		}
-		return &mbdfo, nil
+// setObjectiveTrace records the span context of ctx as the one which the next crank of the objective continues
+func (e *Engine) setObjectiveTrace(id protocols.ObjectiveId, ctx context.Context) {
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		e.traces.Store(string(id), sc)
+	}
+}
 
-	default:
-		return &directfund.Objective{}, errors.New("cannot handle unimplemented objective type")
+// objectiveTrace returns a context holding the span context which the next crank of the objective continues
+func (e *Engine) objectiveTrace(id protocols.ObjectiveId) context.Context {
+	if sc, ok := e.traces.Load(string(id)); ok {
+		return trace.ContextWithSpanContext(context.Background(), sc)
 	}
+	return context.Background()
 }
 
 // fromMsgErr wraps errors from objective construction functions and
@@ -1352,41 +2311,12 @@ func fromMsgErr(id protocols.ObjectiveId, err error) error {
 }
 
 // getProposalObjectiveId returns the objectiveId for a proposal.
-func getProposalObjectiveId(p consensus_channel.Proposal, channelType types.ChannelType) protocols.ObjectiveId {
-	switch p.Type() {
-	case consensus_channel.AddProposal:
-		{
-			var prefix string
-
-			if channelType == types.Swap {
-				prefix = swapfund.ObjectivePrefix
-			} else {
-				prefix = virtualfund.ObjectivePrefix
-			}
-
-			channelId := p.ToAdd.Guarantee.Target().String()
-			return protocols.ObjectiveId(prefix + channelId)
-
-		}
-	case consensus_channel.RemoveProposal:
-		{
-			var prefix string
-
-			if channelType == types.Swap {
-				prefix = swapdefund.ObjectivePrefix
-			} else {
-				prefix = virtualdefund.ObjectivePrefix
-			}
-
-			channelId := p.ToRemove.Target.String()
-			return protocols.ObjectiveId(prefix + channelId)
-
-		}
-	default:
-		{
-			panic("invalid proposal type")
-		}
+func getProposalObjectiveId(p consensus_channel.Proposal, channelType types.ChannelType) (protocols.ObjectiveId, error) {
+	id, ok := registry.ProposalObjectiveId(p.Type(), p.Target(), channelType)
+	if !ok {
+		return "", fmt.Errorf("no objective type makes %s proposals targeting channel %s", p.Type(), p.Target())
 	}
+	return id, nil
 }
 
 // GetConsensusAppAddress returns the address of a deployed ConsensusApp (for ledger channels)
@@ -1399,6 +2329,28 @@ func (e *Engine) GetVirtualPaymentAppAddress() types.Address {
 	return e.chain.GetVirtualPaymentAppAddress()
 }
 
+// POLICY_DECISION_RETENTION is how long the decisions of the policy maker are kept in the store
+const POLICY_DECISION_RETENTION = 30 * 24 * time.Hour
+
+// recordPolicyDecision persists and returns the decision the policy maker made about the objective, if the policy maker records its decisions.
+// Decisions older than POLICY_DECISION_RETENTION are pruned at the same time.
+func (e *Engine) recordPolicyDecision(id protocols.ObjectiveId) (store.PolicyDecision, error) {
+	recorder, ok := FindPolicyMaker[DecisionRecorder](e.policymaker)
+	if !ok {
+		return store.PolicyDecision{}, nil
+	}
+	decision, ok := recorder.TakeDecision(id)
+	if !ok {
+		return store.PolicyDecision{}, nil
+	}
+
+	err := e.store.SetPolicyDecision(decision)
+	if err != nil {
+		return store.PolicyDecision{}, fmt.Errorf("error recording policy decision: %w", err)
+	}
+	return decision, e.store.PrunePolicyDecisions(decision.DecidedAt.Add(-POLICY_DECISION_RETENTION))
+}
+
 type messageDirection string
 
 const (
@@ -1468,16 +2420,31 @@ func (e *Engine) checkError(err error) {
 	if err != nil {
 		e.logger.Error("error in run loop", "err", err)
 
-		for _, nonFatalError := range nonFatalErrors {
-			if errors.Is(err, nonFatalError) {
-				return
-			}
+		if isNonFatal(err) {
+			return
 		}
 
 		panic(err)
 	}
 }
 
+// isNonFatal returns true if the engine should carry on after the error
+func isNonFatal(err error) bool {
+	for _, nonFatalError := range nonFatalErrors {
+		if errors.Is(err, nonFatalError) {
+			return true
+		}
+	}
+	return false
+}
+
+// acknowledgeMessage tells the message service that a received message has been handled, if it waits to be told
+func (e *Engine) acknowledgeMessage(message protocols.Message) {
+	if acknowledger, ok := e.msg.(messageservice.MessageAcknowledger); ok {
+		acknowledger.MessageHandled(message)
+	}
+}
+
 func (e *Engine) GetNodeInfo() types.NodeInfo {
 	return types.NodeInfo{
 		SCAddress:            e.store.GetAddress().String(),
@@ -1548,13 +2515,13 @@ func (e *Engine) rejectSwapIfPendingExists(currentSwapObjective *swap.Objective)
 			objectiveToReject = currentSwapObjective
 		}
 
-		objectiveToReject, sideEffects := objectiveToReject.Reject()
-		err = e.store.SetObjective(objectiveToReject)
+		reason := protocols.FailureReason{Code: protocols.OtherFailure, Message: "conflicts with another pending swap"}
+		objectiveToReject, sideEffects, err := e.rejectWithReason(objectiveToReject, reason)
 		if err != nil {
 			return nil, err
 		}
 
-		err = e.executeSideEffects(sideEffects)
+		err = e.executeSideEffects(e.objectiveTrace(objectiveToReject.Id()), objectiveToReject.Id(), sideEffects)
 		if err != nil {
 			return nil, err
 		}
@@ -19,3 +19,9 @@ type MessageService interface {
 
 	Id() peer.ID
 }
+
+// MessageAcknowledger is implemented by message services which acknowledge a received message only once it is handled.
+type MessageAcknowledger interface {
+	// MessageHandled is called once the effects of a received message are stored
+	MessageHandled(protocols.Message)
+}
@@ -0,0 +1,297 @@
+package p2pms
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/statechannels/go-nitro/internal/metrics"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// envelope wraps a message sent with RELIABLE_MSG_PROTOCOL_ID, so that the recipient can acknowledge and deduplicate it
+type envelope struct {
+	Id      string          `json:"id"`
+	Message json.RawMessage `json:"message"`
+}
+
+// ack is written back by the recipient of an envelope once it has accepted the message
+type ack struct {
+	Id string `json:"id"`
+}
+
+// newMessageId returns an outbox message id. Ids increase monotonically, so that the outbox returns messages in the order they were sent.
+func (ms *P2PMessageService) newMessageId() string {
+	ms.idMu.Lock()
+	defer ms.idMu.Unlock()
+
+	id := uint64(time.Now().UnixNano())
+	if id <= ms.lastId {
+		id = ms.lastId + 1
+	}
+	ms.lastId = id
+	return fmt.Sprintf("%020d", id)
+}
+
+// wakeDelivery signals the delivery worker for the given peer that there are messages in the outbox, starting the worker if needed
+func (ms *P2PMessageService) wakeDelivery(to types.Address) {
+	wake, loaded := ms.deliveries.LoadOrStore(to.String(), make(chan struct{}, 1))
+	if !loaded {
+		ms.wg.Add(1)
+		go ms.deliver(to, wake)
+	}
+
+	select {
+	case wake <- struct{}{}:
+	default: // the worker has already been woken
+	}
+}
+
+// resumeDeliveries starts delivery workers for every peer with messages left in the outbox, e.g. by a previous run of the node
+func (ms *P2PMessageService) resumeDeliveries() error {
+	depths, err := ms.outbox.GetOutboxDepths()
+	if err != nil {
+		return err
+	}
+	for to, depth := range depths {
+		ms.logger.Info("resuming delivery of outbox messages", "to", to.String(), "depth", depth)
+		ms.wakeDelivery(to)
+	}
+	return nil
+}
+
+// deliver delivers the outbox messages addressed to a peer in order, until the message service is closed.
+// A message which cannot be delivered is retried with exponential backoff, and blocks later messages to the same peer.
+func (ms *P2PMessageService) deliver(to types.Address, wake <-chan struct{}) {
+	defer ms.wg.Done()
+
+	backoff := MIN_RETRY_BACKOFF
+	for {
+		msgs, err := ms.outbox.GetOutboxMessages(to)
+		if err == nil && len(msgs) == 0 {
+			select {
+			case <-wake:
+				continue
+			case <-ms.ctx.Done():
+				return
+			}
+		}
+
+		if err == nil {
+			err = ms.deliverMessages(to, msgs)
+		}
+		if err == nil {
+			backoff = MIN_RETRY_BACKOFF
+			continue
+		}
+
+		ms.logger.Warn("could not deliver message, retrying", "to", to.String(), "err", err, "retryIn", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ms.ctx.Done():
+			return
+		}
+		backoff = min(2*backoff, MAX_RETRY_BACKOFF)
+	}
+}
+
+// deliverMessages sends each message in turn, removing it from the outbox once the peer acknowledges it.
+// It returns the first error encountered.
+func (ms *P2PMessageService) deliverMessages(to types.Address, msgs []store.OutboxMessage) error {
+	peerId, err := ms.getPeerId(to)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range msgs {
+		err = ms.deliverMessage(peerId, m)
+		if err != nil {
+			return err
+		}
+		metrics.MessagesSent.WithLabelValues(to.String()).Inc()
+		err = ms.outbox.RemoveOutboxMessage(to, m.Id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deliverMessage sends a message to the peer and waits for it to be acknowledged.
+// Peers which do not support RELIABLE_MSG_PROTOCOL_ID are sent the bare message, which is treated as delivered once written.
+func (ms *P2PMessageService) deliverMessage(peerId peer.ID, m store.OutboxMessage) error {
+	ctx, cancel := context.WithTimeout(ms.ctx, ACK_TIMEOUT)
+	defer cancel()
+
+	s, err := ms.p2pHost.NewStream(ctx, peerId, RELIABLE_MSG_PROTOCOL_ID, GENERAL_MSG_PROTOCOL_ID)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	err = s.SetDeadline(time.Now().Add(ACK_TIMEOUT))
+	if err != nil {
+		return err
+	}
+
+	raw := m.Payload
+	if s.Protocol() == RELIABLE_MSG_PROTOCOL_ID {
+		rawEnvelope, err := json.Marshal(envelope{Id: m.Id, Message: json.RawMessage(m.Payload)})
+		if err != nil {
+			return err
+		}
+		raw = string(rawEnvelope)
+	}
+
+	writer := bufio.NewWriter(s)
+	_, err = writer.WriteString(raw + string(DELIMITER))
+	if err != nil {
+		return err
+	}
+	err = writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	if s.Protocol() != RELIABLE_MSG_PROTOCOL_ID {
+		return nil
+	}
+
+	rawAck, err := bufio.NewReader(s).ReadString(DELIMITER)
+	if err != nil {
+		return fmt.Errorf("no acknowledgement received: %w", err)
+	}
+	var a ack
+	err = json.Unmarshal([]byte(rawAck), &a)
+	if err != nil {
+		return err
+	}
+	if a.Id != m.Id {
+		return fmt.Errorf("received acknowledgement for message %s, expected %s", a.Id, m.Id)
+	}
+	return nil
+}
+
+// delivery is a received message which is being handled by the engine
+type delivery struct {
+	from types.Address
+	id   string
+	done chan struct{} // closed once the engine has handled the message
+}
+
+// deliveryId returns the id of the delivery of the envelope with the given id from the given peer
+func deliveryId(from types.Address, id string) string {
+	return from.String() + "/" + id
+}
+
+// reliableMsgStreamHandler receives an envelope, forwards its message to the engine unless it is a redelivered copy,
+// and acknowledges it once the engine has handled it.
+func (ms *P2PMessageService) reliableMsgStreamHandler(stream network.Stream) {
+	defer stream.Close()
+
+	deadline := time.Now().Add(ACK_TIMEOUT)
+	err := stream.SetDeadline(deadline)
+	if err != nil {
+		ms.logger.Error("error setting stream deadline", "err", err)
+		return
+	}
+
+	raw, err := bufio.NewReader(stream).ReadString(DELIMITER)
+	if err != nil {
+		ms.logger.Error("error reading from stream", "err", err)
+		return
+	}
+
+	var env envelope
+	err = json.Unmarshal([]byte(raw), &env)
+	if err != nil {
+		ms.logger.Error("error unmarshalling envelope", "err", err)
+		return
+	}
+	m, err := protocols.DeserializeMessage(string(env.Message))
+	if err != nil {
+		ms.logger.Error("error deserializing message", "err", err)
+		return
+	}
+
+	// The id is only recorded once the engine has handled the message (see MessageHandled), so a message lost in a crash
+	// before then is redelivered, while a copy redelivered after a lost acknowledgement is dropped
+	received, err := ms.outbox.HasReceivedMessage(m.From, env.Id)
+	if err != nil {
+		ms.logger.Error("error checking received message", "err", err)
+		return
+	}
+	if received {
+		ms.logger.Debug("dropping redelivered message", "from", m.From.String(), "id", env.Id)
+	} else {
+		d := &delivery{from: m.From, id: env.Id, done: make(chan struct{})}
+		m.DeliveryId = deliveryId(m.From, env.Id)
+		if _, loaded := ms.handling.LoadOrStore(m.DeliveryId, d); loaded {
+			// An earlier copy is still being handled. It is not acknowledged, so the sender retries it later.
+			ms.logger.Debug("message is already being handled", "from", m.From.String(), "id", env.Id)
+			return
+		}
+		metrics.MessagesReceived.WithLabelValues(m.From.String()).Inc()
+		select {
+		case ms.toEngine <- m:
+		case <-time.After(time.Until(deadline)):
+			// The engine did not take the message, so it is forgotten and the sender retries it
+			ms.handling.Delete(m.DeliveryId)
+			ms.logger.Debug("timed out passing message to the engine", "from", m.From.String(), "id", env.Id)
+			return
+		case <-ms.ctx.Done():
+			ms.handling.Delete(m.DeliveryId)
+			return
+		}
+
+		select {
+		case <-d.done:
+		case <-time.After(time.Until(deadline)):
+			// The sender retries the message, which is dropped once the engine has handled this copy
+			ms.logger.Debug("timed out waiting for the engine to handle message", "from", m.From.String(), "id", env.Id)
+			return
+		case <-ms.ctx.Done():
+			return
+		}
+	}
+
+	rawAck, err := json.Marshal(ack{Id: env.Id})
+	if err != nil {
+		ms.logger.Error("error marshalling acknowledgement", "err", err)
+		return
+	}
+	writer := bufio.NewWriter(stream)
+	_, err = writer.WriteString(string(rawAck) + string(DELIMITER))
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		ms.logger.Error("error acknowledging message", "err", err)
+	}
+}
+
+// MessageHandled records that a received message has been handled by the engine, so that it is acknowledged
+// and any redelivered copy is dropped.
+func (ms *P2PMessageService) MessageHandled(m protocols.Message) {
+	d, ok := ms.handling.Load(m.DeliveryId)
+	if !ok {
+		return
+	}
+	_, err := ms.outbox.RecordReceivedMessage(d.from, d.id)
+	if err != nil {
+		ms.logger.Error("error recording received message", "err", err)
+	}
+	ms.handling.Delete(m.DeliveryId)
+	close(d.done)
+}
+
+// OutboxDepths returns the number of unacknowledged messages addressed to each peer
+func (ms *P2PMessageService) OutboxDepths() (map[types.Address]int, error) {
+	return ms.outbox.GetOutboxDepths()
+}
@@ -3,11 +3,13 @@ package p2pms
 import (
 	"bufio"
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/libp2p/go-libp2p"
@@ -22,9 +24,14 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/transport/websocket"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/statechannels/go-nitro/internal/logging"
+	"github.com/statechannels/go-nitro/internal/metrics"
 	"github.com/statechannels/go-nitro/internal/safesync"
+	"github.com/statechannels/go-nitro/internal/tracing"
+	"github.com/statechannels/go-nitro/node/engine/store"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // basicPeerInfo contains the basic information about a peer
@@ -36,16 +43,20 @@ type basicPeerInfo struct {
 const (
 	DHT_PROTOCOL_PREFIX       protocol.ID = "/nitro" // use /nitro/kad/1.0.0 instead of /ipfs/kad/1.0.0
 	GENERAL_MSG_PROTOCOL_ID   protocol.ID = "/nitro/msg/1.0.0"
+	RELIABLE_MSG_PROTOCOL_ID  protocol.ID = "/nitro/msg/2.0.0" // messages are wrapped in an envelope and acknowledged by the recipient
 	PEER_EXCHANGE_PROTOCOL_ID protocol.ID = "/nitro/peerinfo/1.0.0"
 
 	DELIMITER                = '\n'
 	BUFFER_SIZE              = 1_000
-	NUM_CONNECT_ATTEMPTS     = 10
-	RETRY_SLEEP_DURATION     = 5 * time.Second
+	ACK_TIMEOUT              = 10 * time.Second // how long we wait for a peer to accept and acknowledge a message
+	MIN_RETRY_BACKOFF        = 500 * time.Millisecond
+	MAX_RETRY_BACKOFF        = time.Minute
 	BOOTSTRAP_SLEEP_DURATION = 100 * time.Millisecond // how often we check for bootpeers in Peerstore
 )
 
 type MessageOpts struct {
+	// PkBytes is the private key of the node's libp2p identity. If it is empty, a new identity is generated.
+	// Peers are looked up by state channel address, so the identity need not be derived from the node's signing key.
 	PkBytes      []byte
 	TcpPort      int
 	WsMsgPort    int
@@ -53,6 +64,8 @@ type MessageOpts struct {
 	PublicIp     string
 	SCAddr       types.Address
 	ExtMultiAddr string
+	// Outbox persists messages until they are acknowledged, normally in the node's store. If it is nil, messages are held in memory.
+	Outbox store.MessageOutbox
 }
 
 // P2PMessageService is a rudimentary message service that uses TCP to send and receive messages.
@@ -68,6 +81,15 @@ type P2PMessageService struct {
 	newPeerInfo chan basicPeerInfo
 	logger      *slog.Logger
 
+	outbox     store.MessageOutbox
+	deliveries *safesync.Map[chan struct{}] // wakes the delivery worker of each peer, keyed by state channel address
+	handling   *safesync.Map[*delivery]     // received messages which the engine has yet to handle, keyed by delivery id
+	idMu       sync.Mutex
+	lastId     uint64 // the last outbox message id
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+
 	MultiAddr string
 }
 
@@ -81,6 +103,13 @@ func NewMessageService(opts MessageOpts) *P2PMessageService {
 		peers:           &safesync.Map[peer.ID]{},
 		scAddr:          opts.SCAddr,
 		logger:          logging.LoggerWithAddress(slog.Default(), opts.SCAddr),
+		outbox:          opts.Outbox,
+		deliveries:      &safesync.Map[chan struct{}]{},
+		handling:        &safesync.Map[*delivery]{},
+	}
+	ms.ctx, ms.cancel = context.WithCancel(context.Background())
+	if ms.outbox == nil {
+		ms.outbox = store.NewMemOutbox()
 	}
 
 	addressFactory := func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
@@ -103,7 +132,14 @@ func NewMessageService(opts MessageOpts) *P2PMessageService {
 		return addrs
 	}
 
-	privateKey, err := p2pcrypto.UnmarshalSecp256k1PrivateKey(opts.PkBytes)
+	var privateKey p2pcrypto.PrivKey
+	var err error
+	if len(opts.PkBytes) > 0 {
+		privateKey, err = p2pcrypto.UnmarshalSecp256k1PrivateKey(opts.PkBytes)
+	} else {
+		ms.logger.Warn("no message service key supplied, generating a new peer identity")
+		privateKey, _, err = p2pcrypto.GenerateSecp256k1Key(rand.Reader)
+	}
 	ms.checkError(err)
 
 	options := []libp2p.Option{
@@ -125,6 +161,7 @@ func NewMessageService(opts MessageOpts) *P2PMessageService {
 
 	ms.p2pHost = host
 	ms.p2pHost.SetStreamHandler(GENERAL_MSG_PROTOCOL_ID, ms.msgStreamHandler)
+	ms.p2pHost.SetStreamHandler(RELIABLE_MSG_PROTOCOL_ID, ms.reliableMsgStreamHandler)
 	ms.p2pHost.SetStreamHandler(PEER_EXCHANGE_PROTOCOL_ID, ms.receivePeerInfo)
 
 	// Print out my own peerInfo
@@ -141,6 +178,9 @@ func NewMessageService(opts MessageOpts) *P2PMessageService {
 	err = ms.setupDht(opts.BootPeers)
 	ms.checkError(err)
 
+	err = ms.resumeDeliveries()
+	ms.checkError(err)
+
 	return ms
 }
 
@@ -293,6 +333,7 @@ func (ms *P2PMessageService) msgStreamHandler(stream network.Stream) {
 		ms.logger.Error("error deserializing message", "err", err)
 		return
 	}
+	metrics.MessagesReceived.WithLabelValues(m.From.String()).Inc()
 	ms.toEngine <- m
 }
 
@@ -329,8 +370,8 @@ func (ms *P2PMessageService) receivePeerInfo(stream network.Stream) {
 	}
 }
 
-func (ms *P2PMessageService) getPeerIdFromDht(scaddr string) (peer.ID, error) {
-	recordBytes, err := ms.dht.GetValue(context.Background(), DHT_RECORD_PREFIX+scaddr)
+func (ms *P2PMessageService) getPeerIdFromDht(ctx context.Context, scaddr string) (peer.ID, error) {
+	recordBytes, err := ms.dht.GetValue(ctx, DHT_RECORD_PREFIX+scaddr)
 	if err != nil {
 		return "", err
 	}
@@ -351,46 +392,46 @@ func (ms *P2PMessageService) getPeerIdFromDht(scaddr string) (peer.ID, error) {
 	return peerId, nil
 }
 
-// Send sends messages to other participants.
-// It blocks until the message is sent.
-// It will retry establishing a stream NUM_CONNECT_ATTEMPTS times before giving up
-func (ms *P2PMessageService) Send(msg protocols.Message) error {
-	raw, err := msg.Serialize()
-	if err != nil {
-		return err
+// getPeerId returns the libp2p peer ID of the node with the given state channel address.
+// It first looks in the local "peers" map. If the address is not found there,
+// it queries the dht to retrieve the peerId, then stores it in the local map for next time.
+func (ms *P2PMessageService) getPeerId(scAddr types.Address) (peer.ID, error) {
+	peerId, ok := ms.peers.Load(scAddr.String())
+	if ok {
+		ms.logger.Debug("found scAddr in local cache", "scAddr", scAddr.String(), "peerId", peerId)
+		return peerId, nil
 	}
 
-	// First try to get peerId from local "peers" map. If the address is not found there,
-	// query the dht to retrieve the peerId, then store in local map for next time
-	peerId, ok := ms.peers.Load(msg.To.String())
-	if !ok {
-		ms.logger.Warn("did not find scAddr in local peers map, fetching from DHT", "scAddr", msg.To.String())
-		peerId, err = ms.getPeerIdFromDht(msg.To.String())
-		if err != nil {
-			ms.logger.Error("did not find scAddr in DHT", "scAddr", msg.To.String())
-			return err
-		}
-	} else {
-		ms.logger.Debug("found scAddr in local cache", "scAddr", msg.To.String(), "peerId", peerId)
+	ms.logger.Warn("did not find scAddr in local peers map, fetching from DHT", "scAddr", scAddr.String())
+	peerId, err := ms.getPeerIdFromDht(ms.ctx, scAddr.String())
+	if err != nil {
+		ms.logger.Error("did not find scAddr in DHT", "scAddr", scAddr.String())
+		return "", err
 	}
+	return peerId, nil
+}
 
-	for i := 0; i < NUM_CONNECT_ATTEMPTS; i++ {
-		s, err := ms.p2pHost.NewStream(context.Background(), peerId, GENERAL_MSG_PROTOCOL_ID)
-		if err == nil {
-			writer := bufio.NewWriter(s)
-			_, err = writer.WriteString(raw + string(DELIMITER)) // We don't care about the number of bytes written
-			if err != nil {
-				return err
-			}
+// Send queues a message for delivery to another participant.
+// It returns once the message is persisted in the outbox. The message is then delivered in the background,
+// and retried with backoff until the recipient acknowledges it.
+func (ms *P2PMessageService) Send(msg protocols.Message) (err error) {
+	ctx, span := tracing.Tracer.Start(tracing.Extract(msg.TraceContext), "P2PMessageService.Send", trace.WithAttributes(attribute.String("to", msg.To.String())))
+	defer func() { tracing.End(span, err) }()
+	if traceContext := tracing.Inject(ctx); traceContext != nil {
+		msg.TraceContext = traceContext
+	}
 
-			writer.Flush()
-			s.Close()
-			return nil
-		}
+	raw, err := msg.Serialize()
+	if err != nil {
+		return err
+	}
 
-		ms.logger.Warn("error opening stream", "err", err, "attempt", i, "to", msg.To.String())
-		time.Sleep(RETRY_SLEEP_DURATION)
+	err = ms.outbox.AddOutboxMessage(store.OutboxMessage{Id: ms.newMessageId(), To: msg.To, Payload: raw})
+	if err != nil {
+		return err
 	}
+
+	ms.wakeDelivery(msg.To)
 	return nil
 }
 
@@ -413,10 +454,14 @@ func (ms *P2PMessageService) SignRequests() <-chan SignatureRequest {
 	return ms.dhtSignRequests
 }
 
-// Close closes the P2PMessageService
+// Close closes the P2PMessageService. Messages which have not been acknowledged are left in the outbox.
 func (ms *P2PMessageService) Close() error {
+	ms.cancel()
 	ms.p2pHost.RemoveStreamHandler(GENERAL_MSG_PROTOCOL_ID)
-	return ms.p2pHost.Close()
+	ms.p2pHost.RemoveStreamHandler(RELIABLE_MSG_PROTOCOL_ID)
+	err := ms.p2pHost.Close()
+	ms.wg.Wait()
+	return err
 }
 
 // PeerInfoReceived returns a channel that receives a PeerInfo when a peer is discovered
@@ -462,7 +507,7 @@ func (ms *P2PMessageService) connectBootPeers(bootPeers []peer.AddrInfo) {
 }
 
 func (ms *P2PMessageService) Ping(ctx context.Context, scAddr string) error {
-	p, err := ms.getPeerIdFromDht(scAddr)
+	p, err := ms.getPeerIdFromDht(ctx, scAddr)
 	if err != nil {
 		return err
 	}
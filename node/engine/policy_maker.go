@@ -1,12 +1,44 @@
 package engine
 
-import "github.com/statechannels/go-nitro/protocols"
+import (
+	"sort"
+	"time"
+
+	"github.com/statechannels/go-nitro/internal/safesync"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
 
 // PolicyMaker is used to decide whether to approve or reject an objective
 type PolicyMaker interface {
 	ShouldApprove(o protocols.Objective) bool
 }
 
+// FindPolicyMaker returns the first policy maker of type T among pm and the policy makers it wraps.
+func FindPolicyMaker[T PolicyMaker](pm PolicyMaker) (T, bool) {
+	for pm != nil {
+		if found, ok := pm.(T); ok {
+			return found, true
+		}
+		wrapper, ok := pm.(interface{ Unwrap() PolicyMaker })
+		if !ok {
+			break
+		}
+		pm = wrapper.Unwrap()
+	}
+
+	var zero T
+	return zero, false
+}
+
+// DecisionRecorder is a policy maker which records the reasons for its decisions.
+// The engine takes every decision it records and persists it in the store, so the policy maker need not keep it.
+type DecisionRecorder interface {
+	PolicyMaker
+	TakeDecision(id protocols.ObjectiveId) (store.PolicyDecision, bool)
+}
+
 // PermissivePolicy is a policy maker that decides to approve every unapproved objective
 type PermissivePolicy struct{}
 
@@ -14,3 +46,81 @@ type PermissivePolicy struct{}
 func (pp *PermissivePolicy) ShouldApprove(o protocols.Objective) bool {
 	return o.GetStatus() == protocols.Unapproved
 }
+
+// PendingApproval describes an objective which is waiting for an operator to approve or reject it.
+type PendingApproval struct {
+	ObjectiveId protocols.ObjectiveId
+	ChannelId   types.Destination
+	ParkedAt    time.Time
+}
+
+// ApprovalQueue is a policy maker which parks objectives as pending until an operator approves or rejects them.
+//
+// Objectives are first evaluated by the wrapped policy maker. Those it rejects are rejected straight away.
+// Those it approves are parked if requiresApproval returns true for them, and approved straight away otherwise.
+type ApprovalQueue struct {
+	policymaker      PolicyMaker
+	requiresApproval func(o protocols.Objective) bool
+	pending          *safesync.Map[PendingApproval]
+}
+
+// NewApprovalQueue constructs an ApprovalQueue wrapping the supplied policy maker.
+// If requiresApproval is nil, every objective approved by the policy maker is parked.
+func NewApprovalQueue(policymaker PolicyMaker, requiresApproval func(o protocols.Objective) bool) *ApprovalQueue {
+	if requiresApproval == nil {
+		requiresApproval = func(protocols.Objective) bool { return true }
+	}
+	return &ApprovalQueue{
+		policymaker:      policymaker,
+		requiresApproval: requiresApproval,
+		pending:          &safesync.Map[PendingApproval]{},
+	}
+}
+
+// ShouldApprove defers to the wrapped policy maker
+func (aq *ApprovalQueue) ShouldApprove(o protocols.Objective) bool {
+	return aq.policymaker.ShouldApprove(o)
+}
+
+// Unwrap returns the wrapped policy maker
+func (aq *ApprovalQueue) Unwrap() PolicyMaker {
+	return aq.policymaker
+}
+
+// RequiresApproval returns true if o must be approved by an operator before it can progress
+func (aq *ApprovalQueue) RequiresApproval(o protocols.Objective) bool {
+	return aq.requiresApproval(o)
+}
+
+// Park adds o to the pending objectives, unless it is already pending
+func (aq *ApprovalQueue) Park(o protocols.Objective) {
+	aq.pending.LoadOrStore(string(o.Id()), PendingApproval{
+		ObjectiveId: o.Id(),
+		ChannelId:   o.OwnsChannel(),
+		ParkedAt:    time.Now(),
+	})
+}
+
+// IsPending returns true if the objective with the given id is waiting for an operator decision
+func (aq *ApprovalQueue) IsPending(id protocols.ObjectiveId) bool {
+	_, ok := aq.pending.Load(string(id))
+	return ok
+}
+
+// Remove removes the objective with the given id from the pending objectives
+func (aq *ApprovalQueue) Remove(id protocols.ObjectiveId) {
+	aq.pending.Delete(string(id))
+}
+
+// Pending returns all objectives waiting for an operator decision, oldest first
+func (aq *ApprovalQueue) Pending() []PendingApproval {
+	pending := []PendingApproval{}
+	aq.pending.Range(func(_ string, pa PendingApproval) bool {
+		pending = append(pending, pa)
+		return true
+	})
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].ParkedAt.Before(pending[j].ParkedAt)
+	})
+	return pending
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/protocols/registry"
+)
+
+// ReaperAction is the action a Reaper takes on an objective which has missed its deadline
+type ReaperAction string
+
+const (
+	// ReaperResend resends the messages declared by the objective's latest crank, and restarts its deadline
+	ReaperResend ReaperAction = "resend"
+	// ReaperChallenge escalates a directdefund objective to an on-chain challenge, and restarts its deadline
+	ReaperChallenge ReaperAction = "challenge"
+	// ReaperFail fails the objective and releases its channel
+	ReaperFail ReaperAction = "fail"
+)
+
+// defaultReaperInterval is how often a Reaper checks for stalled objectives if its rules do not say
+const defaultReaperInterval = time.Minute
+
+// ObjectiveDeadline configures how long objectives of a type may wait without making progress, and what happens when they wait longer.
+type ObjectiveDeadline struct {
+	Timeout time.Duration `toml:"timeout"`
+	Action  ReaperAction  `toml:"action"`
+}
+
+// ReaperRules configure the deadlines of objectives, keyed by objective type (the prefix of an objective id, e.g. "DirectDefunding").
+// Objectives of a type without a deadline may wait indefinitely.
+type ReaperRules struct {
+	// Interval is how often the engine checks for objectives which have missed their deadline
+	Interval  time.Duration                `toml:"interval"`
+	Deadlines map[string]ObjectiveDeadline `toml:"deadlines"`
+}
+
+// LoadReaperRules reads the [reaper] table of the TOML file at configPath.
+// The returned bool is false if the file does not contain a [reaper] table.
+func LoadReaperRules(configPath string) (ReaperRules, bool, error) {
+	var config struct {
+		Reaper ReaperRules `toml:"reaper"`
+	}
+	md, err := toml.DecodeFile(configPath, &config)
+	if err != nil {
+		return ReaperRules{}, false, fmt.Errorf("could not decode reaper rules: %w", err)
+	}
+	return config.Reaper, md.IsDefined("reaper"), nil
+}
+
+// Reaper instructs the engine to act on objectives which have waited on the same thing for longer than their deadline,
+// e.g. on a peer which has stopped responding.
+type Reaper struct {
+	interval  time.Duration
+	deadlines map[string]ObjectiveDeadline
+}
+
+// NewReaper validates the supplied rules and constructs a Reaper which applies them.
+func NewReaper(rules ReaperRules) (*Reaper, error) {
+	r := &Reaper{
+		interval:  rules.Interval,
+		deadlines: make(map[string]ObjectiveDeadline),
+	}
+	if r.interval <= 0 {
+		r.interval = defaultReaperInterval
+	}
+
+	for objectiveType, deadline := range rules.Deadlines {
+		if deadline.Timeout <= 0 {
+			return nil, fmt.Errorf("deadline for %s objectives must have a positive timeout", objectiveType)
+		}
+		switch deadline.Action {
+		case ReaperResend, ReaperFail:
+		case ReaperChallenge:
+			if t, ok := registry.LookupName(objectiveType); !ok || t.Policy.Challenge == nil {
+				return nil, fmt.Errorf("%s objectives cannot be escalated to a challenge", objectiveType)
+			}
+		default:
+			return nil, fmt.Errorf("unknown reaper action %q for %s objectives", deadline.Action, objectiveType)
+		}
+		r.deadlines[objectiveType] = deadline
+	}
+
+	return r, nil
+}
+
+// Interval returns how often the engine should check for objectives which have missed their deadline
+func (r *Reaper) Interval() time.Duration {
+	return r.interval
+}
+
+// Deadline returns the deadline of the objective with the given id, and false if objectives of its type have no deadline
+func (r *Reaper) Deadline(id protocols.ObjectiveId) (ObjectiveDeadline, bool) {
+	objectiveType, _, _ := strings.Cut(string(id), "-")
+	deadline, ok := r.deadlines[objectiveType]
+	return deadline, ok
+}
@@ -0,0 +1,101 @@
+package engine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/protocols"
+)
+
+func TestReaperDeadline(t *testing.T) {
+	r, err := engine.NewReaper(engine.ReaperRules{
+		Deadlines: map[string]engine.ObjectiveDeadline{
+			"DirectFunding":   {Timeout: time.Hour, Action: engine.ReaperFail},
+			"DirectDefunding": {Timeout: time.Minute, Action: engine.ReaperChallenge},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Interval() != time.Minute {
+		t.Fatalf("expected the default interval, got %v", r.Interval())
+	}
+
+	testCases := []struct {
+		id           protocols.ObjectiveId
+		wantDeadline engine.ObjectiveDeadline
+		wantOk       bool
+	}{
+		{"DirectFunding-0x01", engine.ObjectiveDeadline{Timeout: time.Hour, Action: engine.ReaperFail}, true},
+		{"DirectDefunding-0x01", engine.ObjectiveDeadline{Timeout: time.Minute, Action: engine.ReaperChallenge}, true},
+		{"VirtualFund-0x01", engine.ObjectiveDeadline{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.id), func(t *testing.T) {
+			deadline, ok := r.Deadline(tc.id)
+			if ok != tc.wantOk {
+				t.Fatalf("expected ok to be %t, got %t", tc.wantOk, ok)
+			}
+			if deadline != tc.wantDeadline {
+				t.Fatalf("expected deadline %+v, got %+v", tc.wantDeadline, deadline)
+			}
+		})
+	}
+}
+
+func TestNewReaperRejectsInvalidDeadlines(t *testing.T) {
+	testCases := map[string]engine.ObjectiveDeadline{
+		"DirectFunding":   {Timeout: time.Hour, Action: engine.ReaperChallenge},
+		"VirtualFund":     {Timeout: time.Hour, Action: "withdraw"},
+		"DirectDefunding": {Action: engine.ReaperResend},
+	}
+
+	for objectiveType, deadline := range testCases {
+		rules := engine.ReaperRules{Deadlines: map[string]engine.ObjectiveDeadline{objectiveType: deadline}}
+		if _, err := engine.NewReaper(rules); err == nil {
+			t.Fatalf("expected an error for %s deadline %+v", objectiveType, deadline)
+		}
+	}
+}
+
+func TestLoadReaperRules(t *testing.T) {
+	config := `
+pk = "2d999770f7b5d49b694080f987b82bbc9fc9ac2b4dcc10b0f8aba7d700f69c6d"
+
+[reaper]
+interval = "30s"
+
+[reaper.deadlines.VirtualFund]
+timeout = "10m"
+action = "resend"
+
+[reaper.deadlines.DirectDefunding]
+timeout = "1h"
+action = "challenge"
+`
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, found, err := engine.LoadReaperRules(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("expected a reaper table to be found")
+	}
+	if rules.Interval != 30*time.Second ||
+		rules.Deadlines["VirtualFund"] != (engine.ObjectiveDeadline{Timeout: 10 * time.Minute, Action: engine.ReaperResend}) ||
+		rules.Deadlines["DirectDefunding"] != (engine.ObjectiveDeadline{Timeout: time.Hour, Action: engine.ReaperChallenge}) {
+		t.Fatalf("unexpected rules loaded: %+v", rules)
+	}
+	if _, err := engine.NewReaper(rules); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/channel"
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/internal/logging"
+	"github.com/statechannels/go-nitro/internal/safesync"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/protocols/registry"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// PolicyRules are the rules a RulePolicy applies when deciding whether to approve an objective.
+// Empty lists, empty maps and zero durations leave the corresponding rule disabled.
+type PolicyRules struct {
+	// AllowedCounterparties, if non-empty, is the exhaustive list of counterparties we will open channels with.
+	AllowedCounterparties []string `toml:"allowedcounterparties"`
+	// BlockedCounterparties are counterparties we will never open channels with.
+	BlockedCounterparties []string `toml:"blockedcounterparties"`
+	// AllowedIntermediaries, if non-empty, is the exhaustive list of intermediaries a virtual or swap channel may use.
+	AllowedIntermediaries []string `toml:"allowedintermediaries"`
+	// MaxDirectFundDeposit maps an asset address to the largest amount we will deposit when directly funding a channel.
+	MaxDirectFundDeposit map[string]string `toml:"maxdirectfunddeposit"`
+	// MaxGuarantee maps an asset address to the largest guarantee we will fund for a virtual or swap channel.
+	MaxGuarantee map[string]string `toml:"maxguarantee"`
+	// MinChallengeDuration is the shortest challenge duration (in seconds) we will accept.
+	MinChallengeDuration uint32 `toml:"minchallengeduration"`
+	// MaxChallengeDuration is the longest challenge duration (in seconds) we will accept.
+	MaxChallengeDuration uint32 `toml:"maxchallengeduration"`
+}
+
+// RulePolicy is a policy maker that approves or rejects objectives according to a set of PolicyRules.
+// Every decision is recorded together with the reason it was made, and held until the engine takes it to persist in its store.
+type RulePolicy struct {
+	allowedCounterparties map[types.Address]bool
+	blockedCounterparties map[types.Address]bool
+	allowedIntermediaries map[types.Address]bool
+	maxDirectFundDeposit  types.Funds
+	maxGuarantee          types.Funds
+	minChallengeDuration  uint32
+	maxChallengeDuration  uint32
+
+	decisions *safesync.Map[store.PolicyDecision]
+}
+
+// LoadPolicyRules reads the [policy] table of the TOML file at configPath.
+// The returned bool is false if the file does not contain a [policy] table.
+func LoadPolicyRules(configPath string) (PolicyRules, bool, error) {
+	var config struct {
+		Policy PolicyRules `toml:"policy"`
+	}
+	md, err := toml.DecodeFile(configPath, &config)
+	if err != nil {
+		return PolicyRules{}, false, fmt.Errorf("could not decode policy rules: %w", err)
+	}
+	return config.Policy, md.IsDefined("policy"), nil
+}
+
+// NewRulePolicy validates the supplied rules and constructs a RulePolicy which applies them.
+func NewRulePolicy(rules PolicyRules) (*RulePolicy, error) {
+	if rules.MaxChallengeDuration != 0 && rules.MinChallengeDuration > rules.MaxChallengeDuration {
+		return nil, fmt.Errorf("min challenge duration %d exceeds max challenge duration %d", rules.MinChallengeDuration, rules.MaxChallengeDuration)
+	}
+
+	rp := &RulePolicy{
+		minChallengeDuration: rules.MinChallengeDuration,
+		maxChallengeDuration: rules.MaxChallengeDuration,
+		decisions:            &safesync.Map[store.PolicyDecision]{},
+	}
+
+	var err error
+	if rp.allowedCounterparties, err = parseAddressSet(rules.AllowedCounterparties); err != nil {
+		return nil, fmt.Errorf("invalid allowed counterparties: %w", err)
+	}
+	if rp.blockedCounterparties, err = parseAddressSet(rules.BlockedCounterparties); err != nil {
+		return nil, fmt.Errorf("invalid blocked counterparties: %w", err)
+	}
+	if rp.allowedIntermediaries, err = parseAddressSet(rules.AllowedIntermediaries); err != nil {
+		return nil, fmt.Errorf("invalid allowed intermediaries: %w", err)
+	}
+	if rp.maxDirectFundDeposit, err = parseAssetLimits(rules.MaxDirectFundDeposit); err != nil {
+		return nil, fmt.Errorf("invalid max direct fund deposit: %w", err)
+	}
+	if rp.maxGuarantee, err = parseAssetLimits(rules.MaxGuarantee); err != nil {
+		return nil, fmt.Errorf("invalid max guarantee: %w", err)
+	}
+
+	return rp, nil
+}
+
+// ShouldApprove evaluates o against the rules and records the decision along with its reason.
+// Objectives which are not unapproved are never approved.
+func (rp *RulePolicy) ShouldApprove(o protocols.Objective) bool {
+	if o.GetStatus() != protocols.Unapproved {
+		return false
+	}
+
+	reason := rp.evaluate(o)
+	approved := reason == ""
+	if approved {
+		reason = "all policy rules satisfied"
+	}
+
+	rp.decisions.Store(string(o.Id()), store.PolicyDecision{
+		ObjectiveId: o.Id(),
+		Approved:    approved,
+		Reason:      reason,
+		DecidedAt:   time.Now(),
+	})
+	slog.Info("Policy decision", logging.WithObjectiveIdAttribute(o.Id()), "approved", approved, "reason", reason)
+
+	return approved
+}
+
+// Decision returns the recorded decision for the objective with the given id, if one was made and has not been taken.
+func (rp *RulePolicy) Decision(id protocols.ObjectiveId) (store.PolicyDecision, bool) {
+	return rp.decisions.Load(string(id))
+}
+
+// TakeDecision returns the recorded decision for the objective with the given id and forgets it.
+func (rp *RulePolicy) TakeDecision(id protocols.ObjectiveId) (store.PolicyDecision, bool) {
+	decision, ok := rp.decisions.Load(string(id))
+	if ok {
+		rp.decisions.Delete(string(id))
+	}
+	return decision, ok
+}
+
+// evaluate returns the reason o violates the rules, or an empty string if it does not.
+// Objectives of types which do not fund a channel are not evaluated.
+func (rp *RulePolicy) evaluate(o protocols.Objective) string {
+	objectiveType, ok := registry.Lookup(o.Id())
+	if !ok || objectiveType.Policy.FundedChannel == nil {
+		return ""
+	}
+
+	c, direct := objectiveType.Policy.FundedChannel(o)
+	if !direct {
+		return rp.checkFundedChannel(c)
+	}
+	if reason := rp.checkChannel(&c.FixedPart, c.MyIndex); reason != "" {
+		return reason
+	}
+	myDeposit := c.PreFundState().Outcome.TotalAllocatedFor(c.MyDestination())
+	return checkAssetLimits("deposit", myDeposit, rp.maxDirectFundDeposit)
+}
+
+// checkFundedChannel checks a virtual or swap channel, which is funded by guarantees in ledger channels.
+func (rp *RulePolicy) checkFundedChannel(c *channel.Channel) string {
+	if reason := rp.checkChannel(&c.FixedPart, c.MyIndex); reason != "" {
+		return reason
+	}
+	if len(rp.allowedIntermediaries) > 0 {
+		for _, intermediary := range c.Participants[1 : len(c.Participants)-1] {
+			if !rp.allowedIntermediaries[intermediary] {
+				return fmt.Sprintf("intermediary %s is not allowed", intermediary)
+			}
+		}
+	}
+	return checkAssetLimits("guarantee", c.Total(), rp.maxGuarantee)
+}
+
+// checkChannel checks the counterparties and challenge duration of a channel in which we are the participant at myIndex.
+func (rp *RulePolicy) checkChannel(fp *state.FixedPart, myIndex uint) string {
+	for _, counterparty := range counterparties(fp.Participants, myIndex) {
+		if rp.blockedCounterparties[counterparty] {
+			return fmt.Sprintf("counterparty %s is blocked", counterparty)
+		}
+		if len(rp.allowedCounterparties) > 0 && !rp.allowedCounterparties[counterparty] {
+			return fmt.Sprintf("counterparty %s is not allowed", counterparty)
+		}
+	}
+	if fp.ChallengeDuration < rp.minChallengeDuration {
+		return fmt.Sprintf("challenge duration %d is below the minimum of %d", fp.ChallengeDuration, rp.minChallengeDuration)
+	}
+	if rp.maxChallengeDuration != 0 && fp.ChallengeDuration > rp.maxChallengeDuration {
+		return fmt.Sprintf("challenge duration %d exceeds the maximum of %d", fp.ChallengeDuration, rp.maxChallengeDuration)
+	}
+	return ""
+}
+
+// counterparties returns the channel endpoints other than ourselves.
+// Intermediaries are not counterparties, so for a channel with intermediaries only the first and last participants are considered.
+func counterparties(participants []types.Address, myIndex uint) []types.Address {
+	endpoints := []uint{0, uint(len(participants) - 1)}
+	cps := []types.Address{}
+	for _, i := range endpoints {
+		if i != myIndex {
+			cps = append(cps, participants[i])
+		}
+	}
+	return cps
+}
+
+// checkAssetLimits returns a reason if any asset amount exceeds its limit. Assets without a limit are unrestricted.
+func checkAssetLimits(kind string, amounts types.Funds, limits types.Funds) string {
+	for asset, amount := range amounts {
+		limit, ok := limits[asset]
+		if ok && amount.Cmp(limit) > 0 {
+			return fmt.Sprintf("%s of %s for asset %s exceeds the maximum of %s", kind, amount.Text(10), asset, limit.Text(10))
+		}
+	}
+	return ""
+}
+
+func parseAddressSet(addresses []string) (map[types.Address]bool, error) {
+	set := make(map[types.Address]bool, len(addresses))
+	for _, a := range addresses {
+		if !common.IsHexAddress(a) {
+			return nil, fmt.Errorf("%q is not an address", a)
+		}
+		set[common.HexToAddress(a)] = true
+	}
+	return set, nil
+}
+
+func parseAssetLimits(limits map[string]string) (types.Funds, error) {
+	funds := types.Funds{}
+	for asset, amount := range limits {
+		if !common.IsHexAddress(asset) {
+			return nil, fmt.Errorf("%q is not an asset address", asset)
+		}
+		limit, ok := new(big.Int).SetString(amount, 10)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a valid amount for asset %s", amount, asset)
+		}
+		funds[common.HexToAddress(asset)] = limit
+	}
+	return funds, nil
+}
@@ -0,0 +1,108 @@
+package engine_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testdata"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/protocols"
+)
+
+const nativeAsset = "0x0000000000000000000000000000000000000000"
+
+func TestRulePolicy(t *testing.T) {
+	dfo := testdata.Objectives.Directfund.GenericDFO()
+	vfo := testdata.Objectives.Virtualfund.GenericVFO()
+	vfo.Status = protocols.Unapproved
+
+	testCases := []struct {
+		name      string
+		rules     engine.PolicyRules
+		objective protocols.Objective
+		approve   bool
+	}{
+		{"no rules", engine.PolicyRules{}, &dfo, true},
+		{"blocked counterparty", engine.PolicyRules{BlockedCounterparties: []string{testactors.Bob.Address().Hex()}}, &dfo, false},
+		{"allowed counterparty", engine.PolicyRules{AllowedCounterparties: []string{testactors.Bob.Address().Hex()}}, &dfo, true},
+		{"counterparty not allowed", engine.PolicyRules{AllowedCounterparties: []string{testactors.Irene.Address().Hex()}}, &dfo, false},
+		{"deposit within limit", engine.PolicyRules{MaxDirectFundDeposit: map[string]string{nativeAsset: "6"}}, &dfo, true},
+		{"deposit exceeds limit", engine.PolicyRules{MaxDirectFundDeposit: map[string]string{nativeAsset: "5"}}, &dfo, false},
+		{"challenge duration too short", engine.PolicyRules{MinChallengeDuration: 61}, &dfo, false},
+		{"challenge duration too long", engine.PolicyRules{MaxChallengeDuration: 59}, &dfo, false},
+		{"intermediary counterparty is ignored", engine.PolicyRules{AllowedCounterparties: []string{testactors.Bob.Address().Hex()}}, &vfo, true},
+		{"intermediary not allowed", engine.PolicyRules{AllowedIntermediaries: []string{testactors.Bob.Address().Hex()}}, &vfo, false},
+		{"guarantee within limit", engine.PolicyRules{MaxGuarantee: map[string]string{nativeAsset: "10"}}, &vfo, true},
+		{"guarantee exceeds limit", engine.PolicyRules{MaxGuarantee: map[string]string{nativeAsset: "9"}}, &vfo, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rp, err := engine.NewRulePolicy(tc.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := rp.ShouldApprove(tc.objective); got != tc.approve {
+				t.Fatalf("expected approval to be %t, got %t", tc.approve, got)
+			}
+
+			decision, ok := rp.Decision(tc.objective.Id())
+			if !ok {
+				t.Fatal("expected a decision to be recorded")
+			}
+			if decision.Approved != tc.approve || decision.Reason == "" {
+				t.Fatalf("unexpected decision recorded: %+v", decision)
+			}
+		})
+	}
+}
+
+func TestRulePolicyIgnoresApprovedObjectives(t *testing.T) {
+	vfo := testdata.Objectives.Virtualfund.GenericVFO()
+
+	rp, err := engine.NewRulePolicy(engine.PolicyRules{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rp.ShouldApprove(&vfo) {
+		t.Fatal("expected an approved objective not to be approved again")
+	}
+	if _, ok := rp.Decision(vfo.Id()); ok {
+		t.Fatal("expected no decision to be recorded")
+	}
+}
+
+func TestLoadPolicyRules(t *testing.T) {
+	config := `
+pk = "2d999770f7b5d49b694080f987b82bbc9fc9ac2b4dcc10b0f8aba7d700f69c6d"
+
+[policy]
+blockedcounterparties = ["0xBBB676f9cFF8D242e9eaC39D063848807d3D1D94"]
+minchallengeduration = 30
+maxchallengeduration = 3600
+
+[policy.maxdirectfunddeposit]
+"0x0000000000000000000000000000000000000000" = "1000000"
+`
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, found, err := engine.LoadPolicyRules(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("expected a policy table to be found")
+	}
+	if len(rules.BlockedCounterparties) != 1 || rules.MinChallengeDuration != 30 || rules.MaxChallengeDuration != 3600 || rules.MaxDirectFundDeposit[nativeAsset] != "1000000" {
+		t.Fatalf("unexpected rules loaded: %+v", rules)
+	}
+	if _, err := engine.NewRulePolicy(rules); err != nil {
+		t.Fatal(err)
+	}
+}
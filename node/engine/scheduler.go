@@ -0,0 +1,243 @@
+package engine
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/statechannels/go-nitro/channel/consensus_channel"
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/protocols/registry"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// maxPendingTasks is the number of queued tasks above which the run loop stops accepting API requests and peer messages.
+const maxPendingTasks = 1024
+
+// A task is a unit of work for the run loop, such as handling an API request, a chain event, a message or a ledger proposal.
+//
+// The lanes of a task are the channels and peers whose state the task reads or writes. A peer stands for the ledger channel we share with it.
+// Tasks which share a lane run one at a time, in the order they arrived. Tasks with no lanes in common run concurrently.
+// An exclusive task runs alone, after every earlier task has finished and before any later task starts.
+// It is used for work which spans every channel, or whose lanes cannot be determined up front.
+type task struct {
+	kind      string // the kind of input handled by the task, e.g. "message"
+	lanes     []string
+	exclusive bool
+	handle    func() (EngineEvent, error)
+	queued    time.Time // when the task was added to the scheduler
+}
+
+// finishedTask is the result of a task, handed back to the run loop
+type finishedTask struct {
+	task *task
+	res  EngineEvent
+	err  error
+}
+
+// scheduler decides which queued tasks can start. It is only accessed by the run loop.
+type scheduler struct {
+	pending          []*task
+	busy             map[string]bool // lanes of the running tasks
+	running          int
+	exclusiveRunning bool
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{busy: make(map[string]bool)}
+}
+
+// add queues a task behind those already pending
+func (s *scheduler) add(t *task) {
+	t.queued = time.Now()
+	s.pending = append(s.pending, t)
+}
+
+// start removes and returns the pending tasks which can start now, marking them as running.
+// A task can start when none of its lanes is used by a running task or by an earlier pending task, and no exclusive task is ahead of it.
+func (s *scheduler) start() []*task {
+	started := []*task{}
+	claimed := make(map[string]bool) // lanes of earlier pending tasks which must go first
+	blocked := s.exclusiveRunning
+	waiting := s.pending[:0]
+	for _, t := range s.pending {
+		switch {
+		case blocked:
+			waiting = append(waiting, t)
+		case t.exclusive:
+			if s.running == 0 && len(waiting) == 0 {
+				s.exclusiveRunning = true
+				s.running++
+				started = append(started, t)
+			} else {
+				waiting = append(waiting, t)
+			}
+			blocked = true
+		case s.available(t, claimed):
+			for _, lane := range t.lanes {
+				s.busy[lane] = true
+			}
+			s.running++
+			started = append(started, t)
+		default:
+			for _, lane := range t.lanes {
+				claimed[lane] = true
+			}
+			waiting = append(waiting, t)
+		}
+	}
+	clear(s.pending[len(waiting):])
+	s.pending = waiting
+	return started
+}
+
+// available returns true if none of the task's lanes are busy or claimed
+func (s *scheduler) available(t *task, claimed map[string]bool) bool {
+	for _, lane := range t.lanes {
+		if s.busy[lane] || claimed[lane] {
+			return false
+		}
+	}
+	return true
+}
+
+// finish releases the lanes of a task which has finished running
+func (s *scheduler) finish(t *task) {
+	s.running--
+	if t.exclusive {
+		s.exclusiveRunning = false
+		return
+	}
+	for _, lane := range t.lanes {
+		delete(s.busy, lane)
+	}
+}
+
+// newTask returns a task with the supplied lanes, which is exclusive if the lanes could not be determined
+func newTask(kind string, lanes []string, ok bool, handle func() (EngineEvent, error)) *task {
+	if !ok {
+		return &task{kind: kind, exclusive: true, handle: handle}
+	}
+	return &task{kind: kind, lanes: lanes, handle: handle}
+}
+
+// exclusiveTask returns a task which runs alone
+func exclusiveTask(kind string, handle func() (EngineEvent, error)) *task {
+	return &task{kind: kind, exclusive: true, handle: handle}
+}
+
+// participantLanes returns the lanes of a channel: the channel itself and each of our peers in it
+func (e *Engine) participantLanes(channelId types.Destination, participants []types.Address) []string {
+	me := *e.store.GetAddress()
+	lanes := []string{channelId.String()}
+	for _, p := range participants {
+		if p != me {
+			lanes = append(lanes, p.String())
+		}
+	}
+	return lanes
+}
+
+// channelLanes returns the lanes of a stored channel, and false if the channel is not known
+func (e *Engine) channelLanes(channelId types.Destination) ([]string, bool) {
+	if c, ok := e.store.GetChannelById(channelId); ok {
+		return e.participantLanes(channelId, c.Participants), true
+	}
+	if cc, err := e.store.GetConsensusChannelById(channelId); err == nil {
+		return e.participantLanes(channelId, cc.Participants()), true
+	}
+	return nil, false
+}
+
+// objectiveLanes returns the lanes of the channel owned by a stored objective, and false if they cannot be determined
+func (e *Engine) objectiveLanes(id protocols.ObjectiveId) ([]string, bool) {
+	o, err := e.store.GetObjectiveById(id)
+	if err != nil || o.OwnsChannel().IsZero() {
+		return nil, false
+	}
+	return e.channelLanes(o.OwnsChannel())
+}
+
+// payloadLanes returns the lanes of the objective a payload is for. An objective which is not yet stored is identified by the signed state in its payload.
+func (e *Engine) payloadLanes(p protocols.ObjectivePayload) ([]string, bool) {
+	if lanes, ok := e.objectiveLanes(p.ObjectiveId); ok {
+		return lanes, true
+	}
+	ss := state.SignedState{}
+	if err := json.Unmarshal(p.PayloadData, &ss); err != nil || len(ss.State().Participants) == 0 {
+		return nil, false
+	}
+	return e.participantLanes(ss.State().ChannelId(), ss.State().Participants), true
+}
+
+// proposalLanes returns the lanes of a ledger proposal: those of the ledger channel and of the channel it targets
+func (e *Engine) proposalLanes(p consensus_channel.Proposal) ([]string, bool) {
+	ledgerLanes, ok := e.channelLanes(p.LedgerID)
+	if !ok {
+		return nil, false
+	}
+	targetLanes, ok := e.channelLanes(p.Target())
+	return append(ledgerLanes, targetLanes...), ok
+}
+
+// messageLanes returns the lanes of everything carried by a message
+func (e *Engine) messageLanes(message protocols.Message) ([]string, bool) {
+	lanes := []string{}
+	for _, payload := range message.ObjectivePayloads {
+		payloadLanes, ok := e.payloadLanes(payload)
+		if !ok {
+			return nil, false
+		}
+		lanes = append(lanes, payloadLanes...)
+	}
+	for _, entry := range message.LedgerProposals {
+		proposalLanes, ok := e.proposalLanes(entry.Proposal)
+		if !ok {
+			return nil, false
+		}
+		lanes = append(lanes, proposalLanes...)
+	}
+	for _, id := range message.RejectedObjectives {
+		objectiveLanes, ok := e.objectiveLanes(id)
+		if !ok {
+			return nil, false
+		}
+		lanes = append(lanes, objectiveLanes...)
+	}
+	for _, voucher := range message.Payments {
+		lanes = append(lanes, voucher.ChannelId.String())
+	}
+	return lanes, true
+}
+
+// objectiveRequestLanes returns the lanes of an objective request: those of the channel it closes, or those of the peers whose ledger channels fund the channel it opens.
+// The objective type of the request supplies its scope.
+func (e *Engine) objectiveRequestLanes(or protocols.ObjectiveRequest) ([]string, bool) {
+	scope, ok := registry.RequestScope(or)
+	if !ok {
+		return nil, false
+	}
+	if !scope.Channel.IsZero() {
+		return e.channelLanes(scope.Channel)
+	}
+	lanes := []string{}
+	for _, p := range scope.Peers {
+		lanes = append(lanes, p.String())
+	}
+	return lanes, true
+}
+
+// chainEventLanes returns the lanes of the channel a chain event is about.
+// Challenges may concern channels on another chain or create objectives, so are handled exclusively.
+func (e *Engine) chainEventLanes(event chainservice.Event) ([]string, bool) {
+	switch event.(type) {
+	case chainservice.ChallengeRegisteredEvent, chainservice.ChallengeClearedEvent:
+		return nil, false
+	}
+	if lanes, ok := e.channelLanes(event.ChannelID()); ok {
+		return lanes, true
+	}
+	// Events for channels we are not part of are ignored
+	return []string{event.ChannelID().String()}, true
+}
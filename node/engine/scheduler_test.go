@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"testing"
+)
+
+func TestScheduler(t *testing.T) {
+	s := newScheduler()
+	lanes := func(l ...string) *task { return newTask("test", l, true, nil) }
+
+	a1 := lanes("a")
+	b := lanes("b", "peer")
+	a2 := lanes("a")
+	c := lanes("c", "peer")
+	barrier := newTask("test", nil, false, nil)
+	d := lanes("d")
+	for _, task := range []*task{a1, b, a2, c, barrier, d} {
+		s.add(task)
+	}
+
+	expectStarted := func(want ...*task) {
+		t.Helper()
+		started := s.start()
+		if len(started) != len(want) {
+			t.Fatalf("expected %d tasks to start, got %d", len(want), len(started))
+		}
+		for i := range want {
+			if started[i] != want[i] {
+				t.Fatalf("expected task %d to be %+v, got %+v", i, want[i], started[i])
+			}
+		}
+	}
+
+	// Tasks on unrelated lanes start together, while later tasks on a busy lane wait their turn
+	expectStarted(a1, b)
+	expectStarted()
+
+	s.finish(a1)
+	expectStarted(a2)
+
+	s.finish(b)
+	expectStarted(c)
+
+	// The exclusive task waits for every earlier task, and holds back every later one
+	s.finish(a2)
+	expectStarted()
+	s.finish(c)
+	expectStarted(barrier)
+	expectStarted()
+
+	s.finish(barrier)
+	expectStarted(d)
+}
@@ -4,26 +4,23 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/statechannels/go-nitro/channel"
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/crypto"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
 	"github.com/statechannels/go-nitro/payments"
 	"github.com/statechannels/go-nitro/protocols"
-	"github.com/statechannels/go-nitro/protocols/bridgeddefund"
-	"github.com/statechannels/go-nitro/protocols/bridgedfund"
-	"github.com/statechannels/go-nitro/protocols/directdefund"
 	"github.com/statechannels/go-nitro/protocols/directfund"
-	"github.com/statechannels/go-nitro/protocols/mirrorbridgeddefund"
+	"github.com/statechannels/go-nitro/protocols/registry"
 	"github.com/statechannels/go-nitro/protocols/swap"
-	"github.com/statechannels/go-nitro/protocols/swapdefund"
-	"github.com/statechannels/go-nitro/protocols/swapfund"
-	"github.com/statechannels/go-nitro/protocols/virtualdefund"
-	"github.com/statechannels/go-nitro/protocols/virtualfund"
 	"github.com/statechannels/go-nitro/types"
 	"github.com/tidwall/buntdb"
 )
@@ -37,27 +34,71 @@ type DurableStore struct {
 	lastBlockNumSeen   *buntdb.DB
 	swaps              *buntdb.DB
 	channelToSwaps     *buntdb.DB
-
-	key     string // the signing key of the store's engine
-	address string // the (Ethereum) address associated to the signing key
+	outbox             *buntdb.DB
+	receivedMessages   *buntdb.DB
+	pendingSideEffects *buntdb.DB
+	failureReasons     *buntdb.DB
+	policyDecisions    *buntdb.DB
+	objectiveProgress  *buntdb.DB
+	webhookDeadLetters *buntdb.DB
+	txOutbox           *buntdb.DB
+	metadata           *buntdb.DB // holds the schema version and encryption parameters of the store
+
+	cipher *valueCipher // encrypts record values, or nil if the store is not encrypted
+
+	address string // the (Ethereum) address of the store's engine
 	folder  string // the folder where the store's data is stored
 }
 
 // NewDurableStore creates a new DurableStore that uses the given folder to store its data
-// It will create the folder if it does not exist
+// It will create the folder if it does not exist, and migrate existing data to the latest schema version.
+// The key is only used to derive the node's address.
 func NewDurableStore(key []byte, folder string, config buntdb.Config) (Store, error) {
+	return newDurableStore(crypto.GetAddressFromSecretKeyBytes(key), folder, config, EncryptionOpts{})
+}
+
+// NewEncryptedDurableStore creates a new DurableStore whose record values are encrypted at rest with the key described by enc.
+// A new store is encrypted on creation. An existing unencrypted store must first be encrypted in place with EncryptDurableStore.
+func NewEncryptedDurableStore(key []byte, folder string, config buntdb.Config, enc EncryptionOpts) (Store, error) {
+	return newDurableStore(crypto.GetAddressFromSecretKeyBytes(key), folder, config, enc)
+}
+
+func newDurableStore(address types.Address, folder string, config buntdb.Config, enc EncryptionOpts) (Store, error) {
+	ps, err := openDurableStore(address, folder, config)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.setupEncryption(enc)
+	if err != nil {
+		ps.Close()
+		return nil, err
+	}
+
+	report, err := ps.migrate(durableStoreMigrations, false)
+	if err != nil {
+		ps.Close()
+		return nil, fmt.Errorf("error migrating durable store: %w", err)
+	}
+	if len(report.Applied) > 0 {
+		slog.Info("Durable store migrated", "fromVersion", report.FromVersion, "toVersion", report.ToVersion, "changedRecords", len(report.Changes))
+	}
+
+	return ps, nil
+}
+
+// openDurableStore opens the store's databases without migrating them
+func openDurableStore(address types.Address, folder string, config buntdb.Config) (*DurableStore, error) {
 	ps := DurableStore{}
 
-	me := crypto.GetAddressFromSecretKeyBytes(key)
-	dataFolder := filepath.Join(folder, me.String())
+	dataFolder := filepath.Join(folder, address.String())
 
 	err := os.MkdirAll(dataFolder, os.ModePerm)
 	if err != nil {
 		return nil, err
 	}
 
-	ps.key = common.Bytes2Hex(key)
-	ps.address = crypto.GetAddressFromSecretKeyBytes(key).String()
+	ps.address = address.String()
 	ps.folder = folder
 
 	ps.objectives, err = ps.openDB("objectives", config)
@@ -96,6 +137,51 @@ func NewDurableStore(key []byte, folder string, config buntdb.Config) (Store, er
 		return nil, err
 	}
 
+	ps.outbox, err = ps.openDB("outbox", config)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.receivedMessages, err = ps.openDB("received_messages", config)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.pendingSideEffects, err = ps.openDB("pending_side_effects", config)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.failureReasons, err = ps.openDB("failure_reasons", config)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.policyDecisions, err = ps.openDB("policy_decisions", config)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.objectiveProgress, err = ps.openDB("objective_progress", config)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.webhookDeadLetters, err = ps.openDB("webhook_dead_letters", config)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.txOutbox, err = ps.openDB("tx_outbox", config)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.metadata, err = ps.openDB("metadata", config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &ps, nil
 }
 
@@ -128,6 +214,54 @@ func (ds *DurableStore) Close() error {
 	if err != nil {
 		return err
 	}
+	err = ds.lastBlockNumSeen.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.swaps.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.channelToSwaps.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.outbox.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.receivedMessages.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.pendingSideEffects.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.failureReasons.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.policyDecisions.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.objectiveProgress.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.webhookDeadLetters.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.txOutbox.Close()
+	if err != nil {
+		return err
+	}
+	err = ds.metadata.Close()
+	if err != nil {
+		return err
+	}
 	return ds.vouchers.Close()
 }
 
@@ -136,16 +270,11 @@ func (ds *DurableStore) GetAddress() *types.Address {
 	return &address
 }
 
-func (ds *DurableStore) GetChannelSecretKey() *[]byte {
-	val := common.Hex2Bytes(ds.key)
-	return &val
-}
-
 func (ds *DurableStore) GetSwapById(id types.Destination) (payments.Swap, error) {
 	var sJSON string
 	err := ds.swaps.View(func(tx *buntdb.Tx) error {
 		var err error
-		sJSON, err = tx.Get(id.String())
+		sJSON, err = ds.get(tx, id.String())
 		return err
 	})
 
@@ -168,7 +297,7 @@ func (ds *DurableStore) SetSwap(swap payments.Swap) error {
 	}
 
 	err = ds.swaps.Update(func(tx *buntdb.Tx) error {
-		_, _, err := tx.Set(swap.Id.String(), string(sJSON), nil)
+		err := ds.set(tx, swap.Id.String(), string(sJSON))
 		return err
 	})
 	return err
@@ -184,7 +313,7 @@ func (ds *DurableStore) DestroySwapById(id types.Destination) error {
 func (ds *DurableStore) GetObjectiveById(id protocols.ObjectiveId) (protocols.Objective, error) {
 	var obj protocols.Objective
 	err := ds.objectives.View(func(tx *buntdb.Tx) error {
-		objJSON, err := tx.Get(string(id))
+		objJSON, err := ds.get(tx, string(id))
 		if err != nil {
 			return err
 		}
@@ -216,7 +345,7 @@ func (ds *DurableStore) SetObjective(obj protocols.Objective) error {
 	}
 
 	err = ds.objectives.Update(func(tx *buntdb.Tx) error {
-		_, _, err := tx.Set(string(obj.Id()), string(objJSON), nil)
+		err := ds.set(tx, string(obj.Id()), string(objJSON))
 		return err
 	})
 	if err != nil {
@@ -289,7 +418,7 @@ func (ds *DurableStore) SetObjective(obj protocols.Objective) error {
 	var prevOwner protocols.ObjectiveId
 	var isOwned bool = false
 	err = ds.channelToObjective.View(func(tx *buntdb.Tx) error {
-		res, err := tx.Get(string(obj.OwnsChannel().String()))
+		res, err := ds.get(tx, string(obj.OwnsChannel().String()))
 		if err != nil {
 			return nil
 		}
@@ -304,7 +433,7 @@ func (ds *DurableStore) SetObjective(obj protocols.Objective) error {
 	if status := obj.GetStatus(); status == protocols.Approved && !obj.OwnsChannel().IsZero() {
 		if !isOwned {
 			err := ds.channelToObjective.Update(func(tx *buntdb.Tx) error {
-				_, _, err := tx.Set(string(obj.OwnsChannel().String()), string(obj.Id()), nil)
+				err := ds.set(tx, string(obj.OwnsChannel().String()), string(obj.Id()))
 				return err
 			})
 			if err != nil {
@@ -324,7 +453,7 @@ func (ds *DurableStore) SetObjective(obj protocols.Objective) error {
 func (ds *DurableStore) GetLastBlockNumSeen() (uint64, error) {
 	var result uint64
 	err := ds.lastBlockNumSeen.View(func(tx *buntdb.Tx) error {
-		val, err := tx.Get(lastBlockNumSeenKey)
+		val, err := ds.get(tx, lastBlockNumSeenKey)
 		if err != nil {
 			if errors.Is(err, buntdb.ErrNotFound) {
 				result = 0
@@ -341,7 +470,7 @@ func (ds *DurableStore) GetLastBlockNumSeen() (uint64, error) {
 // SetLastBlockNumSeen sets the last blockchain block processed by this node
 func (ds *DurableStore) SetLastBlockNumSeen(blockNumber uint64) error {
 	return ds.lastBlockNumSeen.Update(func(tx *buntdb.Tx) error {
-		_, _, err := tx.Set(lastBlockNumSeenKey, strconv.FormatUint(blockNumber, 10), nil)
+		err := ds.set(tx, lastBlockNumSeenKey, strconv.FormatUint(blockNumber, 10))
 		return err
 	})
 }
@@ -354,7 +483,7 @@ func (ds *DurableStore) SetChannel(ch *channel.Channel) error {
 	}
 
 	err = ds.channels.Update(func(tx *buntdb.Tx) error {
-		_, _, err := tx.Set(ch.Id.String(), string(chJSON), nil)
+		err := ds.set(tx, ch.Id.String(), string(chJSON))
 		return err
 	})
 	return err
@@ -379,7 +508,7 @@ func (ps *DurableStore) SetConsensusChannel(ch *consensus_channel.ConsensusChann
 	}
 
 	err = ps.consensusChannels.Update(func(tx *buntdb.Tx) error {
-		_, _, err := tx.Set(ch.Id.String(), string(chJSON), nil)
+		err := ps.set(tx, ch.Id.String(), string(chJSON))
 		return err
 	})
 
@@ -409,7 +538,7 @@ func (ds *DurableStore) getChannelById(id types.Destination) (channel.Channel, e
 	var chJSON string
 	err := ds.channels.View(func(tx *buntdb.Tx) error {
 		var err error
-		chJSON, err = tx.Get(id.String())
+		chJSON, err = ds.get(tx, id.String())
 		return err
 	})
 
@@ -434,7 +563,7 @@ func (ds *DurableStore) GetChannelsByIds(ids []types.Destination) ([]*channel.Ch
 	var err error
 
 	txError := ds.channels.View(func(tx *buntdb.Tx) error {
-		return tx.Ascend("", func(key, chJSON string) bool {
+		return ds.ascend(tx, func(key, chJSON string) bool {
 			var ch channel.Channel
 			err = json.Unmarshal([]byte(chJSON), &ch)
 			if err != nil {
@@ -469,7 +598,7 @@ func (ds *DurableStore) GetChannelsByAppDefinition(appDef types.Address) ([]*cha
 	toReturn := []*channel.Channel{}
 	var unmarshErr error
 	err := ds.channels.View(func(tx *buntdb.Tx) error {
-		return tx.Ascend("", func(key, chJSON string) bool {
+		return ds.ascend(tx, func(key, chJSON string) bool {
 			var ch channel.Channel
 			unmarshErr = json.Unmarshal([]byte(chJSON), &ch)
 			if unmarshErr != nil {
@@ -496,7 +625,7 @@ func (ds *DurableStore) GetChannelsByAppDefinition(appDef types.Address) ([]*cha
 func (ds *DurableStore) GetChannelsByParticipant(participant types.Address) ([]*channel.Channel, error) {
 	toReturn := []*channel.Channel{}
 	err := ds.channels.View(func(tx *buntdb.Tx) error {
-		err := tx.Ascend("", func(key, chJSON string) bool {
+		err := ds.ascend(tx, func(key, chJSON string) bool {
 			var ch channel.Channel
 			err := json.Unmarshal([]byte(chJSON), &ch)
 			if err != nil {
@@ -524,7 +653,7 @@ func (ds *DurableStore) GetAllConsensusChannels() ([]*consensus_channel.Consensu
 	toReturn := []*consensus_channel.ConsensusChannel{}
 	var unmarshErr error
 	err := ds.consensusChannels.View(func(tx *buntdb.Tx) error {
-		return tx.Ascend("", func(key, chJSON string) bool {
+		return ds.ascend(tx, func(key, chJSON string) bool {
 			var ch consensus_channel.ConsensusChannel
 
 			unmarshErr = json.Unmarshal([]byte(chJSON), &ch)
@@ -550,7 +679,7 @@ func (ds *DurableStore) GetAllChannels() ([]*channel.Channel, error) {
 	toReturn := []*channel.Channel{}
 	var unmarshErr error
 	err := ds.channels.View(func(tx *buntdb.Tx) error {
-		return tx.Ascend("", func(key, chJSON string) bool {
+		return ds.ascend(tx, func(key, chJSON string) bool {
 			var ch channel.Channel
 
 			unmarshErr = json.Unmarshal([]byte(chJSON), &ch)
@@ -574,7 +703,7 @@ func (ds *DurableStore) GetAllChannels() ([]*channel.Channel, error) {
 func (ds *DurableStore) GetConsensusChannelById(id types.Destination) (channel *consensus_channel.ConsensusChannel, err error) {
 	var ch *consensus_channel.ConsensusChannel
 	err = ds.consensusChannels.View(func(tx *buntdb.Tx) error {
-		chJSON, err := tx.Get(id.String())
+		chJSON, err := ds.get(tx, id.String())
 
 		if errors.Is(err, buntdb.ErrNotFound) {
 			return ErrNoSuchChannel
@@ -597,7 +726,7 @@ func (ds *DurableStore) GetConsensusChannelById(id types.Destination) (channel *
 // the supplied counterparty, if such channel exists
 func (ps *DurableStore) GetConsensusChannel(counterparty types.Address) (channel *consensus_channel.ConsensusChannel, ok bool) {
 	err := ps.consensusChannels.View(func(tx *buntdb.Tx) error {
-		return tx.Ascend("", func(key, chJSON string) bool {
+		return ps.ascend(tx, func(key, chJSON string) bool {
 			var ch consensus_channel.ConsensusChannel
 			err := json.Unmarshal([]byte(chJSON), &ch)
 			if err != nil {
@@ -626,7 +755,7 @@ func (ps *DurableStore) GetObjectiveByChannelId(channelId types.Destination) (pr
 	var id protocols.ObjectiveId
 
 	err := ps.channelToObjective.View(func(tx *buntdb.Tx) error {
-		val, err := tx.Get(channelId.String())
+		val, err := ps.get(tx, channelId.String())
 		id = protocols.ObjectiveId(val)
 
 		return err
@@ -643,255 +772,368 @@ func (ps *DurableStore) GetObjectiveByChannelId(channelId types.Destination) (pr
 // objective and attaches it to the objective. The channel data is attached
 // in-place of the objectives existing channel pointers.
 func (ds *DurableStore) populateChannelData(obj protocols.Objective) error {
-	id := obj.Id()
+	return registry.PopulateChannelData(obj, registry.ChannelData{
+		GetChannel:          ds.getChannelById,
+		GetConsensusChannel: ds.GetConsensusChannelById,
+		GetSwap:             ds.GetSwapById,
+	})
+}
 
-	switch o := obj.(type) {
-	case *directfund.Objective:
-		ch, err := ds.getChannelById(o.C.Id)
+func (ds *DurableStore) ReleaseChannelFromOwnership(channelId types.Destination) error {
+	return ds.channelToObjective.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(channelId.String())
+		return err
+	})
+}
+
+func (ds *DurableStore) SetVoucherInfo(channelId types.Destination, v payments.VoucherInfo) error {
+	return ds.vouchers.Update(func(tx *buntdb.Tx) error {
+		vJSON, err := json.Marshal(v)
 		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
+			return err
 		}
+		err = ds.set(tx, channelId.String(), string(vJSON))
 
-		o.C = &ch
-
-		return nil
-	case *directdefund.Objective:
+		return err
+	})
+}
 
-		ch, err := ds.getChannelById(o.C.Id)
+func (ds *DurableStore) GetVoucherInfo(channelId types.Destination) (*payments.VoucherInfo, error) {
+	v := &payments.VoucherInfo{}
+	err := ds.vouchers.View(func(tx *buntdb.Tx) error {
+		vJSON, err := ds.get(tx, channelId.String())
 		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
+			return fmt.Errorf("channelId %s: %w", channelId.String(), ErrLoadVouchers)
 		}
+		return json.Unmarshal([]byte(vJSON), v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
 
-		o.C = &ch
+func (ds *DurableStore) RemoveVoucherInfo(channelId types.Destination) error {
+	return ds.vouchers.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(channelId.String())
+		return err
+	})
+}
 
-		// Populate virtual channels if present
-		if len(o.FundedChannels) != 0 {
-			for virtualChannelId := range o.FundedChannels {
-				updatedVirtualChannel, _ := ds.GetChannelById(virtualChannelId)
-				o.FundedChannels[virtualChannelId] = updatedVirtualChannel
-			}
-		}
+func (ds *DurableStore) AddOutboxMessage(m OutboxMessage) error {
+	return ds.outbox.Update(func(tx *buntdb.Tx) error {
+		return ds.set(tx, outboxMessageKey(m.To, m.Id), m.Payload)
+	})
+}
 
+func (ds *DurableStore) RemoveOutboxMessage(to types.Address, id string) error {
+	err := ds.outbox.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(outboxMessageKey(to, id))
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
 		return nil
-	case *virtualfund.Objective:
-		v, err := ds.getChannelById(o.V.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving virtual channel data for objective %s: %w", id, err)
-		}
-		o.V = &channel.VirtualChannel{Channel: v}
-
-		zeroAddress := types.Destination{}
+	}
+	return err
+}
 
-		if o.ToMyLeft != nil &&
-			o.ToMyLeft.Channel != nil &&
-			o.ToMyLeft.Channel.Id != zeroAddress {
+func (ds *DurableStore) GetOutboxMessages(to types.Address) ([]OutboxMessage, error) {
+	msgs := []OutboxMessage{}
+	prefix := outboxMessageKey(to, "")
 
-			left, err := ds.GetConsensusChannelById(o.ToMyLeft.Channel.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving left ledger channel data for objective %s: %w", id, err)
+	var openErr error
+	err := ds.outbox.View(func(tx *buntdb.Tx) error {
+		return tx.AscendGreaterOrEqual("", prefix, func(key, value string) bool {
+			if !strings.HasPrefix(key, prefix) {
+				return false
 			}
-			o.ToMyLeft.Channel = left
-		}
-
-		if o.ToMyRight != nil &&
-			o.ToMyRight.Channel != nil &&
-			o.ToMyRight.Channel.Id != zeroAddress {
-			right, err := ds.GetConsensusChannelById(o.ToMyRight.Channel.Id)
+			payload, err := ds.openValue(value)
 			if err != nil {
-				return fmt.Errorf("error retrieving right ledger channel data for objective %s: %w", id, err)
+				openErr = err
+				return false
 			}
-			o.ToMyRight.Channel = right
-		}
-
-		return nil
-	case *swap.Objective:
-		ch, err := ds.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
-
-		swap, err := ds.GetSwapById(o.Swap.Id)
-		if err != nil {
-			return fmt.Errorf("error getting swap by Id: %w", err)
-		}
-
-		o.Swap = swap
-		o.C = &channel.SwapChannel{Channel: ch}
-		return nil
-	case *swapfund.Objective:
-		v, err := ds.getChannelById(o.S.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving swap channel data for objective %s: %w", id, err)
-		}
-		o.S = &channel.SwapChannel{Channel: v}
-
-		zeroAddress := types.Destination{}
-
-		if o.ToMyLeft != nil &&
-			o.ToMyLeft.Channel != nil &&
-			o.ToMyLeft.Channel.Id != zeroAddress {
+			msgs = append(msgs, OutboxMessage{Id: strings.TrimPrefix(key, prefix), To: to, Payload: payload})
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return msgs, openErr
+}
 
-			left, err := ds.GetConsensusChannelById(o.ToMyLeft.Channel.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving left ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyLeft.Channel = left
-		}
+func (ds *DurableStore) GetOutboxDepths() (map[types.Address]int, error) {
+	depths := make(map[types.Address]int)
+	err := ds.outbox.View(func(tx *buntdb.Tx) error {
+		return tx.AscendKeys("*", func(key, value string) bool {
+			to, _, _ := strings.Cut(key, "/")
+			depths[common.HexToAddress(to)]++
+			return true
+		})
+	})
+	return depths, err
+}
 
-		if o.ToMyRight != nil &&
-			o.ToMyRight.Channel != nil &&
-			o.ToMyRight.Channel.Id != zeroAddress {
-			right, err := ds.GetConsensusChannelById(o.ToMyRight.Channel.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving right ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyRight.Channel = right
+func (ds *DurableStore) RecordReceivedMessage(from types.Address, id string) (bool, error) {
+	isNew := false
+	err := ds.receivedMessages.Update(func(tx *buntdb.Tx) error {
+		key := receivedMessageKey(from, id)
+		_, err := tx.Get(key)
+		if err == nil {
+			return nil
 		}
-
-		return nil
-	case *virtualdefund.Objective:
-		v, err := ds.getChannelById(o.V.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving virtual channel data for objective %s: %w", id, err)
+		if !errors.Is(err, buntdb.ErrNotFound) {
+			return err
 		}
-		o.V = &channel.VirtualChannel{Channel: v}
+		isNew = true
+		_, _, err = tx.Set(key, "", &buntdb.SetOptions{Expires: true, TTL: ReceivedMessageTTL})
+		return err
+	})
+	return isNew, err
+}
 
-		zeroAddress := types.Destination{}
+func (ds *DurableStore) HasReceivedMessage(from types.Address, id string) (bool, error) {
+	err := ds.receivedMessages.View(func(tx *buntdb.Tx) error {
+		_, err := tx.Get(receivedMessageKey(from, id))
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
 
-		if o.ToMyLeft != nil &&
-			o.ToMyLeft.Id != zeroAddress {
+func (ds *DurableStore) DestroyObjective(id protocols.ObjectiveId) error {
+	return ds.objectives.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(string(id))
+		return err
+	})
+}
 
-			left, err := ds.GetConsensusChannelById(o.ToMyLeft.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving left ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyLeft = left
-		}
+// GetIncompleteObjectives returns the objectives which are approved but not yet completed, sorted by id
+func (ds *DurableStore) GetIncompleteObjectives() ([]protocols.Objective, error) {
+	return ds.GetObjectives(ObjectiveFilter{Statuses: []protocols.ObjectiveStatus{protocols.Approved}})
+}
 
-		if o.ToMyRight != nil &&
-			o.ToMyRight.Id != zeroAddress {
-			right, err := ds.GetConsensusChannelById(o.ToMyRight.Id)
+func (ds *DurableStore) GetObjectives(filter ObjectiveFilter) ([]protocols.Objective, error) {
+	objectives := []protocols.Objective{}
+	var decodeErr error
+	err := ds.objectives.View(func(tx *buntdb.Tx) error {
+		return ds.ascend(tx, func(key, objJSON string) bool {
+			obj, err := decodeObjective(protocols.ObjectiveId(key), []byte(objJSON))
 			if err != nil {
-				return fmt.Errorf("error retrieving right ledger channel data for objective %s: %w", id, err)
+				decodeErr = fmt.Errorf("error decoding objective %s: %w", key, err)
+				return false
 			}
-			o.ToMyRight = right
-		}
-		return nil
-	case *swapdefund.Objective:
-		s, err := ds.getChannelById(o.S.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving virtual channel data for objective %s: %w", id, err)
-		}
-		o.S = &channel.SwapChannel{Channel: s}
-
-		zeroAddress := types.Destination{}
-
-		if o.ToMyLeft != nil &&
-			o.ToMyLeft.Id != zeroAddress {
-
-			left, err := ds.GetConsensusChannelById(o.ToMyLeft.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving left ledger channel data for objective %s: %w", id, err)
+			if filter.Matches(obj) {
+				objectives = append(objectives, obj)
 			}
-			o.ToMyLeft = left
-		}
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
 
-		if o.ToMyRight != nil &&
-			o.ToMyRight.Id != zeroAddress {
-			right, err := ds.GetConsensusChannelById(o.ToMyRight.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving right ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyRight = right
-		}
-		return nil
-	case *bridgedfund.Objective:
-		ch, err := ds.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
+	for _, obj := range objectives {
+		err = ds.populateChannelData(obj)
+		if err != nil && !isFinished(obj) {
+			return nil, fmt.Errorf("error populating channel data for objective %s: %w", obj.Id(), err)
 		}
+	}
+	return objectives, nil
+}
 
-		o.C = &ch
-
-		return nil
+func (ds *DurableStore) SetPendingSideEffects(id protocols.ObjectiveId, se PendingSideEffects) error {
+	seJSON, err := json.Marshal(se)
+	if err != nil {
+		return fmt.Errorf("error setting pending side effects of objective %s: %w", id, err)
+	}
+	return ds.pendingSideEffects.Update(func(tx *buntdb.Tx) error {
+		return ds.set(tx, string(id), string(seJSON))
+	})
+}
 
-	case *bridgeddefund.Objective:
-		ch, err := ds.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
+func (ds *DurableStore) GetPendingSideEffects(id protocols.ObjectiveId) (PendingSideEffects, bool, error) {
+	var seJSON string
+	err := ds.pendingSideEffects.View(func(tx *buntdb.Tx) error {
+		var err error
+		seJSON, err = ds.get(tx, string(id))
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return PendingSideEffects{}, false, nil
+	}
+	if err != nil {
+		return PendingSideEffects{}, false, err
+	}
 
-		o.C = &ch
+	var se PendingSideEffects
+	err = json.Unmarshal([]byte(seJSON), &se)
+	if err != nil {
+		return PendingSideEffects{}, false, fmt.Errorf("error decoding pending side effects of objective %s: %w", id, err)
+	}
+	return se, true, nil
+}
 
+func (ds *DurableStore) RemovePendingSideEffects(id protocols.ObjectiveId) error {
+	err := ds.pendingSideEffects.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(string(id))
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
 		return nil
+	}
+	return err
+}
 
-	case *mirrorbridgeddefund.Objective:
-		ch, err := ds.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
+func (ds *DurableStore) SetFailureReason(id protocols.ObjectiveId, reason protocols.FailureReason) error {
+	reasonJSON, err := json.Marshal(reason)
+	if err != nil {
+		return fmt.Errorf("error setting failure reason of objective %s: %w", id, err)
+	}
+	return ds.failureReasons.Update(func(tx *buntdb.Tx) error {
+		return ds.set(tx, string(id), string(reasonJSON))
+	})
+}
 
-		o.C = &ch
+func (ds *DurableStore) GetFailureReason(id protocols.ObjectiveId) (protocols.FailureReason, bool, error) {
+	var reasonJSON string
+	err := ds.failureReasons.View(func(tx *buntdb.Tx) error {
+		var err error
+		reasonJSON, err = ds.get(tx, string(id))
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return protocols.FailureReason{}, false, nil
+	}
+	if err != nil {
+		return protocols.FailureReason{}, false, err
+	}
 
-		return nil
+	var reason protocols.FailureReason
+	err = json.Unmarshal([]byte(reasonJSON), &reason)
+	if err != nil {
+		return protocols.FailureReason{}, false, fmt.Errorf("error decoding failure reason of objective %s: %w", id, err)
+	}
+	return reason, true, nil
+}
 
-	default:
-		return fmt.Errorf("objective %s did not correctly represent a known Objective type", id)
+func (ds *DurableStore) SetPolicyDecision(d PolicyDecision) error {
+	dJSON, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("error setting policy decision of objective %s: %w", d.ObjectiveId, err)
 	}
+	return ds.policyDecisions.Update(func(tx *buntdb.Tx) error {
+		return ds.set(tx, string(d.ObjectiveId), string(dJSON))
+	})
 }
 
-func (ds *DurableStore) ReleaseChannelFromOwnership(channelId types.Destination) error {
-	return ds.channelToObjective.Update(func(tx *buntdb.Tx) error {
-		_, err := tx.Delete(channelId.String())
+func (ds *DurableStore) GetPolicyDecision(id protocols.ObjectiveId) (PolicyDecision, bool, error) {
+	var dJSON string
+	err := ds.policyDecisions.View(func(tx *buntdb.Tx) error {
+		var err error
+		dJSON, err = ds.get(tx, string(id))
 		return err
 	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return PolicyDecision{}, false, nil
+	}
+	if err != nil {
+		return PolicyDecision{}, false, err
+	}
+
+	var d PolicyDecision
+	err = json.Unmarshal([]byte(dJSON), &d)
+	if err != nil {
+		return PolicyDecision{}, false, fmt.Errorf("error decoding policy decision of objective %s: %w", id, err)
+	}
+	return d, true, nil
 }
 
-func (ds *DurableStore) SetVoucherInfo(channelId types.Destination, v payments.VoucherInfo) error {
-	return ds.vouchers.Update(func(tx *buntdb.Tx) error {
-		vJSON, err := json.Marshal(v)
+func (ds *DurableStore) PrunePolicyDecisions(before time.Time) error {
+	return ds.policyDecisions.Update(func(tx *buntdb.Tx) error {
+		var decodeErr error
+		stale := []string{}
+		err := ds.ascend(tx, func(key, dJSON string) bool {
+			var d PolicyDecision
+			decodeErr = json.Unmarshal([]byte(dJSON), &d)
+			if decodeErr != nil {
+				decodeErr = fmt.Errorf("error decoding policy decision of objective %s: %w", key, decodeErr)
+				return false
+			}
+			if d.DecidedAt.Before(before) {
+				stale = append(stale, key)
+			}
+			return true
+		})
 		if err != nil {
 			return err
 		}
-		_, _, err = tx.Set(channelId.String(), string(vJSON), nil)
+		if decodeErr != nil {
+			return decodeErr
+		}
 
-		return err
+		for _, key := range stale {
+			_, err = tx.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
 	})
 }
 
-func (ds *DurableStore) GetVoucherInfo(channelId types.Destination) (*payments.VoucherInfo, error) {
-	v := &payments.VoucherInfo{}
-	err := ds.vouchers.View(func(tx *buntdb.Tx) error {
-		vJSON, err := tx.Get(channelId.String())
+func (ds *DurableStore) AddObjectiveProgress(id protocols.ObjectiveId, entry ObjectiveProgress) error {
+	return ds.objectiveProgress.Update(func(tx *buntdb.Tx) error {
+		history := []ObjectiveProgress{}
+		historyJSON, err := ds.get(tx, string(id))
+		if err == nil {
+			err = json.Unmarshal([]byte(historyJSON), &history)
+			if err != nil {
+				return fmt.Errorf("error decoding progress of objective %s: %w", id, err)
+			}
+		} else if !errors.Is(err, buntdb.ErrNotFound) {
+			return err
+		}
+
+		updatedJSON, err := json.Marshal(append(history, entry))
 		if err != nil {
-			return fmt.Errorf("channelId %s: %w", channelId.String(), ErrLoadVouchers)
+			return fmt.Errorf("error adding progress of objective %s: %w", id, err)
 		}
-		return json.Unmarshal([]byte(vJSON), v)
+		return ds.set(tx, string(id), string(updatedJSON))
 	})
-	if err != nil {
-		return nil, err
-	}
-	return v, nil
 }
 
-func (ds *DurableStore) RemoveVoucherInfo(channelId types.Destination) error {
-	return ds.vouchers.Update(func(tx *buntdb.Tx) error {
-		_, err := tx.Delete(channelId.String())
+func (ds *DurableStore) GetObjectiveProgress(id protocols.ObjectiveId) ([]ObjectiveProgress, error) {
+	var historyJSON string
+	err := ds.objectiveProgress.View(func(tx *buntdb.Tx) error {
+		var err error
+		historyJSON, err = ds.get(tx, string(id))
 		return err
 	})
-}
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return []ObjectiveProgress{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
 
-func (ds *DurableStore) DestroyObjective(id protocols.ObjectiveId) error {
-	return ds.objectives.Update(func(tx *buntdb.Tx) error {
-		_, err := tx.Delete(string(id))
-		return err
-	})
+	var history []ObjectiveProgress
+	err = json.Unmarshal([]byte(historyJSON), &history)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding progress of objective %s: %w", id, err)
+	}
+	return history, nil
 }
 
 func (ds *DurableStore) GetPendingSwapByChannelId(id types.Destination) (*payments.Swap, error) {
 	var pendingSwapId types.Destination
 	err := ds.objectives.View(func(tx *buntdb.Tx) error {
-		err := tx.Ascend("", func(key, objJSON string) bool {
+		err := ds.ascend(tx, func(key, objJSON string) bool {
 			objId := protocols.ObjectiveId(key)
 			if !swap.IsSwapObjective(objId) {
 				return true // objective not found, continue looking
@@ -928,7 +1170,7 @@ func (ds *DurableStore) GetSwapsByChannelId(id types.Destination) ([]payments.Sw
 	swapQueue := payments.NewSwapsQueue()
 
 	err := ds.channelToSwaps.View(func(tx *buntdb.Tx) error {
-		sJSON, err := tx.Get(id.String())
+		sJSON, err := ds.get(tx, id.String())
 
 		if errors.Is(err, buntdb.ErrNotFound) {
 			return nil
@@ -962,7 +1204,7 @@ func (ds *DurableStore) SetChannelToSwaps(swap payments.Swap) (payments.Swap, er
 	swapQueue := payments.NewSwapsQueue()
 
 	err := ds.channelToSwaps.View(func(tx *buntdb.Tx) error {
-		sJSON, err := tx.Get(swap.ChannelId.String())
+		sJSON, err := ds.get(tx, swap.ChannelId.String())
 
 		if errors.Is(err, buntdb.ErrNotFound) {
 			return nil
@@ -987,7 +1229,7 @@ func (ds *DurableStore) SetChannelToSwaps(swap payments.Swap) (payments.Swap, er
 	}
 
 	err = ds.channelToSwaps.Update(func(tx *buntdb.Tx) error {
-		_, _, err = tx.Set(swap.ChannelId.String(), string(swapsJson), nil)
+		err = ds.set(tx, swap.ChannelId.String(), string(swapsJson))
 		return err
 	})
 	if err != nil {
@@ -996,3 +1238,100 @@ func (ds *DurableStore) SetChannelToSwaps(swap payments.Swap) (payments.Swap, er
 
 	return removedSwap, nil
 }
+
+func (ds *DurableStore) AddWebhookDeadLetter(d WebhookDeadLetter) error {
+	dJSON, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("error adding dead letter %s: %w", d.Id, err)
+	}
+	return ds.webhookDeadLetters.Update(func(tx *buntdb.Tx) error {
+		return ds.set(tx, webhookDeadLetterKey(d.Url, d.Id), string(dJSON))
+	})
+}
+
+func (ds *DurableStore) GetWebhookDeadLetters() ([]WebhookDeadLetter, error) {
+	letters := []WebhookDeadLetter{}
+
+	var decodeErr error
+	err := ds.webhookDeadLetters.View(func(tx *buntdb.Tx) error {
+		return ds.ascend(tx, func(key, dJSON string) bool {
+			var d WebhookDeadLetter
+			err := json.Unmarshal([]byte(dJSON), &d)
+			if err != nil {
+				decodeErr = fmt.Errorf("error decoding dead letter %s: %w", key, err)
+				return false
+			}
+			letters = append(letters, d)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return letters, nil
+}
+
+func (ds *DurableStore) RemoveWebhookDeadLetter(url string, id string) error {
+	err := ds.webhookDeadLetters.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(webhookDeadLetterKey(url, id))
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (ds *DurableStore) SetOutboxTx(o chainservice.OutboxTx) error {
+	oJSON, err := json.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("error setting outbox transaction %s: %w", o.Id, err)
+	}
+	return ds.txOutbox.Update(func(tx *buntdb.Tx) error {
+		return ds.set(tx, o.Id, string(oJSON))
+	})
+}
+
+func (ds *DurableStore) GetOutboxTxs() ([]chainservice.OutboxTx, error) {
+	txs := []chainservice.OutboxTx{}
+
+	var decodeErr error
+	err := ds.txOutbox.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend("", func(key, value string) bool {
+			oJSON, err := ds.openValue(value)
+			if err != nil {
+				decodeErr = err
+				return false
+			}
+			var o chainservice.OutboxTx
+			err = json.Unmarshal([]byte(oJSON), &o)
+			if err != nil {
+				decodeErr = fmt.Errorf("error decoding outbox transaction %s: %w", key, err)
+				return false
+			}
+			txs = append(txs, o)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return txs, nil
+}
+
+func (ds *DurableStore) RemoveOutboxTx(id string) error {
+	err := ds.txOutbox.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(id)
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return nil
+	}
+	return err
+}
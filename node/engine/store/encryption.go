@@ -0,0 +1,325 @@
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/statechannels/go-nitro/types"
+	"github.com/tidwall/buntdb"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	ErrWrongEncryptionKey = types.ConstError("store: wrong encryption key or passphrase")
+	ErrStoreEncrypted     = types.ConstError("store: store is encrypted but no encryption key or passphrase was supplied")
+	ErrStoreNotEncrypted  = types.ConstError("store: store is not encrypted, encrypt it in place before supplying an encryption key or passphrase")
+
+	encryptedValuePrefix = "enc:"
+	encryptionSaltKey    = "encryptionSalt"
+	encryptionCheckKey   = "encryptionCheck"
+	encryptionCheckValue = "go-nitro durable store"
+
+	encryptionKeyLength = 32 // AES-256
+	saltLength          = 16
+)
+
+// EncryptionOpts configures encryption at rest of a DurableStore. Encryption is disabled if neither field is set.
+//
+// Record values are encrypted with AES-256-GCM. Record keys (channel and objective ids) are stored in the clear, as they are needed for lookups.
+type EncryptionOpts struct {
+	// Key is a 32 byte key, e.g. read from an environment variable or key file. It takes precedence over Passphrase.
+	Key []byte
+	// Passphrase is used to derive a key with scrypt. The salt is stored in the store's metadata.
+	Passphrase string
+}
+
+// Enabled returns true if a key or passphrase was supplied
+func (eo EncryptionOpts) Enabled() bool {
+	return len(eo.Key) > 0 || eo.Passphrase != ""
+}
+
+// deriveKey returns the encryption key, deriving it from the passphrase and salt if no key was supplied
+func (eo EncryptionOpts) deriveKey(salt []byte) ([]byte, error) {
+	if len(eo.Key) > 0 {
+		if len(eo.Key) != encryptionKeyLength {
+			return nil, fmt.Errorf("encryption key must be %d bytes, got %d", encryptionKeyLength, len(eo.Key))
+		}
+		return eo.Key, nil
+	}
+	return scrypt.Key([]byte(eo.Passphrase), salt, 1<<15, 8, 1, encryptionKeyLength)
+}
+
+// valueCipher encrypts and decrypts record values
+type valueCipher struct {
+	aead cipher.AEAD
+}
+
+func newValueCipher(key []byte) (*valueCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &valueCipher{aead: aead}, nil
+}
+
+// seal encrypts plaintext under a random nonce, returning a prefixed, base64 encoded value
+func (vc *valueCipher) seal(plaintext string) (string, error) {
+	nonce := make([]byte, vc.aead.NonceSize())
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+	sealed := vc.aead.Seal(nonce, nonce, []byte(plaintext), nil)
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// open decrypts a value produced by seal
+func (vc *valueCipher) open(value string) (string, error) {
+	if !isEncryptedValue(value) {
+		return "", fmt.Errorf("store: found an unencrypted record in an encrypted store, finish encrypting the store in place")
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil {
+		return "", err
+	}
+	nonceSize := vc.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("store: encrypted record is too short")
+	}
+	plaintext, err := vc.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return "", ErrWrongEncryptionKey
+	}
+	return string(plaintext), nil
+}
+
+func isEncryptedValue(value string) bool {
+	return strings.HasPrefix(value, encryptedValuePrefix)
+}
+
+// sealValue encrypts the value if the store is encrypted
+func (ds *DurableStore) sealValue(value string) (string, error) {
+	if ds.cipher == nil {
+		return value, nil
+	}
+	return ds.cipher.seal(value)
+}
+
+// openValue decrypts the value if the store is encrypted
+func (ds *DurableStore) openValue(value string) (string, error) {
+	if ds.cipher == nil {
+		return value, nil
+	}
+	return ds.cipher.open(value)
+}
+
+// get reads the value stored against key, decrypting it if the store is encrypted
+func (ds *DurableStore) get(tx *buntdb.Tx, key string) (string, error) {
+	value, err := tx.Get(key)
+	if err != nil {
+		return "", err
+	}
+	return ds.openValue(value)
+}
+
+// set stores value against key, encrypting it if the store is encrypted
+func (ds *DurableStore) set(tx *buntdb.Tx, key, value string) error {
+	sealed, err := ds.sealValue(value)
+	if err != nil {
+		return err
+	}
+	_, _, err = tx.Set(key, sealed, nil)
+	return err
+}
+
+// ascend iterates over every record of a table in key order, decrypting values if the store is encrypted
+func (ds *DurableStore) ascend(tx *buntdb.Tx, iterator func(key, value string) bool) error {
+	var openErr error
+	err := tx.Ascend("", func(key, value string) bool {
+		value, openErr = ds.openValue(value)
+		if openErr != nil {
+			return false
+		}
+		return iterator(key, value)
+	})
+	if err != nil {
+		return err
+	}
+	return openErr
+}
+
+// getMetadata returns the metadata value stored against key, or "" if there is none
+func (ds *DurableStore) getMetadata(key string) (string, error) {
+	var value string
+	err := ds.metadata.View(func(tx *buntdb.Tx) error {
+		var err error
+		value, err = tx.Get(key)
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return "", nil
+	}
+	return value, err
+}
+
+func (ds *DurableStore) setMetadata(key, value string) error {
+	return ds.metadata.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(key, value, nil)
+		return err
+	})
+}
+
+// isEncrypted returns true if encryption has been initialised for the store
+func (ds *DurableStore) isEncrypted() (bool, error) {
+	check, err := ds.getMetadata(encryptionCheckKey)
+	return check != "", err
+}
+
+// unlock derives the store's key from opts and checks it against the store's encryption check record.
+// The store must already be encrypted.
+func (ds *DurableStore) unlock(opts EncryptionOpts) error {
+	salt, err := ds.getMetadata(encryptionSaltKey)
+	if err != nil {
+		return err
+	}
+	saltBytes, err := base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return err
+	}
+	key, err := opts.deriveKey(saltBytes)
+	if err != nil {
+		return err
+	}
+	vc, err := newValueCipher(key)
+	if err != nil {
+		return err
+	}
+
+	check, err := ds.getMetadata(encryptionCheckKey)
+	if err != nil {
+		return err
+	}
+	plaintext, err := vc.open(check)
+	if err != nil || plaintext != encryptionCheckValue {
+		return ErrWrongEncryptionKey
+	}
+
+	ds.cipher = vc
+	return nil
+}
+
+// initEncryption records a new salt and encryption check record for the key derived from opts, and unlocks the store
+func (ds *DurableStore) initEncryption(opts EncryptionOpts) error {
+	salt := make([]byte, saltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return err
+	}
+	key, err := opts.deriveKey(salt)
+	if err != nil {
+		return err
+	}
+	vc, err := newValueCipher(key)
+	if err != nil {
+		return err
+	}
+	check, err := vc.seal(encryptionCheckValue)
+	if err != nil {
+		return err
+	}
+
+	// The salt is written before the check record, as the check record marks the store as encrypted
+	err = ds.setMetadata(encryptionSaltKey, base64.StdEncoding.EncodeToString(salt))
+	if err != nil {
+		return err
+	}
+	err = ds.setMetadata(encryptionCheckKey, check)
+	if err != nil {
+		return err
+	}
+
+	ds.cipher = vc
+	return nil
+}
+
+// setupEncryption unlocks an encrypted store, or initialises encryption for a new store.
+// An existing unencrypted store must be encrypted with EncryptDurableStore first.
+func (ds *DurableStore) setupEncryption(opts EncryptionOpts) error {
+	encrypted, err := ds.isEncrypted()
+	if err != nil {
+		return err
+	}
+
+	if !opts.Enabled() {
+		if encrypted {
+			return ErrStoreEncrypted
+		}
+		return nil
+	}
+
+	if encrypted {
+		return ds.unlock(opts)
+	}
+
+	empty, err := ds.isEmpty()
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return ErrStoreNotEncrypted
+	}
+	return ds.initEncryption(opts)
+}
+
+// EncryptDurableStore encrypts every record of the unencrypted store in folder, owned by the node with the given address, in place.
+// It returns the number of records encrypted. An interrupted encryption can be resumed by running it again with the same key or passphrase.
+func EncryptDurableStore(address types.Address, folder string, opts EncryptionOpts) (int, error) {
+	if !opts.Enabled() {
+		return 0, fmt.Errorf("an encryption key or passphrase must be supplied")
+	}
+
+	ds, err := openDurableStore(address, folder, buntdb.Config{})
+	if err != nil {
+		return 0, err
+	}
+	defer ds.Close()
+
+	encrypted, err := ds.isEncrypted()
+	if err != nil {
+		return 0, err
+	}
+	if encrypted {
+		err = ds.unlock(opts)
+	} else {
+		err = ds.initEncryption(opts)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	encryptRecord := func(key, value string) (string, error) {
+		if isEncryptedValue(value) {
+			return value, nil
+		}
+		return ds.cipher.seal(value)
+	}
+
+	count := 0
+	for name, db := range ds.tables() {
+		changed, err := migrateTable(db, encryptRecord, false)
+		if err != nil {
+			return count, fmt.Errorf("error encrypting table %s: %w", name, err)
+		}
+		count += len(changed)
+	}
+	return count, nil
+}
@@ -0,0 +1,162 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/statechannels/go-nitro/crypto"
+	td "github.com/statechannels/go-nitro/internal/testdata"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/tidwall/buntdb"
+)
+
+// assertSameObjective compares objectives by their stored encoding
+func assertSameObjective(t *testing.T, got, want protocols.Objective) {
+	t.Helper()
+	gotJSON, err := got.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON, err := want.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(gotJSON) != string(wantJSON) {
+		t.Fatalf("expected the retrieved objective %s to equal the stored objective %s", gotJSON, wantJSON)
+	}
+}
+
+func TestEncryptedDurableStore(t *testing.T) {
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+	enc := EncryptionOpts{Passphrase: "correct horse battery staple"}
+
+	s, err := NewEncryptedDurableStore(migrationTestKey, dataFolder, buntdb.Config{}, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dfo := td.Objectives.Directfund.GenericDFO()
+	if err := s.SetObjective(&dfo); err != nil {
+		t.Fatal(err)
+	}
+
+	raw := getRecord(t, s.(*DurableStore).objectives, string(dfo.Id()))
+	if !strings.HasPrefix(raw, encryptedValuePrefix) || strings.Contains(raw, dfo.C.Id.String()) {
+		t.Fatalf("expected the objective to be stored encrypted, got %s", raw)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewEncryptedDurableStore(migrationTestKey, dataFolder, buntdb.Config{}, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reopened.GetObjectiveById(dfo.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameObjective(t, got, &dfo)
+	if err := reopened.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewEncryptedDurableStore(migrationTestKey, dataFolder, buntdb.Config{}, EncryptionOpts{Passphrase: "wrong"})
+	if !errors.Is(err, ErrWrongEncryptionKey) {
+		t.Fatalf("expected ErrWrongEncryptionKey, got %v", err)
+	}
+
+	_, err = NewDurableStore(migrationTestKey, dataFolder, buntdb.Config{})
+	if !errors.Is(err, ErrStoreEncrypted) {
+		t.Fatalf("expected ErrStoreEncrypted, got %v", err)
+	}
+}
+
+func TestEncryptDurableStore(t *testing.T) {
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+	enc := EncryptionOpts{Key: make([]byte, encryptionKeyLength)}
+
+	s, err := NewDurableStore(migrationTestKey, dataFolder, buntdb.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dfo := td.Objectives.Directfund.GenericDFO()
+	if err := s.SetObjective(&dfo); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetLastBlockNumSeen(15); err != nil {
+		t.Fatal(err)
+	}
+	deadLetter := WebhookDeadLetter{Id: "1", Url: "http://localhost/hook", Payload: "{}", Attempts: 3}
+	if err := s.AddWebhookDeadLetter(deadLetter); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewEncryptedDurableStore(migrationTestKey, dataFolder, buntdb.Config{}, enc)
+	if !errors.Is(err, ErrStoreNotEncrypted) {
+		t.Fatalf("expected an unencrypted store to be rejected, got %v", err)
+	}
+
+	count, err := EncryptDurableStore(crypto.GetAddressFromSecretKeyBytes(migrationTestKey), dataFolder, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count == 0 {
+		t.Fatal("expected records to be encrypted")
+	}
+
+	// A resumed encryption skips records that are already encrypted
+	count, err = EncryptDurableStore(crypto.GetAddressFromSecretKeyBytes(migrationTestKey), dataFolder, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected no records to be encrypted again, got %d", count)
+	}
+
+	encrypted, err := NewEncryptedDurableStore(migrationTestKey, dataFolder, buntdb.Config{}, enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encrypted.Close()
+
+	for name, db := range encrypted.(*DurableStore).tables() {
+		err := db.View(func(tx *buntdb.Tx) error {
+			return tx.Ascend("", func(key, value string) bool {
+				if !isEncryptedValue(value) {
+					t.Errorf("expected record %s/%s to be encrypted", name, key)
+				}
+				return true
+			})
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := encrypted.GetObjectiveById(dfo.Id())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameObjective(t, got, &dfo)
+	blockNum, err := encrypted.GetLastBlockNumSeen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blockNum != 15 {
+		t.Fatalf("expected last block num seen 15, got %d", blockNum)
+	}
+	letters, err := encrypted.GetWebhookDeadLetters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(letters) != 1 || letters[0].Id != deadLetter.Id || letters[0].Url != deadLetter.Url {
+		t.Fatalf("expected dead letter %+v, got %+v", deadLetter, letters)
+	}
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"time"
+
+	"github.com/statechannels/go-nitro/channel"
+	"github.com/statechannels/go-nitro/channel/consensus_channel"
+	"github.com/statechannels/go-nitro/internal/metrics"
+	"github.com/statechannels/go-nitro/payments"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// instrumentedStore wraps a Store, recording the latency of the operations performed while cranking objectives.
+type instrumentedStore struct {
+	Store
+}
+
+// newInstrumentedStore returns a Store which records the latency of operations on the supplied store
+func newInstrumentedStore(s Store) Store {
+	return &instrumentedStore{s}
+}
+
+func (s *instrumentedStore) GetObjectiveById(id protocols.ObjectiveId) (protocols.Objective, error) {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "GetObjectiveById", time.Now())
+	return s.Store.GetObjectiveById(id)
+}
+
+func (s *instrumentedStore) GetObjectiveByChannelId(id types.Destination) (protocols.Objective, bool) {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "GetObjectiveByChannelId", time.Now())
+	return s.Store.GetObjectiveByChannelId(id)
+}
+
+func (s *instrumentedStore) SetObjective(o protocols.Objective) error {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "SetObjective", time.Now())
+	return s.Store.SetObjective(o)
+}
+
+func (s *instrumentedStore) GetChannelById(id types.Destination) (*channel.Channel, bool) {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "GetChannelById", time.Now())
+	return s.Store.GetChannelById(id)
+}
+
+func (s *instrumentedStore) SetChannel(c *channel.Channel) error {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "SetChannel", time.Now())
+	return s.Store.SetChannel(c)
+}
+
+func (s *instrumentedStore) GetConsensusChannel(counterparty types.Address) (*consensus_channel.ConsensusChannel, bool) {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "GetConsensusChannel", time.Now())
+	return s.Store.GetConsensusChannel(counterparty)
+}
+
+func (s *instrumentedStore) GetConsensusChannelById(id types.Destination) (*consensus_channel.ConsensusChannel, error) {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "GetConsensusChannelById", time.Now())
+	return s.Store.GetConsensusChannelById(id)
+}
+
+func (s *instrumentedStore) SetConsensusChannel(c *consensus_channel.ConsensusChannel) error {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "SetConsensusChannel", time.Now())
+	return s.Store.SetConsensusChannel(c)
+}
+
+func (s *instrumentedStore) GetVoucherInfo(channelId types.Destination) (*payments.VoucherInfo, error) {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "GetVoucherInfo", time.Now())
+	return s.Store.GetVoucherInfo(channelId)
+}
+
+func (s *instrumentedStore) SetVoucherInfo(channelId types.Destination, v payments.VoucherInfo) error {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "SetVoucherInfo", time.Now())
+	return s.Store.SetVoucherInfo(channelId, v)
+}
+
+func (s *instrumentedStore) AddOutboxMessage(m OutboxMessage) error {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "AddOutboxMessage", time.Now())
+	return s.Store.AddOutboxMessage(m)
+}
+
+func (s *instrumentedStore) AddObjectiveProgress(id protocols.ObjectiveId, entry ObjectiveProgress) error {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "AddObjectiveProgress", time.Now())
+	return s.Store.AddObjectiveProgress(id, entry)
+}
+
+func (s *instrumentedStore) SetPendingSideEffects(id protocols.ObjectiveId, se PendingSideEffects) error {
+	defer metrics.ObserveSince(metrics.StoreOperationDuration, "SetPendingSideEffects", time.Now())
+	return s.Store.SetPendingSideEffects(id, se)
+}
@@ -3,25 +3,21 @@ package store
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/statechannels/go-nitro/channel"
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/internal/safesync"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
 	"github.com/statechannels/go-nitro/payments"
 	"github.com/statechannels/go-nitro/protocols"
-	"github.com/statechannels/go-nitro/protocols/bridgeddefund"
-	"github.com/statechannels/go-nitro/protocols/bridgedfund"
-	"github.com/statechannels/go-nitro/protocols/directdefund"
 	"github.com/statechannels/go-nitro/protocols/directfund"
-	"github.com/statechannels/go-nitro/protocols/mirrorbridgeddefund"
+	"github.com/statechannels/go-nitro/protocols/registry"
 	"github.com/statechannels/go-nitro/protocols/swap"
-	"github.com/statechannels/go-nitro/protocols/swapdefund"
-	"github.com/statechannels/go-nitro/protocols/swapfund"
-	"github.com/statechannels/go-nitro/protocols/virtualdefund"
-	"github.com/statechannels/go-nitro/protocols/virtualfund"
 	"github.com/statechannels/go-nitro/types"
 )
 
@@ -38,17 +34,28 @@ type MemStore struct {
 	vouchers           safesync.Map[[]byte]
 	swaps              safesync.Map[[]byte]
 	channelToSwaps     safesync.Map[[]byte]
+	pendingSideEffects safesync.Map[[]byte]
+	failureReasons     safesync.Map[[]byte]
+	policyDecisions    safesync.Map[[]byte]
+	objectiveProgress  safesync.Map[[]byte]
+	webhookDeadLetters safesync.Map[[]byte]
+	txOutbox           safesync.Map[[]byte]
 
 	lastBlockSeen blockData
 
-	key     string // the signing key of the store's engine
-	address string // the (Ethereum) address associated to the signing key
+	*memOutbox
+
+	address string // the (Ethereum) address of the store's engine
 }
 
+// NewMemStore creates a MemStore for the node with the given private key. The key is only used to derive the node's address.
 func NewMemStore(key []byte) Store {
+	return newMemStore(crypto.GetAddressFromSecretKeyBytes(key))
+}
+
+func newMemStore(address types.Address) Store {
 	ms := MemStore{}
-	ms.key = common.Bytes2Hex(key)
-	ms.address = crypto.GetAddressFromSecretKeyBytes(key).String()
+	ms.address = address.String()
 
 	ms.objectives = safesync.Map[[]byte]{}
 	ms.channels = safesync.Map[[]byte]{}
@@ -58,6 +65,13 @@ func NewMemStore(key []byte) Store {
 	ms.lastBlockSeen = blockData{}
 	ms.swaps = safesync.Map[[]byte]{}
 	ms.channelToSwaps = safesync.Map[[]byte]{}
+	ms.pendingSideEffects = safesync.Map[[]byte]{}
+	ms.failureReasons = safesync.Map[[]byte]{}
+	ms.policyDecisions = safesync.Map[[]byte]{}
+	ms.objectiveProgress = safesync.Map[[]byte]{}
+	ms.webhookDeadLetters = safesync.Map[[]byte]{}
+	ms.txOutbox = safesync.Map[[]byte]{}
+	ms.memOutbox = newMemOutbox()
 	return &ms
 }
 
@@ -71,11 +85,6 @@ func (ms *MemStore) GetAddress() *types.Address {
 	return &address
 }
 
-func (ms *MemStore) GetChannelSecretKey() *[]byte {
-	val := common.Hex2Bytes(ms.key)
-	return &val
-}
-
 func (ms *MemStore) GetSwapById(id types.Destination) (payments.Swap, error) {
 	sJSON, ok := ms.swaps.Load(id.String())
 	if !ok {
@@ -298,6 +307,146 @@ func (ms *MemStore) DestroyObjective(id protocols.ObjectiveId) error {
 	return nil
 }
 
+// GetIncompleteObjectives returns the objectives which are approved but not yet completed, sorted by id
+func (ms *MemStore) GetIncompleteObjectives() ([]protocols.Objective, error) {
+	return ms.GetObjectives(ObjectiveFilter{Statuses: []protocols.ObjectiveStatus{protocols.Approved}})
+}
+
+func (ms *MemStore) GetObjectives(filter ObjectiveFilter) ([]protocols.Objective, error) {
+	ids := []string{}
+	ms.objectives.Range(func(key string, objJSON []byte) bool {
+		ids = append(ids, key)
+		return true
+	})
+	sort.Strings(ids)
+
+	objectives := []protocols.Objective{}
+	for _, id := range ids {
+		obj, err := ms.GetObjectiveById(protocols.ObjectiveId(id))
+		if err != nil && (obj == nil || !isFinished(obj)) {
+			return nil, err
+		}
+		if filter.Matches(obj) {
+			objectives = append(objectives, obj)
+		}
+	}
+	return objectives, nil
+}
+
+func (ms *MemStore) SetPendingSideEffects(id protocols.ObjectiveId, se PendingSideEffects) error {
+	seJSON, err := json.Marshal(se)
+	if err != nil {
+		return fmt.Errorf("error setting pending side effects of objective %s: %w", id, err)
+	}
+	ms.pendingSideEffects.Store(string(id), seJSON)
+	return nil
+}
+
+func (ms *MemStore) GetPendingSideEffects(id protocols.ObjectiveId) (PendingSideEffects, bool, error) {
+	seJSON, ok := ms.pendingSideEffects.Load(string(id))
+	if !ok {
+		return PendingSideEffects{}, false, nil
+	}
+	var se PendingSideEffects
+	err := json.Unmarshal(seJSON, &se)
+	if err != nil {
+		return PendingSideEffects{}, false, fmt.Errorf("error decoding pending side effects of objective %s: %w", id, err)
+	}
+	return se, true, nil
+}
+
+func (ms *MemStore) RemovePendingSideEffects(id protocols.ObjectiveId) error {
+	ms.pendingSideEffects.Delete(string(id))
+	return nil
+}
+
+func (ms *MemStore) SetFailureReason(id protocols.ObjectiveId, reason protocols.FailureReason) error {
+	reasonJSON, err := json.Marshal(reason)
+	if err != nil {
+		return fmt.Errorf("error setting failure reason of objective %s: %w", id, err)
+	}
+	ms.failureReasons.Store(string(id), reasonJSON)
+	return nil
+}
+
+func (ms *MemStore) GetFailureReason(id protocols.ObjectiveId) (protocols.FailureReason, bool, error) {
+	reasonJSON, ok := ms.failureReasons.Load(string(id))
+	if !ok {
+		return protocols.FailureReason{}, false, nil
+	}
+	var reason protocols.FailureReason
+	err := json.Unmarshal(reasonJSON, &reason)
+	if err != nil {
+		return protocols.FailureReason{}, false, fmt.Errorf("error decoding failure reason of objective %s: %w", id, err)
+	}
+	return reason, true, nil
+}
+
+func (ms *MemStore) SetPolicyDecision(d PolicyDecision) error {
+	dJSON, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("error setting policy decision of objective %s: %w", d.ObjectiveId, err)
+	}
+	ms.policyDecisions.Store(string(d.ObjectiveId), dJSON)
+	return nil
+}
+
+func (ms *MemStore) GetPolicyDecision(id protocols.ObjectiveId) (PolicyDecision, bool, error) {
+	dJSON, ok := ms.policyDecisions.Load(string(id))
+	if !ok {
+		return PolicyDecision{}, false, nil
+	}
+	var d PolicyDecision
+	err := json.Unmarshal(dJSON, &d)
+	if err != nil {
+		return PolicyDecision{}, false, fmt.Errorf("error decoding policy decision of objective %s: %w", id, err)
+	}
+	return d, true, nil
+}
+
+func (ms *MemStore) PrunePolicyDecisions(before time.Time) error {
+	var err error
+	ms.policyDecisions.Range(func(id string, dJSON []byte) bool {
+		var d PolicyDecision
+		err = json.Unmarshal(dJSON, &d)
+		if err != nil {
+			err = fmt.Errorf("error decoding policy decision of objective %s: %w", id, err)
+			return false
+		}
+		if d.DecidedAt.Before(before) {
+			ms.policyDecisions.Delete(id)
+		}
+		return true
+	})
+	return err
+}
+
+func (ms *MemStore) AddObjectiveProgress(id protocols.ObjectiveId, entry ObjectiveProgress) error {
+	history, err := ms.GetObjectiveProgress(id)
+	if err != nil {
+		return err
+	}
+	historyJSON, err := json.Marshal(append(history, entry))
+	if err != nil {
+		return fmt.Errorf("error adding progress of objective %s: %w", id, err)
+	}
+	ms.objectiveProgress.Store(string(id), historyJSON)
+	return nil
+}
+
+func (ms *MemStore) GetObjectiveProgress(id protocols.ObjectiveId) ([]ObjectiveProgress, error) {
+	historyJSON, ok := ms.objectiveProgress.Load(string(id))
+	if !ok {
+		return []ObjectiveProgress{}, nil
+	}
+	var history []ObjectiveProgress
+	err := json.Unmarshal(historyJSON, &history)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding progress of objective %s: %w", id, err)
+	}
+	return history, nil
+}
+
 // SetConsensusChannel sets the channel in the store.
 func (ms *MemStore) SetConsensusChannel(ch *consensus_channel.ConsensusChannel) error {
 	if ch.Id.IsZero() {
@@ -552,253 +701,18 @@ func (ms *MemStore) GetObjectiveByChannelId(channelId types.Destination) (protoc
 // objective and attaches it to the objective. The channel data is attached
 // in-place of the objectives existing channel pointers.
 func (ms *MemStore) populateChannelData(obj protocols.Objective) error {
-	id := obj.Id()
-
-	switch o := obj.(type) {
-	case *directfund.Objective:
-		ch, err := ms.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
-
-		o.C = &ch
-
-		return nil
-	case *directdefund.Objective:
-
-		ch, err := ms.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
-
-		o.C = &ch
-
-		// Populate virtual channels if present
-		if len(o.FundedChannels) != 0 {
-			for virtualChannelId := range o.FundedChannels {
-				updatedVirtualChannel, _ := ms.GetChannelById(virtualChannelId)
-				o.FundedChannels[virtualChannelId] = updatedVirtualChannel
-			}
-		}
-
-		return nil
-	case *virtualfund.Objective:
-		v, err := ms.getChannelById(o.V.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving virtual channel data for objective %s: %w", id, err)
-		}
-		o.V = &channel.VirtualChannel{Channel: v}
-
-		zeroAddress := types.Destination{}
-
-		if o.ToMyLeft != nil &&
-			o.ToMyLeft.Channel != nil &&
-			o.ToMyLeft.Channel.Id != zeroAddress {
-
-			left, err := ms.GetConsensusChannelById(o.ToMyLeft.Channel.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving left ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyLeft.Channel = left
-		}
-
-		if o.ToMyRight != nil &&
-			o.ToMyRight.Channel != nil &&
-			o.ToMyRight.Channel.Id != zeroAddress {
-			right, err := ms.GetConsensusChannelById(o.ToMyRight.Channel.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving right ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyRight.Channel = right
-		}
-
-		return nil
-	case *virtualdefund.Objective:
-		v, err := ms.getChannelById(o.V.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving virtual channel data for objective %s: %w", id, err)
-		}
-		o.V = &channel.VirtualChannel{Channel: v}
-
-		zeroAddress := types.Destination{}
-
-		if o.ToMyLeft != nil &&
-			o.ToMyLeft.Id != zeroAddress {
-
-			left, err := ms.GetConsensusChannelById(o.ToMyLeft.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving left ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyLeft = left
-		}
-
-		if o.ToMyRight != nil &&
-			o.ToMyRight.Id != zeroAddress {
-			right, err := ms.GetConsensusChannelById(o.ToMyRight.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving right ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyRight = right
-		}
-		return nil
-	case *swap.Objective:
-		ch, err := ms.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
-
-		swap, err := ms.GetSwapById(o.Swap.Id)
-		if err != nil {
-			return fmt.Errorf("error getting swap by id: %w", err)
-		}
-
-		o.Swap = swap
-		o.C = &channel.SwapChannel{Channel: ch}
-		return nil
-
-	case *swapfund.Objective:
-		v, err := ms.getChannelById(o.S.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving swap channel data for objective %s: %w", id, err)
-		}
-		o.S = &channel.SwapChannel{Channel: v}
-
-		zeroAddress := types.Destination{}
-
-		if o.ToMyLeft != nil &&
-			o.ToMyLeft.Channel != nil &&
-			o.ToMyLeft.Channel.Id != zeroAddress {
-
-			left, err := ms.GetConsensusChannelById(o.ToMyLeft.Channel.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving left ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyLeft.Channel = left
-		}
-
-		if o.ToMyRight != nil &&
-			o.ToMyRight.Channel != nil &&
-			o.ToMyRight.Channel.Id != zeroAddress {
-			right, err := ms.GetConsensusChannelById(o.ToMyRight.Channel.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving right ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyRight.Channel = right
-		}
-
-		return nil
-
-	case *swapdefund.Objective:
-		s, err := ms.getChannelById(o.S.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving virtual channel data for objective %s: %w", id, err)
-		}
-		o.S = &channel.SwapChannel{Channel: s}
-
-		zeroAddress := types.Destination{}
-
-		if o.ToMyLeft != nil &&
-			o.ToMyLeft.Id != zeroAddress {
-
-			left, err := ms.GetConsensusChannelById(o.ToMyLeft.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving left ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyLeft = left
-		}
-
-		if o.ToMyRight != nil &&
-			o.ToMyRight.Id != zeroAddress {
-			right, err := ms.GetConsensusChannelById(o.ToMyRight.Id)
-			if err != nil {
-				return fmt.Errorf("error retrieving right ledger channel data for objective %s: %w", id, err)
-			}
-			o.ToMyRight = right
-		}
-		return nil
-	case *bridgedfund.Objective:
-		ch, err := ms.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
-
-		o.C = &ch
-
-		return nil
-	case *bridgeddefund.Objective:
-		ch, err := ms.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
-
-		o.C = &ch
-
-		return nil
-
-	case *mirrorbridgeddefund.Objective:
-		ch, err := ms.getChannelById(o.C.Id)
-		if err != nil {
-			return fmt.Errorf("error retrieving channel data for objective %s: %w", id, err)
-		}
-
-		o.C = &ch
-
-		return nil
-
-	default:
-		return fmt.Errorf("objective %s did not correctly represent a known Objective type", id)
-	}
+	return registry.PopulateChannelData(obj, registry.ChannelData{
+		GetChannel:          ms.getChannelById,
+		GetConsensusChannel: ms.GetConsensusChannelById,
+		GetSwap:             ms.GetSwapById,
+	})
 }
 
 // decodeObjective is a helper which encapsulates the deserialization
 // of Objective JSON data. The decoded objectives will not have any
 // channel data other than the channel Id.
 func decodeObjective(id protocols.ObjectiveId, data []byte) (protocols.Objective, error) {
-	switch {
-	case directfund.IsDirectFundObjective(id):
-		dfo := directfund.Objective{}
-		err := dfo.UnmarshalJSON(data)
-		return &dfo, err
-	case directdefund.IsDirectDefundObjective(id):
-		ddfo := directdefund.Objective{}
-		err := ddfo.UnmarshalJSON(data)
-		return &ddfo, err
-	case virtualfund.IsVirtualFundObjective(id):
-		vfo := virtualfund.Objective{}
-		err := vfo.UnmarshalJSON(data)
-		return &vfo, err
-	case virtualdefund.IsVirtualDefundObjective(id):
-		dvfo := virtualdefund.Objective{}
-		err := dvfo.UnmarshalJSON(data)
-		return &dvfo, err
-	case bridgedfund.IsBridgedFundObjective(id):
-		bfo := bridgedfund.Objective{}
-		err := bfo.UnmarshalJSON(data)
-		return &bfo, err
-	case bridgeddefund.IsBridgedDefundObjective(id):
-		bdfo := bridgeddefund.Objective{}
-		err := bdfo.UnmarshalJSON(data)
-		return &bdfo, err
-	case mirrorbridgeddefund.IsMirrorBridgedDefundObjective(id):
-		mbdfo := mirrorbridgeddefund.Objective{}
-		err := mbdfo.UnmarshalJSON(data)
-		return &mbdfo, err
-	case swapfund.IsSwapFundObjective(id):
-		sfo := swapfund.Objective{}
-		err := sfo.UnmarshalJSON(data)
-		return &sfo, err
-	case swapdefund.IsSwapDefundObjective(id):
-		sdfo := swapdefund.Objective{}
-		err := sdfo.UnmarshalJSON(data)
-		return &sdfo, err
-	case swap.IsSwapObjective(id):
-		so := swap.Objective{}
-		err := so.UnmarshalJSON(data)
-		return &so, err
-	default:
-		return nil, fmt.Errorf("objective id %s does not correspond to a known Objective type", id)
-
-	}
+	return registry.Decode(id, data)
 }
 
 func (ms *MemStore) ReleaseChannelFromOwnership(channelId types.Destination) error {
@@ -834,6 +748,165 @@ func (ms *MemStore) RemoveVoucherInfo(channelId types.Destination) error {
 	return nil
 }
 
+func (ms *MemStore) AddWebhookDeadLetter(d WebhookDeadLetter) error {
+	dJSON, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("error adding dead letter %s: %w", d.Id, err)
+	}
+	ms.webhookDeadLetters.Store(webhookDeadLetterKey(d.Url, d.Id), dJSON)
+	return nil
+}
+
+func (ms *MemStore) GetWebhookDeadLetters() ([]WebhookDeadLetter, error) {
+	letters := []WebhookDeadLetter{}
+	var err error
+	ms.webhookDeadLetters.Range(func(key string, dJSON []byte) bool {
+		var d WebhookDeadLetter
+		err = json.Unmarshal(dJSON, &d)
+		if err != nil {
+			err = fmt.Errorf("error decoding dead letter %s: %w", key, err)
+			return false
+		}
+		letters = append(letters, d)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	sortWebhookDeadLetters(letters)
+	return letters, nil
+}
+
+func (ms *MemStore) RemoveWebhookDeadLetter(url string, id string) error {
+	ms.webhookDeadLetters.Delete(webhookDeadLetterKey(url, id))
+	return nil
+}
+
+func (ms *MemStore) SetOutboxTx(o chainservice.OutboxTx) error {
+	oJSON, err := json.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("error setting outbox transaction %s: %w", o.Id, err)
+	}
+	ms.txOutbox.Store(o.Id, oJSON)
+	return nil
+}
+
+func (ms *MemStore) GetOutboxTxs() ([]chainservice.OutboxTx, error) {
+	txs := []chainservice.OutboxTx{}
+	var err error
+	ms.txOutbox.Range(func(id string, oJSON []byte) bool {
+		var o chainservice.OutboxTx
+		err = json.Unmarshal(oJSON, &o)
+		if err != nil {
+			err = fmt.Errorf("error decoding outbox transaction %s: %w", id, err)
+			return false
+		}
+		txs = append(txs, o)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	chainservice.SortOutboxTxs(txs)
+	return txs, nil
+}
+
+func (ms *MemStore) RemoveOutboxTx(id string) error {
+	ms.txOutbox.Delete(id)
+	return nil
+}
+
+// memOutbox is an in-memory MessageOutbox
+type memOutbox struct {
+	mu       sync.Mutex
+	messages map[types.Address]map[string]OutboxMessage // keyed by recipient, then message id
+	received map[string]time.Time                       // receipt times keyed by sender and message id
+}
+
+// NewMemOutbox returns a MessageOutbox which does not persist messages across restarts
+func NewMemOutbox() MessageOutbox {
+	return newMemOutbox()
+}
+
+func newMemOutbox() *memOutbox {
+	return &memOutbox{
+		messages: make(map[types.Address]map[string]OutboxMessage),
+		received: make(map[string]time.Time),
+	}
+}
+
+func (mo *memOutbox) AddOutboxMessage(m OutboxMessage) error {
+	mo.mu.Lock()
+	defer mo.mu.Unlock()
+
+	if mo.messages[m.To] == nil {
+		mo.messages[m.To] = make(map[string]OutboxMessage)
+	}
+	mo.messages[m.To][m.Id] = m
+	return nil
+}
+
+func (mo *memOutbox) RemoveOutboxMessage(to types.Address, id string) error {
+	mo.mu.Lock()
+	defer mo.mu.Unlock()
+
+	delete(mo.messages[to], id)
+	if len(mo.messages[to]) == 0 {
+		delete(mo.messages, to)
+	}
+	return nil
+}
+
+func (mo *memOutbox) GetOutboxMessages(to types.Address) ([]OutboxMessage, error) {
+	mo.mu.Lock()
+	defer mo.mu.Unlock()
+
+	msgs := make([]OutboxMessage, 0, len(mo.messages[to]))
+	for _, m := range mo.messages[to] {
+		msgs = append(msgs, m)
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Id < msgs[j].Id })
+	return msgs, nil
+}
+
+func (mo *memOutbox) GetOutboxDepths() (map[types.Address]int, error) {
+	mo.mu.Lock()
+	defer mo.mu.Unlock()
+
+	depths := make(map[types.Address]int, len(mo.messages))
+	for to, msgs := range mo.messages {
+		depths[to] = len(msgs)
+	}
+	return depths, nil
+}
+
+func (mo *memOutbox) RecordReceivedMessage(from types.Address, id string) (bool, error) {
+	mo.mu.Lock()
+	defer mo.mu.Unlock()
+
+	now := time.Now()
+	for key, receivedAt := range mo.received {
+		if now.Sub(receivedAt) > ReceivedMessageTTL {
+			delete(mo.received, key)
+		}
+	}
+
+	key := receivedMessageKey(from, id)
+	if _, ok := mo.received[key]; ok {
+		return false, nil
+	}
+	mo.received[key] = now
+	return true, nil
+}
+
+func (mo *memOutbox) HasReceivedMessage(from types.Address, id string) (bool, error) {
+	mo.mu.Lock()
+	defer mo.mu.Unlock()
+
+	receivedAt, ok := mo.received[receivedMessageKey(from, id)]
+	return ok && time.Since(receivedAt) <= ReceivedMessageTTL, nil
+}
+
 // contains is a helper function which returns true if the given item is included in col
 func contains[T types.Destination | protocols.ObjectiveId](col []T, item T) bool {
 	for _, i := range col {
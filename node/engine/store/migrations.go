@@ -0,0 +1,288 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+
+	"github.com/statechannels/go-nitro/types"
+	"github.com/tidwall/buntdb"
+)
+
+const schemaVersionKey = "schemaVersion"
+
+// RecordMigration upgrades a single record of a DurableStore table. It returns the upgraded value,
+// or the value unchanged if the record needs no upgrade.
+//
+// Migrations may be interrupted part way through a table, so a RecordMigration must accept records it has already upgraded.
+type RecordMigration func(key, value string) (string, error)
+
+// DurableStoreMigration upgrades a DurableStore from schema version Version-1 to Version.
+type DurableStoreMigration struct {
+	Version     uint
+	Description string
+	// Records maps the name of a table (e.g. "objectives" or "channels") to the migration applied to each of its records
+	Records map[string]RecordMigration
+}
+
+// durableStoreMigrations is the registry of migrations, in order of version. The latest version is the version of the last migration.
+//
+// When a change to a stored type (e.g. virtualfund.Objective or channel.Channel) alters its JSON encoding,
+// append a migration which rewrites the existing records in the new encoding.
+var durableStoreMigrations = []DurableStoreMigration{
+	{
+		Version:     1,
+		Description: "Record the schema version of the store",
+	},
+}
+
+// MigrationChange describes a record that is (or would be, in a dry run) rewritten by a migration
+type MigrationChange struct {
+	Version uint
+	Table   string
+	Key     string
+}
+
+// MigrationReport describes the migrations applied (or that would be applied, in a dry run) when opening a DurableStore
+type MigrationReport struct {
+	FromVersion uint
+	ToVersion   uint
+	Applied     []DurableStoreMigration
+	Changes     []MigrationChange
+}
+
+func latestVersion(migrations []DurableStoreMigration) uint {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// MigrateDurableStore migrates the store in folder, owned by the node with the given address, to the latest schema version,
+// as NewDurableStore does, and reports the changes.
+// In a dry run the changes NewDurableStore would make are reported, but the store is left unchanged.
+// An encrypted store must be migrated with its encryption key or passphrase.
+func MigrateDurableStore(address types.Address, folder string, enc EncryptionOpts, dryRun bool) (MigrationReport, error) {
+	ds, err := openDurableStore(address, folder, buntdb.Config{})
+	if err != nil {
+		return MigrationReport{}, err
+	}
+	defer ds.Close()
+
+	err = ds.setupEncryption(enc)
+	if err != nil {
+		return MigrationReport{}, err
+	}
+
+	return ds.migrate(durableStoreMigrations, dryRun)
+}
+
+// tables returns the store's databases keyed by table name.
+// The received_messages table is omitted: its records are expiring message ids with empty values.
+func (ds *DurableStore) tables() map[string]*buntdb.DB {
+	return map[string]*buntdb.DB{
+		"objectives":           ds.objectives,
+		"channels":             ds.channels,
+		"consensus_channels":   ds.consensusChannels,
+		"channel_to_objective": ds.channelToObjective,
+		"vouchers":             ds.vouchers,
+		"lastBlockNumSeen":     ds.lastBlockNumSeen,
+		"swap":                 ds.swaps,
+		"channelToSwaps":       ds.channelToSwaps,
+		"outbox":               ds.outbox,
+		"pending_side_effects": ds.pendingSideEffects,
+		"failure_reasons":      ds.failureReasons,
+		"policy_decisions":     ds.policyDecisions,
+		"objective_progress":   ds.objectiveProgress,
+		"webhook_dead_letters": ds.webhookDeadLetters,
+		"tx_outbox":            ds.txOutbox,
+	}
+}
+
+// schemaVersion returns the schema version recorded in the store, and whether one was recorded
+func (ds *DurableStore) schemaVersion() (uint, bool, error) {
+	var val string
+	err := ds.metadata.View(func(tx *buntdb.Tx) error {
+		var err error
+		val, err = tx.Get(schemaVersionKey)
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	version, err := strconv.ParseUint(val, 10, 32)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid schema version %q: %w", val, err)
+	}
+	return uint(version), true, nil
+}
+
+func (ds *DurableStore) setSchemaVersion(version uint) error {
+	return ds.metadata.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(schemaVersionKey, strconv.FormatUint(uint64(version), 10), nil)
+		return err
+	})
+}
+
+// isEmpty returns true if none of the store's tables contain any records
+func (ds *DurableStore) isEmpty() (bool, error) {
+	for _, db := range ds.tables() {
+		n := 0
+		err := db.View(func(tx *buntdb.Tx) error {
+			var err error
+			n, err = tx.Len()
+			return err
+		})
+		if err != nil || n > 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// migrate brings the store up to the latest version of the supplied migrations.
+//
+// A store without a recorded version is either new, in which case it is stamped with the latest version,
+// or was written before versioning was introduced, in which case every migration is applied.
+// In a dry run the changes are reported but nothing is written.
+func (ds *DurableStore) migrate(migrations []DurableStoreMigration, dryRun bool) (MigrationReport, error) {
+	latest := latestVersion(migrations)
+
+	version, recorded, err := ds.schemaVersion()
+	if err != nil {
+		return MigrationReport{}, err
+	}
+	if !recorded {
+		empty, err := ds.isEmpty()
+		if err != nil {
+			return MigrationReport{}, err
+		}
+		if empty {
+			version = latest
+		}
+	}
+	report := MigrationReport{FromVersion: version, ToVersion: latest}
+
+	if version > latest {
+		return report, fmt.Errorf("store schema version %d is newer than the latest version %d supported by this node", version, latest)
+	}
+
+	tables := ds.tables()
+	for _, m := range migrations {
+		if m.Version <= version {
+			continue
+		}
+
+		// Tables are migrated in a fixed order so that reports are reproducible
+		tableNames := make([]string, 0, len(m.Records))
+		for table := range m.Records {
+			tableNames = append(tableNames, table)
+		}
+		sort.Strings(tableNames)
+
+		for _, table := range tableNames {
+			migrateRecord := m.Records[table]
+			db, ok := tables[table]
+			if !ok {
+				return report, fmt.Errorf("migration %d: unknown table %s", m.Version, table)
+			}
+
+			changes, err := migrateTable(db, ds.decrypted(migrateRecord), dryRun)
+			if err != nil {
+				return report, fmt.Errorf("migration %d: table %s: %w", m.Version, table, err)
+			}
+			for _, key := range changes {
+				report.Changes = append(report.Changes, MigrationChange{Version: m.Version, Table: table, Key: key})
+			}
+		}
+		report.Applied = append(report.Applied, m)
+
+		if dryRun {
+			continue
+		}
+		// The version is recorded after each migration so that an interrupted upgrade resumes from the failed migration
+		err = ds.setSchemaVersion(m.Version)
+		if err != nil {
+			return report, err
+		}
+		slog.Info("Migrated durable store", "version", m.Version, "description", m.Description)
+	}
+
+	if !recorded && !dryRun {
+		err = ds.setSchemaVersion(latest)
+		if err != nil {
+			return report, err
+		}
+	}
+
+	return report, nil
+}
+
+// decrypted wraps migrateRecord so that it is applied to decrypted values of an encrypted store.
+// Records the migration leaves unchanged keep their original ciphertext.
+func (ds *DurableStore) decrypted(migrateRecord RecordMigration) RecordMigration {
+	if ds.cipher == nil {
+		return migrateRecord
+	}
+	return func(key, value string) (string, error) {
+		plaintext, err := ds.openValue(value)
+		if err != nil {
+			return "", err
+		}
+		upgraded, err := migrateRecord(key, plaintext)
+		if err != nil || upgraded == plaintext {
+			return value, err
+		}
+		return ds.sealValue(upgraded)
+	}
+}
+
+// migrateTable applies migrateRecord to every record of db, returning the keys of the records that changed.
+// In a dry run the upgraded records are computed but not written.
+func migrateTable(db *buntdb.DB, migrateRecord RecordMigration, dryRun bool) ([]string, error) {
+	updates := map[string]string{}
+	changed := []string{}
+
+	var migrateErr error
+	err := db.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend("", func(key, value string) bool {
+			upgraded, err := migrateRecord(key, value)
+			if err != nil {
+				migrateErr = fmt.Errorf("record %s: %w", key, err)
+				return false
+			}
+			if upgraded != value {
+				updates[key] = upgraded
+				changed = append(changed, key)
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if migrateErr != nil {
+		return nil, migrateErr
+	}
+
+	if dryRun || len(updates) == 0 {
+		return changed, nil
+	}
+
+	err = db.Update(func(tx *buntdb.Tx) error {
+		for key, value := range updates {
+			_, _, err := tx.Set(key, value, nil)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return changed, err
+}
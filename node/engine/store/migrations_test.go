@@ -0,0 +1,166 @@
+package store
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/crypto"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/tidwall/buntdb"
+)
+
+var migrationTestKey = common.Hex2Bytes(`2af069c584758f9ec47c4224a8becc1983f28acfbe837bd7710b70f9fc6d5e44`)
+
+// testMigrations upgrade vouchers from a bare amount to a JSON object
+var testMigrations = []DurableStoreMigration{
+	{Version: 1, Description: "baseline"},
+	{
+		Version:     2,
+		Description: "wrap voucher amounts",
+		Records: map[string]RecordMigration{
+			"vouchers": func(key, value string) (string, error) {
+				if strings.HasPrefix(value, "{") {
+					return value, nil
+				}
+				return `{"Amount":` + value + `}`, nil
+			},
+		},
+	},
+}
+
+func setRecord(t *testing.T, db *buntdb.DB, key, value string) {
+	t.Helper()
+	err := db.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(key, value, nil)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getRecord(t *testing.T, db *buntdb.DB, key string) string {
+	t.Helper()
+	var value string
+	err := db.View(func(tx *buntdb.Tx) error {
+		var err error
+		value, err = tx.Get(key)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func TestMigrateNewStore(t *testing.T) {
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+
+	ds, err := openDurableStore(crypto.GetAddressFromSecretKeyBytes(migrationTestKey), dataFolder, buntdb.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ds.Close()
+
+	report, err := ds.migrate(testMigrations, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Applied) != 0 {
+		t.Fatalf("expected no migrations to be applied to a new store, got %d", len(report.Applied))
+	}
+
+	version, recorded, err := ds.schemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recorded || version != 2 {
+		t.Fatalf("expected a new store to be stamped with version 2, got %d (recorded: %t)", version, recorded)
+	}
+}
+
+func TestMigrateUnversionedStore(t *testing.T) {
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+
+	ds, err := openDurableStore(crypto.GetAddressFromSecretKeyBytes(migrationTestKey), dataFolder, buntdb.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ds.Close()
+
+	// A store written before versioning was introduced has records but no schema version
+	setRecord(t, ds.vouchers, "a", "5")
+	setRecord(t, ds.vouchers, "b", `{"Amount":7}`)
+
+	report, err := ds.migrate(testMigrations, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.FromVersion != 0 || report.ToVersion != 2 || len(report.Applied) != 2 {
+		t.Fatalf("unexpected dry run report %+v", report)
+	}
+	if len(report.Changes) != 1 || report.Changes[0] != (MigrationChange{Version: 2, Table: "vouchers", Key: "a"}) {
+		t.Fatalf("expected the dry run to report a change to voucher a only, got %+v", report.Changes)
+	}
+	if got := getRecord(t, ds.vouchers, "a"); got != "5" {
+		t.Fatalf("expected the dry run not to change voucher a, got %s", got)
+	}
+	if _, recorded, _ := ds.schemaVersion(); recorded {
+		t.Fatal("expected the dry run not to record a schema version")
+	}
+
+	_, err = ds.migrate(testMigrations, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getRecord(t, ds.vouchers, "a"); got != `{"Amount":5}` {
+		t.Fatalf("expected voucher a to be migrated, got %s", got)
+	}
+	version, _, err := ds.schemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 2 {
+		t.Fatalf("expected schema version 2, got %d", version)
+	}
+
+	report, err = ds.migrate(testMigrations, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Applied) != 0 {
+		t.Fatalf("expected an up to date store not to be migrated again, got %+v", report)
+	}
+}
+
+func TestMigrateRejectsNewerStore(t *testing.T) {
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+
+	ds, err := openDurableStore(crypto.GetAddressFromSecretKeyBytes(migrationTestKey), dataFolder, buntdb.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ds.Close()
+
+	err = ds.setSchemaVersion(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = ds.migrate(testMigrations, false)
+	if err == nil {
+		t.Fatal("expected a store written by a newer node to be rejected")
+	}
+}
+
+func TestDurableStoreMigrationsAreOrdered(t *testing.T) {
+	for i, m := range durableStoreMigrations {
+		if m.Version != uint(i+1) {
+			t.Fatalf("migration %d has version %d, expected %d", i, m.Version, i+1)
+		}
+	}
+}
@@ -0,0 +1,1221 @@
+package store
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	_ "github.com/lib/pq"
+	"github.com/statechannels/go-nitro/channel"
+	"github.com/statechannels/go-nitro/channel/consensus_channel"
+	"github.com/statechannels/go-nitro/crypto"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/payments"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/protocols/directfund"
+	"github.com/statechannels/go-nitro/protocols/registry"
+	"github.com/statechannels/go-nitro/protocols/swap"
+	"github.com/statechannels/go-nitro/types"
+	_ "modernc.org/sqlite"
+)
+
+// The SQL drivers supported by SQLStore
+const (
+	SQLiteDriver   = "sqlite"
+	PostgresDriver = "postgres"
+)
+
+// sqlMigrations holds the statements that bring the schema from one version to the next.
+// The schema version of a database is the number of migrations applied to it, so
+// migrations must only ever be appended to this list.
+//
+// Statements must be valid for both SQLite and Postgres.
+var sqlMigrations = [][]string{
+	// 1: initial schema
+	{
+		`CREATE TABLE objectives (
+			id TEXT PRIMARY KEY,
+			status SMALLINT NOT NULL,
+			swap_channel_id TEXT,
+			swap_status SMALLINT,
+			objective TEXT NOT NULL
+		)`,
+		`CREATE INDEX objectives_status_idx ON objectives (status)`,
+		`CREATE INDEX objectives_swap_idx ON objectives (swap_channel_id, swap_status)`,
+
+		`CREATE TABLE channels (
+			id TEXT PRIMARY KEY,
+			app_definition TEXT NOT NULL,
+			channel_type SMALLINT NOT NULL,
+			channel TEXT NOT NULL
+		)`,
+		`CREATE INDEX channels_app_definition_idx ON channels (app_definition)`,
+		`CREATE INDEX channels_channel_type_idx ON channels (channel_type)`,
+
+		`CREATE TABLE channel_participants (
+			participant TEXT NOT NULL,
+			channel_id TEXT NOT NULL,
+			PRIMARY KEY (participant, channel_id)
+		)`,
+		`CREATE INDEX channel_participants_channel_id_idx ON channel_participants (channel_id)`,
+
+		`CREATE TABLE consensus_channels (
+			id TEXT PRIMARY KEY,
+			leader TEXT NOT NULL,
+			follower TEXT NOT NULL,
+			channel TEXT NOT NULL
+		)`,
+		`CREATE INDEX consensus_channels_leader_idx ON consensus_channels (leader)`,
+		`CREATE INDEX consensus_channels_follower_idx ON consensus_channels (follower)`,
+
+		`CREATE TABLE channel_to_objective (
+			channel_id TEXT PRIMARY KEY,
+			objective_id TEXT NOT NULL
+		)`,
+
+		`CREATE TABLE vouchers (
+			channel_id TEXT PRIMARY KEY,
+			voucher_info TEXT NOT NULL
+		)`,
+
+		`CREATE TABLE swaps (
+			id TEXT PRIMARY KEY,
+			channel_id TEXT NOT NULL,
+			swap TEXT NOT NULL
+		)`,
+		`CREATE INDEX swaps_channel_id_idx ON swaps (channel_id)`,
+
+		`CREATE TABLE channel_to_swaps (
+			channel_id TEXT PRIMARY KEY,
+			swaps TEXT NOT NULL
+		)`,
+
+		`CREATE TABLE store_metadata (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		)`,
+	},
+	// 2: p2p message outbox
+	{
+		`CREATE TABLE outbox (
+			recipient TEXT NOT NULL,
+			id TEXT NOT NULL,
+			payload TEXT NOT NULL,
+			PRIMARY KEY (recipient, id)
+		)`,
+
+		`CREATE TABLE received_messages (
+			sender TEXT NOT NULL,
+			id TEXT NOT NULL,
+			received_at BIGINT NOT NULL,
+			PRIMARY KEY (sender, id)
+		)`,
+		`CREATE INDEX received_messages_received_at_idx ON received_messages (received_at)`,
+	},
+	// 3: side effects of in-flight objectives, replayed after a restart
+	{
+		`CREATE TABLE pending_side_effects (
+			objective_id TEXT PRIMARY KEY,
+			side_effects TEXT NOT NULL
+		)`,
+	},
+	// 4: why objectives failed or were rejected
+	{
+		`CREATE TABLE failure_reasons (
+			objective_id TEXT PRIMARY KEY,
+			reason TEXT NOT NULL
+		)`,
+	},
+	// 5: progress history of objectives
+	{
+		`CREATE TABLE objective_progress (
+			objective_id TEXT NOT NULL,
+			seq INTEGER NOT NULL,
+			entry TEXT NOT NULL,
+			PRIMARY KEY (objective_id, seq)
+		)`,
+	},
+	// 6: webhook events which could not be delivered
+	{
+		`CREATE TABLE webhook_dead_letters (
+			id TEXT NOT NULL,
+			url TEXT NOT NULL,
+			dead_letter TEXT NOT NULL,
+			PRIMARY KEY (id, url)
+		)`,
+	},
+	// 7: chain transactions waiting to be confirmed
+	{
+		`CREATE TABLE tx_outbox (
+			id TEXT PRIMARY KEY,
+			tx TEXT NOT NULL
+		)`,
+	},
+	// 8: decisions policy makers made about objectives
+	{
+		`CREATE TABLE policy_decisions (
+			objective_id TEXT PRIMARY KEY,
+			decided_at BIGINT NOT NULL,
+			decision TEXT NOT NULL
+		)`,
+		`CREATE INDEX policy_decisions_decided_at ON policy_decisions (decided_at)`,
+	},
+}
+
+// sqlQuerier is satisfied by both *sql.DB and *sql.Tx, so that helpers can be shared by standalone and transactional writes
+type sqlQuerier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+// SQLStore is a Store backed by a SQLite or Postgres database.
+// Unlike the DurableStore, channels are indexed by participant, app definition and type, and objectives by status,
+// so lookups do not need to scan and unmarshal every record.
+type SQLStore struct {
+	db *sql.DB
+
+	address string // the (Ethereum) address of the store's engine
+}
+
+// NewSQLStore opens the database identified by driver and dataSource, and migrates it to the latest schema version.
+// The key is only used to derive the node's address.
+func NewSQLStore(key []byte, driver string, dataSource string) (Store, error) {
+	return newSQLStore(crypto.GetAddressFromSecretKeyBytes(key), driver, dataSource)
+}
+
+func newSQLStore(address types.Address, driver string, dataSource string) (Store, error) {
+	if driver != SQLiteDriver && driver != PostgresDriver {
+		return nil, fmt.Errorf("unsupported sql driver %q, expected %q or %q", driver, SQLiteDriver, PostgresDriver)
+	}
+
+	db, err := sql.Open(driver, dataSource)
+	if err != nil {
+		return nil, err
+	}
+	if driver == SQLiteDriver {
+		// SQLite only allows a single writer, and each connection to an in-memory database sees a different database
+		db.SetMaxOpenConns(1)
+	}
+
+	err = migrateSQLSchema(db)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error migrating sql store schema: %w", err)
+	}
+
+	ss := SQLStore{db: db}
+	ss.address = address.String()
+
+	return &ss, nil
+}
+
+// migrateSQLSchema applies every migration newer than the schema version recorded in the database.
+// Each migration is applied in its own transaction along with the bump of the schema version.
+func migrateSQLSchema(db *sql.DB) error {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`)
+	if err != nil {
+		return err
+	}
+
+	var version int
+	err = db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version)
+	if err != nil {
+		return err
+	}
+	if version > len(sqlMigrations) {
+		return fmt.Errorf("database schema version %d is newer than the latest known version %d", version, len(sqlMigrations))
+	}
+
+	for v := version; v < len(sqlMigrations); v++ {
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		for _, stmt := range sqlMigrations[v] {
+			_, err = tx.Exec(stmt)
+			if err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("migration %d: %w", v+1, err)
+			}
+		}
+		_, err = tx.Exec(`DELETE FROM schema_version`)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO schema_version (version) VALUES ($1)`, v+1)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		err = tx.Commit()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ss *SQLStore) Close() error {
+	return ss.db.Close()
+}
+
+func (ss *SQLStore) GetAddress() *types.Address {
+	address := common.HexToAddress(ss.address)
+	return &address
+}
+
+// withTx runs fn in a transaction, committing if fn succeeds and rolling back otherwise
+func (ss *SQLStore) withTx(fn func(tx *sql.Tx) error) error {
+	tx, err := ss.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = fn(tx)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (ss *SQLStore) GetSwapById(id types.Destination) (payments.Swap, error) {
+	var sJSON string
+	err := ss.db.QueryRow(`SELECT swap FROM swaps WHERE id = $1`, id.String()).Scan(&sJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return payments.Swap{}, ErrNoSuchSwap
+	}
+	if err != nil {
+		return payments.Swap{}, err
+	}
+
+	var swap payments.Swap
+	err = json.Unmarshal([]byte(sJSON), &swap)
+	if err != nil {
+		return payments.Swap{}, fmt.Errorf("error unmarshaling swap %s", id)
+	}
+
+	return swap, nil
+}
+
+func (ss *SQLStore) SetSwap(swap payments.Swap) error {
+	return setSQLSwap(ss.db, swap)
+}
+
+func setSQLSwap(q sqlQuerier, swap payments.Swap) error {
+	sJSON, err := json.Marshal(swap)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(`INSERT INTO swaps (id, channel_id, swap) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET channel_id = excluded.channel_id, swap = excluded.swap`,
+		swap.Id.String(), swap.ChannelId.String(), string(sJSON))
+	return err
+}
+
+func (ss *SQLStore) DestroySwapById(id types.Destination) error {
+	_, err := ss.db.Exec(`DELETE FROM swaps WHERE id = $1`, id.String())
+	return err
+}
+
+func (ss *SQLStore) GetObjectiveById(id protocols.ObjectiveId) (protocols.Objective, error) {
+	var objJSON string
+	err := ss.db.QueryRow(`SELECT objective FROM objectives WHERE id = $1`, string(id)).Scan(&objJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrNoSuchObjective, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	obj, err := decodeObjective(id, []byte(objJSON))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding objective %s: %w", id, err)
+	}
+
+	err = ss.populateChannelData(obj)
+	if err != nil {
+		// return existing objective data along with error
+		return obj, fmt.Errorf("error populating channel data for objective %s: %w", id, err)
+	}
+
+	return obj, nil
+}
+
+// SetObjective writes the objective and its related channels and swaps in a single transaction
+func (ss *SQLStore) SetObjective(obj protocols.Objective) error {
+	objJSON, err := obj.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("error setting objective %s: %w", obj.Id(), err)
+	}
+
+	// Swap objectives are indexed by channel and swap status, so that pending swaps can be found without decoding every objective
+	var swapChannelId, swapStatus any
+	if so, isSwapObj := obj.(*swap.Objective); isSwapObj {
+		swapChannelId = so.C.Id.String()
+		swapStatus = int(so.SwapStatus)
+	}
+
+	return ss.withTx(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT INTO objectives (id, status, swap_channel_id, swap_status, objective) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (id) DO UPDATE SET status = excluded.status, swap_channel_id = excluded.swap_channel_id, swap_status = excluded.swap_status, objective = excluded.objective`,
+			string(obj.Id()), int(obj.GetStatus()), swapChannelId, swapStatus, string(objJSON))
+		if err != nil {
+			return err
+		}
+
+		for _, rel := range obj.Related() {
+			switch related := rel.(type) {
+			case *channel.VirtualChannel:
+				err := setSQLChannel(tx, &related.Channel)
+				if err != nil {
+					return fmt.Errorf("error setting virtual channel %s from objective %s: %w", related.Id, obj.Id(), err)
+				}
+			case *channel.SwapChannel:
+				err := setSQLChannel(tx, &related.Channel)
+				if err != nil {
+					return fmt.Errorf("error setting swap channel %s from objective %s: %w", related.Id, obj.Id(), err)
+				}
+
+			case *payments.Swap:
+				err := setSQLSwap(tx, *related)
+				if err != nil {
+					return fmt.Errorf("error setting swap %s from objective %s: %w", related.Id, obj.Id(), err)
+				}
+
+				so, isSwapObj := obj.(*swap.Objective)
+				if !isSwapObj {
+					return fmt.Errorf("expected swap objective")
+				}
+
+				if so.GetStatus() == protocols.Completed && so.SwapStatus == types.Accepted {
+					// Add swap to channelToSwaps map if successful
+					removedSwap, err := setSQLChannelToSwaps(tx, *related)
+					if err != nil {
+						return fmt.Errorf("error setting channel to swaps %s from objective %s: %w", related.Id, obj.Id(), err)
+					}
+
+					// Remove old swap if exist
+					if !removedSwap.Id.IsZero() {
+						_, err = tx.Exec(`DELETE FROM swaps WHERE id = $1`, removedSwap.Id.String())
+						if err != nil {
+							return fmt.Errorf("error in destroying old swap %s: %w", removedSwap.Id, err)
+						}
+					}
+				}
+
+				if so.GetStatus() == protocols.Rejected {
+					// Delete the rejected swap
+					_, err = tx.Exec(`DELETE FROM swaps WHERE id = $1`, so.Swap.Id.String())
+					if err != nil {
+						return fmt.Errorf("error in destroying old swap %s: %w", so.Swap.Id, err)
+					}
+				}
+
+			case *channel.Channel:
+				err := setSQLChannel(tx, related)
+				if err != nil {
+					return fmt.Errorf("error setting channel %s from objective %s: %w", related.Id, obj.Id(), err)
+				}
+			case *consensus_channel.ConsensusChannel:
+				err := setSQLConsensusChannel(tx, related)
+				if err != nil {
+					return fmt.Errorf("error setting consensus channel %s from objective %s: %w", related.Id, obj.Id(), err)
+				}
+			default:
+				return fmt.Errorf("unexpected type: %T", rel)
+			}
+		}
+
+		if status := obj.GetStatus(); status != protocols.Approved || obj.OwnsChannel().IsZero() {
+			return nil
+		}
+
+		// Objective ownership can only be transferred if the channel is not owned by another objective
+		var prevOwner string
+		err = tx.QueryRow(`SELECT objective_id FROM channel_to_objective WHERE channel_id = $1`, obj.OwnsChannel().String()).Scan(&prevOwner)
+		if errors.Is(err, sql.ErrNoRows) {
+			_, err = tx.Exec(`INSERT INTO channel_to_objective (channel_id, objective_id) VALUES ($1, $2)`, obj.OwnsChannel().String(), string(obj.Id()))
+			if err != nil {
+				return fmt.Errorf("cannot transfer ownership of channel: %w", err)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if protocols.ObjectiveId(prevOwner) != obj.Id() {
+			return fmt.Errorf("cannot transfer ownership of channel from objective %s to %s", prevOwner, obj.Id())
+		}
+		return nil
+	})
+}
+
+// GetLastBlockNumSeen retrieves the last blockchain block processed by this node
+func (ss *SQLStore) GetLastBlockNumSeen() (uint64, error) {
+	var val string
+	err := ss.db.QueryRow(`SELECT value FROM store_metadata WHERE key = $1`, lastBlockNumSeenKey).Scan(&val)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(val, 10, 64)
+}
+
+// SetLastBlockNumSeen sets the last blockchain block processed by this node
+func (ss *SQLStore) SetLastBlockNumSeen(blockNumber uint64) error {
+	_, err := ss.db.Exec(`INSERT INTO store_metadata (key, value) VALUES ($1, $2)
+		ON CONFLICT (key) DO UPDATE SET value = excluded.value`,
+		lastBlockNumSeenKey, strconv.FormatUint(blockNumber, 10))
+	return err
+}
+
+// SetChannel sets the channel in the store.
+func (ss *SQLStore) SetChannel(ch *channel.Channel) error {
+	return ss.withTx(func(tx *sql.Tx) error {
+		return setSQLChannel(tx, ch)
+	})
+}
+
+// setSQLChannel writes the channel along with its participant index. It should be run in a transaction.
+func setSQLChannel(q sqlQuerier, ch *channel.Channel) error {
+	chJSON, err := ch.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(`INSERT INTO channels (id, app_definition, channel_type, channel) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET app_definition = excluded.app_definition, channel_type = excluded.channel_type, channel = excluded.channel`,
+		ch.Id.String(), ch.AppDefinition.String(), int(ch.Type), string(chJSON))
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(`DELETE FROM channel_participants WHERE channel_id = $1`, ch.Id.String())
+	if err != nil {
+		return err
+	}
+	for _, p := range ch.Participants {
+		_, err = q.Exec(`INSERT INTO channel_participants (participant, channel_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, p.String(), ch.Id.String())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DestroyChannel deletes the channel with id id.
+func (ss *SQLStore) DestroyChannel(id types.Destination) error {
+	return ss.withTx(func(tx *sql.Tx) error {
+		_, err := tx.Exec(`DELETE FROM channel_participants WHERE channel_id = $1`, id.String())
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`DELETE FROM channels WHERE id = $1`, id.String())
+		return err
+	})
+}
+
+// SetConsensusChannel sets the channel in the store.
+func (ss *SQLStore) SetConsensusChannel(ch *consensus_channel.ConsensusChannel) error {
+	return setSQLConsensusChannel(ss.db, ch)
+}
+
+func setSQLConsensusChannel(q sqlQuerier, ch *consensus_channel.ConsensusChannel) error {
+	if ch.Id.IsZero() {
+		return fmt.Errorf("cannot store a channel with a zero id")
+	}
+	chJSON, err := ch.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(`INSERT INTO consensus_channels (id, leader, follower, channel) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO UPDATE SET leader = excluded.leader, follower = excluded.follower, channel = excluded.channel`,
+		ch.Id.String(), ch.Leader().String(), ch.Follower().String(), string(chJSON))
+	return err
+}
+
+// DestroyConsensusChannel deletes the channel with id id.
+func (ss *SQLStore) DestroyConsensusChannel(id types.Destination) error {
+	_, err := ss.db.Exec(`DELETE FROM consensus_channels WHERE id = $1`, id.String())
+	return err
+}
+
+// GetChannelById retrieves the channel with the supplied id, if it exists.
+func (ss *SQLStore) GetChannelById(id types.Destination) (c *channel.Channel, ok bool) {
+	ch, err := ss.getChannelById(id)
+	if err != nil {
+		return &channel.Channel{}, false
+	}
+
+	return &ch, true
+}
+
+// getChannelById returns the stored channel
+func (ss *SQLStore) getChannelById(id types.Destination) (channel.Channel, error) {
+	var chJSON string
+	err := ss.db.QueryRow(`SELECT channel FROM channels WHERE id = $1`, id.String()).Scan(&chJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return channel.Channel{}, ErrNoSuchChannel
+	}
+	if err != nil {
+		return channel.Channel{}, err
+	}
+
+	var ch channel.Channel
+	err = ch.UnmarshalJSON([]byte(chJSON))
+	if err != nil {
+		return channel.Channel{}, fmt.Errorf("error unmarshaling channel %s", id)
+	}
+
+	return ch, nil
+}
+
+// queryChannels returns the channels selected by query, which must select a single channel column
+func (ss *SQLStore) queryChannels(query string, args ...any) ([]*channel.Channel, error) {
+	rows, err := ss.db.Query(query, args...)
+	if err != nil {
+		return []*channel.Channel{}, err
+	}
+	defer rows.Close()
+
+	toReturn := []*channel.Channel{}
+	for rows.Next() {
+		var chJSON string
+		err := rows.Scan(&chJSON)
+		if err != nil {
+			return []*channel.Channel{}, err
+		}
+
+		var ch channel.Channel
+		err = json.Unmarshal([]byte(chJSON), &ch)
+		if err != nil {
+			return []*channel.Channel{}, err
+		}
+		toReturn = append(toReturn, &ch)
+	}
+	if err := rows.Err(); err != nil {
+		return []*channel.Channel{}, err
+	}
+
+	return toReturn, nil
+}
+
+// GetChannelsByIds returns any channels with ids in the supplied list.
+func (ss *SQLStore) GetChannelsByIds(ids []types.Destination) ([]*channel.Channel, error) {
+	if len(ids) == 0 {
+		return []*channel.Channel{}, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = id.String()
+	}
+
+	return ss.queryChannels(`SELECT channel FROM channels WHERE id IN (`+strings.Join(placeholders, ", ")+`)`, args...)
+}
+
+// GetChannelsByAppDefinition returns any channels that include the given app definition
+func (ss *SQLStore) GetChannelsByAppDefinition(appDef types.Address) ([]*channel.Channel, error) {
+	return ss.queryChannels(`SELECT channel FROM channels WHERE app_definition = $1`, appDef.String())
+}
+
+// GetChannelsByParticipant returns any channels that include the given participant
+func (ss *SQLStore) GetChannelsByParticipant(participant types.Address) ([]*channel.Channel, error) {
+	return ss.queryChannels(`SELECT c.channel FROM channels c
+		JOIN channel_participants p ON p.channel_id = c.id
+		WHERE p.participant = $1`, participant.String())
+}
+
+// GetAllChannels retrieves all channels stored in the SQLStore
+func (ss *SQLStore) GetAllChannels() ([]*channel.Channel, error) {
+	return ss.queryChannels(`SELECT channel FROM channels`)
+}
+
+// queryConsensusChannels returns the consensus channels selected by query, which must select a single channel column
+func (ss *SQLStore) queryConsensusChannels(query string, args ...any) ([]*consensus_channel.ConsensusChannel, error) {
+	rows, err := ss.db.Query(query, args...)
+	if err != nil {
+		return []*consensus_channel.ConsensusChannel{}, err
+	}
+	defer rows.Close()
+
+	toReturn := []*consensus_channel.ConsensusChannel{}
+	for rows.Next() {
+		var chJSON string
+		err := rows.Scan(&chJSON)
+		if err != nil {
+			return []*consensus_channel.ConsensusChannel{}, err
+		}
+
+		var ch consensus_channel.ConsensusChannel
+		err = json.Unmarshal([]byte(chJSON), &ch)
+		if err != nil {
+			return []*consensus_channel.ConsensusChannel{}, err
+		}
+		toReturn = append(toReturn, &ch)
+	}
+	if err := rows.Err(); err != nil {
+		return []*consensus_channel.ConsensusChannel{}, err
+	}
+
+	return toReturn, nil
+}
+
+func (ss *SQLStore) GetAllConsensusChannels() ([]*consensus_channel.ConsensusChannel, error) {
+	return ss.queryConsensusChannels(`SELECT channel FROM consensus_channels`)
+}
+
+// GetConsensusChannelById returns a ConsensusChannel with the given channel id
+func (ss *SQLStore) GetConsensusChannelById(id types.Destination) (channel *consensus_channel.ConsensusChannel, err error) {
+	chs, err := ss.queryConsensusChannels(`SELECT channel FROM consensus_channels WHERE id = $1`, id.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(chs) == 0 {
+		return nil, ErrNoSuchChannel
+	}
+	return chs[0], nil
+}
+
+// GetConsensusChannel returns a ConsensusChannel between the calling node and
+// the supplied counterparty, if such channel exists
+func (ss *SQLStore) GetConsensusChannel(counterparty types.Address) (channel *consensus_channel.ConsensusChannel, ok bool) {
+	chs, err := ss.queryConsensusChannels(`SELECT channel FROM consensus_channels WHERE leader = $1 OR follower = $1 LIMIT 1`, counterparty.String())
+	if err != nil || len(chs) == 0 {
+		return nil, false
+	}
+	return chs[0], true
+}
+
+func (ss *SQLStore) GetObjectiveByChannelId(channelId types.Destination) (protocols.Objective, bool) {
+	var id string
+	err := ss.db.QueryRow(`SELECT objective_id FROM channel_to_objective WHERE channel_id = $1`, channelId.String()).Scan(&id)
+	if err != nil {
+		return &directfund.Objective{}, false
+	}
+
+	objective, err := ss.GetObjectiveById(protocols.ObjectiveId(id))
+	return objective, err == nil
+}
+
+// populateChannelData fetches stored Channel data relevant to the given
+// objective and attaches it to the objective. The channel data is attached
+// in-place of the objectives existing channel pointers.
+func (ss *SQLStore) populateChannelData(obj protocols.Objective) error {
+	return registry.PopulateChannelData(obj, registry.ChannelData{
+		GetChannel:          ss.getChannelById,
+		GetConsensusChannel: ss.GetConsensusChannelById,
+		GetSwap:             ss.GetSwapById,
+	})
+}
+
+func (ss *SQLStore) ReleaseChannelFromOwnership(channelId types.Destination) error {
+	_, err := ss.db.Exec(`DELETE FROM channel_to_objective WHERE channel_id = $1`, channelId.String())
+	return err
+}
+
+func (ss *SQLStore) SetVoucherInfo(channelId types.Destination, v payments.VoucherInfo) error {
+	vJSON, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	_, err = ss.db.Exec(`INSERT INTO vouchers (channel_id, voucher_info) VALUES ($1, $2)
+		ON CONFLICT (channel_id) DO UPDATE SET voucher_info = excluded.voucher_info`,
+		channelId.String(), string(vJSON))
+	return err
+}
+
+func (ss *SQLStore) GetVoucherInfo(channelId types.Destination) (*payments.VoucherInfo, error) {
+	var vJSON string
+	err := ss.db.QueryRow(`SELECT voucher_info FROM vouchers WHERE channel_id = $1`, channelId.String()).Scan(&vJSON)
+	if err != nil {
+		return nil, fmt.Errorf("channelId %s: %w", channelId.String(), ErrLoadVouchers)
+	}
+
+	v := &payments.VoucherInfo{}
+	err = json.Unmarshal([]byte(vJSON), v)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (ss *SQLStore) RemoveVoucherInfo(channelId types.Destination) error {
+	_, err := ss.db.Exec(`DELETE FROM vouchers WHERE channel_id = $1`, channelId.String())
+	return err
+}
+
+func (ss *SQLStore) AddOutboxMessage(m OutboxMessage) error {
+	_, err := ss.db.Exec(`INSERT INTO outbox (recipient, id, payload) VALUES ($1, $2, $3)
+		ON CONFLICT (recipient, id) DO UPDATE SET payload = excluded.payload`,
+		m.To.String(), m.Id, m.Payload)
+	return err
+}
+
+func (ss *SQLStore) RemoveOutboxMessage(to types.Address, id string) error {
+	_, err := ss.db.Exec(`DELETE FROM outbox WHERE recipient = $1 AND id = $2`, to.String(), id)
+	return err
+}
+
+func (ss *SQLStore) GetOutboxMessages(to types.Address) ([]OutboxMessage, error) {
+	rows, err := ss.db.Query(`SELECT id, payload FROM outbox WHERE recipient = $1 ORDER BY id`, to.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	msgs := []OutboxMessage{}
+	for rows.Next() {
+		m := OutboxMessage{To: to}
+		err = rows.Scan(&m.Id, &m.Payload)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs, rows.Err()
+}
+
+func (ss *SQLStore) GetOutboxDepths() (map[types.Address]int, error) {
+	rows, err := ss.db.Query(`SELECT recipient, COUNT(*) FROM outbox GROUP BY recipient`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	depths := make(map[types.Address]int)
+	for rows.Next() {
+		var to string
+		var depth int
+		err = rows.Scan(&to, &depth)
+		if err != nil {
+			return nil, err
+		}
+		depths[common.HexToAddress(to)] = depth
+	}
+	return depths, rows.Err()
+}
+
+func (ss *SQLStore) RecordReceivedMessage(from types.Address, id string) (bool, error) {
+	isNew := false
+	err := ss.withTx(func(tx *sql.Tx) error {
+		now := time.Now()
+		_, err := tx.Exec(`DELETE FROM received_messages WHERE received_at < $1`, now.Add(-ReceivedMessageTTL).Unix())
+		if err != nil {
+			return err
+		}
+
+		res, err := tx.Exec(`INSERT INTO received_messages (sender, id, received_at) VALUES ($1, $2, $3)
+			ON CONFLICT (sender, id) DO NOTHING`,
+			from.String(), id, now.Unix())
+		if err != nil {
+			return err
+		}
+		inserted, err := res.RowsAffected()
+		isNew = inserted > 0
+		return err
+	})
+	return isNew, err
+}
+
+func (ss *SQLStore) HasReceivedMessage(from types.Address, id string) (bool, error) {
+	var count int
+	err := ss.db.QueryRow(`SELECT COUNT(*) FROM received_messages WHERE sender = $1 AND id = $2 AND received_at >= $3`,
+		from.String(), id, time.Now().Add(-ReceivedMessageTTL).Unix()).Scan(&count)
+	return count > 0, err
+}
+
+func (ss *SQLStore) DestroyObjective(id protocols.ObjectiveId) error {
+	_, err := ss.db.Exec(`DELETE FROM objectives WHERE id = $1`, string(id))
+	return err
+}
+
+// GetIncompleteObjectives returns the objectives which are approved but not yet completed, sorted by id
+func (ss *SQLStore) GetIncompleteObjectives() ([]protocols.Objective, error) {
+	return ss.GetObjectives(ObjectiveFilter{Statuses: []protocols.ObjectiveStatus{protocols.Approved}})
+}
+
+func (ss *SQLStore) GetObjectives(filter ObjectiveFilter) ([]protocols.Objective, error) {
+	// The status and type are matched by the database, the channel once the objectives are decoded
+	conditions := []string{}
+	args := []any{}
+	if len(filter.Statuses) > 0 {
+		placeholders := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			args = append(args, int(status))
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf("status IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type+"-%")
+		conditions = append(conditions, fmt.Sprintf("id LIKE $%d", len(args)))
+	}
+	query := `SELECT id, objective FROM objectives`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := ss.db.Query(query+` ORDER BY id`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	objectives := []protocols.Objective{}
+	for rows.Next() {
+		var id, objJSON string
+		err = rows.Scan(&id, &objJSON)
+		if err != nil {
+			return nil, err
+		}
+		obj, err := decodeObjective(protocols.ObjectiveId(id), []byte(objJSON))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding objective %s: %w", id, err)
+		}
+		if filter.Matches(obj) {
+			objectives = append(objectives, obj)
+		}
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objectives {
+		err = ss.populateChannelData(obj)
+		if err != nil && !isFinished(obj) {
+			return nil, fmt.Errorf("error populating channel data for objective %s: %w", obj.Id(), err)
+		}
+	}
+	return objectives, nil
+}
+
+func (ss *SQLStore) SetPendingSideEffects(id protocols.ObjectiveId, se PendingSideEffects) error {
+	seJSON, err := json.Marshal(se)
+	if err != nil {
+		return fmt.Errorf("error setting pending side effects of objective %s: %w", id, err)
+	}
+	_, err = ss.db.Exec(`INSERT INTO pending_side_effects (objective_id, side_effects) VALUES ($1, $2)
+		ON CONFLICT (objective_id) DO UPDATE SET side_effects = excluded.side_effects`,
+		string(id), string(seJSON))
+	return err
+}
+
+func (ss *SQLStore) GetPendingSideEffects(id protocols.ObjectiveId) (PendingSideEffects, bool, error) {
+	var seJSON string
+	err := ss.db.QueryRow(`SELECT side_effects FROM pending_side_effects WHERE objective_id = $1`, string(id)).Scan(&seJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PendingSideEffects{}, false, nil
+	}
+	if err != nil {
+		return PendingSideEffects{}, false, err
+	}
+
+	var se PendingSideEffects
+	err = json.Unmarshal([]byte(seJSON), &se)
+	if err != nil {
+		return PendingSideEffects{}, false, fmt.Errorf("error decoding pending side effects of objective %s: %w", id, err)
+	}
+	return se, true, nil
+}
+
+func (ss *SQLStore) RemovePendingSideEffects(id protocols.ObjectiveId) error {
+	_, err := ss.db.Exec(`DELETE FROM pending_side_effects WHERE objective_id = $1`, string(id))
+	return err
+}
+
+func (ss *SQLStore) SetFailureReason(id protocols.ObjectiveId, reason protocols.FailureReason) error {
+	reasonJSON, err := json.Marshal(reason)
+	if err != nil {
+		return fmt.Errorf("error setting failure reason of objective %s: %w", id, err)
+	}
+	_, err = ss.db.Exec(`INSERT INTO failure_reasons (objective_id, reason) VALUES ($1, $2)
+		ON CONFLICT (objective_id) DO UPDATE SET reason = excluded.reason`,
+		string(id), string(reasonJSON))
+	return err
+}
+
+func (ss *SQLStore) GetFailureReason(id protocols.ObjectiveId) (protocols.FailureReason, bool, error) {
+	var reasonJSON string
+	err := ss.db.QueryRow(`SELECT reason FROM failure_reasons WHERE objective_id = $1`, string(id)).Scan(&reasonJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return protocols.FailureReason{}, false, nil
+	}
+	if err != nil {
+		return protocols.FailureReason{}, false, err
+	}
+
+	var reason protocols.FailureReason
+	err = json.Unmarshal([]byte(reasonJSON), &reason)
+	if err != nil {
+		return protocols.FailureReason{}, false, fmt.Errorf("error decoding failure reason of objective %s: %w", id, err)
+	}
+	return reason, true, nil
+}
+
+func (ss *SQLStore) SetPolicyDecision(d PolicyDecision) error {
+	dJSON, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("error setting policy decision of objective %s: %w", d.ObjectiveId, err)
+	}
+	_, err = ss.db.Exec(`INSERT INTO policy_decisions (objective_id, decided_at, decision) VALUES ($1, $2, $3)
+		ON CONFLICT (objective_id) DO UPDATE SET decided_at = excluded.decided_at, decision = excluded.decision`,
+		string(d.ObjectiveId), d.DecidedAt.UnixNano(), string(dJSON))
+	return err
+}
+
+func (ss *SQLStore) GetPolicyDecision(id protocols.ObjectiveId) (PolicyDecision, bool, error) {
+	var dJSON string
+	err := ss.db.QueryRow(`SELECT decision FROM policy_decisions WHERE objective_id = $1`, string(id)).Scan(&dJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return PolicyDecision{}, false, nil
+	}
+	if err != nil {
+		return PolicyDecision{}, false, err
+	}
+
+	var d PolicyDecision
+	err = json.Unmarshal([]byte(dJSON), &d)
+	if err != nil {
+		return PolicyDecision{}, false, fmt.Errorf("error decoding policy decision of objective %s: %w", id, err)
+	}
+	return d, true, nil
+}
+
+func (ss *SQLStore) PrunePolicyDecisions(before time.Time) error {
+	_, err := ss.db.Exec(`DELETE FROM policy_decisions WHERE decided_at < $1`, before.UnixNano())
+	return err
+}
+
+func (ss *SQLStore) AddObjectiveProgress(id protocols.ObjectiveId, entry ObjectiveProgress) error {
+	entryJSON, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error adding progress of objective %s: %w", id, err)
+	}
+	return ss.withTx(func(tx *sql.Tx) error {
+		var seq int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM objective_progress WHERE objective_id = $1`, string(id)).Scan(&seq)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(`INSERT INTO objective_progress (objective_id, seq, entry) VALUES ($1, $2, $3)`, string(id), seq, string(entryJSON))
+		return err
+	})
+}
+
+func (ss *SQLStore) GetObjectiveProgress(id protocols.ObjectiveId) ([]ObjectiveProgress, error) {
+	rows, err := ss.db.Query(`SELECT entry FROM objective_progress WHERE objective_id = $1 ORDER BY seq`, string(id))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []ObjectiveProgress{}
+	for rows.Next() {
+		var entryJSON string
+		err = rows.Scan(&entryJSON)
+		if err != nil {
+			return nil, err
+		}
+		var entry ObjectiveProgress
+		err = json.Unmarshal([]byte(entryJSON), &entry)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding progress of objective %s: %w", id, err)
+		}
+		history = append(history, entry)
+	}
+	return history, rows.Err()
+}
+
+func (ss *SQLStore) GetPendingSwapByChannelId(id types.Destination) (*payments.Swap, error) {
+	var objJSON string
+	err := ss.db.QueryRow(`SELECT objective FROM objectives WHERE swap_channel_id = $1 AND swap_status = $2 LIMIT 1`,
+		id.String(), int(types.PendingConfirmation)).Scan(&objJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var obj swap.Objective
+	err = json.Unmarshal([]byte(objJSON), &obj)
+	if err != nil {
+		return nil, err
+	}
+
+	swap, err := ss.GetSwapById(obj.Swap.Id)
+	if err == nil {
+		return &swap, nil
+	}
+
+	return nil, nil
+}
+
+func (ss *SQLStore) GetSwapsByChannelId(id types.Destination) ([]payments.Swap, error) {
+	swapQueue, err := getSQLSwapsQueue(ss.db, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var swapsToReturn []payments.Swap
+	swaps := swapQueue.Values()
+	for _, swap := range swaps {
+		s, err := ss.GetSwapById(swap.Id)
+		if errors.Is(err, ErrNoSuchSwap) {
+			continue
+		}
+		swapsToReturn = append(swapsToReturn, s)
+	}
+
+	return swapsToReturn, nil
+}
+
+func (ss *SQLStore) SetChannelToSwaps(swap payments.Swap) (payments.Swap, error) {
+	var removedSwap payments.Swap
+	err := ss.withTx(func(tx *sql.Tx) error {
+		var err error
+		removedSwap, err = setSQLChannelToSwaps(tx, swap)
+		return err
+	})
+	return removedSwap, err
+}
+
+// getSQLSwapsQueue returns the queue of recent swaps for the channel, which is empty if there are none
+func getSQLSwapsQueue(q sqlQuerier, channelId types.Destination) (*payments.SwapsQueue, error) {
+	swapQueue := payments.NewSwapsQueue()
+
+	var sJSON string
+	err := q.QueryRow(`SELECT swaps FROM channel_to_swaps WHERE channel_id = $1`, channelId.String()).Scan(&sJSON)
+	if errors.Is(err, sql.ErrNoRows) {
+		return swapQueue, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = swapQueue.UnmarshalJSON([]byte(sJSON))
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling swap queue %w", err)
+	}
+	return swapQueue, nil
+}
+
+// setSQLChannelToSwaps adds swap to the queue of recent swaps for its channel, returning any swap that was pushed out of the queue
+func setSQLChannelToSwaps(q sqlQuerier, swap payments.Swap) (payments.Swap, error) {
+	swapQueue, err := getSQLSwapsQueue(q, swap.ChannelId)
+	if err != nil {
+		return payments.Swap{}, err
+	}
+
+	removedSwap := swapQueue.Enqueue(swap)
+
+	swapsJson, err := swapQueue.MarshalJSON()
+	if err != nil {
+		return payments.Swap{}, fmt.Errorf("error marshalling swap queue %w", err)
+	}
+
+	_, err = q.Exec(`INSERT INTO channel_to_swaps (channel_id, swaps) VALUES ($1, $2)
+		ON CONFLICT (channel_id) DO UPDATE SET swaps = excluded.swaps`,
+		swap.ChannelId.String(), string(swapsJson))
+	if err != nil {
+		return payments.Swap{}, err
+	}
+
+	return removedSwap, nil
+}
+
+func (ss *SQLStore) AddWebhookDeadLetter(d WebhookDeadLetter) error {
+	dJSON, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("error adding dead letter %s: %w", d.Id, err)
+	}
+	_, err = ss.db.Exec(`INSERT INTO webhook_dead_letters (id, url, dead_letter) VALUES ($1, $2, $3)
+		ON CONFLICT (id, url) DO UPDATE SET dead_letter = excluded.dead_letter`,
+		d.Id, d.Url, string(dJSON))
+	return err
+}
+
+func (ss *SQLStore) GetWebhookDeadLetters() ([]WebhookDeadLetter, error) {
+	rows, err := ss.db.Query(`SELECT dead_letter FROM webhook_dead_letters ORDER BY id, url`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	letters := []WebhookDeadLetter{}
+	for rows.Next() {
+		var dJSON string
+		err = rows.Scan(&dJSON)
+		if err != nil {
+			return nil, err
+		}
+		var d WebhookDeadLetter
+		err = json.Unmarshal([]byte(dJSON), &d)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding dead letter: %w", err)
+		}
+		letters = append(letters, d)
+	}
+	return letters, rows.Err()
+}
+
+func (ss *SQLStore) RemoveWebhookDeadLetter(url string, id string) error {
+	_, err := ss.db.Exec(`DELETE FROM webhook_dead_letters WHERE id = $1 AND url = $2`, id, url)
+	return err
+}
+
+func (ss *SQLStore) SetOutboxTx(o chainservice.OutboxTx) error {
+	oJSON, err := json.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("error setting outbox transaction %s: %w", o.Id, err)
+	}
+	_, err = ss.db.Exec(`INSERT INTO tx_outbox (id, tx) VALUES ($1, $2)
+		ON CONFLICT (id) DO UPDATE SET tx = excluded.tx`,
+		o.Id, string(oJSON))
+	return err
+}
+
+func (ss *SQLStore) GetOutboxTxs() ([]chainservice.OutboxTx, error) {
+	rows, err := ss.db.Query(`SELECT tx FROM tx_outbox ORDER BY id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := []chainservice.OutboxTx{}
+	for rows.Next() {
+		var oJSON string
+		err = rows.Scan(&oJSON)
+		if err != nil {
+			return nil, err
+		}
+		var o chainservice.OutboxTx
+		err = json.Unmarshal([]byte(oJSON), &o)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding outbox transaction: %w", err)
+		}
+		txs = append(txs, o)
+	}
+	return txs, rows.Err()
+}
+
+func (ss *SQLStore) RemoveOutboxTx(id string) error {
+	_, err := ss.db.Exec(`DELETE FROM tx_outbox WHERE id = $1`, id)
+	return err
+}
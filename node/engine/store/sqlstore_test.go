@@ -0,0 +1,234 @@
+package store_test
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/statechannels/go-nitro/channel"
+	cc "github.com/statechannels/go-nitro/channel/consensus_channel"
+	"github.com/statechannels/go-nitro/channel/state"
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	td "github.com/statechannels/go-nitro/internal/testdata"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/payments"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+var sqlStoreTestKey = common.Hex2Bytes(`2af069c584758f9ec47c4224a8becc1983f28acfbe837bd7710b70f9fc6d5e44`)
+
+func newTestSQLStore(t *testing.T) (store.Store, string) {
+	t.Helper()
+	pk := sqlStoreTestKey
+
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	t.Cleanup(cleanup)
+	dataSource := filepath.Join(dataFolder, "store.db")
+
+	sqlStore, err := store.NewSQLStore(pk, store.SQLiteDriver, dataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = sqlStore.Close() })
+	return sqlStore, dataSource
+}
+
+func TestSQLStoreObjectives(t *testing.T) {
+	ss, _ := newTestSQLStore(t)
+
+	id := protocols.ObjectiveId("404")
+	if got, err := ss.GetObjectiveById(id); err == nil {
+		t.Fatalf("expected not to find the %s objective, but found %v", id, got)
+	}
+
+	dfo := td.Objectives.Directfund.GenericDFO()
+	vfo := td.Objectives.Virtualfund.GenericVFO()
+	for _, want := range []protocols.Objective{&dfo, &vfo} {
+		if err := ss.SetObjective(want); err != nil {
+			t.Fatalf("error setting objective %v: %s", want, err)
+		}
+
+		got, err := ss.GetObjectiveById(want.Id())
+		if err != nil {
+			t.Fatalf("expected to find the inserted objective, but didn't: %s", err)
+		}
+		if diff := compareObjectives(got, want); diff != "" {
+			t.Fatalf("expected no diff between set and retrieved objective, but found:\n%s", diff)
+		}
+	}
+
+	if _, ok := ss.GetObjectiveByChannelId(dfo.C.Id); ok {
+		t.Fatal("when an unapproved objective is stored, the objective should not own the channel")
+	}
+
+	dfo.Status = protocols.Approved
+	if err := ss.SetObjective(&dfo); err != nil {
+		t.Fatal(err)
+	}
+	got, ok := ss.GetObjectiveByChannelId(dfo.C.Id)
+	if !ok {
+		t.Fatal("expected the approved objective to own its channel")
+	}
+	if diff := compareObjectives(got, &dfo); diff != "" {
+		t.Fatalf("expected no diff between set and retrieved objective, but found:\n%s", diff)
+	}
+
+	if err := ss.ReleaseChannelFromOwnership(dfo.C.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ss.GetObjectiveByChannelId(dfo.C.Id); ok {
+		t.Fatal("expected the channel to be released")
+	}
+}
+
+func TestSQLStoreChannelQueries(t *testing.T) {
+	ss, _ := newTestSQLStore(t)
+
+	ledger := td.Objectives.Directfund.GenericDFO().C
+	if err := ss.SetChannel(ledger); err != nil {
+		t.Fatal(err)
+	}
+
+	s := ledger.PreFundState()
+	s.Participants = []types.Address{ta.Alice.Address(), ta.Irene.Address(), ta.Bob.Address()}
+	s.AppDefinition = common.HexToAddress("0x7e29E5Ab8EF33F050c7cc10B5a0456D975C5F88d")
+	virtual, err := channel.New(s, 0, types.Virtual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.SetChannel(virtual); err != nil {
+		t.Fatal(err)
+	}
+
+	compare := cmp.AllowUnexported(channel.Channel{}, big.Int{}, state.SignedState{})
+
+	got, err := ss.GetChannelsByParticipant(ta.Irene.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, []*channel.Channel{virtual}, compare); diff != "" {
+		t.Fatalf("fetched result different than expected %s", diff)
+	}
+
+	got, err = ss.GetChannelsByAppDefinition(ledger.AppDefinition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, []*channel.Channel{ledger}, compare); diff != "" {
+		t.Fatalf("fetched result different than expected %s", diff)
+	}
+
+	got, err = ss.GetChannelsByIds([]types.Destination{ledger.Id, virtual.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 channels, got %d", len(got))
+	}
+
+	if err := ss.DestroyChannel(virtual.Id); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ss.GetChannelsByParticipant(ta.Irene.Address())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected the destroyed channel to be removed from the participant index, got %d channels", len(got))
+	}
+}
+
+func TestSQLStoreConsensusChannels(t *testing.T) {
+	ss, _ := newTestSQLStore(t)
+
+	fp := td.Objectives.Directfund.GenericDFO().C.FixedPart
+	fp.Participants[0] = ta.Alice.Address()
+	fp.Participants[1] = ta.Bob.Address()
+	left := cc.NewBalance(ta.Alice.Destination(), big.NewInt(6))
+	right := cc.NewBalance(ta.Bob.Destination(), big.NewInt(4))
+	outcome := cc.NewLedgerOutcome(types.Address{}, left, right, []cc.Guarantee{})
+
+	initialVars := cc.Vars{Outcome: *outcome, TurnNum: 0}
+	aliceSig, _ := initialVars.AsState(fp).Sign(ta.Alice.PrivateKey)
+	bobsSig, _ := initialVars.AsState(fp).Sign(ta.Bob.PrivateKey)
+
+	want, err := cc.NewLeaderChannel(fp, 0, *outcome, [2]state.Signature{aliceSig, bobsSig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.SetConsensusChannel(&want); err != nil {
+		t.Fatal(err)
+	}
+
+	compare := cmp.AllowUnexported(cc.ConsensusChannel{}, big.Int{}, cc.LedgerOutcome{}, cc.Balance{}, cc.Guarantee{}, cc.Add{}, cc.Proposal{}, cc.Remove{})
+	for _, counterparty := range []types.Address{ta.Alice.Address(), ta.Bob.Address()} {
+		got, ok := ss.GetConsensusChannel(counterparty)
+		if !ok {
+			t.Fatalf("expected to find the consensus channel with %s", counterparty)
+		}
+		if diff := cmp.Diff(*got, want, compare); diff != "" {
+			t.Fatalf("fetched result different than expected %s", diff)
+		}
+	}
+
+	if _, ok := ss.GetConsensusChannel(ta.Irene.Address()); ok {
+		t.Fatal("expected not to find a consensus channel with Irene")
+	}
+
+	if err := ss.DestroyConsensusChannel(want.Id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ss.GetConsensusChannelById(want.Id); err != store.ErrNoSuchChannel {
+		t.Fatalf("expected ErrNoSuchChannel, got %v", err)
+	}
+}
+
+func TestSQLStoreReopen(t *testing.T) {
+	ss, dataSource := newTestSQLStore(t)
+
+	if err := ss.SetLastBlockNumSeen(15); err != nil {
+		t.Fatal(err)
+	}
+	channelId := types.Destination{1}
+	voucherInfo := payments.VoucherInfo{ChannelPayer: ta.Alice.Address(), StartingBalance: big.NewInt(10), LargestVoucher: payments.Voucher{ChannelId: channelId, Amount: big.NewInt(3)}}
+	if err := ss.SetVoucherInfo(channelId, voucherInfo); err != nil {
+		t.Fatal(err)
+	}
+	if err := ss.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening an up to date database must not reapply any migrations
+	reopened, err := store.NewSQLStore(sqlStoreTestKey, store.SQLiteDriver, dataSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+
+	got, err := reopened.GetLastBlockNumSeen()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 15 {
+		t.Fatalf("expected last block num seen 15, got %d", got)
+	}
+
+	gotVoucherInfo, err := reopened.GetVoucherInfo(channelId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotVoucherInfo.LargestVoucher.Amount.Cmp(big.NewInt(3)) != 0 {
+		t.Fatalf("expected largest voucher amount 3, got %v", gotVoucherInfo.LargestVoucher.Amount)
+	}
+}
+
+func TestNewSQLStoreRejectsUnknownDriver(t *testing.T) {
+	pk := common.Hex2Bytes(`2af069c584758f9ec47c4224a8becc1983f28acfbe837bd7710b70f9fc6d5e44`)
+	if _, err := store.NewSQLStore(pk, "mysql", ""); err == nil {
+		t.Fatal("expected an unsupported driver to be rejected")
+	}
+}
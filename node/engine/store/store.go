@@ -4,11 +4,16 @@ package store // import "github.com/statechannels/go-nitro/node/engine/store"
 import (
 	"io"
 	"log/slog"
+	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/statechannels/go-nitro/channel"
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/crypto"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
 	"github.com/statechannels/go-nitro/payments"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
@@ -21,14 +26,18 @@ const (
 	ErrNoSuchSwap       = types.ConstError("store: failed to find required swap data")
 	ErrLoadVouchers     = types.ConstError("store: could not load vouchers")
 	lastBlockNumSeenKey = "lastBlockNumSeen"
+
+	// ReceivedMessageTTL is how long the id of a received message is remembered in order to drop redelivered copies
+	ReceivedMessageTTL = 24 * time.Hour
 )
 
-// Store is responsible for persisting objectives, objective metadata, states, signatures, private keys and blockchain data
+// Store is responsible for persisting objectives, objective metadata, states, signatures and blockchain data.
+// It does not hold the node's private key: signing is performed by a crypto.Signer.
 type Store interface {
-	GetChannelSecretKey() *[]byte                                                 // Get a pointer to a secret key for signing channel updates
-	GetAddress() *types.Address                                                   // Get the (Ethereum) address associated with the ChannelSecretKey
+	GetAddress() *types.Address                                                   // Get the (Ethereum) address of the node that owns the store
 	GetObjectiveById(protocols.ObjectiveId) (protocols.Objective, error)          // Read an existing objective
 	GetObjectiveByChannelId(types.Destination) (obj protocols.Objective, ok bool) // Get the objective that currently owns the channel with the supplied ChannelId
+	GetObjectives(filter ObjectiveFilter) ([]protocols.Objective, error)          // Returns the objectives which match the filter, sorted by id. Finished objectives may lack channel data
 	SetObjective(protocols.Objective) error                                       // Write an objective
 	DestroyObjective(id protocols.ObjectiveId) error                              // Deletes an objective identified by the given ObjectiveId
 	GetChannelsByIds(ids []types.Destination) ([]*channel.Channel, error)         // Returns a collection of channels with the given ids
@@ -46,6 +55,13 @@ type Store interface {
 	GetSwapsByChannelId(id types.Destination) ([]payments.Swap, error)
 	SetChannelToSwaps(swap payments.Swap) (payments.Swap, error)
 	ConsensusChannelStore
+	MessageOutbox
+	RecoveryStore
+	FailureStore
+	PolicyDecisionStore
+	ProgressStore
+	WebhookDeadLetterStore
+	chainservice.TxOutbox
 	payments.VoucherStore
 	io.Closer
 }
@@ -58,34 +74,202 @@ type ConsensusChannelStore interface {
 	DestroyConsensusChannel(id types.Destination) error
 }
 
+// OutboxMessage is a serialized message waiting to be acknowledged by the peer it is addressed to
+type OutboxMessage struct {
+	Id      string
+	To      types.Address
+	Payload string
+}
+
+// MessageOutbox persists outgoing messages until their recipients acknowledge them,
+// and the ids of recently received messages so that redelivered messages can be dropped.
+type MessageOutbox interface {
+	AddOutboxMessage(OutboxMessage) error
+	RemoveOutboxMessage(to types.Address, id string) error
+	GetOutboxMessages(to types.Address) ([]OutboxMessage, error) // Returns the messages addressed to the peer, sorted by Id
+	GetOutboxDepths() (map[types.Address]int, error)             // Returns the number of unacknowledged messages addressed to each peer
+	// RecordReceivedMessage records the id of a message received from a peer, returning false if it was already recorded.
+	// Ids are remembered for ReceivedMessageTTL.
+	RecordReceivedMessage(from types.Address, id string) (bool, error)
+	// HasReceivedMessage returns true if the id of a message received from a peer is recorded, without recording it
+	HasReceivedMessage(from types.Address, id string) (bool, error)
+}
+
+// PendingSideEffects are the side effects declared by the latest crank of an objective which declared any.
+// They are recorded so that an objective interrupted by a restart can pick up where it stopped.
+type PendingSideEffects struct {
+	Messages []protocols.Message
+	// UnsubmittedTransactions is true until the transactions declared by the crank have been handed to the chain service
+	UnsubmittedTransactions bool
+}
+
+// RecoveryStore persists what the engine needs to resume in-flight objectives after a restart.
+type RecoveryStore interface {
+	GetIncompleteObjectives() ([]protocols.Objective, error) // Returns the objectives which are approved but not yet completed
+	SetPendingSideEffects(id protocols.ObjectiveId, se PendingSideEffects) error
+	GetPendingSideEffects(id protocols.ObjectiveId) (se PendingSideEffects, ok bool, err error)
+	RemovePendingSideEffects(id protocols.ObjectiveId) error
+}
+
+// ObjectiveFilter selects objectives by status, type and the channel they own. Zero-valued fields match every objective.
+type ObjectiveFilter struct {
+	Statuses []protocols.ObjectiveStatus
+	// Type is the prefix of the objective id, e.g. "VirtualDefund"
+	Type      string
+	ChannelId types.Destination
+}
+
+// Matches returns true if the objective satisfies every criterion of the filter
+func (f ObjectiveFilter) Matches(o protocols.Objective) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, o.GetStatus()) {
+		return false
+	}
+	if f.Type != "" && !strings.HasPrefix(string(o.Id()), f.Type+"-") {
+		return false
+	}
+	return f.ChannelId.IsZero() || o.OwnsChannel() == f.ChannelId
+}
+
+// isFinished returns true if the objective has completed or been rejected, after which the data of its channels may no longer be stored
+func isFinished(o protocols.Objective) bool {
+	return o.GetStatus() == protocols.Completed || o.GetStatus() == protocols.Rejected
+}
+
+// ObjectiveProgress is an entry in the progress history of an objective. An entry is recorded whenever the objective's status
+// or what it is waiting for changes, and whenever cranking the objective declares side effects.
+type ObjectiveProgress struct {
+	Time       time.Time
+	Status     protocols.ObjectiveStatus
+	WaitingFor protocols.WaitingFor
+	// MessagesTo holds the recipients of the messages declared alongside the entry
+	MessagesTo []types.Address `json:",omitempty"`
+	// Transactions holds the types of the transactions declared alongside the entry, e.g. "DepositTransaction"
+	Transactions []string `json:",omitempty"`
+}
+
+// ProgressStore persists the progress history of objectives, so that it is possible to tell why an objective is stuck.
+type ProgressStore interface {
+	AddObjectiveProgress(id protocols.ObjectiveId, entry ObjectiveProgress) error
+	GetObjectiveProgress(id protocols.ObjectiveId) ([]ObjectiveProgress, error) // Returns the progress history of the objective, oldest first
+}
+
+// FailureStore persists why objectives failed or were rejected.
+type FailureStore interface {
+	SetFailureReason(id protocols.ObjectiveId, reason protocols.FailureReason) error
+	GetFailureReason(id protocols.ObjectiveId) (reason protocols.FailureReason, ok bool, err error)
+}
+
+// PolicyDecision records the outcome of a policy maker evaluating an objective, and the reason it was made
+type PolicyDecision struct {
+	ObjectiveId protocols.ObjectiveId
+	Approved    bool
+	Reason      string
+	DecidedAt   time.Time
+}
+
+// PolicyDecisionStore persists the decisions policy makers made about objectives, so that they can be looked up later.
+type PolicyDecisionStore interface {
+	SetPolicyDecision(d PolicyDecision) error
+	GetPolicyDecision(id protocols.ObjectiveId) (d PolicyDecision, ok bool, err error)
+	PrunePolicyDecisions(before time.Time) error // Removes the decisions made before the given time
+}
+
+// WebhookDeadLetter is a webhook event which could not be delivered to an endpoint
+type WebhookDeadLetter struct {
+	Id        string // the id of the event
+	Url       string // the endpoint the event was addressed to
+	Payload   string // the JSON encoded event
+	Attempts  int
+	LastError string
+	Time      time.Time // when delivery was abandoned
+}
+
+// WebhookDeadLetterStore persists webhook events which could not be delivered, so that they can be inspected and redelivered.
+type WebhookDeadLetterStore interface {
+	AddWebhookDeadLetter(WebhookDeadLetter) error
+	GetWebhookDeadLetters() ([]WebhookDeadLetter, error) // Returns the dead letters, sorted by Id
+	RemoveWebhookDeadLetter(url string, id string) error
+}
+
 type StoreOpts struct {
-	PkBytes            []byte
+	// PkBytes is the node's private key. It is only used to derive the store's address when Address is not supplied, and is not retained.
+	PkBytes []byte
+	// Address is the node's address. It takes precedence over PkBytes, e.g. when signing with a remote signer while the private key
+	// is only the message service identity.
+	Address            types.Address
 	UseDurableStore    bool
 	DurableStoreFolder string
 	BuntDbConfig       buntdb.Config
+	// SQLDriver selects a SQLStore using the given driver ("sqlite" or "postgres"), taking precedence over UseDurableStore.
+	SQLDriver string
+	// SQLDataSource is passed to the SQL driver. For SQLite it defaults to a database file in the DurableStoreFolder.
+	SQLDataSource string
+	// Encryption enables encryption at rest of a durable store
+	Encryption EncryptionOpts
 }
 
 func NewStore(options StoreOpts) (Store, error) {
-	if options.PkBytes == nil {
-		panic("pk must be provided to Store")
+	me := options.Address
+	if me == (types.Address{}) && len(options.PkBytes) > 0 {
+		me = crypto.GetAddressFromSecretKeyBytes(options.PkBytes)
+	}
+	if me == (types.Address{}) {
+		panic("pk or address must be provided to Store")
 	}
 
 	var ourStore Store
 	var err error
 
-	if options.UseDurableStore {
-		me := crypto.GetAddressFromSecretKeyBytes(options.PkBytes)
+	if options.SQLDriver != "" {
+		dataSource := options.SQLDataSource
+		if dataSource == "" && options.SQLDriver == SQLiteDriver {
+			dataFolder := filepath.Join(options.DurableStoreFolder, me.String())
+			err = os.MkdirAll(dataFolder, os.ModePerm)
+			if err != nil {
+				return nil, err
+			}
+			dataSource = filepath.Join(dataFolder, "store.db")
+		}
+
+		slog.Info("Initialising sql store...", "driver", options.SQLDriver)
+		ourStore, err = newSQLStore(me, options.SQLDriver, dataSource)
+		if err != nil {
+			return nil, err
+		}
+	} else if options.UseDurableStore {
 		dataFolder := filepath.Join(options.DurableStoreFolder, me.String())
 
 		slog.Info("Initialising durable store...", "dataFolder", dataFolder)
-		ourStore, err = NewDurableStore(options.PkBytes, dataFolder, buntdb.Config{})
+		ourStore, err = newDurableStore(me, dataFolder, buntdb.Config{}, options.Encryption)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		slog.Info("Initialising mem store...")
-		ourStore = NewMemStore(options.PkBytes)
+		ourStore = newMemStore(me)
 	}
 
-	return ourStore, nil
+	return newInstrumentedStore(ourStore), nil
+}
+
+// outboxMessageKey returns the key of an outbox message, which sorts messages by recipient and then id
+func outboxMessageKey(to types.Address, id string) string {
+	return to.String() + "/" + id
+}
+
+// receivedMessageKey returns the key under which the id of a message received from a peer is recorded
+func receivedMessageKey(from types.Address, id string) string {
+	return from.String() + "/" + id
+}
+
+// webhookDeadLetterKey leads with the event id, so that dead letters are ordered by id
+func webhookDeadLetterKey(url string, id string) string {
+	return id + " " + url
+}
+
+// sortWebhookDeadLetters sorts dead letters by id, then url
+func sortWebhookDeadLetters(letters []WebhookDeadLetter) {
+	slices.SortFunc(letters, func(a, b WebhookDeadLetter) int {
+		return strings.Compare(webhookDeadLetterKey(a.Url, a.Id), webhookDeadLetterKey(b.Url, b.Id))
+	})
 }
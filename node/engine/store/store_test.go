@@ -3,7 +3,9 @@ package store_test
 import (
 	"math"
 	"math/big"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/go-cmp/cmp"
@@ -11,10 +13,10 @@ import (
 	cc "github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/channel/state/outcome"
-	nc "github.com/statechannels/go-nitro/crypto"
 	ta "github.com/statechannels/go-nitro/internal/testactors"
 	td "github.com/statechannels/go-nitro/internal/testdata"
 	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
 	"github.com/statechannels/go-nitro/node/engine/store"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/protocols/directfund"
@@ -115,21 +117,35 @@ func TestGetObjectiveByChannelId(t *testing.T) {
 	}
 }
 
-func TestGetChannelSecretKey(t *testing.T) {
+func TestGetAddress(t *testing.T) {
 	// from state/test-fixtures.go
 	sk := common.Hex2Bytes("caab404f975b4620747174a75f08d98b4e5a7053b691b41bcfc0d839d48b7634")
 	pk := common.HexToAddress("0xF5A1BB5607C9D079E46d1B3Dc33f257d937b43BD")
 
 	ms := store.NewMemStore(sk)
-	key := ms.GetChannelSecretKey()
 
-	msg := []byte("sign this")
+	if got := *ms.GetAddress(); got != pk {
+		t.Fatalf("expected address %x, but got %x", pk, got)
+	}
+}
 
-	signedMsg, _ := nc.SignEthereumMessage(msg, *key)
-	recoveredSigner, _ := nc.RecoverEthereumMessageSigner(msg, signedMsg)
+func TestNewStoreAddress(t *testing.T) {
+	// The address derived from the private key is used when no address is supplied
+	s, err := store.NewStore(store.StoreOpts{PkBytes: ta.Alice.PrivateKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *s.GetAddress(); got != ta.Alice.Address() {
+		t.Fatalf("expected address %s, got %s", ta.Alice.Address(), got)
+	}
 
-	if recoveredSigner != pk {
-		t.Fatalf("expected to recover %x, but got %x", pk, recoveredSigner)
+	// With a remote signer, the private key is only the message service identity, so the signer's address is used
+	s, err = store.NewStore(store.StoreOpts{PkBytes: ta.Alice.PrivateKey, Address: ta.Bob.Address()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := *s.GetAddress(); got != ta.Bob.Address() {
+		t.Fatalf("expected the signer's address %s, got %s", ta.Bob.Address(), got)
 	}
 }
 
@@ -170,7 +186,7 @@ func TestConsensusChannelStore(t *testing.T) {
 	// Generate a new proposal so we test that the proposal queue is being fetched properly
 	proposedGuarantee := cc.NewGuarantee(big.NewInt(1), types.Destination{2}, left.AsAllocation().Destination, right.AsAllocation().Destination)
 	proposal := cc.NewAddProposal(leader.Id, proposedGuarantee, big.NewInt(1), common.Address{})
-	_, err = leader.Propose(proposal, ta.Alice.PrivateKey)
+	_, err = leader.Propose(proposal, ta.Alice.Signer())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -265,8 +281,13 @@ func TestBigNumberStorage(t *testing.T) {
 		t.Fatal(err)
 	}
 	memStore := store.NewMemStore(pk)
+	sqlStore, err := store.NewSQLStore(pk, store.SQLiteDriver, filepath.Join(dataFolder, "store.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlStore.Close()
 
-	for _, store := range []store.Store{durableStore, memStore} {
+	for _, store := range []store.Store{durableStore, memStore, sqlStore} {
 		// Set the large amount to 100 * math.MaxInt64
 		// 9223372036854775807 * 100 = 922337203685477580700
 		largeAmount := big.NewInt(math.MaxInt64)
@@ -303,3 +324,413 @@ func TestBigNumberStorage(t *testing.T) {
 		}
 	}
 }
+
+// allStores returns a fresh store of each kind, keyed by name, which are closed when the test finishes
+func allStores(t *testing.T) map[string]store.Store {
+	t.Helper()
+	pk := common.Hex2Bytes(`2af069c584758f9ec47c4224a8becc1983f28acfbe837bd7710b70f9fc6d5e44`)
+
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	t.Cleanup(cleanup)
+	durableStore, err := store.NewDurableStore(pk, filepath.Join(dataFolder, "durable"), buntdb.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { durableStore.Close() })
+	encryptedStore, err := store.NewEncryptedDurableStore(pk, filepath.Join(dataFolder, "encrypted"), buntdb.Config{}, store.EncryptionOpts{Passphrase: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { encryptedStore.Close() })
+	sqlStore, err := store.NewSQLStore(pk, store.SQLiteDriver, filepath.Join(dataFolder, "store.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlStore.Close() })
+
+	return map[string]store.Store{
+		"mem":       store.NewMemStore(pk),
+		"durable":   durableStore,
+		"encrypted": encryptedStore,
+		"sql":       sqlStore,
+	}
+}
+
+func TestMessageOutbox(t *testing.T) {
+	outboxes := allStores(t)
+	for name, outbox := range outboxes {
+		t.Run(name, func(t *testing.T) {
+			// Messages are added out of order to check that they are returned sorted by id
+			msgs := []store.OutboxMessage{
+				{Id: "0002", To: ta.Bob.Address(), Payload: `{"b":2}`},
+				{Id: "0001", To: ta.Bob.Address(), Payload: `{"b":1}`},
+				{Id: "0001", To: ta.Irene.Address(), Payload: `{"i":1}`},
+			}
+			for _, m := range msgs {
+				if err := outbox.AddOutboxMessage(m); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := outbox.GetOutboxMessages(ta.Bob.Address())
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []store.OutboxMessage{msgs[1], msgs[0]}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("outbox messages mismatch (-want +got):\n%s", diff)
+			}
+
+			depths, err := outbox.GetOutboxDepths()
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantDepths := map[types.Address]int{ta.Bob.Address(): 2, ta.Irene.Address(): 1}
+			if diff := cmp.Diff(wantDepths, depths); diff != "" {
+				t.Fatalf("outbox depths mismatch (-want +got):\n%s", diff)
+			}
+
+			for _, m := range msgs {
+				if err := outbox.RemoveOutboxMessage(m.To, m.Id); err != nil {
+					t.Fatal(err)
+				}
+			}
+			depths, err = outbox.GetOutboxDepths()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(depths) != 0 {
+				t.Fatalf("expected an empty outbox, got depths %v", depths)
+			}
+
+			received, err := outbox.HasReceivedMessage(ta.Alice.Address(), "0001")
+			if err != nil || received {
+				t.Fatalf("expected a message which was not recorded to be unreceived, got %v, %v", received, err)
+			}
+			isNew, err := outbox.RecordReceivedMessage(ta.Alice.Address(), "0001")
+			if err != nil || !isNew {
+				t.Fatalf("expected the first receipt to be new, got %v, %v", isNew, err)
+			}
+			received, err = outbox.HasReceivedMessage(ta.Alice.Address(), "0001")
+			if err != nil || !received {
+				t.Fatalf("expected a recorded message to be received, got %v, %v", received, err)
+			}
+			isNew, err = outbox.RecordReceivedMessage(ta.Alice.Address(), "0001")
+			if err != nil || isNew {
+				t.Fatalf("expected a redelivered message to be a duplicate, got %v, %v", isNew, err)
+			}
+			isNew, err = outbox.RecordReceivedMessage(ta.Bob.Address(), "0001")
+			if err != nil || !isNew {
+				t.Fatalf("expected the same id from another sender to be new, got %v, %v", isNew, err)
+			}
+		})
+	}
+}
+
+func TestRecoveryStore(t *testing.T) {
+	stores := allStores(t)
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			dfo := td.Objectives.Directfund.GenericDFO()  // unapproved
+			vfo := td.Objectives.Virtualfund.GenericVFO() // approved
+			for _, o := range []protocols.Objective{&dfo, &vfo} {
+				if err := s.SetObjective(o); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			incomplete, err := s.GetIncompleteObjectives()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(incomplete) != 1 || incomplete[0].Id() != vfo.Id() {
+				t.Fatalf("expected only %s to be incomplete, got %v", vfo.Id(), incomplete)
+			}
+
+			approved := dfo.Approve()
+			if err := s.SetObjective(approved); err != nil {
+				t.Fatal(err)
+			}
+			incomplete, err = s.GetIncompleteObjectives()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(incomplete) != 2 || incomplete[0].Id() != dfo.Id() || incomplete[1].Id() != vfo.Id() {
+				t.Fatalf("expected %s and %s to be incomplete, got %v", dfo.Id(), vfo.Id(), incomplete)
+			}
+			if diff := compareObjectives(incomplete[1], &vfo); diff != "" {
+				t.Fatalf("incomplete objective mismatch (-want +got):\n%s", diff)
+			}
+
+			_, ok, err := s.GetPendingSideEffects(vfo.Id())
+			if err != nil || ok {
+				t.Fatalf("expected no pending side effects, got %v, %v", ok, err)
+			}
+
+			payload, err := protocols.CreateObjectivePayload(vfo.Id(), virtualfund.SignedStatePayload, vfo.V.PreFundState())
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := store.PendingSideEffects{
+				Messages:                []protocols.Message{{To: ta.Bob.Address(), ObjectivePayloads: []protocols.ObjectivePayload{payload}}},
+				UnsubmittedTransactions: true,
+			}
+			if err := s.SetPendingSideEffects(vfo.Id(), want); err != nil {
+				t.Fatal(err)
+			}
+			got, ok, err := s.GetPendingSideEffects(vfo.Id())
+			if err != nil || !ok {
+				t.Fatalf("expected pending side effects, got %v, %v", ok, err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("pending side effects mismatch (-want +got):\n%s", diff)
+			}
+
+			if err := s.RemovePendingSideEffects(vfo.Id()); err != nil {
+				t.Fatal(err)
+			}
+			_, ok, err = s.GetPendingSideEffects(vfo.Id())
+			if err != nil || ok {
+				t.Fatalf("expected the pending side effects to be removed, got %v, %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestFailureStore(t *testing.T) {
+	stores := allStores(t)
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			id := protocols.ObjectiveId("DirectFunding-0x01")
+			_, ok, err := s.GetFailureReason(id)
+			if err != nil || ok {
+				t.Fatalf("expected no failure reason, got %v, %v", ok, err)
+			}
+
+			for _, want := range []protocols.FailureReason{
+				{Code: protocols.Timeout, Message: "objective missed its deadline"},
+				{Code: protocols.PolicyRejection, Message: "rejected by policy"},
+			} {
+				if err := s.SetFailureReason(id, want); err != nil {
+					t.Fatal(err)
+				}
+				got, ok, err := s.GetFailureReason(id)
+				if err != nil || !ok {
+					t.Fatalf("expected a failure reason, got %v, %v", ok, err)
+				}
+				if got != want {
+					t.Fatalf("expected failure reason %+v, got %+v", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestProgressStore(t *testing.T) {
+	stores := allStores(t)
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			dfo := td.Objectives.Directfund.GenericDFO()  // unapproved
+			vfo := td.Objectives.Virtualfund.GenericVFO() // approved
+			for _, o := range []protocols.Objective{&dfo, &vfo} {
+				if err := s.SetObjective(o); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			testCases := map[string]struct {
+				filter store.ObjectiveFilter
+				want   []protocols.ObjectiveId
+			}{
+				"everything":   {store.ObjectiveFilter{}, []protocols.ObjectiveId{dfo.Id(), vfo.Id()}},
+				"by status":    {store.ObjectiveFilter{Statuses: []protocols.ObjectiveStatus{protocols.Unapproved}}, []protocols.ObjectiveId{dfo.Id()}},
+				"by statuses":  {store.ObjectiveFilter{Statuses: []protocols.ObjectiveStatus{protocols.Approved, protocols.Completed}}, []protocols.ObjectiveId{vfo.Id()}},
+				"by type":      {store.ObjectiveFilter{Type: "VirtualFund"}, []protocols.ObjectiveId{vfo.Id()}},
+				"by channel":   {store.ObjectiveFilter{ChannelId: dfo.C.Id}, []protocols.ObjectiveId{dfo.Id()}},
+				"no match":     {store.ObjectiveFilter{Type: "VirtualFund", ChannelId: dfo.C.Id}, []protocols.ObjectiveId{}},
+				"unknown type": {store.ObjectiveFilter{Type: "Virtual"}, []protocols.ObjectiveId{}},
+			}
+			for name, tc := range testCases {
+				objectives, err := s.GetObjectives(tc.filter)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got := []protocols.ObjectiveId{}
+				for _, o := range objectives {
+					got = append(got, o.Id())
+				}
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Fatalf("%s: objectives mismatch (-want +got):\n%s", name, diff)
+				}
+			}
+
+			history, err := s.GetObjectiveProgress(vfo.Id())
+			if err != nil || len(history) != 0 {
+				t.Fatalf("expected no progress, got %v, %v", history, err)
+			}
+
+			want := []store.ObjectiveProgress{
+				{Time: time.Unix(1, 0).UTC(), Status: protocols.Approved, WaitingFor: virtualfund.WaitingForCompletePrefund, MessagesTo: []types.Address{ta.Bob.Address()}},
+				{Time: time.Unix(2, 0).UTC(), Status: protocols.Approved, WaitingFor: virtualfund.WaitingForCompleteFunding},
+				{Time: time.Unix(3, 0).UTC(), Status: protocols.Rejected},
+			}
+			for _, entry := range want {
+				if err := s.AddObjectiveProgress(vfo.Id(), entry); err != nil {
+					t.Fatal(err)
+				}
+			}
+			history, err = s.GetObjectiveProgress(vfo.Id())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, history); diff != "" {
+				t.Fatalf("progress mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWebhookDeadLetterStore(t *testing.T) {
+	stores := allStores(t)
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			abandoned := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			// Dead letters are added out of order to check that they are returned sorted by id, then url
+			letters := []store.WebhookDeadLetter{
+				{Id: "0002", Url: "http://a.example", Payload: `{"n":2}`, Attempts: 5, LastError: "503 Service Unavailable", Time: abandoned},
+				{Id: "0001", Url: "http://b.example", Payload: `{"n":1}`, Attempts: 5, LastError: "connection refused", Time: abandoned},
+				{Id: "0001", Url: "http://a.example", Payload: `{"n":1}`, Attempts: 5, LastError: "503 Service Unavailable", Time: abandoned},
+			}
+			for _, d := range letters {
+				if err := s.AddWebhookDeadLetter(d); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := s.GetWebhookDeadLetters()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []store.WebhookDeadLetter{letters[2], letters[1], letters[0]}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("dead letters mismatch (-want +got):\n%s", diff)
+			}
+
+			if err := s.RemoveWebhookDeadLetter("http://a.example", "0001"); err != nil {
+				t.Fatal(err)
+			}
+			// Removing a dead letter which does not exist is not an error
+			if err := s.RemoveWebhookDeadLetter("http://c.example", "0001"); err != nil {
+				t.Fatal(err)
+			}
+			got, err = s.GetWebhookDeadLetters()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want = []store.WebhookDeadLetter{letters[1], letters[0]}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("dead letters mismatch after removal (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPolicyDecisionStore(t *testing.T) {
+	stores := allStores(t)
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			decided := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+			old := store.PolicyDecision{ObjectiveId: "DirectFunding-0x01", Approved: false, Reason: "counterparty 0x01 is blocked", DecidedAt: decided}
+			recent := store.PolicyDecision{ObjectiveId: "DirectFunding-0x02", Approved: true, Reason: "all policy rules satisfied", DecidedAt: decided.Add(time.Hour)}
+			for _, d := range []store.PolicyDecision{old, recent} {
+				if err := s.SetPolicyDecision(d); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, ok, err := s.GetPolicyDecision(old.ObjectiveId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatalf("expected a decision for %s", old.ObjectiveId)
+			}
+			if diff := cmp.Diff(old, got); diff != "" {
+				t.Fatalf("decision mismatch (-want +got):\n%s", diff)
+			}
+
+			if err := s.PrunePolicyDecisions(decided.Add(time.Minute)); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok, err := s.GetPolicyDecision(old.ObjectiveId); err != nil || ok {
+				t.Fatalf("expected the decision for %s to be pruned, got ok=%t err=%v", old.ObjectiveId, ok, err)
+			}
+			got, ok, err = s.GetPolicyDecision(recent.ObjectiveId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatalf("expected the decision for %s to survive pruning", recent.ObjectiveId)
+			}
+			if diff := cmp.Diff(recent, got); diff != "" {
+				t.Fatalf("decision mismatch after pruning (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTxOutboxStore(t *testing.T) {
+	stores := allStores(t)
+
+	channelId := types.Destination{1}
+	updated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	// Transactions are set out of order to check that they are returned sorted by id
+	txs := []chainservice.OutboxTx{
+		{Id: "0002", ObjectiveId: "DirectDefunding-0x01", Tx: protocols.NewWithdrawAllTransaction(channelId, state.NewSignedState(state.TestState)), Status: chainservice.TxQueued, UpdatedAt: updated},
+		{Id: "0001", ObjectiveId: "DirectFunding-0x01", Tx: protocols.NewDepositTransaction(channelId, types.Funds{common.Address{}: big.NewInt(5)}), TxHashes: []common.Hash{{1}, {2}}, Status: chainservice.TxSubmitted, UpdatedAt: updated},
+	}
+	compareTxs := func(a, b []chainservice.OutboxTx) string {
+		return cmp.Diff(a, b, cmp.AllowUnexported(protocols.ChainTransactionBase{}, state.SignedState{}, big.Int{}))
+	}
+
+	for name, s := range stores {
+		t.Run(name, func(t *testing.T) {
+			for _, o := range txs {
+				if err := s.SetOutboxTx(o); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := s.GetOutboxTxs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []chainservice.OutboxTx{txs[1], txs[0]}
+			if diff := compareTxs(want, got); diff != "" {
+				t.Fatalf("outbox mismatch (-want +got):\n%s", diff)
+			}
+
+			// Setting a transaction again updates it
+			confirmed := txs[1]
+			confirmed.Status, confirmed.BlockNum, confirmed.BlockHash = chainservice.TxConfirmed, 7, common.Hash{7}
+			if err := s.SetOutboxTx(confirmed); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.RemoveOutboxTx("0002"); err != nil {
+				t.Fatal(err)
+			}
+			// Removing a transaction which does not exist is not an error
+			if err := s.RemoveOutboxTx("0003"); err != nil {
+				t.Fatal(err)
+			}
+			got, err = s.GetOutboxTxs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want = []chainservice.OutboxTx{confirmed}
+			if diff := compareTxs(want, got); diff != "" {
+				t.Fatalf("outbox mismatch after update (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package engine
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/BurntSushi/toml"
+	"github.com/statechannels/go-nitro/types"
+)
+
+const (
+	watchtowerCheckpoint = "checkpoint"
+	watchtowerChallenge  = "challenge"
+	watchtowerNone       = "none"
+)
+
+// WatchtowerRules configure how a Watchtower responds to challenges registered against a state older than the latest supported state we hold.
+// The response for each channel type is one of "checkpoint", "challenge" or "none". An empty response is treated as "none".
+type WatchtowerRules struct {
+	Ledger  string `toml:"ledger"`
+	Virtual string `toml:"virtual"`
+	Swap    string `toml:"swap"`
+	// ResponseMargin is the minimum number of seconds which must remain before a challenge finalizes for a response to be submitted.
+	ResponseMargin uint64 `toml:"responsemargin"`
+}
+
+// DefaultWatchtowerRules returns rules which checkpoint the latest supported state of any channel challenged with a stale state.
+func DefaultWatchtowerRules() WatchtowerRules {
+	return WatchtowerRules{
+		Ledger:  watchtowerCheckpoint,
+		Virtual: watchtowerCheckpoint,
+		Swap:    watchtowerCheckpoint,
+	}
+}
+
+// LoadWatchtowerRules reads the [watchtower] table of the TOML file at configPath.
+// The returned bool is false if the file does not contain a [watchtower] table.
+func LoadWatchtowerRules(configPath string) (WatchtowerRules, bool, error) {
+	var config struct {
+		Watchtower WatchtowerRules `toml:"watchtower"`
+	}
+	md, err := toml.DecodeFile(configPath, &config)
+	if err != nil {
+		return WatchtowerRules{}, false, fmt.Errorf("could not decode watchtower rules: %w", err)
+	}
+	return config.Watchtower, md.IsDefined("watchtower"), nil
+}
+
+// Watchtower instructs the engine to respond on its own to challenges registered against stale states,
+// by submitting a checkpoint or a counter challenge with the latest supported state.
+type Watchtower struct {
+	responses      map[types.ChannelType]types.CounterChallengeAction
+	responseMargin uint64
+}
+
+// NewWatchtower validates the supplied rules and constructs a Watchtower which applies them.
+func NewWatchtower(rules WatchtowerRules) (*Watchtower, error) {
+	w := &Watchtower{
+		responses:      make(map[types.ChannelType]types.CounterChallengeAction),
+		responseMargin: rules.ResponseMargin,
+	}
+
+	for channelType, response := range map[types.ChannelType]string{types.Ledger: rules.Ledger, types.Virtual: rules.Virtual, types.Swap: rules.Swap} {
+		switch response {
+		case watchtowerCheckpoint:
+			w.responses[channelType] = types.Checkpoint
+		case watchtowerChallenge:
+			w.responses[channelType] = types.Challenge
+		case watchtowerNone, "":
+		default:
+			return nil, fmt.Errorf("unknown watchtower response %q", response)
+		}
+	}
+
+	return w, nil
+}
+
+// Response decides how to respond to a challenge on a channel of the given type.
+// It returns false if the challenged state is not stale, if channels of that type are not watched,
+// or if the challenge finalizes too soon after latestBlockTime for a response to land in time.
+func (w *Watchtower) Response(channelType types.ChannelType, challengedTurnNum uint64, latestSupportedTurnNum uint64, finalizesAt *big.Int, latestBlockTime uint64) (types.CounterChallengeAction, bool) {
+	action, ok := w.responses[channelType]
+	if !ok || latestSupportedTurnNum <= challengedTurnNum {
+		return action, false
+	}
+
+	deadline := new(big.Int).SetUint64(latestBlockTime + w.responseMargin)
+	if finalizesAt == nil || finalizesAt.Cmp(deadline) <= 0 {
+		return action, false
+	}
+
+	return action, true
+}
@@ -0,0 +1,88 @@
+package engine_test
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestWatchtowerResponse(t *testing.T) {
+	w, err := engine.NewWatchtower(engine.WatchtowerRules{
+		Ledger:         "checkpoint",
+		Virtual:        "challenge",
+		ResponseMargin: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name                   string
+		channelType            types.ChannelType
+		challengedTurnNum      uint64
+		latestSupportedTurnNum uint64
+		finalizesAt            int64
+		latestBlockTime        uint64
+		wantAction             types.CounterChallengeAction
+		wantRespond            bool
+	}{
+		{"stale ledger challenge", types.Ledger, 3, 5, 100, 50, types.Checkpoint, true},
+		{"stale virtual challenge", types.Virtual, 3, 5, 100, 50, types.Challenge, true},
+		{"unwatched swap channel", types.Swap, 3, 5, 100, 50, types.Checkpoint, false},
+		{"latest state challenged", types.Ledger, 5, 5, 100, 50, types.Checkpoint, false},
+		{"within response margin", types.Ledger, 3, 5, 100, 90, types.Checkpoint, false},
+		{"already finalized", types.Ledger, 3, 5, 100, 120, types.Checkpoint, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			action, respond := w.Response(tc.channelType, tc.challengedTurnNum, tc.latestSupportedTurnNum, big.NewInt(tc.finalizesAt), tc.latestBlockTime)
+			if respond != tc.wantRespond {
+				t.Fatalf("expected respond to be %t, got %t", tc.wantRespond, respond)
+			}
+			if respond && action != tc.wantAction {
+				t.Fatalf("expected action %v, got %v", tc.wantAction, action)
+			}
+		})
+	}
+}
+
+func TestNewWatchtowerRejectsUnknownResponse(t *testing.T) {
+	if _, err := engine.NewWatchtower(engine.WatchtowerRules{Ledger: "withdraw"}); err == nil {
+		t.Fatal("expected an error for an unknown response")
+	}
+}
+
+func TestLoadWatchtowerRules(t *testing.T) {
+	config := `
+pk = "2d999770f7b5d49b694080f987b82bbc9fc9ac2b4dcc10b0f8aba7d700f69c6d"
+
+[watchtower]
+ledger = "challenge"
+virtual = "checkpoint"
+swap = "none"
+responsemargin = 60
+`
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(configPath, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rules, found, err := engine.LoadWatchtowerRules(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("expected a watchtower table to be found")
+	}
+	if rules.Ledger != "challenge" || rules.Virtual != "checkpoint" || rules.Swap != "none" || rules.ResponseMargin != 60 {
+		t.Fatalf("unexpected rules loaded: %+v", rules)
+	}
+	if _, err := engine.NewWatchtower(rules); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -2,8 +2,11 @@
 package node // import "github.com/statechannels/go-nitro/node"
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"math/big"
 	"runtime/debug"
@@ -13,6 +16,7 @@ import (
 	"github.com/statechannels/go-nitro/channel"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/channel/state/outcome"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/internal/safesync"
 	"github.com/statechannels/go-nitro/node/engine"
 	"github.com/statechannels/go-nitro/node/engine/chainservice"
@@ -36,23 +40,34 @@ import (
 	"github.com/statechannels/go-nitro/types"
 )
 
+// ErrNoApprovalQueue is returned when an operator decision is requested from a node which does not park objectives for approval.
+var ErrNoApprovalQueue = errors.New("node is not configured to park objectives for approval")
+
 // Node provides the interface for the consuming application
 type Node struct {
 	engine                      engine.Engine // The core business logic of the node
 	Address                     *types.Address
 	channelNotifier             *notifier.ChannelNotifier
 	completedObjectivesNotifier *notifier.CompletedObjetivesNotifier
+	webhookNotifier             *notifier.WebhookNotifier
 
 	completedObjectives *safesync.Map[chan struct{}]
-	failedObjectives    chan protocols.ObjectiveId
-	receivedVouchers    chan payments.Voucher
+	failedObjectives    *notifier.Topic[protocols.FailedObjective]
+	receivedVouchers    *notifier.Topic[payments.Voucher]
+	challengeResponses  *notifier.Topic[types.ChallengeResponse]
+	txReplacements      *notifier.Topic[types.TransactionReplacement]
 	chainId             *big.Int
 	store               store.Store
+	signer              crypto.Signer
 	vm                  *payments.VoucherManager
+	policymaker         engine.PolicyMaker
+
+	ctx context.Context // ctx is the context of the API call being served. It is only set on copies of the node made by WithContext.
 }
 
-// New is the constructor for a Node. It accepts a messaging service, a chain service, and a store as injected dependencies.
-func New(messageService messageservice.MessageService, chainservice chainservice.ChainService, store store.Store, policymaker engine.PolicyMaker) Node {
+// New is the constructor for a Node. It accepts a messaging service, a chain service, a store and a signer as injected dependencies.
+// The policy maker decides whether to approve objectives, and opts holds the optional components of the node's engine.
+func New(messageService messageservice.MessageService, chainservice chainservice.ChainService, store store.Store, signer crypto.Signer, policymaker engine.PolicyMaker, opts engine.EngineOpts) Node {
 	n := Node{}
 	n.Address = store.GetAddress()
 
@@ -62,17 +77,27 @@ func New(messageService messageservice.MessageService, chainservice chainservice
 	}
 	n.chainId = chainId
 	n.store = store
+	n.signer = signer
+	n.policymaker = policymaker
 	n.vm = payments.NewVoucherManager(*store.GetAddress(), store)
 
-	n.engine = engine.New(n.vm, messageService, chainservice, store, policymaker, n.handleEngineEvent)
 	n.completedObjectives = &safesync.Map[chan struct{}]{}
 
-	n.failedObjectives = make(chan protocols.ObjectiveId, 100)
-	// Using a larger buffer since payments can be sent frequently.
-	n.receivedVouchers = make(chan payments.Voucher, 1000)
+	n.failedObjectives = notifier.NewTopic[protocols.FailedObjective](nil)
+	n.receivedVouchers = notifier.NewTopic[payments.Voucher](nil)
+	n.challengeResponses = notifier.NewTopic[types.ChallengeResponse](nil)
+	n.txReplacements = notifier.NewTopic[types.TransactionReplacement](nil)
+	// The shared subscriptions behind FailedObjectives, ReceivedVouchers and ChallengeResponses receive every update from the start
+	n.failedObjectives.Default()
+	n.receivedVouchers.Default()
+	n.challengeResponses.Default()
 
 	n.channelNotifier = notifier.NewChannelNotifier(store, n.vm)
 	n.completedObjectivesNotifier = notifier.NewCompletedObjectivesNotifier()
+	n.webhookNotifier = notifier.NewWebhookNotifier(signer.Address(), store)
+
+	// The engine is constructed last, since objectives it recovers on startup may emit events straight away
+	n.engine = engine.New(n.vm, messageService, chainservice, store, signer, policymaker, opts, n.handleEngineEvent)
 
 	return n
 }
@@ -86,20 +111,24 @@ func (n *Node) handleEngineEvent(update engine.EngineEvent) {
 
 		// Broadcast completed objective ID to all listeners
 		n.completedObjectivesNotifier.BroadcastCompletedObjective(completed.Id())
+		n.webhookNotifier.NotifyObjectiveCompleted(completed.Id())
 	}
 
 	for _, erred := range update.FailedObjectives {
-		n.failedObjectives <- erred
+		n.failedObjectives.Publish(erred)
+		n.webhookNotifier.NotifyObjectiveFailed(erred)
 	}
 
 	for _, payment := range update.ReceivedVouchers {
-		n.receivedVouchers <- payment
+		n.receivedVouchers.Publish(payment)
+		n.webhookNotifier.NotifyVoucherReceived(payment)
 	}
 
 	for _, updated := range update.LedgerChannelUpdates {
 
 		err := n.channelNotifier.NotifyLedgerUpdated(updated)
 		n.handleError(err)
+		n.webhookNotifier.NotifyLedgerUpdated(updated)
 	}
 	for _, updated := range update.PaymentChannelUpdates {
 		marshalledInfo, er := json.Marshal(updated)
@@ -111,16 +140,34 @@ func (n *Node) handleEngineEvent(update engine.EngineEvent) {
 
 		err := n.channelNotifier.NotifyPaymentUpdated(updated)
 		n.handleError(err)
+		n.webhookNotifier.NotifyPaymentUpdated(updated)
 	}
 
 	for _, updated := range update.SwapUpdates {
 		err := n.channelNotifier.NotifySwapUpdated(updated)
 		n.handleError(err)
+		n.webhookNotifier.NotifySwapUpdated(updated)
+	}
+
+	for _, response := range update.ChallengeResponses {
+		n.challengeResponses.Publish(response)
+	}
+
+	for _, replacement := range update.TransactionReplacements {
+		n.txReplacements.Publish(replacement)
 	}
 }
 
 // Begin API
 
+// WithContext returns a copy of the node whose objective requests continue the trace of ctx.
+// It is used to trace an objective from the API call which requested it.
+func (n *Node) WithContext(ctx context.Context) *Node {
+	traced := *n
+	traced.ctx = ctx
+	return &traced
+}
+
 // Version returns the go-nitro version
 func (n *Node) Version() string {
 	info, _ := debug.ReadBuildInfo()
@@ -144,22 +191,82 @@ func (n *Node) Version() string {
 	return version
 }
 
+// SubscribeLedgerUpdates returns a subscription to the updates of all ledger channels. It must be closed once it is no longer read.
+func (n *Node) SubscribeLedgerUpdates(opts notifier.SubscriptionOpts) *notifier.Subscription[query.LedgerChannelInfo] {
+	return n.channelNotifier.SubscribeLedgerUpdates(opts)
+}
+
+// SubscribeLedgerChannel returns a subscription to the updates of the ledger channel with the given id. It must be closed once it is no longer read.
+func (n *Node) SubscribeLedgerChannel(ledgerId types.Destination, opts notifier.SubscriptionOpts) *notifier.Subscription[query.LedgerChannelInfo] {
+	return n.channelNotifier.SubscribeLedgerChannel(ledgerId, opts)
+}
+
+// SubscribePaymentUpdates returns a subscription to the updates of all payment channels. It must be closed once it is no longer read.
+func (n *Node) SubscribePaymentUpdates(opts notifier.SubscriptionOpts) *notifier.Subscription[query.PaymentChannelInfo] {
+	return n.channelNotifier.SubscribePaymentUpdates(opts)
+}
+
+// SubscribePaymentChannel returns a subscription to the updates of the payment channel with the given id. It must be closed once it is no longer read.
+func (n *Node) SubscribePaymentChannel(channelId types.Destination, opts notifier.SubscriptionOpts) *notifier.Subscription[query.PaymentChannelInfo] {
+	return n.channelNotifier.SubscribePaymentChannel(channelId, opts)
+}
+
+// SubscribeSwapUpdates returns a subscription to the updates of all swaps. It must be closed once it is no longer read.
+func (n *Node) SubscribeSwapUpdates(opts notifier.SubscriptionOpts) *notifier.Subscription[query.SwapInfo] {
+	return n.channelNotifier.SubscribeSwapUpdates(opts)
+}
+
+// SubscribeCompletedObjectives returns a subscription to the ids of completed objectives. It must be closed once it is no longer read.
+func (n *Node) SubscribeCompletedObjectives(opts notifier.SubscriptionOpts) *notifier.Subscription[protocols.ObjectiveId] {
+	return n.completedObjectivesNotifier.SubscribeCompletedObjectives(opts)
+}
+
+// SubscribeFailedObjectives returns a subscription to the objectives which fail, with the reason. It must be closed once it is no longer read.
+func (n *Node) SubscribeFailedObjectives(opts notifier.SubscriptionOpts) *notifier.Subscription[protocols.FailedObjective] {
+	return n.failedObjectives.Subscribe(opts)
+}
+
+// SubscribeReceivedVouchers returns a subscription to the payment vouchers received by the node. It must be closed once it is no longer read.
+func (n *Node) SubscribeReceivedVouchers(opts notifier.SubscriptionOpts) *notifier.Subscription[payments.Voucher] {
+	return n.receivedVouchers.Subscribe(opts)
+}
+
+// SubscribeChallengeResponses returns a subscription to the challenge responses made whenever the node checkpoints or counter challenges
+// a challenge registered against a stale state. It must be closed once it is no longer read.
+func (n *Node) SubscribeChallengeResponses(opts notifier.SubscriptionOpts) *notifier.Subscription[types.ChallengeResponse] {
+	return n.challengeResponses.Subscribe(opts)
+}
+
+// SubscribeTransactionReplacements returns a subscription to the chain transactions which the node resubmits with higher fees
+// after they stay pending for too long. It must be closed once it is no longer read.
+func (n *Node) SubscribeTransactionReplacements(opts notifier.SubscriptionOpts) *notifier.Subscription[types.TransactionReplacement] {
+	return n.txReplacements.Subscribe(opts)
+}
+
 // LedgerUpdates returns a chan that receives ledger channel info whenever that ledger channel is updated. Not suitable for multiple subscribers.
+//
+// Deprecated: use SubscribeLedgerUpdates.
 func (n *Node) LedgerUpdates() <-chan query.LedgerChannelInfo {
 	return n.channelNotifier.RegisterForAllLedgerUpdates()
 }
 
 // PaymentUpdates returns a chan that receives payment channel info whenever that payment channel is updated. Not suitable fo multiple subscribers.
+//
+// Deprecated: use SubscribePaymentUpdates.
 func (n *Node) PaymentUpdates() <-chan query.PaymentChannelInfo {
 	return n.channelNotifier.RegisterForAllPaymentUpdates()
 }
 
 // SwapUpdates returns a chan that receives swap info whenever a swap objective is updated. Not suitable fo multiple subscribers.
+//
+// Deprecated: use SubscribeSwapUpdates.
 func (n *Node) SwapUpdates() <-chan query.SwapInfo {
 	return n.channelNotifier.RegisterForAllSwapUpdates()
 }
 
 // CompletedObjectives returns a chan that receives a objective ID whenever that objective is completed
+//
+// Deprecated: use SubscribeCompletedObjectives.
 func (n *Node) CompletedObjectives() <-chan protocols.ObjectiveId {
 	return n.completedObjectivesNotifier.RegisterForAllCompletedObjectives()
 }
@@ -171,29 +278,60 @@ func (n *Node) ObjectiveCompleteChan(id protocols.ObjectiveId) <-chan struct{} {
 }
 
 // LedgerUpdatedChan returns a chan that receives a ledger channel info whenever the ledger with given id is updated
+//
+// Deprecated: use SubscribeLedgerChannel.
 func (n *Node) LedgerUpdatedChan(ledgerId types.Destination) <-chan query.LedgerChannelInfo {
 	return n.channelNotifier.RegisterForLedgerUpdates(ledgerId)
 }
 
 // PaymentChannelUpdatedChan returns a chan that receives a payment channel info whenever the payment channel with given id is updated
+//
+// Deprecated: use SubscribePaymentChannel.
 func (n *Node) PaymentChannelUpdatedChan(ledgerId types.Destination) <-chan query.PaymentChannelInfo {
 	return n.channelNotifier.RegisterForPaymentChannelUpdates(ledgerId)
 }
 
-// FailedObjectives returns a chan that receives an objective id whenever that objective has failed
-func (n *Node) FailedObjectives() <-chan protocols.ObjectiveId {
-	return n.failedObjectives
+// FailedObjectives returns a chan that receives an objective id and the reason whenever that objective has failed. Not suitable for multiple subscribers.
+//
+// Deprecated: use SubscribeFailedObjectives.
+func (n *Node) FailedObjectives() <-chan protocols.FailedObjective {
+	return n.failedObjectives.Default().Updates()
+}
+
+// ChallengeResponses returns a chan that receives a challenge response whenever the node checkpoints or counter challenges
+// a challenge registered against a stale state. Not suitable for multiple subscribers.
+//
+// Deprecated: use SubscribeChallengeResponses.
+func (n *Node) ChallengeResponses() <-chan types.ChallengeResponse {
+	return n.challengeResponses.Default().Updates()
+}
+
+// AddWebhook starts POSTing JSON events, signed with opts.Secret, to the webhook endpoint described by opts.
+func (n *Node) AddWebhook(opts notifier.WebhookOpts) error {
+	return n.webhookNotifier.AddEndpoint(opts)
+}
+
+// GetWebhookDeadLetters returns the webhook events which could not be delivered
+func (n *Node) GetWebhookDeadLetters() ([]store.WebhookDeadLetter, error) {
+	return n.store.GetWebhookDeadLetters()
 }
 
-// ReceivedVouchers returns a chan that receives a voucher every time we receive a payment voucher
+// RedeliverWebhookDeadLetters queues the webhook events which could not be delivered for delivery again, returning how many were queued
+func (n *Node) RedeliverWebhookDeadLetters() (int, error) {
+	return n.webhookNotifier.Redeliver()
+}
+
+// ReceivedVouchers returns a chan that receives a voucher every time we receive a payment voucher. Not suitable for multiple subscribers.
+//
+// Deprecated: use SubscribeReceivedVouchers.
 func (n *Node) ReceivedVouchers() <-chan payments.Voucher {
-	return n.receivedVouchers
+	return n.receivedVouchers.Default().Updates()
 }
 
 // CreateVoucher creates and returns a voucher for the given channelId which increments the redeemable balance by amount.
 // It is the responsibility of the caller to send the voucher to the payee.
 func (n *Node) CreateVoucher(channelId types.Destination, amount *big.Int) (payments.Voucher, error) {
-	voucher, err := n.vm.Pay(channelId, amount, *n.store.GetChannelSecretKey())
+	voucher, err := n.vm.Pay(channelId, amount, n.signer)
 	if err != nil {
 		return payments.Voucher{}, err
 	}
@@ -205,6 +343,7 @@ func (n *Node) CreateVoucher(channelId types.Destination, amount *big.Int) (paym
 	if err != nil {
 		return voucher, err
 	}
+	n.webhookNotifier.NotifyPaymentUpdated(info)
 	return voucher, nil
 }
 
@@ -240,7 +379,7 @@ func (n *Node) CreateSwapChannel(Intermediaries []types.Address, CounterParty ty
 	}
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Response(*n.Address), nil
@@ -259,7 +398,7 @@ func (n *Node) CreatePaymentChannel(Intermediaries []types.Address, CounterParty
 	)
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Response(*n.Address), nil
@@ -299,7 +438,7 @@ func (n *Node) SwapAssets(channelId types.Destination, tokenIn common.Address, t
 	objectiveRequest := swap.NewObjectiveRequest(channelId, tokenIn, tokenOut, amountIn, amountOut, swapChannel.FixedPart, nonce)
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Response(), nil
@@ -310,7 +449,7 @@ func (n *Node) ClosePaymentChannel(channelId types.Destination) (protocols.Objec
 	objectiveRequest := virtualdefund.NewObjectiveRequest(channelId)
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Id(*n.Address, n.chainId), nil
 }
@@ -319,7 +458,7 @@ func (n *Node) CloseSwapChannel(channelId types.Destination) (protocols.Objectiv
 	objectiveRequest := swapdefund.NewObjectiveRequest(channelId)
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Id(*n.Address, n.chainId), nil
 }
@@ -349,7 +488,7 @@ func (n *Node) CreateLedgerChannel(Counterparty types.Address, ChallengeDuration
 	}
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Response(*n.Address, n.chainId), nil
 }
@@ -379,7 +518,7 @@ func (n *Node) CreateBridgeChannel(Counterparty types.Address, ChallengeDuration
 	}
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Response(*n.Address, n.chainId), nil
 }
@@ -389,7 +528,7 @@ func (n *Node) CloseLedgerChannel(channelId types.Destination, isChallenge bool)
 	objectiveRequest := directdefund.NewObjectiveRequest(channelId, isChallenge)
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Id(*n.Address, n.chainId), nil
 }
@@ -398,7 +537,7 @@ func (n *Node) CloseBridgeChannel(channelId types.Destination) (protocols.Object
 	objectiveRequest := bridgeddefund.NewObjectiveRequest(channelId)
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Id(*n.Address, n.chainId), nil
 }
@@ -407,7 +546,7 @@ func (n *Node) MirrorBridgedDefund(l1ChannelId types.Destination, l2SignedState
 	objectiveRequest := mirrorbridgeddefund.NewObjectiveRequest(l1ChannelId, l2SignedState, isChallenge)
 
 	// Send the event to the engine
-	n.engine.ObjectiveRequestsFromAPI <- objectiveRequest
+	n.engine.ObjectiveRequestsFromAPI <- engine.ObjectiveRequest{ObjectiveRequest: objectiveRequest, Ctx: n.ctx}
 	objectiveRequest.WaitForObjectiveToStart()
 	return objectiveRequest.Id(*n.Address, n.chainId), nil
 }
@@ -484,19 +623,47 @@ func (n *Node) GetLedgerChannel(id types.Destination) (query.LedgerChannelInfo,
 	return query.GetLedgerChannelInfo(id, n.store)
 }
 
+// GetSignedState returns the latest supported signed state of the ledger, payment or swap channel with the given id
 func (n *Node) GetSignedState(id types.Destination) (state.SignedState, error) {
 	consensusChannel, err := n.store.GetConsensusChannelById(id)
-	if err != nil {
+	if err == nil {
+		return consensusChannel.SupportedSignedState(), nil
+	}
+	if !errors.Is(err, store.ErrNoSuchChannel) {
 		return state.SignedState{}, err
 	}
 
-	return consensusChannel.SupportedSignedState(), nil
+	c, ok := n.store.GetChannelById(id)
+	if !ok {
+		return state.SignedState{}, err
+	}
+	return c.LatestSupportedSignedState()
 }
 
 func (n *Node) GetObjectiveById(objectiveId protocols.ObjectiveId) (protocols.Objective, error) {
 	return n.store.GetObjectiveById(objectiveId)
 }
 
+// GetFailureReason returns why the objective failed or was rejected, and false if it has not failed
+func (n *Node) GetFailureReason(objectiveId protocols.ObjectiveId) (protocols.FailureReason, bool, error) {
+	return n.store.GetFailureReason(objectiveId)
+}
+
+// GetObjectives returns information about the objectives which match the filter, e.g. every approved virtualdefund objective
+func (n *Node) GetObjectives(filter store.ObjectiveFilter) ([]query.ObjectiveInfo, error) {
+	return query.GetObjectives(filter, n.store)
+}
+
+// GetPolicyDecision returns the decision the policy maker made about the objective, and false if none is recorded
+func (n *Node) GetPolicyDecision(objectiveId protocols.ObjectiveId) (store.PolicyDecision, bool, error) {
+	return n.store.GetPolicyDecision(objectiveId)
+}
+
+// GetObjectiveProgress returns the progress history of the objective, oldest first
+func (n *Node) GetObjectiveProgress(objectiveId protocols.ObjectiveId) ([]store.ObjectiveProgress, error) {
+	return n.store.GetObjectiveProgress(objectiveId)
+}
+
 // Close stops the node from responding to any input.
 func (n *Node) Close() error {
 	if err := n.engine.Close(); err != nil {
@@ -513,6 +680,17 @@ func (n *Node) Close() error {
 	}
 	slog.Debug("DEBUG: node.go-close closed completedObjectivesNotifier", "nodeAddress", n.Address.String())
 
+	for _, topic := range []io.Closer{n.failedObjectives, n.receivedVouchers, n.challengeResponses} {
+		if err := topic.Close(); err != nil {
+			return err
+		}
+	}
+
+	// The webhook notifier is closed before the store, since undelivered events are written to the store
+	if err := n.webhookNotifier.Close(); err != nil {
+		return err
+	}
+
 	return n.store.Close()
 }
 
@@ -565,8 +743,56 @@ func (n *Node) ConfirmSwap(swapId types.Destination, action types.SwapStatus) er
 	return nil
 }
 
-func (n *Node) RetryObjectiveTx(objectiveId protocols.ObjectiveId) {
-	n.engine.RetryObjectiveTxRequestFromAPI <- types.RetryObjectiveTxRequest{ObjectiveId: string(objectiveId)}
+// GetOutboxDepths returns the number of messages addressed to each peer which are waiting to be acknowledged.
+func (n *Node) GetOutboxDepths() (map[types.Address]int, error) {
+	return n.store.GetOutboxDepths()
+}
+
+// GetPendingApprovals returns the objectives which are waiting for an operator to approve or reject them.
+func (n *Node) GetPendingApprovals() ([]engine.PendingApproval, error) {
+	queue, ok := engine.FindPolicyMaker[*engine.ApprovalQueue](n.policymaker)
+	if !ok {
+		return nil, ErrNoApprovalQueue
+	}
+	return queue.Pending(), nil
+}
+
+// ApproveObjective approves an objective which is waiting for operator approval, allowing it to progress.
+func (n *Node) ApproveObjective(objectiveId protocols.ObjectiveId) error {
+	return n.decideObjective(objectiveId, true)
+}
+
+// RejectObjective rejects an objective which is waiting for operator approval. The other participants are notified of the rejection.
+func (n *Node) RejectObjective(objectiveId protocols.ObjectiveId) error {
+	return n.decideObjective(objectiveId, false)
+}
+
+func (n *Node) decideObjective(objectiveId protocols.ObjectiveId, approve bool) error {
+	queue, ok := engine.FindPolicyMaker[*engine.ApprovalQueue](n.policymaker)
+	if !ok {
+		return ErrNoApprovalQueue
+	}
+	if !queue.IsPending(objectiveId) {
+		return fmt.Errorf("objective %s is not pending approval", objectiveId)
+	}
+
+	n.engine.ApprovalDecisionsFromAPI <- engine.ApprovalDecision{ObjectiveId: objectiveId, Approve: approve}
+	return nil
+}
+
+// CancelObjective cancels an objective which has not finished. The other participants are notified, and any ledger funding
+// added or proposed by a virtualfund or swapfund objective is rolled back.
+func (n *Node) CancelObjective(objectiveId protocols.ObjectiveId) error {
+	objective, err := n.store.GetObjectiveById(objectiveId)
+	if err != nil {
+		return err
+	}
+	if status := objective.GetStatus(); status != protocols.Unapproved && status != protocols.Approved {
+		return fmt.Errorf("objective %s has already finished with status %v", objectiveId, status)
+	}
+
+	n.engine.CancelObjectiveRequestsFromAPI <- engine.CancelObjectiveRequest{ObjectiveId: objectiveId}
+	return nil
 }
 
 func (n *Node) GetNodeInfo() types.NodeInfo {
@@ -10,101 +10,140 @@ import (
 
 const ALL_NOTIFICATIONS = "all"
 
-// ChannelNotifier is used to notify multiple listeners of a channel update.
+// ChannelNotifier is used to notify multiple subscribers of a channel update.
+// Each kind of update has a topic for every channel, and a topic which receives the updates of all channels.
 type ChannelNotifier struct {
-	ledgerListeners  *safesync.Map[*ledgerChannelListeners]
-	paymentListeners *safesync.Map[*paymentChannelListeners]
-	swapListeners    *safesync.Map[*swapListeners]
-	store            store.Store
-	vm               *payments.VoucherManager
+	ledgerTopics  *safesync.Map[*Topic[query.LedgerChannelInfo]]
+	paymentTopics *safesync.Map[*Topic[query.PaymentChannelInfo]]
+	swapTopics    *safesync.Map[*Topic[query.SwapInfo]]
+	store         store.Store
+	vm            *payments.VoucherManager
 }
 
 // NewChannelNotifier constructs a channel notifier using the provided store.
 func NewChannelNotifier(store store.Store, vm *payments.VoucherManager) *ChannelNotifier {
 	return &ChannelNotifier{
-		ledgerListeners:  &safesync.Map[*ledgerChannelListeners]{},
-		paymentListeners: &safesync.Map[*paymentChannelListeners]{},
-		swapListeners:    &safesync.Map[*swapListeners]{},
-		store:            store,
-		vm:               vm,
+		ledgerTopics:  &safesync.Map[*Topic[query.LedgerChannelInfo]]{},
+		paymentTopics: &safesync.Map[*Topic[query.PaymentChannelInfo]]{},
+		swapTopics:    &safesync.Map[*Topic[query.SwapInfo]]{},
+		store:         store,
+		vm:            vm,
 	}
 }
 
+func (cn *ChannelNotifier) ledgerTopic(key string) *Topic[query.LedgerChannelInfo] {
+	t, _ := cn.ledgerTopics.LoadOrStore(key, newLedgerChannelTopic())
+	return t
+}
+
+func (cn *ChannelNotifier) paymentTopic(key string) *Topic[query.PaymentChannelInfo] {
+	t, _ := cn.paymentTopics.LoadOrStore(key, newPaymentChannelTopic())
+	return t
+}
+
+func (cn *ChannelNotifier) swapTopic(key string) *Topic[query.SwapInfo] {
+	t, _ := cn.swapTopics.LoadOrStore(key, newSwapTopic())
+	return t
+}
+
+// SubscribeLedgerUpdates returns a subscription to the updates of all ledger channels.
+func (cn *ChannelNotifier) SubscribeLedgerUpdates(opts SubscriptionOpts) *Subscription[query.LedgerChannelInfo] {
+	return cn.ledgerTopic(ALL_NOTIFICATIONS).Subscribe(opts)
+}
+
+// SubscribeLedgerChannel returns a subscription to the updates of a specific ledger channel.
+func (cn *ChannelNotifier) SubscribeLedgerChannel(cId types.Destination, opts SubscriptionOpts) *Subscription[query.LedgerChannelInfo] {
+	return cn.ledgerTopic(cId.String()).Subscribe(opts)
+}
+
+// SubscribePaymentUpdates returns a subscription to the updates of all payment channels.
+func (cn *ChannelNotifier) SubscribePaymentUpdates(opts SubscriptionOpts) *Subscription[query.PaymentChannelInfo] {
+	return cn.paymentTopic(ALL_NOTIFICATIONS).Subscribe(opts)
+}
+
+// SubscribePaymentChannel returns a subscription to the updates of a specific payment channel.
+func (cn *ChannelNotifier) SubscribePaymentChannel(cId types.Destination, opts SubscriptionOpts) *Subscription[query.PaymentChannelInfo] {
+	return cn.paymentTopic(cId.String()).Subscribe(opts)
+}
+
+// SubscribeSwapUpdates returns a subscription to the updates of all swaps.
+func (cn *ChannelNotifier) SubscribeSwapUpdates(opts SubscriptionOpts) *Subscription[query.SwapInfo] {
+	return cn.swapTopic(ALL_NOTIFICATIONS).Subscribe(opts)
+}
+
 // RegisterForAllLedgerUpdates returns a buffered channel that will receive updates for all ledger channels.
+// The channel is shared by every caller.
+//
+// Deprecated: use SubscribeLedgerUpdates, which returns a subscription that can be closed.
 func (cn *ChannelNotifier) RegisterForAllLedgerUpdates() <-chan query.LedgerChannelInfo {
-	li, _ := cn.ledgerListeners.LoadOrStore(ALL_NOTIFICATIONS, newLedgerChannelListeners())
-	return li.getOrCreateListener()
+	return cn.ledgerTopic(ALL_NOTIFICATIONS).Default().Updates()
 }
 
 // RegisterForLedgerUpdates returns a buffered channel that will receive updates for a specific ledger channel.
+//
+// Deprecated: use SubscribeLedgerChannel, which returns a subscription that can be closed.
 func (cn *ChannelNotifier) RegisterForLedgerUpdates(cId types.Destination) <-chan query.LedgerChannelInfo {
-	li, _ := cn.ledgerListeners.LoadOrStore(cId.String(), newLedgerChannelListeners())
-	return li.createNewListener()
+	return cn.SubscribeLedgerChannel(cId, SubscriptionOpts{}).Updates()
 }
 
 // RegisterForAllPaymentUpdates returns a buffered channel that will receive updates for all payment channels.
+// The channel is shared by every caller.
+//
+// Deprecated: use SubscribePaymentUpdates, which returns a subscription that can be closed.
 func (cn *ChannelNotifier) RegisterForAllPaymentUpdates() <-chan query.PaymentChannelInfo {
-	li, _ := cn.paymentListeners.LoadOrStore(ALL_NOTIFICATIONS, newPaymentChannelListeners())
-	return li.getOrCreateListener()
+	return cn.paymentTopic(ALL_NOTIFICATIONS).Default().Updates()
 }
 
-// RegisterForLedgerUpdates returns a buffered channel that will receive updates or a specific payment channel.
+// RegisterForPaymentChannelUpdates returns a buffered channel that will receive updates or a specific payment channel.
+//
+// Deprecated: use SubscribePaymentChannel, which returns a subscription that can be closed.
 func (cn *ChannelNotifier) RegisterForPaymentChannelUpdates(cId types.Destination) <-chan query.PaymentChannelInfo {
-	li, _ := cn.paymentListeners.LoadOrStore(cId.String(), newPaymentChannelListeners())
-	return li.createNewListener()
+	return cn.SubscribePaymentChannel(cId, SubscriptionOpts{}).Updates()
 }
 
 // RegisterForAllSwapUpdates returns a buffered channel that will receive updates for all swaps.
+// The channel is shared by every caller.
+//
+// Deprecated: use SubscribeSwapUpdates, which returns a subscription that can be closed.
 func (cn *ChannelNotifier) RegisterForAllSwapUpdates() <-chan query.SwapInfo {
-	li, _ := cn.swapListeners.LoadOrStore(ALL_NOTIFICATIONS, newSwapListeners())
-	return li.getOrCreateListener()
+	return cn.swapTopic(ALL_NOTIFICATIONS).Default().Updates()
 }
 
-// NotifyLedgerUpdated notifies all listeners of a ledger channel update.
+// NotifyLedgerUpdated notifies all subscribers of a ledger channel update.
 // It should be called whenever a ledger channel is updated.
 func (cn *ChannelNotifier) NotifyLedgerUpdated(info query.LedgerChannelInfo) error {
-	li, _ := cn.ledgerListeners.LoadOrStore(info.ID.String(), newLedgerChannelListeners())
-	li.Notify(info)
-	allLi, _ := cn.ledgerListeners.LoadOrStore(ALL_NOTIFICATIONS, newLedgerChannelListeners())
-	allLi.Notify(info)
-
+	cn.ledgerTopic(info.ID.String()).Publish(info)
+	cn.ledgerTopic(ALL_NOTIFICATIONS).Publish(info)
 	return nil
 }
 
-// NotifyPaymentUpdated notifies all listeners of a payment channel update.
+// NotifyPaymentUpdated notifies all subscribers of a payment channel update.
 // It should be called whenever a payment channel is updated.
 func (cn *ChannelNotifier) NotifyPaymentUpdated(info query.PaymentChannelInfo) error {
-	li, _ := cn.paymentListeners.LoadOrStore(info.ID.String(), newPaymentChannelListeners())
-	li.Notify(info)
-
-	allLi, _ := cn.paymentListeners.LoadOrStore(ALL_NOTIFICATIONS, newPaymentChannelListeners())
-	allLi.Notify(info)
-
+	cn.paymentTopic(info.ID.String()).Publish(info)
+	cn.paymentTopic(ALL_NOTIFICATIONS).Publish(info)
 	return nil
 }
 
+// NotifySwapUpdated notifies all subscribers of a swap update.
 func (cn *ChannelNotifier) NotifySwapUpdated(info query.SwapInfo) error {
-	li, _ := cn.swapListeners.LoadOrStore(info.Id.String(), newSwapListeners())
-	li.Notify(info)
-
-	allLi, _ := cn.swapListeners.LoadOrStore(ALL_NOTIFICATIONS, newSwapListeners())
-	allLi.Notify(info)
-
+	cn.swapTopic(info.Id.String()).Publish(info)
+	cn.swapTopic(ALL_NOTIFICATIONS).Publish(info)
 	return nil
 }
 
-// Close closes the notifier and all listeners.
+// Close closes the notifier and all subscriptions.
 func (cn *ChannelNotifier) Close() error {
 	var err error
-	cn.ledgerListeners.Range(func(k string, v *ledgerChannelListeners) bool {
+	cn.ledgerTopics.Range(func(k string, v *Topic[query.LedgerChannelInfo]) bool {
 		err = v.Close()
 		return err == nil
 	})
-	cn.paymentListeners.Range(func(k string, v *paymentChannelListeners) bool {
+	cn.paymentTopics.Range(func(k string, v *Topic[query.PaymentChannelInfo]) bool {
 		err = v.Close()
 		return err == nil
 	})
-	cn.swapListeners.Range(func(k string, v *swapListeners) bool {
+	cn.swapTopics.Range(func(k string, v *Topic[query.SwapInfo]) bool {
 		err = v.Close()
 		return err == nil
 	})
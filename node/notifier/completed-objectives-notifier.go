@@ -1,39 +1,37 @@
 package notifier
 
 import (
-	"github.com/statechannels/go-nitro/internal/safesync"
 	"github.com/statechannels/go-nitro/protocols"
 )
 
 type CompletedObjetivesNotifier struct {
-	completedObjectivesListeners *safesync.Map[*completedObjectivesListeners]
+	completedObjectives *Topic[protocols.ObjectiveId]
 }
 
 func NewCompletedObjectivesNotifier() *CompletedObjetivesNotifier {
 	return &CompletedObjetivesNotifier{
-		completedObjectivesListeners: &safesync.Map[*completedObjectivesListeners]{},
+		completedObjectives: newCompletedObjectivesTopic(),
 	}
 }
 
+// SubscribeCompletedObjectives returns a subscription to the ids of objectives which complete from now on
+func (con *CompletedObjetivesNotifier) SubscribeCompletedObjectives(opts SubscriptionOpts) *Subscription[protocols.ObjectiveId] {
+	return con.completedObjectives.Subscribe(opts)
+}
+
 // RegisterForAllCompletedObjectives returns a buffered channel that will receive all completed objective IDs
+//
+// Deprecated: use SubscribeCompletedObjectives, which returns a subscription that can be closed.
 func (con *CompletedObjetivesNotifier) RegisterForAllCompletedObjectives() <-chan protocols.ObjectiveId {
-	li, _ := con.completedObjectivesListeners.LoadOrStore(ALL_NOTIFICATIONS, newCompletedObjectivesListeners())
-	return li.createNewListener()
+	return con.completedObjectives.Subscribe(SubscriptionOpts{}).Updates()
 }
 
 // BroadcastCompletedObjective broadcasts the completed objectives to all the listeners
 func (con *CompletedObjetivesNotifier) BroadcastCompletedObjective(objectiveId protocols.ObjectiveId) {
-	li, _ := con.completedObjectivesListeners.LoadOrStore(ALL_NOTIFICATIONS, newCompletedObjectivesListeners())
-	li.broadcastCompletedObjective(objectiveId)
+	con.completedObjectives.Publish(objectiveId)
 }
 
-// Close closes the notifier and all listeners
+// Close closes the notifier and all subscriptions
 func (con *CompletedObjetivesNotifier) Close() error {
-	var err error
-	con.completedObjectivesListeners.Range(func(k string, v *completedObjectivesListeners) bool {
-		err = v.Close()
-		return err == nil
-	})
-
-	return err
+	return con.completedObjectives.Close()
 }
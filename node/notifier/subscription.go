@@ -0,0 +1,219 @@
+package notifier
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+const DEFAULT_BUFFER_SIZE = 1000
+
+var (
+	// ErrSubscriptionOverflow is reported by a subscription with the Disconnect policy which fell too far behind
+	ErrSubscriptionOverflow = errors.New("notifier: subscription buffer overflowed")
+	// ErrNotifierClosed is reported by subscriptions which were open when their notifier was closed
+	ErrNotifierClosed = errors.New("notifier: closed")
+)
+
+// OverflowPolicy decides what happens to an update for a subscription whose buffer is full.
+type OverflowPolicy int
+
+const (
+	// DropOldest discards the oldest buffered update to make room for the new one
+	DropOldest OverflowPolicy = iota
+	// Disconnect closes the subscription, which then reports ErrSubscriptionOverflow
+	Disconnect
+)
+
+// SubscriptionOpts configures a subscription. Zero-valued fields take their defaults.
+type SubscriptionOpts struct {
+	// BufferSize is the number of updates held for the subscriber. Defaults to DEFAULT_BUFFER_SIZE.
+	BufferSize int
+	// Overflow is applied when an update arrives while the buffer is full. Defaults to DropOldest.
+	Overflow OverflowPolicy
+}
+
+// Subscription delivers the updates published to a Topic after it subscribed. Publishing never blocks on a subscriber:
+// once the buffer is full, the subscription's OverflowPolicy applies.
+//
+// A subscription must be closed once it is no longer read, so that the topic stops buffering updates for it.
+type Subscription[T any] struct {
+	updates chan T
+	opts    SubscriptionOpts
+	topic   *Topic[T]
+	// shared is set on the default subscription of a topic, which is only closed with the topic
+	shared bool
+
+	mu      sync.Mutex
+	closed  bool
+	err     error
+	dropped uint64
+}
+
+// Updates returns the chan on which updates are delivered. It is closed when the subscription is closed.
+func (s *Subscription[T]) Updates() <-chan T {
+	return s.updates
+}
+
+// Err returns why the subscription was closed by its topic: ErrSubscriptionOverflow or ErrNotifierClosed.
+// It returns nil while the subscription is open, and after the subscriber closed it.
+func (s *Subscription[T]) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Dropped returns the number of updates discarded by the DropOldest policy
+func (s *Subscription[T]) Dropped() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dropped
+}
+
+// Close unsubscribes from the topic and closes the Updates chan. It is safe to call more than once.
+// Closing the default subscription of a topic does nothing, since it is shared by every caller of Default.
+func (s *Subscription[T]) Close() error {
+	if s.shared {
+		return nil
+	}
+	s.topic.remove(s)
+	s.end(nil)
+	return nil
+}
+
+// end closes the Updates chan, recording the cause. It returns false if the subscription had already ended.
+func (s *Subscription[T]) end(cause error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.closed = true
+	s.err = cause
+	close(s.updates)
+	return true
+}
+
+// send delivers an update without blocking, applying the overflow policy if the buffer is full.
+// It returns false if the subscription has ended.
+func (s *Subscription[T]) send(update T) bool {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return false
+	}
+
+	for {
+		select {
+		case s.updates <- update:
+			s.mu.Unlock()
+			return true
+		default:
+		}
+
+		if s.opts.Overflow == Disconnect {
+			s.mu.Unlock()
+			slog.Warn("Disconnecting subscriber which fell behind", "bufferSize", s.opts.BufferSize)
+			s.end(ErrSubscriptionOverflow)
+			return false
+		}
+		// Only the topic sends on the chan, so once an update has been discarded there is room for the new one
+		select {
+		case <-s.updates:
+			s.dropped++
+		default:
+		}
+	}
+}
+
+// Topic publishes updates to its subscriptions.
+type Topic[T any] struct {
+	// isDuplicate reports whether an update repeats the previous one, in which case it is not published. It may be nil.
+	isDuplicate func(prev, next T) bool
+
+	mu            sync.Mutex
+	subscriptions []*Subscription[T]
+	// defaultSubscription is shared by callers of Default
+	defaultSubscription *Subscription[T]
+	prev                T
+	published           bool
+	closed              bool
+}
+
+// NewTopic constructs a topic. If isDuplicate is supplied, updates which it reports to repeat the previously published update are dropped.
+func NewTopic[T any](isDuplicate func(prev, next T) bool) *Topic[T] {
+	return &Topic[T]{isDuplicate: isDuplicate}
+}
+
+// Subscribe returns a subscription to the updates published from now on.
+// If the topic is closed, the subscription is closed straight away and reports ErrNotifierClosed.
+func (t *Topic[T]) Subscribe(opts SubscriptionOpts) *Subscription[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.subscribe(opts)
+}
+
+func (t *Topic[T]) subscribe(opts SubscriptionOpts) *Subscription[T] {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = DEFAULT_BUFFER_SIZE
+	}
+	s := &Subscription[T]{updates: make(chan T, opts.BufferSize), opts: opts, topic: t}
+	if t.closed {
+		s.end(ErrNotifierClosed)
+		return s
+	}
+	t.subscriptions = append(t.subscriptions, s)
+	return s
+}
+
+// Default returns a subscription with default options which is shared by every caller, creating it on first use.
+// It backs the chan based APIs which predate subscriptions, and is only closed with the topic: closing it does nothing.
+func (t *Topic[T]) Default() *Subscription[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.defaultSubscription == nil {
+		t.defaultSubscription = t.subscribe(SubscriptionOpts{})
+		t.defaultSubscription.shared = true
+	}
+	return t.defaultSubscription
+}
+
+// Publish delivers an update to every subscription, unless it duplicates the previous update.
+func (t *Topic[T]) Publish(update T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	if t.isDuplicate != nil && t.published && t.isDuplicate(t.prev, update) {
+		return
+	}
+
+	t.subscriptions = slices.DeleteFunc(t.subscriptions, func(s *Subscription[T]) bool {
+		return !s.send(update)
+	})
+	t.prev = update
+	t.published = true
+}
+
+func (t *Topic[T]) remove(s *Subscription[T]) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.subscriptions = slices.DeleteFunc(t.subscriptions, func(other *Subscription[T]) bool { return other == s })
+}
+
+// Close closes every subscription, which then report ErrNotifierClosed. Nothing is published after a topic is closed.
+func (t *Topic[T]) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	for _, s := range t.subscriptions {
+		s.end(ErrNotifierClosed)
+	}
+	t.subscriptions = nil
+	return nil
+}
@@ -0,0 +1,141 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+)
+
+// drain returns the updates buffered by a subscription
+func drain[T any](s *Subscription[T]) []T {
+	updates := []T{}
+	for {
+		select {
+		case u, ok := <-s.Updates():
+			if !ok {
+				return updates
+			}
+			updates = append(updates, u)
+		default:
+			return updates
+		}
+	}
+}
+
+func TestDropOldest(t *testing.T) {
+	topic := NewTopic[int](nil)
+	s := topic.Subscribe(SubscriptionOpts{BufferSize: 2, Overflow: DropOldest})
+
+	for i := 1; i <= 5; i++ {
+		topic.Publish(i)
+	}
+
+	if got := drain(s); len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Fatalf("expected the 2 newest updates [4 5], got %v", got)
+	}
+	if s.Dropped() != 3 {
+		t.Fatalf("expected 3 dropped updates, got %d", s.Dropped())
+	}
+	if s.Err() != nil {
+		t.Fatalf("expected the subscription to stay open, got %v", s.Err())
+	}
+}
+
+func TestDisconnect(t *testing.T) {
+	topic := NewTopic[int](nil)
+	slow := topic.Subscribe(SubscriptionOpts{BufferSize: 2, Overflow: Disconnect})
+	fast := topic.Subscribe(SubscriptionOpts{BufferSize: 10})
+
+	for i := 1; i <= 3; i++ {
+		topic.Publish(i)
+	}
+
+	// The buffered updates are still delivered before the chan closes
+	if got := drain(slow); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Fatalf("expected the buffered updates [1 2], got %v", got)
+	}
+	if _, ok := <-slow.Updates(); ok {
+		t.Fatal("expected the slow subscription to be closed")
+	}
+	if !errors.Is(slow.Err(), ErrSubscriptionOverflow) {
+		t.Fatalf("expected %v, got %v", ErrSubscriptionOverflow, slow.Err())
+	}
+	if got := drain(fast); len(got) != 3 {
+		t.Fatalf("expected other subscriptions to be unaffected, got %v", got)
+	}
+	if len(topic.subscriptions) != 1 {
+		t.Fatalf("expected the disconnected subscription to be removed from the topic, got %d subscriptions", len(topic.subscriptions))
+	}
+}
+
+func TestSubscriptionClose(t *testing.T) {
+	topic := NewTopic[int](nil)
+	s := topic.Subscribe(SubscriptionOpts{})
+	other := topic.Subscribe(SubscriptionOpts{})
+
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Closing twice is harmless
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	topic.Publish(1)
+
+	if _, ok := <-s.Updates(); ok {
+		t.Fatal("expected a closed subscription to receive nothing")
+	}
+	if s.Err() != nil {
+		t.Fatalf("expected no error after the subscriber closed the subscription, got %v", s.Err())
+	}
+	if got := drain(other); len(got) != 1 {
+		t.Fatalf("expected other subscriptions to be unaffected, got %v", got)
+	}
+
+	if err := topic.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-other.Updates(); ok || !errors.Is(other.Err(), ErrNotifierClosed) {
+		t.Fatalf("expected the subscription to be closed with %v, got %v", ErrNotifierClosed, other.Err())
+	}
+	if late := topic.Subscribe(SubscriptionOpts{}); !errors.Is(late.Err(), ErrNotifierClosed) {
+		t.Fatalf("expected a subscription to a closed topic to report %v, got %v", ErrNotifierClosed, late.Err())
+	}
+}
+
+func TestDefaultSubscriptionIsOnlyClosedWithTheTopic(t *testing.T) {
+	topic := NewTopic[int](nil)
+	first := topic.Default()
+	second := topic.Default()
+	if first != second {
+		t.Fatal("expected every caller to share the default subscription")
+	}
+
+	// One caller closing the default subscription must not close it for the others
+	if err := first.Close(); err != nil {
+		t.Fatal(err)
+	}
+	topic.Publish(1)
+	if got := drain(second); len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected the default subscription to stay open, got %v", got)
+	}
+
+	if err := topic.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-second.Updates(); ok || !errors.Is(second.Err(), ErrNotifierClosed) {
+		t.Fatalf("expected the default subscription to be closed with %v, got %v", ErrNotifierClosed, second.Err())
+	}
+}
+
+func TestDuplicateUpdates(t *testing.T) {
+	topic := NewTopic(func(prev, next int) bool { return prev == next })
+	s := topic.Subscribe(SubscriptionOpts{})
+
+	for _, u := range []int{0, 0, 1, 1, 0} {
+		topic.Publish(u)
+	}
+
+	if got := drain(s); len(got) != 3 || got[0] != 0 || got[1] != 1 || got[2] != 0 {
+		t.Fatalf("expected [0 1 0], got %v", got)
+	}
+}
@@ -0,0 +1,391 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/node/query"
+	"github.com/statechannels/go-nitro/payments"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/rand"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// WebhookEventType identifies the kind of engine event a webhook event reports
+type WebhookEventType string
+
+const (
+	ObjectiveCompletedEvent    WebhookEventType = "objective_completed"
+	ObjectiveFailedEvent       WebhookEventType = "objective_failed"
+	LedgerChannelUpdatedEvent  WebhookEventType = "ledger_channel_updated"
+	PaymentChannelUpdatedEvent WebhookEventType = "payment_channel_updated"
+	SwapUpdatedEvent           WebhookEventType = "swap_updated"
+	VoucherReceivedEvent       WebhookEventType = "voucher_received"
+)
+
+const (
+	// WebhookSignatureHeader carries the hex encoded HMAC-SHA256 of the request body, keyed with the secret of the endpoint
+	WebhookSignatureHeader = "X-Nitro-Signature"
+	// WebhookAddressHeader carries the address of the node which sent the event
+	WebhookAddressHeader = "X-Nitro-Address"
+
+	webhookQueueSize = 1000
+)
+
+// WebhookEvent is the JSON body POSTed to webhook endpoints.
+type WebhookEvent struct {
+	// Id is unique to the event and is preserved across retries, so that receivers can drop duplicates.
+	// Ids sort in the order the events were raised.
+	Id   string
+	Type WebhookEventType
+	Node types.Address
+	Time time.Time
+	// Data holds the ObjectiveCompletedData, protocols.FailedObjective, query.LedgerChannelInfo, query.PaymentChannelInfo,
+	// query.SwapInfo or payments.Voucher reported by the event, depending on its Type
+	Data json.RawMessage
+}
+
+// ObjectiveCompletedData is the data of an ObjectiveCompletedEvent
+type ObjectiveCompletedData struct {
+	ObjectiveId protocols.ObjectiveId
+}
+
+// WebhookOpts configures a webhook endpoint. Zero-valued fields take their defaults.
+type WebhookOpts struct {
+	Url string
+	// Secret keys the HMAC-SHA256 signature sent with each event, with which the endpoint can check that the event came from the node.
+	// It is required.
+	Secret string
+	// Events selects the events POSTed to the endpoint. Every event is POSTed if it is empty.
+	Events []WebhookEventType
+	// MaxAttempts is the number of attempts made to deliver an event before it goes to the dead-letter store. Defaults to 5.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, which doubles with each further retry. Defaults to 1 second.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay between retries. Defaults to 1 minute.
+	MaxBackoff time.Duration
+	// Timeout bounds each delivery attempt. Defaults to 10 seconds.
+	Timeout time.Duration
+}
+
+func (o WebhookOpts) withDefaults() WebhookOpts {
+	if o.MaxAttempts <= 0 {
+		o.MaxAttempts = 5
+	}
+	if o.MinBackoff <= 0 {
+		o.MinBackoff = time.Second
+	}
+	if o.MaxBackoff <= 0 {
+		o.MaxBackoff = time.Minute
+	}
+	if o.MaxBackoff < o.MinBackoff {
+		o.MaxBackoff = o.MinBackoff
+	}
+	if o.Timeout <= 0 {
+		o.Timeout = 10 * time.Second
+	}
+	return o
+}
+
+// WebhookNotifier POSTs JSON events, signed with the secret of each endpoint, to webhook endpoints.
+//
+// Each endpoint receives its events in order, from a goroutine of its own, so a slow endpoint does not hold up the others
+// or the engine. A failed delivery is retried with exponential backoff, and an event which cannot be delivered
+// is written to the dead-letter store, from which it can be redelivered.
+type WebhookNotifier struct {
+	address     types.Address
+	deadLetters store.WebhookDeadLetterStore
+	client      *http.Client
+
+	mu        sync.Mutex
+	endpoints []*webhookEndpoint
+	closed    bool
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+type webhookEndpoint struct {
+	opts  WebhookOpts
+	queue chan webhookDelivery
+}
+
+// webhookDelivery is an event waiting to be delivered to an endpoint
+type webhookDelivery struct {
+	id   string
+	body []byte
+}
+
+// NewWebhookNotifier constructs a webhook notifier which raises events on behalf of the node with the given address,
+// and writes undeliverable events to the dead-letter store. It has no endpoints until they are added with AddEndpoint.
+func NewWebhookNotifier(address types.Address, deadLetters store.WebhookDeadLetterStore) *WebhookNotifier {
+	return &WebhookNotifier{
+		address:     address,
+		deadLetters: deadLetters,
+		client:      &http.Client{},
+		quit:        make(chan struct{}),
+	}
+}
+
+// AddEndpoint starts POSTing events to the endpoint described by opts.
+func (wn *WebhookNotifier) AddEndpoint(opts WebhookOpts) error {
+	u, err := url.Parse(opts.Url)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url %q: %w", opts.Url, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid webhook url %q: expected an http or https url", opts.Url)
+	}
+	if opts.Secret == "" {
+		return fmt.Errorf("webhook %s has no secret", opts.Url)
+	}
+
+	wn.mu.Lock()
+	defer wn.mu.Unlock()
+	if wn.closed {
+		return errors.New("webhook notifier is closed")
+	}
+	for _, e := range wn.endpoints {
+		if e.opts.Url == opts.Url {
+			return fmt.Errorf("webhook %s is already registered", opts.Url)
+		}
+	}
+
+	e := &webhookEndpoint{opts: opts.withDefaults(), queue: make(chan webhookDelivery, webhookQueueSize)}
+	wn.endpoints = append(wn.endpoints, e)
+	wn.wg.Add(1)
+	go wn.run(e)
+	return nil
+}
+
+// NotifyObjectiveCompleted POSTs an ObjectiveCompletedEvent
+func (wn *WebhookNotifier) NotifyObjectiveCompleted(id protocols.ObjectiveId) {
+	wn.notify(ObjectiveCompletedEvent, ObjectiveCompletedData{ObjectiveId: id})
+}
+
+// NotifyObjectiveFailed POSTs an ObjectiveFailedEvent
+func (wn *WebhookNotifier) NotifyObjectiveFailed(failed protocols.FailedObjective) {
+	wn.notify(ObjectiveFailedEvent, failed)
+}
+
+// NotifyLedgerUpdated POSTs a LedgerChannelUpdatedEvent
+func (wn *WebhookNotifier) NotifyLedgerUpdated(info query.LedgerChannelInfo) {
+	wn.notify(LedgerChannelUpdatedEvent, info)
+}
+
+// NotifyPaymentUpdated POSTs a PaymentChannelUpdatedEvent
+func (wn *WebhookNotifier) NotifyPaymentUpdated(info query.PaymentChannelInfo) {
+	wn.notify(PaymentChannelUpdatedEvent, info)
+}
+
+// NotifySwapUpdated POSTs a SwapUpdatedEvent
+func (wn *WebhookNotifier) NotifySwapUpdated(info query.SwapInfo) {
+	wn.notify(SwapUpdatedEvent, info)
+}
+
+// NotifyVoucherReceived POSTs a VoucherReceivedEvent
+func (wn *WebhookNotifier) NotifyVoucherReceived(v payments.Voucher) {
+	wn.notify(VoucherReceivedEvent, v)
+}
+
+// notify queues an event for delivery to every endpoint which subscribes to its type. It does not block:
+// an event which does not fit in the queue of an endpoint goes straight to the dead-letter store.
+func (wn *WebhookNotifier) notify(eventType WebhookEventType, data any) {
+	wn.mu.Lock()
+	defer wn.mu.Unlock()
+	if wn.closed || len(wn.endpoints) == 0 {
+		return
+	}
+
+	event, err := wn.newEvent(eventType, data)
+	if err != nil {
+		slog.Error("failed to encode webhook event", "type", eventType, "err", err)
+		return
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("failed to encode webhook event", "type", eventType, "err", err)
+		return
+	}
+
+	delivery := webhookDelivery{id: event.Id, body: body}
+	for _, e := range wn.endpoints {
+		if len(e.opts.Events) > 0 && !slices.Contains(e.opts.Events, eventType) {
+			continue
+		}
+		select {
+		case e.queue <- delivery:
+		default:
+			wn.addDeadLetter(e, delivery, 0, errors.New("delivery queue is full"))
+		}
+	}
+}
+
+func (wn *WebhookNotifier) newEvent(eventType WebhookEventType, data any) (WebhookEvent, error) {
+	dataJSON, err := json.Marshal(data)
+	if err != nil {
+		return WebhookEvent{}, err
+	}
+	now := time.Now()
+	return WebhookEvent{
+		// The zero-padded timestamp makes ids sort in the order events were raised
+		Id:   fmt.Sprintf("%020d-%016x", now.UnixNano(), rand.Uint64()),
+		Type: eventType,
+		Node: wn.address,
+		Time: now.UTC(),
+		Data: dataJSON,
+	}, nil
+}
+
+// run delivers the events queued for an endpoint until the notifier is closed
+func (wn *WebhookNotifier) run(e *webhookEndpoint) {
+	defer wn.wg.Done()
+	for {
+		select {
+		case <-wn.quit:
+			// Events which have not been delivered are kept, so that they can be redelivered later
+			for {
+				select {
+				case d := <-e.queue:
+					wn.addDeadLetter(e, d, 0, errors.New("webhook notifier closed before delivery"))
+				default:
+					return
+				}
+			}
+		case d := <-e.queue:
+			wn.deliver(e, d)
+		}
+	}
+}
+
+// deliver POSTs an event to an endpoint, retrying with exponential backoff, and writes it to the dead-letter store if every attempt fails
+func (wn *WebhookNotifier) deliver(e *webhookEndpoint, d webhookDelivery) {
+	mac := hmac.New(sha256.New, []byte(e.opts.Secret))
+	mac.Write(d.body)
+	sig := hex.EncodeToString(mac.Sum(nil))
+
+	backoff := e.opts.MinBackoff
+	for attempt := 1; ; attempt++ {
+		err := wn.post(e, d, sig)
+		if err == nil {
+			return
+		}
+		slog.Warn("webhook delivery failed", "url", e.opts.Url, "event", d.id, "attempt", attempt, "err", err)
+		if attempt >= e.opts.MaxAttempts {
+			wn.addDeadLetter(e, d, attempt, err)
+			return
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-wn.quit:
+			wn.addDeadLetter(e, d, attempt, err)
+			return
+		}
+		backoff = min(2*backoff, e.opts.MaxBackoff)
+	}
+}
+
+func (wn *WebhookNotifier) post(e *webhookEndpoint, d webhookDelivery, sig string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), e.opts.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.opts.Url, bytes.NewReader(d.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSignatureHeader, sig)
+	req.Header.Set(WebhookAddressHeader, wn.address.String())
+
+	resp, err := wn.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+func (wn *WebhookNotifier) addDeadLetter(e *webhookEndpoint, d webhookDelivery, attempts int, cause error) {
+	err := wn.deadLetters.AddWebhookDeadLetter(store.WebhookDeadLetter{
+		Id:        d.id,
+		Url:       e.opts.Url,
+		Payload:   string(d.body),
+		Attempts:  attempts,
+		LastError: cause.Error(),
+		Time:      time.Now().UTC(),
+	})
+	if err != nil {
+		slog.Error("failed to store undeliverable webhook event", "url", e.opts.Url, "event", d.id, "err", err)
+	}
+}
+
+// Redeliver removes the dead letters addressed to registered endpoints from the dead-letter store, and queues them for delivery again.
+// It returns the number of dead letters queued. Dead letters addressed to endpoints which are no longer registered are left in the store.
+func (wn *WebhookNotifier) Redeliver() (int, error) {
+	letters, err := wn.deadLetters.GetWebhookDeadLetters()
+	if err != nil {
+		return 0, err
+	}
+
+	wn.mu.Lock()
+	defer wn.mu.Unlock()
+	if wn.closed {
+		return 0, errors.New("webhook notifier is closed")
+	}
+
+	queued := 0
+	for _, letter := range letters {
+		i := slices.IndexFunc(wn.endpoints, func(e *webhookEndpoint) bool { return e.opts.Url == letter.Url })
+		if i == -1 {
+			continue
+		}
+		// The dead letter is removed before it is queued, since a failed redelivery writes it to the store again
+		err = wn.deadLetters.RemoveWebhookDeadLetter(letter.Url, letter.Id)
+		if err != nil {
+			return queued, err
+		}
+		select {
+		case wn.endpoints[i].queue <- webhookDelivery{id: letter.Id, body: []byte(letter.Payload)}:
+			queued++
+		default:
+			// The endpoint is backed up, so this and the remaining dead letters stay in the store
+			return queued, wn.deadLetters.AddWebhookDeadLetter(letter)
+		}
+	}
+	return queued, nil
+}
+
+// Close stops delivering events. Events which have not been delivered are written to the dead-letter store.
+func (wn *WebhookNotifier) Close() error {
+	wn.mu.Lock()
+	if wn.closed {
+		wn.mu.Unlock()
+		return nil
+	}
+	wn.closed = true
+	wn.mu.Unlock()
+
+	close(wn.quit)
+	wn.wg.Wait()
+	return nil
+}
@@ -332,6 +332,30 @@ func GetSwapChannelsByLedger(ledgerId types.Destination, s store.Store) ([]SwapC
 	return toReturn, nil
 }
 
+// GetObjectives returns information about the objectives which match the filter, including what each is currently waiting for
+func GetObjectives(filter store.ObjectiveFilter, s store.Store) ([]ObjectiveInfo, error) {
+	objectives, err := s.GetObjectives(filter)
+	if err != nil {
+		return []ObjectiveInfo{}, err
+	}
+
+	toReturn := []ObjectiveInfo{}
+	for _, o := range objectives {
+		info := ObjectiveInfo{Id: o.Id(), Status: o.GetStatus(), ChannelId: o.OwnsChannel()}
+		history, err := s.GetObjectiveProgress(o.Id())
+		if err != nil {
+			return []ObjectiveInfo{}, err
+		}
+		if len(history) > 0 {
+			latest := history[len(history)-1]
+			info.WaitingFor = latest.WaitingFor
+			info.LastUpdated = latest.Time
+		}
+		toReturn = append(toReturn, info)
+	}
+	return toReturn, nil
+}
+
 // GetLedgerChannelInfo returns the LedgerChannelInfo for the given channel
 // It does this by querying the provided store
 func GetLedgerChannelInfo(id types.Destination, store store.Store) (LedgerChannelInfo, error) {
@@ -1,8 +1,11 @@
 package query
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/statechannels/go-nitro/channel"
+	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
 )
 
@@ -59,6 +62,16 @@ type SwapInfo struct {
 	ChannelId types.Destination
 }
 
+// ObjectiveInfo summarizes an objective and what it is currently waiting for
+type ObjectiveInfo struct {
+	Id         protocols.ObjectiveId
+	Status     protocols.ObjectiveStatus
+	ChannelId  types.Destination
+	WaitingFor protocols.WaitingFor
+	// LastUpdated is when the objective last changed status or what it is waiting for, and is zero if it has never been cranked
+	LastUpdated time.Time
+}
+
 // LedgerChannelBalance contains the balance of a ledger channel
 type LedgerChannelBalance struct {
 	AssetAddress types.Address
@@ -0,0 +1,261 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+	"github.com/tidwall/buntdb"
+)
+
+// waitForPendingApproval polls the node until the objective with the given id is pending approval.
+func waitForPendingApproval(t *testing.T, n *node.Node, id protocols.ObjectiveId) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		pending, err := n.GetPendingApprovals()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, pa := range pending {
+			if pa.ObjectiveId == id {
+				return
+			}
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("objective %s was never parked for approval", id)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+// waitForObjectiveStatus polls the node until the objective with the given id has the given status.
+func waitForObjectiveStatus(t *testing.T, n *node.Node, id protocols.ObjectiveId, status protocols.ObjectiveStatus) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		objective, err := n.GetObjectiveById(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objective.GetStatus() == status {
+			return
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("objective %s has status %v, expected %v", id, objective.GetStatus(), status)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestApprovalQueue(t *testing.T) {
+	setup := func(t *testing.T) (node.Node, node.Node) {
+		chain := chainservice.NewMockChain()
+		broker := messageservice.NewBroker()
+
+		nodeA := node.New(
+			messageservice.NewTestMessageService(ta.Alice.Address(), broker, 0),
+			chainservice.NewMockChainService(chain, ta.Alice.Address()),
+			store.NewMemStore(ta.Alice.PrivateKey),
+			ta.Alice.Signer(),
+			&engine.PermissivePolicy{},
+			engine.EngineOpts{},
+		)
+		nodeB := node.New(
+			messageservice.NewTestMessageService(ta.Bob.Address(), broker, 0),
+			chainservice.NewMockChainService(chain, ta.Bob.Address()),
+			store.NewMemStore(ta.Bob.PrivateKey),
+			ta.Bob.Signer(),
+			engine.NewApprovalQueue(&engine.PermissivePolicy{}, nil),
+			engine.EngineOpts{},
+		)
+		return nodeA, nodeB
+	}
+
+	t.Run("approve", func(t *testing.T) {
+		nodeA, nodeB := setup(t)
+		defer closeNode(t, &nodeA)
+		defer closeNode(t, &nodeB)
+
+		outcome := CreateLedgerOutcome(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+		response, err := nodeA.CreateLedgerChannel(*nodeB.Address, 0, outcome)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		waitForPendingApproval(t, &nodeB, response.Id)
+
+		objective, err := nodeB.GetObjectiveById(response.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objective.GetStatus() != protocols.Unapproved {
+			t.Fatalf("expected parked objective to be unapproved, got %v", objective.GetStatus())
+		}
+
+		chA := nodeA.ObjectiveCompleteChan(response.Id)
+		chB := nodeB.ObjectiveCompleteChan(response.Id)
+		if err := nodeB.ApproveObjective(response.Id); err != nil {
+			t.Fatal(err)
+		}
+		<-chA
+		<-chB
+
+		pending, err := nodeB.GetPendingApprovals()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pending) != 0 {
+			t.Fatalf("expected no pending approvals, got %+v", pending)
+		}
+		if err := nodeB.ApproveObjective(response.Id); err == nil {
+			t.Fatal("expected an error approving an objective which is not pending")
+		}
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		nodeA, nodeB := setup(t)
+		defer closeNode(t, &nodeA)
+		defer closeNode(t, &nodeB)
+
+		outcome := CreateLedgerOutcome(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+		response, err := nodeA.CreateLedgerChannel(*nodeB.Address, 0, outcome)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		waitForPendingApproval(t, &nodeB, response.Id)
+
+		chA := nodeA.ObjectiveCompleteChan(response.Id)
+		chB := nodeB.ObjectiveCompleteChan(response.Id)
+		if err := nodeB.RejectObjective(response.Id); err != nil {
+			t.Fatal(err)
+		}
+		<-chA
+		<-chB
+
+		for _, n := range []node.Node{nodeA, nodeB} {
+			objective, err := n.GetObjectiveById(response.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if objective.GetStatus() != protocols.Rejected {
+				t.Fatalf("expected objective to be rejected, got %v", objective.GetStatus())
+			}
+			// Alice learns why from Bob's rejection notice
+			reason, ok, err := n.GetFailureReason(response.Id)
+			if err != nil || !ok || reason.Code != protocols.PolicyRejection {
+				t.Fatalf("expected a %s failure reason, got %+v, %v, %v", protocols.PolicyRejection, reason, ok, err)
+			}
+		}
+	})
+
+	// restartScenario has Bob park Alice's objective, then restarts Bob with the given policy maker.
+	// It returns the channel on which Alice reports the objective as completed.
+	restartScenario := func(t *testing.T, policymaker func() engine.PolicyMaker) (nodeA, nodeB *node.Node, id protocols.ObjectiveId, completedA <-chan struct{}) {
+		chain := chainservice.NewMockChain()
+		broker := messageservice.NewBroker()
+		dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+		t.Cleanup(cleanup)
+
+		a := node.New(
+			messageservice.NewTestMessageService(ta.Alice.Address(), broker, 0),
+			chainservice.NewMockChainService(chain, ta.Alice.Address()),
+			store.NewMemStore(ta.Alice.PrivateKey),
+			ta.Alice.Signer(),
+			&engine.PermissivePolicy{},
+			engine.EngineOpts{},
+		)
+		t.Cleanup(func() { closeNode(t, &a) })
+		newNodeB := func(policymaker engine.PolicyMaker) node.Node {
+			storeB, err := store.NewDurableStore(ta.Bob.PrivateKey, dataFolder, buntdb.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return node.New(
+				messageservice.NewTestMessageService(ta.Bob.Address(), broker, 0),
+				chainservice.NewMockChainService(chain, ta.Bob.Address()),
+				storeB,
+				ta.Bob.Signer(),
+				policymaker,
+				engine.EngineOpts{},
+			)
+		}
+		b := newNodeB(engine.NewApprovalQueue(&engine.PermissivePolicy{}, nil))
+
+		outcome := CreateLedgerOutcome(*a.Address, *b.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+		response, err := a.CreateLedgerChannel(*b.Address, 0, outcome)
+		if err != nil {
+			t.Fatal(err)
+		}
+		waitForPendingApproval(t, &b, response.Id)
+		completedA = a.ObjectiveCompleteChan(response.Id)
+
+		closeNode(t, &b)
+		b = newNodeB(policymaker())
+		t.Cleanup(func() { closeNode(t, &b) })
+		return &a, &b, response.Id, completedA
+	}
+
+	t.Run("after restart", func(t *testing.T) {
+		_, nodeB, id, chA := restartScenario(t, func() engine.PolicyMaker { return engine.NewApprovalQueue(&engine.PermissivePolicy{}, nil) })
+
+		// The objective is parked again when Bob restarts, so the operator can still decide on it
+		waitForPendingApproval(t, nodeB, id)
+
+		chB := nodeB.ObjectiveCompleteChan(id)
+		if err := nodeB.ApproveObjective(id); err != nil {
+			t.Fatal(err)
+		}
+		<-chA
+		<-chB
+	})
+
+	t.Run("after restart without queue", func(t *testing.T) {
+		_, nodeB, id, chA := restartScenario(t, func() engine.PolicyMaker { return &engine.PermissivePolicy{} })
+
+		// The objective no longer needs an operator decision, so Bob approves it once he restarts
+		<-chA
+		waitForObjectiveStatus(t, nodeB, id, protocols.Completed)
+	})
+
+	t.Run("after restart with rejecting policy", func(t *testing.T) {
+		nodeA, nodeB, id, chA := restartScenario(t, func() engine.PolicyMaker {
+			policy, err := engine.NewRulePolicy(engine.PolicyRules{BlockedCounterparties: []string{ta.Alice.Address().String()}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return policy
+		})
+
+		// The policy now rejects the objective, so Bob rejects it once he restarts and tells Alice why
+		<-chA
+		waitForObjectiveStatus(t, nodeB, id, protocols.Rejected)
+		reason, ok, err := nodeA.GetFailureReason(id)
+		if err != nil || !ok || reason.Code != protocols.PolicyRejection {
+			t.Fatalf("expected a %s failure reason, got %+v, %v, %v", protocols.PolicyRejection, reason, ok, err)
+		}
+	})
+
+	t.Run("without queue", func(t *testing.T) {
+		nodeA, nodeB := setup(t)
+		defer closeNode(t, &nodeA)
+		defer closeNode(t, &nodeB)
+
+		if _, err := nodeA.GetPendingApprovals(); err != node.ErrNoApprovalQueue {
+			t.Fatalf("expected %v, got %v", node.ErrNoApprovalQueue, err)
+		}
+	})
+}
@@ -117,6 +117,7 @@ func TestBridgeFlow(t *testing.T) {
 		BridgePublicIp:    DEFAULT_PUBLIC_IP,
 		NodeL1MsgPort:     int(tcL1.Participants[1].Port),
 		NodeL2MsgPort:     int(tcL2.Participants[1].Port),
+		LaconicdClient:    infraL2.localLaconicd.Client(tcL1.Participants[1].Address()),
 	}
 
 	nodeAChainservice, err := chainservice.NewEthChainService(chainservice.ChainOpts{
@@ -133,6 +134,7 @@ func TestBridgeFlow(t *testing.T) {
 	}
 
 	nodeAPrimeChainservice, err := chainservice.NewLaconicdChainService(chainservice.LaconicdChainOpts{
+		Client:     infraL2.localLaconicd.Client(tcL2.Participants[0].Address()),
 		VpaAddress: infraL1.anvilChain.ContractAddresses.VpaAddress,
 		CaAddress:  infraL1.anvilChain.ContractAddresses.CaAddress,
 	})
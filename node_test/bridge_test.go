@@ -551,7 +551,7 @@ func TestExitL2WithVirtualChannelStateUnilaterally(t *testing.T) {
 		newState := state.StateFromFixedAndVariablePart(voucherState.State().FixedPart(), vp)
 
 		// APrime signs constructed state and adds it to the virtual channel
-		_, _ = virtualChannel.SignAndAddState(newState, &tcL2.Participants[1].PrivateKey)
+		_, _ = virtualChannel.SignAndAddState(newState, tcL2.Participants[1].Signer())
 
 		// Update store with updated virtual channel
 		_ = storeAPrime.SetChannel(virtualChannel)
@@ -884,6 +884,7 @@ func initializeUtilsWithBridge(t *testing.T, closeBridge bool) (UtilsWithBridge,
 		BridgePublicIp:    DEFAULT_PUBLIC_IP,
 		NodeL1MsgPort:     int(tcL1.Participants[1].Port),
 		NodeL2MsgPort:     int(tcL2.Participants[0].Port),
+		LaconicdClient:    infraL2.localLaconicd.Client(tcL1.Participants[1].Address()),
 	}
 
 	bridge := bridge.New()
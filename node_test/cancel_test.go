@@ -0,0 +1,125 @@
+package node_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/statechannels/go-nitro/channel"
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/node/query"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// proposalDroppingMessageService discards outgoing messages carrying ledger proposals while drop is set, as if they were lost
+type proposalDroppingMessageService struct {
+	messageservice.TestMessageService
+	drop *atomic.Bool
+}
+
+func (p proposalDroppingMessageService) Send(msg protocols.Message) error {
+	if p.drop.Load() && len(msg.LedgerProposals) > 0 {
+		return nil
+	}
+	return p.TestMessageService.Send(msg)
+}
+
+// waitForLedger polls the store until its copy of the ledger channel satisfies the condition.
+func waitForLedger(t *testing.T, s store.Store, ledgerId types.Destination, description string, condition func(includesTarget bool, queued int) bool, target types.Destination) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		cc, err := s.GetConsensusChannelById(ledgerId)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if condition(cc.IncludesTarget(target), len(cc.ProposalQueue())) {
+			return
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("ledger %s of %s never %s", ledgerId, s.GetAddress(), description)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestCancelObjective(t *testing.T) {
+	chain := chainservice.NewMockChain()
+	broker := messageservice.NewBroker()
+
+	newNode := func(actor ta.Actor, msg messageservice.MessageService) (node.Node, store.Store) {
+		s := store.NewMemStore(actor.PrivateKey)
+		n := node.New(msg, chainservice.NewMockChainService(chain, actor.Address()), s, actor.Signer(), &engine.PermissivePolicy{}, engine.EngineOpts{})
+		return n, s
+	}
+
+	nodeA, storeA := newNode(ta.Alice, messageservice.NewTestMessageService(ta.Alice.Address(), broker, 0))
+	defer closeNode(t, &nodeA)
+	nodeI, storeI := newNode(ta.Irene, messageservice.NewTestMessageService(ta.Irene.Address(), broker, 0))
+	defer closeNode(t, &nodeI)
+	dropProposalsB := &atomic.Bool{}
+	nodeB, storeB := newNode(ta.Bob, proposalDroppingMessageService{messageservice.NewTestMessageService(ta.Bob.Address(), broker, 0), dropProposalsB})
+	defer closeNode(t, &nodeB)
+
+	ledgerAI := openLedgerChannel(t, nodeA, nodeI, types.Address{}, 0)
+	ledgerIB := openLedgerChannel(t, nodeI, nodeB, types.Address{}, 0)
+
+	// Bob's countersignature on Irene's Add is lost, so the objective stalls with the Add still queued in Irene's ledger
+	dropProposalsB.Store(true)
+	response, err := nodeA.CreatePaymentChannel([]types.Address{*nodeI.Address}, *nodeB.Address, 0, initialPaymentOutcome(*nodeA.Address, *nodeB.Address, types.Address{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	funded := func(includesTarget bool, _ int) bool { return includesTarget }
+	proposed := func(_ bool, queued int) bool { return queued > 0 }
+	waitForLedger(t, storeA, ledgerAI, "funded the channel", funded, response.ChannelId)
+	waitForLedger(t, storeB, ledgerIB, "funded the channel", funded, response.ChannelId)
+	waitForLedger(t, storeI, ledgerIB, "proposed funding the channel", proposed, response.ChannelId)
+	dropProposalsB.Store(false)
+
+	chA := nodeA.ObjectiveCompleteChan(response.Id)
+	chI := nodeI.ObjectiveCompleteChan(response.Id)
+	chB := nodeB.ObjectiveCompleteChan(response.Id)
+	if err := nodeA.CancelObjective(response.Id); err != nil {
+		t.Fatal(err)
+	}
+	<-chA
+	<-chI
+	<-chB
+
+	for _, n := range []node.Node{nodeA, nodeI, nodeB} {
+		objective, err := n.GetObjectiveById(response.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objective.GetStatus() != protocols.Rejected {
+			t.Fatalf("expected cancelled objective to be rejected, got %v", objective.GetStatus())
+		}
+		reason, ok, err := n.GetFailureReason(response.Id)
+		if err != nil || !ok || reason.Code != protocols.Cancellation {
+			t.Fatalf("expected a %s failure reason, got %+v, %v, %v", protocols.Cancellation, reason, ok, err)
+		}
+	}
+
+	// Every participant ends up with ledgers which neither fund the channel nor have proposals queued
+	rolledBack := func(includesTarget bool, queued int) bool { return !includesTarget && queued == 0 }
+	waitForLedger(t, storeA, ledgerAI, "rolled back", rolledBack, response.ChannelId)
+	waitForLedger(t, storeI, ledgerAI, "rolled back", rolledBack, response.ChannelId)
+	waitForLedger(t, storeI, ledgerIB, "rolled back", rolledBack, response.ChannelId)
+	waitForLedger(t, storeB, ledgerIB, "rolled back", rolledBack, response.ChannelId)
+
+	checkLedgerChannel(t, ledgerAI, CreateLedgerOutcome(*nodeA.Address, *nodeI.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{}), query.Open, channel.Open, nodeA, nodeI)
+	checkLedgerChannel(t, ledgerIB, CreateLedgerOutcome(*nodeI.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{}), query.Open, channel.Open, nodeI, nodeB)
+
+	if err := nodeA.CancelObjective(response.Id); err == nil {
+		t.Fatal("expected an error cancelling an objective which has finished")
+	}
+}
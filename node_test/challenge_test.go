@@ -100,6 +100,53 @@ func TestChallenge(t *testing.T) {
 	testhelpers.Assert(t, balanceNodeB.Cmp(big.NewInt(ledgerChannelDeposit)) == 0, "Balance of Bob (%v) should be equal to ledgerChannelDeposit (%v)", balanceNodeB, ledgerChannelDeposit)
 }
 
+func TestChallengeOnLaconicd(t *testing.T) {
+	testCase := TestCase{
+		Description:       "Direct defund with Challenge on laconicd",
+		Chain:             LaconicdChain,
+		MessageService:    TestMessageService,
+		ChallengeDuration: 5,
+		MessageDelay:      0,
+		LogName:           "challenge_laconicd_test",
+		Participants: []TestParticipant{
+			{StoreType: MemStore, Actor: testactors.Alice},
+			{StoreType: MemStore, Actor: testactors.Bob},
+		},
+	}
+
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+
+	infra := setupSharedInfra(testCase)
+	defer infra.Close(t)
+
+	nodeA, _, _, storeA, _ := setupIntegrationNode(testCase, testCase.Participants[0], infra, []string{}, dataFolder)
+	defer nodeA.Close()
+	nodeB, _, _, storeB, _ := setupIntegrationNode(testCase, testCase.Participants[1], infra, []string{}, dataFolder)
+	defer nodeB.Close()
+
+	ledgerChannel := openLedgerChannel(t, nodeA, nodeB, common.Address{}, uint32(testCase.ChallengeDuration))
+
+	// Alice registers a challenge, which both engines must handle although laconicd has no L1 channel for it
+	response, err := nodeA.CloseLedgerChannel(ledgerChannel, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chA := nodeA.ObjectiveCompleteChan(response)
+	chB := nodeB.ObjectiveCompleteChan(response)
+
+	time.Sleep(3 * time.Second)
+	objectiveA, _ := storeA.GetObjectiveByChannelId(ledgerChannel)
+	objectiveB, _ := storeB.GetObjectiveByChannelId(ledgerChannel)
+	objA, _ := objectiveA.(*directdefund.Objective)
+	objB, _ := objectiveB.(*directdefund.Objective)
+	testhelpers.Assert(t, objA.C.OnChain.ChannelMode == channel.Challenge, "Expected channel status to be challenge")
+	testhelpers.Assert(t, objB.C.OnChain.ChannelMode == channel.Challenge, "Expected channel status to be challenge")
+
+	<-chA
+	<-chB
+}
+
 func TestCheckpoint(t *testing.T) {
 	testCase := TestCase{
 		Description:       "Check point test",
@@ -412,7 +459,7 @@ func TestVirtualPaymentChannel(t *testing.T) {
 	newState := state.StateFromFixedAndVariablePart(voucherState.State().FixedPart(), vp)
 
 	// Bob signs constructed state and adds it to the virtual channel
-	_, _ = virtualChannel.SignAndAddState(newState, &tc.Participants[1].PrivateKey)
+	_, _ = virtualChannel.SignAndAddState(newState, tc.Participants[1].Signer())
 
 	// Update store with updated virtual channel
 	_ = storeB.SetChannel(virtualChannel)
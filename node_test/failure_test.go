@@ -0,0 +1,86 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testdata"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestInsufficientLedgerFunds(t *testing.T) {
+	chain := chainservice.NewMockChain()
+	broker := messageservice.NewBroker()
+
+	newNode := func(actor ta.Actor) node.Node {
+		return node.New(
+			messageservice.NewTestMessageService(actor.Address(), broker, 0),
+			chainservice.NewMockChainService(chain, actor.Address()),
+			store.NewMemStore(actor.PrivateKey),
+			actor.Signer(),
+			&engine.PermissivePolicy{},
+			engine.EngineOpts{},
+		)
+	}
+
+	nodeA := newNode(ta.Alice)
+	defer closeNode(t, &nodeA)
+	nodeI := newNode(ta.Irene)
+	defer closeNode(t, &nodeI)
+	nodeB := newNode(ta.Bob)
+	defer closeNode(t, &nodeB)
+
+	openLedgerChannel(t, nodeA, nodeI, types.Address{}, 0)
+	openLedgerChannel(t, nodeI, nodeB, types.Address{}, 0)
+
+	// Alice asks for more than she has in her ledger channel with Irene
+	outcome := testdata.Outcomes.Create(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit+1, 0, types.Address{})
+	response, err := nodeA.CreatePaymentChannel([]types.Address{*nodeI.Address}, *nodeB.Address, 0, outcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejected := []<-chan struct{}{nodeI.ObjectiveCompleteChan(response.Id), nodeB.ObjectiveCompleteChan(response.Id)}
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case failed := <-nodeA.FailedObjectives():
+			if failed.ObjectiveId != response.Id {
+				continue
+			}
+			if failed.Reason.Code != protocols.InsufficientLedgerFunds {
+				t.Fatalf("expected a %s failure, got %+v", protocols.InsufficientLedgerFunds, failed.Reason)
+			}
+		case <-timeout:
+			t.Fatalf("objective %s was never reported as failed", response.Id)
+		}
+		break
+	}
+
+	// The other participants are told why the objective failed
+	for i, n := range []node.Node{nodeI, nodeB} {
+		select {
+		case <-rejected[i]:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("objective %s was never rejected by %s", response.Id, n.Address)
+		}
+		objective, err := n.GetObjectiveById(response.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objective.GetStatus() != protocols.Rejected {
+			t.Fatalf("expected objective to be rejected, got %v", objective.GetStatus())
+		}
+		reason, ok, err := n.GetFailureReason(response.Id)
+		if err != nil || !ok || reason.Code != protocols.InsufficientLedgerFunds {
+			t.Fatalf("expected a %s failure reason, got %+v, %v, %v", protocols.InsufficientLedgerFunds, reason, ok, err)
+		}
+	}
+}
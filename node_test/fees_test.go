@@ -0,0 +1,58 @@
+package node_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/notifier"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// replacingChainService is a mock chain service whose engine events are supplied by the test
+type replacingChainService struct {
+	*chainservice.MockChainService
+	events chan chainservice.Event
+}
+
+func (rcs *replacingChainService) EventEngineFeed() <-chan chainservice.Event {
+	return rcs.events
+}
+
+func TestTransactionReplacementsReachSubscribers(t *testing.T) {
+	chain := &replacingChainService{
+		MockChainService: chainservice.NewMockChainService(chainservice.NewMockChain(), ta.Alice.Address()),
+		events:           make(chan chainservice.Event, 1),
+	}
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+	nodeA, _ := setupNode(ta.Alice.PrivateKey, chain, messageservice.NewBroker(), 0, dataFolder)
+	defer closeNode(t, &nodeA)
+
+	replacements := nodeA.SubscribeTransactionReplacements(notifier.SubscriptionOpts{})
+	defer replacements.Close()
+
+	replaced := chainservice.TransactionReplacedEvent{
+		ReplacedTxHash: common.HexToHash("0x01"),
+		Nonce:          7,
+		GasTipCap:      big.NewInt(2),
+		GasFeeCap:      big.NewInt(20),
+	}
+	chain.events <- replaced
+
+	select {
+	case replacement := <-replacements.Updates():
+		want := types.TransactionReplacement{ReplacedTxHash: replaced.ReplacedTxHash, Nonce: 7, GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(20)}
+		if replacement.ReplacedTxHash != want.ReplacedTxHash || replacement.Nonce != want.Nonce ||
+			replacement.GasTipCap.Cmp(want.GasTipCap) != 0 || replacement.GasFeeCap.Cmp(want.GasFeeCap) != 0 {
+			t.Fatalf("expected replacement %+v, got %+v", want, replacement)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the transaction replacement to be published")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math/big"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -41,10 +42,14 @@ func setupNode(pk []byte, chain chainservice.ChainService, msgBroker messageserv
 	if err != nil {
 		panic(err)
 	}
-	return node.New(messageservice, chain, store, &engine.PermissivePolicy{}), store
+	signer, err := crypto.NewKeySigner(pk)
+	if err != nil {
+		panic(err)
+	}
+	return node.New(messageservice, chain, store, signer, &engine.PermissivePolicy{}, engine.EngineOpts{}), store
 }
 
-func closeNode(t *testing.T, node *node.Node) {
+func closeNode(t testing.TB, node *node.Node) {
 	err := node.Close()
 	if err != nil {
 		t.Fatal(err)
@@ -61,13 +66,13 @@ func waitForPeerInfoExchange(services ...*p2pms.P2PMessageService) {
 	}
 }
 
-func closeSimulatedChain(t *testing.T, chain chainservice.SimulatedChain) {
+func closeSimulatedChain(t testing.TB, chain chainservice.SimulatedChain) {
 	if err := chain.Close(); err != nil {
 		t.Fatal(err)
 	}
 }
 
-func setupMessageService(tc TestCase, tp TestParticipant, si sharedTestInfrastructure, bootPeers []string) (messageservice.MessageService, string) {
+func setupMessageService(tc TestCase, tp TestParticipant, si sharedTestInfrastructure, bootPeers []string, outbox store.MessageOutbox) (messageservice.MessageService, string) {
 	switch tc.MessageService {
 	case TestMessageService:
 		return messageservice.NewTestMessageService(tp.Address(), *si.broker, tc.MessageDelay), ""
@@ -80,6 +85,7 @@ func setupMessageService(tc TestCase, tp TestParticipant, si sharedTestInfrastru
 			SCAddr:    tp.Address(),
 			PkBytes:   tp.PrivateKey,
 			BootPeers: bootPeers,
+			Outbox:    outbox,
 		})
 
 		return ms, ms.MultiAddr
@@ -116,8 +122,10 @@ func setupChainService(tc TestCase, tp TestParticipant, si sharedTestInfrastruct
 	case LaconicdChain:
 		cs, err := chainservice.NewLaconicdChainService(
 			chainservice.LaconicdChainOpts{
-				VpaAddress: si.laconicdChain.ContractAddresses.VpaAddress,
-				CaAddress:  si.laconicdChain.ContractAddresses.CaAddress,
+				Client:       si.localLaconicd.Client(tp.Actor.Address()),
+				PollInterval: 100 * time.Millisecond,
+				VpaAddress:   si.laconicdChain.ContractAddresses.VpaAddress,
+				CaAddress:    si.laconicdChain.ContractAddresses.CaAddress,
 			})
 		if err != nil {
 			panic(err)
@@ -139,6 +147,13 @@ func setupStore(tc TestCase, tp TestParticipant, si sharedTestInfrastructure, da
 			panic(err)
 		}
 		return s
+	case SQLStore:
+		dataSource := filepath.Join(dataFolder, fmt.Sprintf("%s.db", tp.Actor.Name))
+		s, err := store.NewSQLStore(tp.PrivateKey, store.SQLiteDriver, dataSource)
+		if err != nil {
+			panic(err)
+		}
+		return s
 	default:
 		panic(fmt.Sprintf("Unknown store type %s", tp.StoreType))
 	}
@@ -146,10 +161,10 @@ func setupStore(tc TestCase, tp TestParticipant, si sharedTestInfrastructure, da
 
 func setupIntegrationNode(tc TestCase, tp TestParticipant, si sharedTestInfrastructure, bootPeers []string, dataFolder string) (node.Node, messageservice.MessageService, string, store.Store, chainservice.ChainService) {
 	logging.SetupDefaultFileLogger(tc.LogName+"_"+string(tp.Name)+".log", slog.LevelDebug)
-	messageService, multiAddr := setupMessageService(tc, tp, si, bootPeers)
-	cs := setupChainService(tc, tp, si)
 	store := setupStore(tc, tp, si, dataFolder)
-	n := node.New(messageService, cs, store, &engine.PermissivePolicy{})
+	messageService, multiAddr := setupMessageService(tc, tp, si, bootPeers, store)
+	cs := setupChainService(tc, tp, si)
+	n := node.New(messageService, cs, store, tp.Actor.Signer(), &engine.PermissivePolicy{}, engine.EngineOpts{})
 	return n, messageService, multiAddr, store, cs
 }
 
@@ -287,6 +302,7 @@ func setupSharedInfra(tc TestCase) sharedTestInfrastructure {
 		infra.anvilChain = chain
 	case LaconicdChain:
 		infra.laconicdChain = chainutils.LaconicdChain{}
+		infra.localLaconicd = chainservice.NewLocalLaconicd(chainservice.LocalLaconicdOpts{BlockInterval: time.Second})
 	default:
 		panic("Unknown chain service")
 	}
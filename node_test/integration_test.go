@@ -40,6 +40,26 @@ func TestSimpleIntegrationScenario(t *testing.T) {
 	RunIntegrationTestCase(simpleCase, t)
 }
 
+func TestSQLStoreIntegrationScenario(t *testing.T) {
+	sqlCase := TestCase{
+		Description:    "SQL store test",
+		Chain:          MockChain,
+		MessageService: TestMessageService,
+		NumOfChannels:  2,
+		MessageDelay:   0,
+		LogName:        "sql_store_integration",
+		NumOfHops:      1,
+		NumOfPayments:  2,
+		Participants: []TestParticipant{
+			{StoreType: SQLStore, Actor: testactors.Alice},
+			{StoreType: SQLStore, Actor: testactors.Bob},
+			{StoreType: SQLStore, Actor: testactors.Irene},
+		},
+	}
+
+	RunIntegrationTestCase(sqlCase, t)
+}
+
 func TestComplexIntegrationScenario(t *testing.T) {
 	complexCase := TestCase{
 		Description:    "Complex test",
@@ -60,6 +80,26 @@ func TestComplexIntegrationScenario(t *testing.T) {
 	RunIntegrationTestCase(complexCase, t)
 }
 
+func TestLaconicdIntegrationScenario(t *testing.T) {
+	laconicdCase := TestCase{
+		Description:    "Laconicd test",
+		Chain:          LaconicdChain,
+		MessageService: TestMessageService,
+		NumOfChannels:  1,
+		MessageDelay:   0,
+		LogName:        "laconicd_integration",
+		NumOfHops:      1,
+		NumOfPayments:  1,
+		Participants: []TestParticipant{
+			{StoreType: MemStore, Actor: testactors.Alice},
+			{StoreType: MemStore, Actor: testactors.Bob},
+			{StoreType: MemStore, Actor: testactors.Irene},
+		},
+	}
+
+	RunIntegrationTestCase(laconicdCase, t)
+}
+
 // RunIntegrationTestCase runs the integration test case.
 func RunIntegrationTestCase(tc TestCase, t *testing.T) {
 	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
@@ -0,0 +1,109 @@
+package node_test
+
+import (
+	"io"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/statechannels/go-nitro/internal/metrics"
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestMetrics(t *testing.T) {
+	chain := chainservice.NewMockChain()
+	broker := messageservice.NewBroker()
+
+	newNode := func(actor ta.Actor) node.Node {
+		s, err := store.NewStore(store.StoreOpts{PkBytes: actor.PrivateKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+		msg := messageservice.NewTestMessageService(actor.Address(), broker, 0)
+		return node.New(msg, chainservice.NewMockChainService(chain, actor.Address()), s, actor.Signer(), &engine.PermissivePolicy{}, engine.EngineOpts{})
+	}
+
+	// Counters are shared by every node in the process, so the test checks how much they grow
+	started := func(objectiveType string) float64 {
+		return testutil.ToFloat64(metrics.ObjectivesStarted.WithLabelValues(objectiveType))
+	}
+	completed := func(objectiveType string) float64 {
+		return testutil.ToFloat64(metrics.ObjectivesCompleted.WithLabelValues(objectiveType))
+	}
+	vouchers := func(direction string) (count, amount float64) {
+		return testutil.ToFloat64(metrics.Vouchers.WithLabelValues(direction)), testutil.ToFloat64(metrics.VoucherAmount.WithLabelValues(direction))
+	}
+
+	startedDF, completedDF := started("DirectFunding"), completed("DirectFunding")
+	startedVF, completedVF := started("VirtualFund"), completed("VirtualFund")
+	sentCount, sentAmount := vouchers(metrics.Sent)
+	receivedCount, receivedAmount := vouchers(metrics.Received)
+
+	nodeA := newNode(ta.Alice)
+	defer closeNode(t, &nodeA)
+	nodeI := newNode(ta.Irene)
+	defer closeNode(t, &nodeI)
+	nodeB := newNode(ta.Bob)
+	defer closeNode(t, &nodeB)
+
+	openLedgerChannel(t, nodeA, nodeI, types.Address{}, 0)
+	openLedgerChannel(t, nodeI, nodeB, types.Address{}, 0)
+
+	response, err := nodeA.CreatePaymentChannel([]types.Address{*nodeI.Address}, *nodeB.Address, 0, initialPaymentOutcome(*nodeA.Address, *nodeB.Address, types.Address{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForObjectives(t, nodeA, nodeB, []node.Node{nodeI}, []protocols.ObjectiveId{response.Id})
+
+	err = nodeA.Pay(response.ChannelId, big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-nodeB.ReceivedVouchers()
+
+	expectGrowth := func(name string, before, after, want float64) {
+		t.Helper()
+		if after-before != want {
+			t.Errorf("expected %s to grow by %v, got %v", name, want, after-before)
+		}
+	}
+	// Each ledger channel is funded by an objective on both of its participants, and the payment channel by an objective on all three nodes
+	expectGrowth("started DirectFunding objectives", startedDF, started("DirectFunding"), 4)
+	expectGrowth("completed DirectFunding objectives", completedDF, completed("DirectFunding"), 4)
+	expectGrowth("started VirtualFund objectives", startedVF, started("VirtualFund"), 3)
+	expectGrowth("completed VirtualFund objectives", completedVF, completed("VirtualFund"), 3)
+
+	newSentCount, newSentAmount := vouchers(metrics.Sent)
+	newReceivedCount, newReceivedAmount := vouchers(metrics.Received)
+	expectGrowth("sent vouchers", sentCount, newSentCount, 1)
+	expectGrowth("amount sent", sentAmount, newSentAmount, 5)
+	expectGrowth("received vouchers", receivedCount, newReceivedCount, 1)
+	expectGrowth("amount received", receivedAmount, newReceivedAmount, 5)
+
+	// The latencies are exported alongside the counters
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, series := range []string{
+		`nitro_engine_task_duration_seconds_count{kind="objective_request"}`,
+		`nitro_engine_task_duration_seconds_count{kind="message"}`,
+		`nitro_store_operation_duration_seconds_count{operation="SetObjective"}`,
+		`nitro_payments_vouchers_total{direction="received"}`,
+	} {
+		if !strings.Contains(string(body), series) {
+			t.Errorf("expected the metrics to include %s", series)
+		}
+	}
+}
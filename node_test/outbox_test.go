@@ -0,0 +1,97 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node"
+	p2pms "github.com/statechannels/go-nitro/node/engine/messageservice/p2p-message-service"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// waitForOutboxDepth waits until the node's outbox holds the expected number of messages for the peer
+func waitForOutboxDepth(t *testing.T, n node.Node, peer types.Address, expected int) {
+	deadline := time.After(30 * time.Second)
+	for {
+		depths, err := n.GetOutboxDepths()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if depths[peer] == expected {
+			return
+		}
+
+		select {
+		case <-deadline:
+			t.Fatalf("expected an outbox depth of %d for %s, got %d", expected, peer, depths[peer])
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+func TestOutboxDeliversToOfflinePeer(t *testing.T) {
+	tc := TestCase{
+		Description:    "Outbox test",
+		Chain:          MockChain,
+		MessageService: P2PMessageService,
+		LogName:        "outbox",
+		Participants: []TestParticipant{
+			{StoreType: DurableStore, Actor: testactors.Alice},
+			{StoreType: DurableStore, Actor: testactors.Bob},
+			{StoreType: DurableStore, Actor: testactors.Irene},
+		},
+	}
+
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+
+	infra := setupSharedInfra(tc)
+	defer infra.Close(t)
+
+	// Irene is only used as a boot peer, so that Alice can find Bob through the DHT once he comes online
+	nodeI, msgI, multiAddrI, _, _ := setupIntegrationNode(tc, tc.Participants[2], infra, []string{}, dataFolder)
+	defer nodeI.Close()
+	nodeA, msgA, _, _, _ := setupIntegrationNode(tc, tc.Participants[0], infra, []string{multiAddrI}, dataFolder)
+	defer nodeA.Close()
+	waitForPeerInfoExchange(msgI.(*p2pms.P2PMessageService), msgA.(*p2pms.P2PMessageService))
+
+	// Bob is offline, so Alice's messages wait in her outbox
+	bob := testactors.Bob.Address()
+	outcome := CreateLedgerOutcome(*nodeA.Address, bob, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+	response, err := nodeA.CreateLedgerChannel(bob, 0, outcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForOutboxDepth(t, nodeA, bob, 1)
+	completedA := nodeA.ObjectiveCompleteChan(response.Id)
+
+	nodeB, _, _, _, _ := setupIntegrationNode(tc, tc.Participants[1], infra, []string{multiAddrI}, dataFolder)
+	defer nodeB.Close()
+
+	select {
+	case <-completedA:
+	case <-time.After(time.Minute):
+		t.Fatalf("objective %s did not complete", response.Id)
+	}
+
+	// Bob may complete the objective before we could subscribe to its completion, so his copy is polled from his store
+	deadline := time.After(30 * time.Second)
+	for {
+		objectiveB, err := nodeB.GetObjectiveById(response.Id)
+		if err == nil && objectiveB.GetStatus() == protocols.Completed {
+			break
+		}
+
+		select {
+		case <-deadline:
+			t.Fatalf("objective %s did not complete on Bob's node, err %v", response.Id, err)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	waitForOutboxDepth(t, nodeA, bob, 0)
+	waitForOutboxDepth(t, nodeB, *nodeA.Address, 0)
+}
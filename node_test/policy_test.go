@@ -0,0 +1,81 @@
+package node_test
+
+import (
+	"strings"
+	"testing"
+
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestRulePolicyDecisions(t *testing.T) {
+	chain := chainservice.NewMockChain()
+	broker := messageservice.NewBroker()
+
+	policy, err := engine.NewRulePolicy(engine.PolicyRules{BlockedCounterparties: []string{ta.Alice.Address().String()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeA := node.New(
+		messageservice.NewTestMessageService(ta.Alice.Address(), broker, 0),
+		chainservice.NewMockChainService(chain, ta.Alice.Address()),
+		store.NewMemStore(ta.Alice.PrivateKey),
+		ta.Alice.Signer(),
+		&engine.PermissivePolicy{},
+		engine.EngineOpts{},
+	)
+	defer closeNode(t, &nodeA)
+	nodeB := node.New(
+		messageservice.NewTestMessageService(ta.Bob.Address(), broker, 0),
+		chainservice.NewMockChainService(chain, ta.Bob.Address()),
+		store.NewMemStore(ta.Bob.PrivateKey),
+		ta.Bob.Signer(),
+		policy,
+		engine.EngineOpts{},
+	)
+	defer closeNode(t, &nodeB)
+
+	outcome := CreateLedgerOutcome(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+	response, err := nodeA.CreateLedgerChannel(*nodeB.Address, 0, outcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Bob records the decision before sending the rejection notice which completes Alice's objective
+	<-nodeA.ObjectiveCompleteChan(response.Id)
+
+	// The decision has been handed over to Bob's store, so it can be looked up later
+	if _, ok := policy.Decision(response.Id); ok {
+		t.Fatal("expected the policy to have forgotten the decision once it was recorded")
+	}
+	decision, ok, err := nodeB.GetPolicyDecision(response.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatalf("expected a policy decision to be recorded for %s", response.Id)
+	}
+	if decision.ObjectiveId != response.Id || decision.Approved || !strings.Contains(decision.Reason, "is blocked") {
+		t.Fatalf("expected a rejection because Alice is blocked, got %+v", decision)
+	}
+
+	objective, err := nodeB.GetObjectiveById(response.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objective.GetStatus() != protocols.Rejected {
+		t.Fatalf("expected objective to be rejected, got %v", objective.GetStatus())
+	}
+
+	// Alice learns which rule rejected the objective from Bob's rejection notice
+	reason, ok, err := nodeA.GetFailureReason(response.Id)
+	if err != nil || !ok || reason.Code != protocols.PolicyRejection || reason.Message != decision.Reason {
+		t.Fatalf("expected a %s failure reason of %q, got %+v, %v, %v", protocols.PolicyRejection, decision.Reason, reason, ok, err)
+	}
+}
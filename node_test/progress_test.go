@@ -0,0 +1,99 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/protocols/directfund"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestObjectiveProgress(t *testing.T) {
+	chain := chainservice.NewMockChain()
+	broker := messageservice.NewBroker()
+
+	nodeA := node.New(
+		messageservice.NewTestMessageService(ta.Alice.Address(), broker, 0),
+		chainservice.NewMockChainService(chain, ta.Alice.Address()),
+		store.NewMemStore(ta.Alice.PrivateKey),
+		ta.Alice.Signer(),
+		&engine.PermissivePolicy{},
+		engine.EngineOpts{},
+	)
+	defer closeNode(t, &nodeA)
+	// Bob parks objectives until an operator decides on them, so Alice's objectives stall waiting for him
+	nodeB := node.New(
+		messageservice.NewTestMessageService(ta.Bob.Address(), broker, 0),
+		chainservice.NewMockChainService(chain, ta.Bob.Address()),
+		store.NewMemStore(ta.Bob.PrivateKey),
+		ta.Bob.Signer(),
+		engine.NewApprovalQueue(&engine.PermissivePolicy{}, nil),
+		engine.EngineOpts{},
+	)
+	defer closeNode(t, &nodeB)
+
+	outcome := CreateLedgerOutcome(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+	response, err := nodeA.CreateLedgerChannel(*nodeB.Address, 0, outcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForPendingApproval(t, &nodeB, response.Id)
+
+	objectives, err := nodeA.GetObjectives(store.ObjectiveFilter{Statuses: []protocols.ObjectiveStatus{protocols.Approved}, Type: "DirectFunding"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objectives) != 1 || objectives[0].Id != response.Id || objectives[0].ChannelId != response.ChannelId {
+		t.Fatalf("expected only the stalled objective to be returned, got %+v", objectives)
+	}
+	if objectives[0].WaitingFor != directfund.WaitingForCompletePrefund || objectives[0].LastUpdated.IsZero() {
+		t.Fatalf("expected the objective to be waiting for %s, got %+v", directfund.WaitingForCompletePrefund, objectives[0])
+	}
+
+	progress, err := nodeA.GetObjectiveProgress(response.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) != 1 || len(progress[0].MessagesTo) != 1 || progress[0].MessagesTo[0] != *nodeB.Address {
+		t.Fatalf("expected a single entry recording the prefund sent to Bob, got %+v", progress)
+	}
+
+	chA := nodeA.ObjectiveCompleteChan(response.Id)
+	if err := nodeB.ApproveObjective(response.Id); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-chA:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("objective %s never completed", response.Id)
+	}
+
+	progress, err = nodeA.GetObjectiveProgress(response.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := progress[len(progress)-1]
+	if latest.Status != protocols.Completed || latest.WaitingFor != directfund.WaitingForNothing {
+		t.Fatalf("expected the history to end with the objective completing, got %+v", progress)
+	}
+	for i := 1; i < len(progress); i++ {
+		if progress[i].Time.Before(progress[i-1].Time) {
+			t.Fatalf("expected the history to be ordered oldest first, got %+v", progress)
+		}
+	}
+
+	objectives, err = nodeA.GetObjectives(store.ObjectiveFilter{Statuses: []protocols.ObjectiveStatus{protocols.Approved}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objectives) != 0 {
+		t.Fatalf("expected no objectives in progress, got %+v", objectives)
+	}
+}
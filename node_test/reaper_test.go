@@ -0,0 +1,124 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// waitForFailedObjective waits for the node to report the objective with the given id as failed, and returns the reason
+func waitForFailedObjective(t *testing.T, n *node.Node, id protocols.ObjectiveId) protocols.FailureReason {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case failed := <-n.FailedObjectives():
+			if failed.ObjectiveId == id {
+				return failed.Reason
+			}
+		case <-timeout:
+			t.Fatalf("objective %s was never reported as failed", id)
+		}
+	}
+}
+
+func TestReaper(t *testing.T) {
+	// Bob parks every objective until an operator decides on it, so Alice's objectives stall waiting for him
+	setup := func(t *testing.T, action engine.ReaperAction) (node.Node, store.Store, node.Node) {
+		chain := chainservice.NewMockChain()
+		broker := messageservice.NewBroker()
+
+		reaper, err := engine.NewReaper(engine.ReaperRules{
+			Interval:  50 * time.Millisecond,
+			Deadlines: map[string]engine.ObjectiveDeadline{"DirectFunding": {Timeout: 200 * time.Millisecond, Action: action}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		storeA := store.NewMemStore(ta.Alice.PrivateKey)
+		nodeA := node.New(
+			messageservice.NewTestMessageService(ta.Alice.Address(), broker, 0),
+			chainservice.NewMockChainService(chain, ta.Alice.Address()),
+			storeA,
+			ta.Alice.Signer(),
+			&engine.PermissivePolicy{},
+			engine.EngineOpts{Reaper: reaper},
+		)
+		nodeB := node.New(
+			messageservice.NewTestMessageService(ta.Bob.Address(), broker, 0),
+			chainservice.NewMockChainService(chain, ta.Bob.Address()),
+			store.NewMemStore(ta.Bob.PrivateKey),
+			ta.Bob.Signer(),
+			engine.NewApprovalQueue(&engine.PermissivePolicy{}, nil),
+			engine.EngineOpts{},
+		)
+		return nodeA, storeA, nodeB
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		nodeA, storeA, nodeB := setup(t, engine.ReaperFail)
+		defer closeNode(t, &nodeA)
+		defer closeNode(t, &nodeB)
+
+		outcome := CreateLedgerOutcome(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+		response, err := nodeA.CreateLedgerChannel(*nodeB.Address, 0, outcome)
+		if err != nil {
+			t.Fatal(err)
+		}
+		completed := nodeA.ObjectiveCompleteChan(response.Id)
+
+		if reason := waitForFailedObjective(t, &nodeA, response.Id); reason.Code != protocols.Timeout {
+			t.Fatalf("expected a %s failure, got %+v", protocols.Timeout, reason)
+		}
+		// Anyone waiting on the objective is released once it has failed
+		select {
+		case <-completed:
+		case <-time.After(time.Second):
+			t.Fatal("expected the failed objective to be reported as completed")
+		}
+
+		objective, err := nodeA.GetObjectiveById(response.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objective.GetStatus() != protocols.Rejected {
+			t.Fatalf("expected failed objective to be rejected, got %v", objective.GetStatus())
+		}
+		if _, owned := storeA.GetObjectiveByChannelId(response.ChannelId); owned {
+			t.Fatalf("expected channel %s to be released", response.ChannelId)
+		}
+	})
+
+	t.Run("resend", func(t *testing.T) {
+		nodeA, _, nodeB := setup(t, engine.ReaperResend)
+		defer closeNode(t, &nodeA)
+		defer closeNode(t, &nodeB)
+
+		outcome := CreateLedgerOutcome(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+		response, err := nodeA.CreateLedgerChannel(*nodeB.Address, 0, outcome)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The deadline restarts after each resend, so the objective keeps being reported until it progresses
+		waitForFailedObjective(t, &nodeA, response.Id)
+		waitForFailedObjective(t, &nodeA, response.Id)
+
+		objective, err := nodeA.GetObjectiveById(response.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objective.GetStatus() != protocols.Approved {
+			t.Fatalf("expected objective to still be in progress, got %v", objective.GetStatus())
+		}
+	})
+}
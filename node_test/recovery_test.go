@@ -0,0 +1,99 @@
+package node_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/statechannels/go-nitro/internal/logging"
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+	"github.com/tidwall/buntdb"
+)
+
+// droppingMessageService discards every outgoing message, as if the node crashed before its messages were sent
+type droppingMessageService struct {
+	messageservice.TestMessageService
+}
+
+func (d droppingMessageService) Send(msg protocols.Message) error {
+	return nil
+}
+
+func TestRecoverObjectiveAfterRestart(t *testing.T) {
+	logFile := "test_recover_objective_after_restart.log"
+	logging.SetupDefaultFileLogger(logFile, slog.LevelDebug)
+
+	sim, bindings, ethAccounts, err := chainservice.SetupSimulatedBackend(3)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainA, err := chainservice.NewSimulatedBackendChainService(sim, bindings, ethAccounts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainB, err := chainservice.NewSimulatedBackendChainService(sim, bindings, ethAccounts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broker := messageservice.NewBroker()
+
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+
+	storeA, err := store.NewDurableStore(ta.Alice.PrivateKey, dataFolder, buntdb.Config{SyncPolicy: buntdb.Always})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messageserviceA := droppingMessageService{messageservice.NewTestMessageService(ta.Alice.Address(), broker, 0)}
+	nodeA := node.New(messageserviceA, chainA, storeA, ta.Alice.Signer(), &engine.PermissivePolicy{}, engine.EngineOpts{})
+
+	nodeB, _ := setupNode(ta.Bob.PrivateKey, chainB, broker, 0, dataFolder)
+	defer closeNode(t, &nodeB)
+
+	outcome := CreateLedgerOutcome(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+	response, err := nodeA.CreateLedgerChannel(*nodeB.Address, 0, outcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	completedB := nodeB.ObjectiveCompleteChan(response.Id)
+
+	// Alice's first message to Bob is lost when her node "crashes"
+	closeNode(t, &nodeA)
+
+	anotherChainA, err := chainservice.NewSimulatedBackendChainService(sim, bindings, ethAccounts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	anotherStoreA, err := store.NewDurableStore(ta.Alice.PrivateKey, dataFolder, buntdb.Config{SyncPolicy: buntdb.Always})
+	if err != nil {
+		t.Fatal(err)
+	}
+	anotherMessageserviceA := messageservice.NewTestMessageService(ta.Alice.Address(), broker, 0)
+	anotherNodeA := node.New(anotherMessageserviceA, anotherChainA, anotherStoreA, ta.Alice.Signer(), &engine.PermissivePolicy{}, engine.EngineOpts{})
+	defer closeNode(t, &anotherNodeA)
+	completedA := anotherNodeA.ObjectiveCompleteChan(response.Id)
+
+	// The restarted node resends the lost message without any new message or chain event to prompt it
+	for _, completed := range []<-chan struct{}{completedA, completedB} {
+		select {
+		case <-completed:
+		case <-time.After(time.Minute):
+			t.Fatalf("objective %s was not recovered", response.Id)
+		}
+	}
+
+	_, ok, err := anotherStoreA.GetPendingSideEffects(response.Id)
+	if err != nil || ok {
+		t.Fatalf("expected the pending side effects of the completed objective to be removed, got %v, %v", ok, err)
+	}
+}
@@ -0,0 +1,202 @@
+package node_test
+
+import (
+	"context"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/statechannels/go-nitro/internal/logging"
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/protocols/directdefund"
+	"github.com/statechannels/go-nitro/protocols/directfund"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestReorgRollsBackChainData(t *testing.T) {
+	logFile := "test_reorg_rolls_back_chain_data.log"
+	logging.SetupDefaultFileLogger(logFile, slog.LevelDebug)
+
+	sim, bindings, ethAccounts, err := chainservice.SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainA, err := chainservice.NewSimulatedBackendChainService(sim, bindings, ethAccounts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bob follows another chain, so never sees Alice's deposit and never deposits himself. The funding objective is left waiting for his deposit.
+	otherSim, otherBindings, otherEthAccounts, err := chainservice.SetupSimulatedBackend(1)
+	defer closeSimulatedChain(t, otherSim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainB, err := chainservice.NewSimulatedBackendChainService(otherSim, otherBindings, otherEthAccounts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broker := messageservice.NewBroker()
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+
+	nodeA, storeA := setupNode(ta.Alice.PrivateKey, chainA, broker, 0, dataFolder)
+	defer closeNode(t, &nodeA)
+	nodeB, _ := setupNode(ta.Bob.PrivateKey, chainB, broker, 0, dataFolder)
+	defer closeNode(t, &nodeB)
+
+	ancestor, err := sim.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outcome := CreateLedgerOutcome(*nodeA.Address, *nodeB.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+	response, err := nodeA.CreateLedgerChannel(*nodeB.Address, 0, outcome)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	heldByChannel := func() *big.Int {
+		t.Helper()
+		c, ok := storeA.GetChannelById(response.ChannelId)
+		if !ok {
+			t.Fatalf("channel %s not found", response.ChannelId)
+		}
+		return c.OnChain.Holdings[types.Address{}]
+	}
+	deposited := big.NewInt(ledgerChannelDeposit)
+	waitForHoldings := func(want *big.Int, mine bool) {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			if heldByChannel().Cmp(want) == 0 {
+				return
+			}
+			if mine {
+				sim.Commit()
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Fatalf("expected %s to be held by the channel, got %s", want, heldByChannel())
+	}
+
+	// Alice's deposit is mined in the first block after the ancestor
+	waitForHoldings(deposited, false)
+
+	// A reorg replaces the block of the deposit. Alice's node rolls the channel back to the ancestor, then applies the deposit again
+	// once the outbox has resubmitted it on the canonical chain.
+	err = sim.Fork(ancestor.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForHoldings(big.NewInt(0), true)
+	waitForHoldings(deposited, true)
+
+	objective, err := nodeA.GetObjectiveById(response.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objective.GetStatus() != protocols.Approved {
+		t.Fatalf("expected the objective to be waiting for Bob's deposit, got status %v", objective.GetStatus())
+	}
+	progress, err := nodeA.GetObjectiveProgress(response.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest := progress[len(progress)-1]; latest.WaitingFor != directfund.WaitingForCompleteFunding {
+		t.Fatalf("expected the objective to be waiting for complete funding, got %s", latest.WaitingFor)
+	}
+}
+
+func TestReorgUndoesDirectDefundSpawnedByChallenge(t *testing.T) {
+	logFile := "test_reorg_undoes_direct_defund_spawned_by_challenge.log"
+	logging.SetupDefaultFileLogger(logFile, slog.LevelDebug)
+
+	sim, bindings, ethAccounts, err := chainservice.SetupSimulatedBackend(2)
+	defer closeSimulatedChain(t, sim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainA, err := chainservice.NewSimulatedBackendChainService(sim, bindings, ethAccounts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainB, err := chainservice.NewSimulatedBackendChainService(sim, bindings, ethAccounts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	broker := messageservice.NewBroker()
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+
+	nodeA, _ := setupNode(ta.Alice.PrivateKey, chainA, broker, 0, dataFolder)
+	defer closeNode(t, &nodeA)
+	nodeB, storeB := setupNode(ta.Bob.PrivateKey, chainB, broker, 0, dataFolder)
+	defer closeNode(t, &nodeB)
+
+	ledgerChannel := openLedgerChannel(t, nodeA, nodeB, types.Address{}, 1000)
+	ancestor, err := sim.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// spawnedAtBlock returns the block of the challenge which spawned Bob's direct defund objective, if any
+	spawnedAtBlock := func() (uint64, bool) {
+		objective, ok := storeB.GetObjectiveByChannelId(ledgerChannel)
+		if !ok {
+			return 0, false
+		}
+		ddfo, ok := objective.(*directdefund.Objective)
+		return ddfo.SpawnedAtBlock, ok && ddfo.SpawnedAtBlock != 0
+	}
+	mineUntil := func(done func() bool) {
+		t.Helper()
+		for i := 0; i < 100; i++ {
+			if done() {
+				return
+			}
+			sim.Commit()
+			time.Sleep(50 * time.Millisecond)
+		}
+		t.Fatal("Bob's node did not reach the expected state")
+	}
+
+	// Alice's challenge spawns a direct defund objective on Bob's node, which takes over governance from the consensus channel
+	_, err = nodeA.CloseLedgerChannel(ledgerChannel, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mineUntil(func() bool {
+		_, ok := spawnedAtBlock()
+		return ok
+	})
+	if _, err := storeB.GetConsensusChannelById(ledgerChannel); err == nil {
+		t.Fatal("expected the consensus channel to be destroyed once the challenge spawned the objective")
+	}
+
+	// A reorg replaces the block of the challenge. Bob's node undoes the objective and restores the consensus channel,
+	// until the challenge is mined again on the canonical chain and spawns the objective once more.
+	err = sim.Fork(ancestor.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	mineUntil(func() bool {
+		_, spawned := spawnedAtBlock()
+		_, err := storeB.GetConsensusChannelById(ledgerChannel)
+		return !spawned && err == nil
+	})
+	if _, ok := storeB.GetChannelById(ledgerChannel); ok {
+		t.Fatal("expected the channel to be destroyed once the consensus channel was restored")
+	}
+	mineUntil(func() bool {
+		_, ok := spawnedAtBlock()
+		return ok
+	})
+}
@@ -418,13 +418,20 @@ func setupNitroNodeWithRPCClient(
 		BootPeers: bootPeers,
 		PublicIp:  "127.0.0.1",
 		SCAddr:    *ourStore.GetAddress(),
+		Outbox:    ourStore,
 	})
 
+	signer, err := crypto.NewKeySigner(pkBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	node := node.New(
 		messageService,
 		chain,
 		ourStore,
-		&engine.PermissivePolicy{})
+		signer,
+		&engine.PermissivePolicy{}, engine.EngineOpts{})
 
 	var useNats bool
 	switch connectionType {
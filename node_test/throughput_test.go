@@ -0,0 +1,99 @@
+package node_test
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/statechannels/go-nitro/crypto"
+	"github.com/statechannels/go-nitro/internal/logging"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// BenchmarkHubThroughput measures how many ledger channels per second a hub funds with its peers on a simulated chain.
+// The hub handles objectives on unrelated ledger channels concurrently, but its chain service still submits one transaction at a time,
+// so funding channels at once only overlaps the rest of the work, such as exchanging states with peers and waiting for their deposits.
+func BenchmarkHubThroughput(b *testing.B) {
+	for _, inFlight := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("in-flight=%d", inFlight), func(b *testing.B) {
+			benchmarkHubThroughput(b, inFlight)
+		})
+	}
+}
+
+func benchmarkHubThroughput(b *testing.B, inFlight int) {
+	defaultLogger := slog.Default()
+	defer slog.SetDefault(defaultLogger)
+	logging.SetupDefaultLogger(io.Discard, slog.LevelError)
+
+	// The hub uses the first account, and each peer one of the others
+	sim, bindings, ethAccounts, err := chainservice.SetupSimulatedBackend(uint64(b.N + 1))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { closeSimulatedChain(b, sim) })
+	broker := messageservice.NewBroker()
+
+	newNode := func(account int) node.Node {
+		pk, address := crypto.GeneratePrivateKeyAndAddress()
+		signer, err := crypto.NewKeySigner(pk)
+		if err != nil {
+			b.Fatal(err)
+		}
+		chain, err := chainservice.NewSimulatedBackendChainService(sim, bindings, ethAccounts[account])
+		if err != nil {
+			b.Fatal(err)
+		}
+		n := node.New(
+			messageservice.NewTestMessageService(address, broker, 0),
+			chain,
+			store.NewMemStore(pk),
+			signer,
+			&engine.PermissivePolicy{},
+			engine.EngineOpts{},
+		)
+		b.Cleanup(func() { closeNode(b, &n) })
+		return n
+	}
+
+	hub := newNode(0)
+	peers := make(chan node.Node, b.N)
+	for i := 0; i < b.N; i++ {
+		peers <- newNode(i + 1)
+	}
+	close(peers)
+
+	b.ResetTimer()
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < inFlight; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for peer := range peers {
+				outcome := CreateLedgerOutcome(*hub.Address, *peer.Address, ledgerChannelDeposit, ledgerChannelDeposit, types.Address{})
+				response, err := hub.CreateLedgerChannel(*peer.Address, 0, outcome)
+				if err != nil {
+					b.Error(err)
+					return
+				}
+				hubCompleted, peerCompleted := hub.ObjectiveCompleteChan(response.Id), peer.ObjectiveCompleteChan(response.Id)
+				<-hubCompleted
+				<-peerCompleted
+			}
+		}()
+	}
+	wg.Wait()
+
+	b.StopTimer()
+	b.ReportMetric(float64(b.N)/time.Since(start).Seconds(), "objectives/s")
+}
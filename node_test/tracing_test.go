@@ -0,0 +1,85 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/tracing"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestObjectiveTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer func() {
+		// Spans are no longer recorded once the provider is shut down
+		if err := provider.Shutdown(context.Background()); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	chain := chainservice.NewMockChain()
+	broker := messageservice.NewBroker()
+	newNode := func(actor ta.Actor) node.Node {
+		msg := messageservice.NewTestMessageService(actor.Address(), broker, 0)
+		return node.New(msg, chainservice.NewMockChainService(chain, actor.Address()), store.NewMemStore(actor.PrivateKey), actor.Signer(), &engine.PermissivePolicy{}, engine.EngineOpts{})
+	}
+	nodeA := newNode(ta.Alice)
+	defer closeNode(t, &nodeA)
+	nodeI := newNode(ta.Irene)
+	defer closeNode(t, &nodeI)
+	nodeB := newNode(ta.Bob)
+	defer closeNode(t, &nodeB)
+
+	openLedgerChannel(t, nodeA, nodeI, types.Address{}, 0)
+	openLedgerChannel(t, nodeI, nodeB, types.Address{}, 0)
+
+	ctx, root := tracing.Tracer.Start(context.Background(), "test")
+	response, err := nodeA.WithContext(ctx).CreatePaymentChannel([]types.Address{*nodeI.Address}, *nodeB.Address, 0, initialPaymentOutcome(*nodeA.Address, *nodeB.Address, types.Address{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForObjectives(t, nodeA, nodeB, []node.Node{nodeI}, []protocols.ObjectiveId{response.Id})
+	root.End()
+
+	traceId := root.SpanContext().TraceID()
+	cranks := 0
+	senders := map[string]bool{}
+	for _, span := range recorder.Ended() {
+		attributes := map[string]string{}
+		for _, a := range span.Attributes() {
+			attributes[string(a.Key)] = a.Value.Emit()
+		}
+
+		switch {
+		case span.Name() == "Crank" && attributes["objective.id"] == string(response.Id):
+			if span.SpanContext().TraceID() != traceId {
+				t.Errorf("expected every crank of %s to be part of trace %s, got %s", response.Id, traceId, span.SpanContext().TraceID())
+			}
+			cranks++
+		case span.Name() == "handleMessage" && span.SpanContext().TraceID() == traceId:
+			senders[attributes["from"]] = true
+		}
+	}
+
+	// The objective is cranked at least once by each of Alice, Irene and Bob
+	if cranks < 3 {
+		t.Errorf("expected at least 3 cranks of %s, got %d", response.Id, cranks)
+	}
+	for _, n := range []node.Node{nodeA, nodeI, nodeB} {
+		if !senders[n.Address.String()] {
+			t.Errorf("expected the trace to include the handling of a message from %s", n.Address)
+		}
+	}
+}
@@ -28,6 +28,7 @@ type StoreType string
 const (
 	MemStore     StoreType = "MemStore"
 	DurableStore StoreType = "DurableStore"
+	SQLStore     StoreType = "SQLStore"
 )
 
 type ChainType string
@@ -92,6 +93,7 @@ type sharedTestInfrastructure struct {
 	simulatedChain chainservice.SimulatedChain
 	anvilChain     *chainservice.AnvilChain
 	laconicdChain  chainutils.LaconicdChain
+	localLaconicd  *chainservice.LocalLaconicd
 	bindings       *chainservice.Bindings
 	ethAccounts    []*bind.TransactOpts
 }
@@ -104,4 +106,10 @@ func (sti *sharedTestInfrastructure) Close(t *testing.T) {
 	if sti.anvilChain != nil {
 		utils.StopCommands(sti.anvilChain.AnvilCmd)
 	}
+
+	if sti.localLaconicd != nil {
+		if err := sti.localLaconicd.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
 }
@@ -0,0 +1,90 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	interRpc "github.com/statechannels/go-nitro/internal/rpc"
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+	"github.com/statechannels/go-nitro/watchtower"
+	"github.com/tidwall/buntdb"
+)
+
+const WATCHTOWER_RPC_PORT = 4120
+
+func TestWatchtowerPusherUploadsPaymentChannels(t *testing.T) {
+	chain := chainservice.NewMockChain()
+	broker := messageservice.NewBroker()
+	newNode := func(actor ta.Actor) node.Node {
+		msg := messageservice.NewTestMessageService(actor.Address(), broker, 0)
+		return node.New(msg, chainservice.NewMockChainService(chain, actor.Address()), store.NewMemStore(actor.PrivateKey), actor.Signer(), &engine.PermissivePolicy{}, engine.EngineOpts{})
+	}
+	nodeA := newNode(ta.Alice)
+	defer closeNode(t, &nodeA)
+	nodeI := newNode(ta.Irene)
+	defer closeNode(t, &nodeI)
+	nodeB := newNode(ta.Bob)
+	defer closeNode(t, &nodeB)
+
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	defer cleanup()
+	wtStore, err := watchtower.NewDurableStore(dataFolder, buntdb.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wt := watchtower.New(chainservice.NewMockChainService(chain, ta.Ivan.Address()), wtStore, 0)
+	defer wt.Close()
+
+	wtServer, err := interRpc.InitializeWatchtowerRpcServer(wt, WATCHTOWER_RPC_PORT, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wtServer.Close()
+
+	ledgerId := openLedgerChannel(t, nodeA, nodeI, types.Address{}, 0)
+	openLedgerChannel(t, nodeI, nodeB, types.Address{}, 0)
+
+	pusher, err := interRpc.StartWatchtowerPusher(&nodeA, wtServer.Url(), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pusher.Close()
+
+	response, err := nodeA.CreatePaymentChannel([]types.Address{*nodeI.Address}, *nodeB.Address, 0, initialPaymentOutcome(*nodeA.Address, *nodeB.Address, types.Address{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForObjectives(t, nodeA, nodeB, []node.Node{nodeI}, []protocols.ObjectiveId{response.Id})
+
+	// The ledger channel existing on startup, and the payment channel created since, are both uploaded
+	for _, channelId := range []types.Destination{ledgerId, response.ChannelId} {
+		expected, err := nodeA.GetSignedState(channelId)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var watched watchtower.WatchedState
+		deadline := time.Now().Add(10 * time.Second)
+		for time.Now().Before(deadline) {
+			watched, err = wt.GetWatchedState(channelId)
+			if err == nil && watched.SignedState.State().TurnNum >= expected.State().TurnNum {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if err != nil {
+			t.Fatalf("expected the state of channel %s to reach the watchtower: %v", channelId, err)
+		}
+		if watched.SignedState.State().TurnNum != expected.State().TurnNum {
+			t.Fatalf("expected the watchtower to hold turn %d of channel %s, got %d", expected.State().TurnNum, channelId, watched.SignedState.State().TurnNum)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package node_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ta "github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/node/engine/messageservice"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/node/notifier"
+	"github.com/statechannels/go-nitro/payments"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// webhookReceiver records the events POSTed to it, responding with a server error while it is failing
+type webhookReceiver struct {
+	t       *testing.T
+	secret  string
+	failing atomic.Bool
+
+	mu     sync.Mutex
+	events []notifier.WebhookEvent
+}
+
+func (wr *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if wr.failing.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		wr.t.Error(err)
+		return
+	}
+	sig, err := hex.DecodeString(r.Header.Get(notifier.WebhookSignatureHeader))
+	if err != nil {
+		wr.t.Error(err)
+		return
+	}
+	mac := hmac.New(sha256.New, []byte(wr.secret))
+	mac.Write(body)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		wr.t.Error("expected the event to be signed with the secret of the endpoint")
+		return
+	}
+
+	var event notifier.WebhookEvent
+	err = json.Unmarshal(body, &event)
+	if err != nil {
+		wr.t.Error(err)
+		return
+	}
+	if event.Node.String() != r.Header.Get(notifier.WebhookAddressHeader) {
+		wr.t.Errorf("expected the event to be raised by %s, got %s", r.Header.Get(notifier.WebhookAddressHeader), event.Node)
+	}
+
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	wr.events = append(wr.events, event)
+}
+
+// waitForEvent returns the first event of the given type which satisfies match
+func (wr *webhookReceiver) waitForEvent(eventType notifier.WebhookEventType, match func(data json.RawMessage) bool) notifier.WebhookEvent {
+	wr.t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		wr.mu.Lock()
+		for _, e := range wr.events {
+			if e.Type == eventType && match(e.Data) {
+				wr.mu.Unlock()
+				return e
+			}
+		}
+		wr.mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+	}
+	wr.t.Fatalf("timed out waiting for a %s event", eventType)
+	return notifier.WebhookEvent{}
+}
+
+func TestWebhooks(t *testing.T) {
+	chain := chainservice.NewMockChain()
+	broker := messageservice.NewBroker()
+	newNode := func(actor ta.Actor) node.Node {
+		msg := messageservice.NewTestMessageService(actor.Address(), broker, 0)
+		return node.New(msg, chainservice.NewMockChainService(chain, actor.Address()), store.NewMemStore(actor.PrivateKey), actor.Signer(), &engine.PermissivePolicy{}, engine.EngineOpts{})
+	}
+	nodeA := newNode(ta.Alice)
+	defer closeNode(t, &nodeA)
+	nodeI := newNode(ta.Irene)
+	defer closeNode(t, &nodeI)
+	nodeB := newNode(ta.Bob)
+	defer closeNode(t, &nodeB)
+
+	billing := &webhookReceiver{t: t, secret: "billing secret"}
+	billingServer := httptest.NewServer(billing)
+	defer billingServer.Close()
+	err := nodeB.AddWebhook(notifier.WebhookOpts{Url: billingServer.URL, Secret: billing.secret})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The flaky endpoint only subscribes to vouchers, and gives up quickly
+	flaky := &webhookReceiver{t: t, secret: "flaky secret"}
+	flaky.failing.Store(true)
+	flakyServer := httptest.NewServer(flaky)
+	defer flakyServer.Close()
+	err = nodeB.AddWebhook(notifier.WebhookOpts{
+		Url:         flakyServer.URL,
+		Secret:      flaky.secret,
+		Events:      []notifier.WebhookEventType{notifier.VoucherReceivedEvent},
+		MaxAttempts: 3,
+		MinBackoff:  10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := nodeB.AddWebhook(notifier.WebhookOpts{Url: "ws://localhost:1234", Secret: "secret"}); err == nil {
+		t.Fatal("expected a webhook with a non-http url to be refused")
+	}
+	if err := nodeB.AddWebhook(notifier.WebhookOpts{Url: "http://localhost:1234"}); err == nil {
+		t.Fatal("expected a webhook without a secret to be refused")
+	}
+
+	ledgerId := openLedgerChannel(t, nodeI, nodeB, types.Address{}, 0)
+	openLedgerChannel(t, nodeA, nodeI, types.Address{}, 0)
+	billing.waitForEvent(notifier.LedgerChannelUpdatedEvent, func(data json.RawMessage) bool {
+		var info struct{ ID types.Destination }
+		return json.Unmarshal(data, &info) == nil && info.ID == ledgerId
+	})
+
+	response, err := nodeA.CreatePaymentChannel([]types.Address{*nodeI.Address}, *nodeB.Address, 0, initialPaymentOutcome(*nodeA.Address, *nodeB.Address, types.Address{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitForObjectives(t, nodeA, nodeB, []node.Node{nodeI}, []protocols.ObjectiveId{response.Id})
+	billing.waitForEvent(notifier.ObjectiveCompletedEvent, func(data json.RawMessage) bool {
+		var completed notifier.ObjectiveCompletedData
+		return json.Unmarshal(data, &completed) == nil && completed.ObjectiveId == response.Id
+	})
+
+	err = nodeA.Pay(response.ChannelId, big.NewInt(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	isVoucher := func(data json.RawMessage) bool {
+		var v payments.Voucher
+		return json.Unmarshal(data, &v) == nil && v.ChannelId == response.ChannelId && v.Amount.Cmp(big.NewInt(5)) == 0
+	}
+	voucherEvent := billing.waitForEvent(notifier.VoucherReceivedEvent, isVoucher)
+
+	// Once its attempts are exhausted, the voucher goes to the dead-letter store
+	var deadLetters []store.WebhookDeadLetter
+	deadline := time.Now().Add(10 * time.Second)
+	for len(deadLetters) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		deadLetters, err = nodeB.GetWebhookDeadLetters()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(deadLetters) != 1 {
+		t.Fatalf("expected 1 dead letter, got %+v", deadLetters)
+	}
+	if d := deadLetters[0]; d.Url != flakyServer.URL || d.Id != voucherEvent.Id || d.Attempts != 3 {
+		t.Fatalf("expected the voucher event %s to the flaky endpoint to be dead-lettered after 3 attempts, got %+v", voucherEvent.Id, d)
+	}
+
+	// Redelivered events keep their id
+	flaky.failing.Store(false)
+	queued, err := nodeB.RedeliverWebhookDeadLetters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if queued != 1 {
+		t.Fatalf("expected 1 dead letter to be redelivered, got %d", queued)
+	}
+	redelivered := flaky.waitForEvent(notifier.VoucherReceivedEvent, isVoucher)
+	if redelivered.Id != voucherEvent.Id {
+		t.Errorf("expected the redelivered event to have id %s, got %s", voucherEvent.Id, redelivered.Id)
+	}
+	deadLetters, err = nodeB.GetWebhookDeadLetters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deadLetters) != 0 {
+		t.Errorf("expected no dead letters after redelivery, got %+v", deadLetters)
+	}
+}
@@ -16,7 +16,12 @@ import {
   parseAssetData as parseAssetsData,
   parseSwapAssetsData,
 } from "./utils";
-import { AssetData, ConfirmSwapAction, CounterChallengeAction } from "./types";
+import {
+  AssetData,
+  ConfirmSwapAction,
+  CounterChallengeAction,
+  ObjectiveStatus,
+} from "./types";
 import { ZERO_ETHEREUM_ADDRESS } from "./constants";
 
 yargs(hideBin(process.argv))
@@ -996,16 +1001,55 @@ yargs(hideBin(process.argv))
     }
   )
   .command(
-    "retry-objective-tx <objectiveId>",
-    "Retries transaction for given objective",
+    "get-pending-approvals",
+    "Get objectives waiting for an operator to approve or reject them",
+    async () => {},
+    async (yargs) => {
+      const rpcPort = yargs.p;
+      const rpcHost = yargs.h;
+      const isSecure = yargs.s;
+
+      const rpcClient = await NitroRpcClient.CreateHttpNitroClient(
+        getRPCUrl(rpcHost, rpcPort),
+        isSecure
+      );
+      const pendingApprovals = await rpcClient.GetPendingApprovals();
+      prettyJson(pendingApprovals);
+
+      await rpcClient.Close();
+      process.exit(0);
+    }
+  )
+  .command(
+    "get-outbox-depths",
+    "Get the number of messages waiting to be acknowledged by each peer",
+    async () => {},
+    async (yargs) => {
+      const rpcPort = yargs.p;
+      const rpcHost = yargs.h;
+      const isSecure = yargs.s;
+
+      const rpcClient = await NitroRpcClient.CreateHttpNitroClient(
+        getRPCUrl(rpcHost, rpcPort),
+        isSecure
+      );
+      const outboxDepths = await rpcClient.GetOutboxDepths();
+      prettyJson(outboxDepths);
+
+      await rpcClient.Close();
+      process.exit(0);
+    }
+  )
+  .command(
+    "approve-objective <objectiveId>",
+    "Approves an objective waiting for operator approval",
     (yargsBuilder) => {
       return yargsBuilder.positional("objectiveId", {
-        describe: "The id of the objective to send transaction for",
+        describe: "The id of the objective to approve",
         type: "string",
         demandOption: true,
       });
     },
-
     async (yargs) => {
       const rpcPort = yargs.p;
       const rpcHost = yargs.h;
@@ -1015,25 +1059,117 @@ yargs(hideBin(process.argv))
         getRPCUrl(rpcHost, rpcPort),
         isSecure
       );
+      const id = await rpcClient.ApproveObjective(yargs.objectiveId);
+
+      console.log(`Approved objective ${id}`);
+      await rpcClient.Close();
+      process.exit(0);
+    }
+  )
+  .command(
+    "reject-objective <objectiveId>",
+    "Rejects an objective waiting for operator approval",
+    (yargsBuilder) => {
+      return yargsBuilder.positional("objectiveId", {
+        describe: "The id of the objective to reject",
+        type: "string",
+        demandOption: true,
+      });
+    },
+    async (yargs) => {
+      const rpcPort = yargs.p;
+      const rpcHost = yargs.h;
+      const isSecure = yargs.s;
 
-      const id = await rpcClient.RetryObjectiveTx(yargs.objectiveId);
+      const rpcClient = await NitroRpcClient.CreateHttpNitroClient(
+        getRPCUrl(rpcHost, rpcPort),
+        isSecure
+      );
+      const id = await rpcClient.RejectObjective(yargs.objectiveId);
 
-      console.log(`Transaction retried for objective ${id}`);
+      console.log(`Rejected objective ${id}`);
       await rpcClient.Close();
       process.exit(0);
     }
   )
   .command(
-    "retry-tx <txHash>",
-    "Retries transaction with given transaction hash",
+    "cancel-objective <objectiveId>",
+    "Cancels an objective which has not finished",
     (yargsBuilder) => {
-      return yargsBuilder.positional("txHash", {
-        describe: "Hash of transaction to retry",
+      return yargsBuilder.positional("objectiveId", {
+        describe: "The id of the objective to cancel",
         type: "string",
         demandOption: true,
       });
     },
+    async (yargs) => {
+      const rpcPort = yargs.p;
+      const rpcHost = yargs.h;
+      const isSecure = yargs.s;
+
+      const rpcClient = await NitroRpcClient.CreateHttpNitroClient(
+        getRPCUrl(rpcHost, rpcPort),
+        isSecure
+      );
+      const id = await rpcClient.CancelObjective(yargs.objectiveId);
+
+      console.log(`Cancelled objective ${id}`);
+      await rpcClient.Close();
+      process.exit(0);
+    }
+  )
+  .command(
+    "get-objectives",
+    "Get objectives, along with what each is currently waiting for",
+    (yargsBuilder) => {
+      return yargsBuilder
+        .option("status", {
+          describe: "Only get objectives with these statuses",
+          type: "array",
+          choices: ["Unapproved", "Approved", "Rejected", "Completed"],
+        })
+        .option("type", {
+          describe: 'Only get objectives of this type, e.g. "VirtualDefund"',
+          type: "string",
+        })
+        .option("channelId", {
+          describe: "Only get objectives which own this channel",
+          type: "string",
+        });
+    },
+    async (yargs) => {
+      const rpcPort = yargs.p;
+      const rpcHost = yargs.h;
+      const isSecure = yargs.s;
 
+      const rpcClient = await NitroRpcClient.CreateHttpNitroClient(
+        getRPCUrl(rpcHost, rpcPort),
+        isSecure
+      );
+      const statuses = yargs.status?.map(
+        (status) => ObjectiveStatus[status as keyof typeof ObjectiveStatus]
+      );
+      const objectives = await rpcClient.GetObjectives(
+        statuses,
+        yargs.type,
+        yargs.channelId
+      );
+      prettyJson(objectives);
+
+      await rpcClient.Close();
+      process.exit(0);
+    }
+  )
+  .command(
+    "get-objective-progress <objectiveId>",
+    "Get the progress history of an objective",
+    (yargsBuilder) => {
+      return yargsBuilder.positional("objectiveId", {
+        describe: "The id of the objective",
+        type: "string",
+        demandOption: true,
+      });
+    },
     async (yargs) => {
       const rpcPort = yargs.p;
       const rpcHost = yargs.h;
@@ -1043,10 +1179,35 @@ yargs(hideBin(process.argv))
         getRPCUrl(rpcHost, rpcPort),
         isSecure
       );
+      const progress = await rpcClient.GetObjectiveProgress(yargs.objectiveId);
+      prettyJson(progress);
+
+      await rpcClient.Close();
+      process.exit(0);
+    }
+  )
+  .command(
+    "get-policy-decision <objectiveId>",
+    "Get the decision the policy maker made about an objective, and why",
+    (yargsBuilder) => {
+      return yargsBuilder.positional("objectiveId", {
+        describe: "The id of the objective",
+        type: "string",
+        demandOption: true,
+      });
+    },
+    async (yargs) => {
+      const rpcPort = yargs.p;
+      const rpcHost = yargs.h;
+      const isSecure = yargs.s;
 
-      const txHash = await rpcClient.RetryTx(yargs.txHash);
+      const rpcClient = await NitroRpcClient.CreateHttpNitroClient(
+        getRPCUrl(rpcHost, rpcPort),
+        isSecure
+      );
+      const decision = await rpcClient.GetPolicyDecision(yargs.objectiveId);
+      prettyJson(decision);
 
-      console.log(`Transaction with hash ${txHash} retried`);
       await rpcClient.Close();
       process.exit(0);
     }
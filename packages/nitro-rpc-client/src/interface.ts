@@ -3,8 +3,10 @@ import {
   ChannelStatus,
   CounterChallengeAction,
   CounterChallengeResult,
+  FailedObjective,
   LedgerChannelInfo,
   ObjectiveResponse,
+  ObjectiveStatus,
   PaymentChannelInfo,
   PaymentPayload,
   ReceiveVoucherResult,
@@ -224,6 +226,12 @@ interface syncAPI {
    * WaitForObjectiveToComplete blocks until the objective with the given ID is complete.
    */
   WaitForObjectiveToComplete(objectiveId: string): Promise<void>;
+
+  /**
+   * onObjectiveFailed attaches a callback which is triggered whenever an objective fails, with the reason it failed.
+   * Returns a cleanup function which can be used to remove the subscription.
+   */
+  onObjectiveFailed(callback: (failed: FailedObjective) => void): () => void;
 }
 
 export interface RpcClientApi
@@ -251,19 +259,65 @@ export interface RpcClientApi
   Close(): Promise<void>;
 
   /**
-   * RetryObjectiveTx retries failed txs related to given objective.
+   * GetPendingApprovals queries the RPC server for objectives waiting for an operator to approve or reject them.
+   *
+   * @returns A JSON encoded list of the pending objectives
+   */
+  GetPendingApprovals(): Promise<string>;
+  /**
+   * GetOutboxDepths queries the RPC server for the number of messages waiting to be acknowledged by each peer.
+   *
+   * @returns A JSON encoded map from peer address to the number of unacknowledged messages
+   */
+  GetOutboxDepths(): Promise<string>;
+  /**
+   * ApproveObjective approves an objective which is waiting for operator approval.
+   *
+   * @param objectiveId - The id of the objective to approve
+   * @returns The id of the approved objective
+   */
+  ApproveObjective(objectiveId: string): Promise<string>;
+  /**
+   * RejectObjective rejects an objective which is waiting for operator approval.
+   *
+   * @param objectiveId - The id of the objective to reject
+   * @returns The id of the rejected objective
+   */
+  RejectObjective(objectiveId: string): Promise<string>;
+  /**
+   * CancelObjective cancels an objective which has not finished. Ledger funding proposed by a cancelled virtual or swap funding objective is rolled back.
+   *
+   * @param objectiveId - The id of the objective to cancel
+   * @returns The id of the cancelled objective
+   */
+  CancelObjective(objectiveId: string): Promise<string>;
+  /**
+   * GetObjectives queries the RPC server for objectives, along with what each is currently waiting for.
+   *
+   * @param statuses - Only return objectives with one of these statuses. All statuses if omitted.
+   * @param type - Only return objectives of this type, e.g. "VirtualDefund". All types if omitted.
+   * @param channelId - Only return objectives which own this channel. All channels if omitted.
+   * @returns A JSON encoded list of the matching objectives
+   */
+  GetObjectives(
+    statuses?: ObjectiveStatus[],
+    type?: string,
+    channelId?: string
+  ): Promise<string>;
+  /**
+   * GetObjectiveProgress queries the RPC server for the progress history of an objective: its status changes, what it waited for and the side effects it produced.
    *
-   * @param objectiveId - The objective to retry failed txs
-   * @returns objectiveId of the objective for which tx was retried
+   * @param objectiveId - The id of the objective
+   * @returns A JSON encoded list of progress entries, oldest first
    */
-  RetryObjectiveTx(objectiveId: string): Promise<string>;
+  GetObjectiveProgress(objectiveId: string): Promise<string>;
   /**
-   * RetryTx retries tx with given tx hash if it is failed.
+   * GetPolicyDecision queries the RPC server for the decision the policy maker made about an objective, and the reason it was made.
    *
-   * @param txHash - Hash of the tx to retry
-   * @returns objectiveId of the objective for which tx was retried
+   * @param objectiveId - The id of the objective
+   * @returns A JSON encoded policy decision
    */
-  RetryTx(txHash: string): Promise<string>;
+  GetPolicyDecision(objectiveId: string): Promise<string>;
   /**
    * CounterChallenge responds to the ongoing challenge on a channel with either `challenge` or `checkpoint` actions.
    *
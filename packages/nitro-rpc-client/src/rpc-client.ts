@@ -17,6 +17,7 @@ import {
   CounterChallengeAction,
   CounterChallengeResult,
   ObjectiveCompleteNotification,
+  FailedObjective,
   MirrorBridgedDefundObjectiveRequest,
   GetNodeInfo,
   AssetData,
@@ -27,6 +28,7 @@ import {
   ConfirmSwapAction,
   ConfirmSwapResult,
   SwapChannelInfo,
+  ObjectiveStatus,
 } from "./types";
 import { Transport } from "./transport";
 import { createOutcome, generateRequest } from "./utils";
@@ -150,6 +152,15 @@ export class NitroRpcClient implements RpcClientApi {
     };
   }
 
+  public onObjectiveFailed(
+    callback: (failed: FailedObjective) => void
+  ): () => void {
+    this.transport.Notifications.on("objective_failed", callback);
+    return () => {
+      this.transport.Notifications.off("objective_failed", callback);
+    };
+  }
+
   public async CreateLedgerChannel(
     counterParty: string,
     assetsData: AssetData[],
@@ -166,14 +177,6 @@ export class NitroRpcClient implements RpcClientApi {
     return this.sendRequest("create_ledger_channel", payload);
   }
 
-  public async RetryObjectiveTx(objectiveId: string): Promise<string> {
-    return this.sendRequest("retry_objective_tx", { ObjectiveId: objectiveId });
-  }
-
-  public async RetryTx(txHash: string): Promise<string> {
-    return this.sendRequest("retry_tx", { TxHash: txHash });
-  }
-
   public async CreateSwapChannel(
     counterParty: string,
     intermediaries: string[],
@@ -396,6 +399,50 @@ export class NitroRpcClient implements RpcClientApi {
     });
   }
 
+  public async GetPendingApprovals(): Promise<string> {
+    return this.sendRequest("get_pending_approvals", {});
+  }
+
+  public async GetOutboxDepths(): Promise<string> {
+    return this.sendRequest("get_outbox_depths", {});
+  }
+
+  public async ApproveObjective(objectiveId: string): Promise<string> {
+    return this.sendRequest("approve_objective", { ObjectiveId: objectiveId });
+  }
+
+  public async RejectObjective(objectiveId: string): Promise<string> {
+    return this.sendRequest("reject_objective", { ObjectiveId: objectiveId });
+  }
+
+  public async CancelObjective(objectiveId: string): Promise<string> {
+    return this.sendRequest("cancel_objective", { ObjectiveId: objectiveId });
+  }
+
+  public async GetObjectives(
+    statuses?: ObjectiveStatus[],
+    type?: string,
+    channelId?: string
+  ): Promise<string> {
+    return this.sendRequest("get_objectives", {
+      Statuses: statuses,
+      Type: type,
+      ChannelId: channelId,
+    });
+  }
+
+  public async GetObjectiveProgress(objectiveId: string): Promise<string> {
+    return this.sendRequest("get_objective_progress", {
+      ObjectiveId: objectiveId,
+    });
+  }
+
+  public async GetPolicyDecision(objectiveId: string): Promise<string> {
+    return this.sendRequest("get_policy_decision", {
+      ObjectiveId: objectiveId,
+    });
+  }
+
   public async GetL2ObjectiveFromL1(l1ObjectiveId: string): Promise<string> {
     return this.sendRequest("get_l2_objective_from_l1", {
       L1ObjectiveId: l1ObjectiveId,
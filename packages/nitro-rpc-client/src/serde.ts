@@ -3,12 +3,14 @@ import JSONbig from "json-bigint";
 
 import {
   Balance,
+  ChallengeResponse,
   ChannelMode,
   ChannelStatus,
   ConfirmSwapAction,
   ConfirmSwapResult,
   CounterChallengeAction,
   CounterChallengeResult,
+  FailedObjective,
   LedgerChannelInfo,
   PaymentChannelInfo,
   RPCNotification,
@@ -248,9 +250,15 @@ export function getAndValidateResult<T extends RequestMethod>(
       );
     case "get_pending_bridge_txs":
     case "get_l2_objective_from_l1":
-    case "retry_objective_tx":
-    case "retry_tx":
     case "get_objective":
+    case "get_pending_approvals":
+    case "get_outbox_depths":
+    case "approve_objective":
+    case "reject_objective":
+    case "cancel_objective":
+    case "get_objectives":
+    case "get_objective_progress":
+    case "get_policy_decision":
     case "get_auth_token":
     case "close_ledger_channel":
     case "close_bridge_channel":
@@ -380,6 +388,10 @@ export function getAndValidateNotification<T extends RPCNotification["method"]>(
       );
     case "objective_completed":
       return data as string;
+    case "challenge_responded":
+      return data as ChallengeResponse;
+    case "objective_failed":
+      return data as FailedObjective;
     default:
       throw new Error(`Unknown method: ${method}`);
   }
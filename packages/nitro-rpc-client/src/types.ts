@@ -161,18 +161,6 @@ export type DirectFundRequest = JsonRpcRequest<
   "create_ledger_channel",
   DirectFundPayload
 >;
-export type RetryObjectiveTxRequest = JsonRpcRequest<
-  "retry_objective_tx",
-  {
-    ObjectiveId: string;
-  }
->;
-export type RetryTxRequest = JsonRpcRequest<
-  "retry_tx",
-  {
-    TxHash: string;
-  }
->;
 export type PaymentRequest = JsonRpcRequest<"pay", PaymentPayload>;
 
 export type SwapRequest = JsonRpcRequest<"swap_initiate", SwapInitiatePayload>;
@@ -247,6 +235,60 @@ export type GetObjectiveRequest = JsonRpcRequest<
   }
 >;
 
+export type GetPendingApprovalsRequest = JsonRpcRequest<
+  "get_pending_approvals",
+  Record<string, never>
+>;
+
+export type GetOutboxDepthsRequest = JsonRpcRequest<
+  "get_outbox_depths",
+  Record<string, never>
+>;
+
+export type ApproveObjectiveRequest = JsonRpcRequest<
+  "approve_objective",
+  {
+    ObjectiveId: string;
+  }
+>;
+
+export type RejectObjectiveRequest = JsonRpcRequest<
+  "reject_objective",
+  {
+    ObjectiveId: string;
+  }
+>;
+
+export type CancelObjectiveRequest = JsonRpcRequest<
+  "cancel_objective",
+  {
+    ObjectiveId: string;
+  }
+>;
+
+export type GetObjectivesRequest = JsonRpcRequest<
+  "get_objectives",
+  {
+    Statuses?: ObjectiveStatus[];
+    Type?: string;
+    ChannelId?: string;
+  }
+>;
+
+export type GetObjectiveProgressRequest = JsonRpcRequest<
+  "get_objective_progress",
+  {
+    ObjectiveId: string;
+  }
+>;
+
+export type GetPolicyDecisionRequest = JsonRpcRequest<
+  "get_policy_decision",
+  {
+    ObjectiveId: string;
+  }
+>;
+
 export type GetPendingBridgeTxsRequest = JsonRpcRequest<
   "get_pending_bridge_txs",
   {
@@ -316,8 +358,6 @@ export type VersionResponse = JsonRpcResponse<string>;
 export type GetNodeInfoResponse = JsonRpcResponse<GetNodeInfo>;
 export type GetAddressResponse = JsonRpcResponse<string>;
 export type DirectFundResponse = JsonRpcResponse<ObjectiveResponse>;
-export type RetryObjectiveTxResponse = JsonRpcResponse<string>;
-export type RetryTxResponse = JsonRpcResponse<string>;
 export type DirectDefundResponse = JsonRpcResponse<string>;
 export type MirrorBridgedDefundResponse = JsonRpcResponse<string>;
 export type BridgedDefundResponse = JsonRpcResponse<string>;
@@ -332,6 +372,14 @@ export type GetSwapChannelsByLedgerResponse = JsonRpcResponse<
   SwapChannelInfo[]
 >;
 export type GetObjectiveResponse = JsonRpcResponse<string>;
+export type GetPendingApprovalsResponse = JsonRpcResponse<string>;
+export type GetOutboxDepthsResponse = JsonRpcResponse<string>;
+export type ApproveObjectiveResponse = JsonRpcResponse<string>;
+export type RejectObjectiveResponse = JsonRpcResponse<string>;
+export type CancelObjectiveResponse = JsonRpcResponse<string>;
+export type GetObjectivesResponse = JsonRpcResponse<string>;
+export type GetObjectiveProgressResponse = JsonRpcResponse<string>;
+export type GetPolicyDecisionResponse = JsonRpcResponse<string>;
 export type GetL2ObjectiveFromL1Response = JsonRpcResponse<string>;
 export type GetPendingBridgeTxsResponse = JsonRpcResponse<string>;
 export type CreateVoucherResponse = JsonRpcResponse<Voucher>;
@@ -343,8 +391,6 @@ export type ReceiveVoucherResponse = JsonRpcResponse<ReceiveVoucherResult>;
 export type RPCRequestAndResponses = {
   get_auth_token: [GetAuthTokenRequest, GetAuthTokenResponse];
   create_ledger_channel: [DirectFundRequest, DirectFundResponse];
-  retry_objective_tx: [RetryObjectiveTxRequest, RetryObjectiveTxResponse];
-  retry_tx: [RetryTxRequest, RetryTxResponse];
   close_ledger_channel: [DirectDefundRequest, DirectDefundResponse];
   close_bridge_channel: [BridgedDefundRequest, BridgedDefundResponse];
   mirror_bridged_defund: [
@@ -386,6 +432,20 @@ export type RPCRequestAndResponses = {
     GetSwapChannelsByLedgerResponse
   ];
   get_objective: [GetObjectiveRequest, GetObjectiveResponse];
+  get_pending_approvals: [
+    GetPendingApprovalsRequest,
+    GetPendingApprovalsResponse
+  ];
+  get_outbox_depths: [GetOutboxDepthsRequest, GetOutboxDepthsResponse];
+  approve_objective: [ApproveObjectiveRequest, ApproveObjectiveResponse];
+  reject_objective: [RejectObjectiveRequest, RejectObjectiveResponse];
+  cancel_objective: [CancelObjectiveRequest, CancelObjectiveResponse];
+  get_objectives: [GetObjectivesRequest, GetObjectivesResponse];
+  get_objective_progress: [
+    GetObjectiveProgressRequest,
+    GetObjectiveProgressResponse
+  ];
+  get_policy_decision: [GetPolicyDecisionRequest, GetPolicyDecisionResponse];
   get_l2_objective_from_l1: [
     GetL2ObjectiveFromL1Request,
     GetL2ObjectiveFromL1Response
@@ -411,7 +471,9 @@ export type RPCResponse =
 export type RPCNotification =
   | ObjectiveCompleteNotification
   | PaymentChannelUpdatedNotification
-  | LedgerChannelUpdatedNotification;
+  | LedgerChannelUpdatedNotification
+  | ChallengeRespondedNotification
+  | ObjectiveFailedNotification;
 export type NotificationMethod = RPCNotification["method"];
 export type NotificationParams = RPCNotification["params"];
 export type PaymentChannelUpdatedNotification = JsonRpcNotification<
@@ -429,6 +491,59 @@ export type ObjectiveCompleteNotification = JsonRpcNotification<
   string
 >;
 
+export type ChallengeRespondedNotification = JsonRpcNotification<
+  "challenge_responded",
+  ChallengeResponse
+>;
+
+export type ObjectiveFailedNotification = JsonRpcNotification<
+  "objective_failed",
+  FailedObjective
+>;
+
+/**
+ * Classifies why an objective failed or was rejected.
+ */
+/**
+ * The status of an objective, as encoded by the node
+ */
+export enum ObjectiveStatus {
+  Unapproved,
+  Approved,
+  Rejected,
+  Completed,
+}
+
+export type FailureCode =
+  | "PolicyRejection"
+  | "InsufficientLedgerFunds"
+  | "InvalidSignature"
+  | "Timeout"
+  | "Cancellation"
+  | "OtherFailure";
+
+/**
+ * An objective which failed or was rejected, and why.
+ */
+export type FailedObjective = {
+  ObjectiveId: string;
+  Reason: {
+    Code: FailureCode;
+    Message: string;
+  };
+};
+
+/**
+ * A checkpoint or counter challenge the node submitted in response to a challenge registered against a stale state.
+ */
+export type ChallengeResponse = {
+  ChannelId: string;
+  Action: CounterChallengeAction;
+  ChallengedTurnNum: number;
+  ResponseTurnNum: number;
+  FinalizesAt: number;
+};
+
 /**
  * Outcome related types
  */
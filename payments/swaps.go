@@ -115,6 +115,16 @@ func (s Swap) Sign(secretKey []byte) (state.Signature, error) {
 	return nc.SignEthereumMessage(hash.Bytes(), secretKey)
 }
 
+// SignWith generates an ECDSA signature on the swap using the supplied signer, as Sign does
+func (s Swap) SignWith(signer nc.Signer) (state.Signature, error) {
+	hash, error := s.Hash()
+	if error != nil {
+		return state.Signature{}, error
+	}
+
+	return nc.SignEthereumMessageWith(hash.Bytes(), signer)
+}
+
 func (s Swap) AddSignature(sig state.Signature, myIndex uint) {
 	s.Sigs[myIndex] = sig
 }
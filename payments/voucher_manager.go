@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/types"
 )
 
@@ -50,7 +51,7 @@ func (vm *VoucherManager) Remove(channelId types.Destination) error {
 
 // Pay will deduct amount from balance and add it to paid, returning a signed voucher for the
 // total amount paid.
-func (vm *VoucherManager) Pay(channelId types.Destination, amount *big.Int, pk []byte) (Voucher, error) {
+func (vm *VoucherManager) Pay(channelId types.Destination, amount *big.Int, signer crypto.Signer) (Voucher, error) {
 	vInfo, err := vm.store.GetVoucherInfo(channelId)
 	if err != nil {
 		return Voucher{}, fmt.Errorf("channel not registered: %w", err)
@@ -66,7 +67,7 @@ func (vm *VoucherManager) Pay(channelId types.Destination, amount *big.Int, pk [
 	newAmount := big.NewInt(0).Add(vInfo.LargestVoucher.Amount, amount)
 	voucher := Voucher{Amount: big.NewInt(0).Set(newAmount), ChannelId: channelId}
 
-	if err := voucher.Sign(pk); err != nil {
+	if err := voucher.SignWith(signer); err != nil {
 		return voucher, err
 	}
 
@@ -54,12 +54,21 @@ func (v *Voucher) Hash() (types.Bytes32, error) {
 }
 
 func (v *Voucher) Sign(pk []byte) error {
+	signer, err := nitroCrypto.NewKeySigner(pk)
+	if err != nil {
+		return err
+	}
+	return v.SignWith(signer)
+}
+
+// SignWith signs the voucher using the supplied signer
+func (v *Voucher) SignWith(signer nitroCrypto.Signer) error {
 	hash, err := v.Hash()
 	if err != nil {
 		return err
 	}
 
-	sig, err := nitroCrypto.SignEthereumMessage(hash.Bytes(), pk)
+	sig, err := nitroCrypto.SignEthereumMessageWith(hash.Bytes(), signer)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package paymentsmanager
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/big"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/notifier"
 	"github.com/statechannels/go-nitro/node/query"
 	"github.com/statechannels/go-nitro/payments"
 	"github.com/statechannels/go-nitro/types"
@@ -26,6 +28,9 @@ const (
 	// Note: Make sure the transport has timeout > DEFAULT_VOUCHER_CHECK_ATTEMPTS * DEFAULT_VOUCHER_CHECK_INTERVAL
 	DEFAULT_VOUCHER_CHECK_INTERVAL = 1
 	DEFAULT_VOUCHER_CHECK_ATTEMPTS = 5
+
+	// Number of received vouchers buffered for the payments manager
+	DEFAULT_VOUCHER_BUFFER_SIZE = 10000
 )
 
 var (
@@ -84,13 +89,25 @@ func NewPaymentsManager(nitro *node.Node) (PaymentsManager, error) {
 func (pm *PaymentsManager) Start(wg *sync.WaitGroup) {
 	slog.Info("starting payments manager...")
 
+	// Subscribe before starting the goroutine, so that no voucher received after Start returns is missed
+	vouchers := pm.subscribe()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		pm.run()
+		pm.run(vouchers)
 	}()
 }
 
+// subscribe subscribes to received vouchers. Should the payments manager fall so far behind that the buffer overflows,
+// the subscription is disconnected rather than silently dropping vouchers.
+func (pm *PaymentsManager) subscribe() *notifier.Subscription[payments.Voucher] {
+	return pm.nitro.SubscribeReceivedVouchers(notifier.SubscriptionOpts{
+		BufferSize: DEFAULT_VOUCHER_BUFFER_SIZE,
+		Overflow:   notifier.Disconnect,
+	})
+}
+
 func (pm *PaymentsManager) Stop() error {
 	slog.Info("stopping payments manager...")
 	close(pm.quitChan)
@@ -140,11 +157,23 @@ func (pm *PaymentsManager) checkVoucherInCache(voucherHash common.Hash, signerAd
 	return true, true
 }
 
-func (pm *PaymentsManager) run() {
+func (pm *PaymentsManager) run(vouchers *notifier.Subscription[payments.Voucher]) {
 	slog.Info("starting voucher subscription...")
+	defer func() { vouchers.Close() }()
+
 	for {
 		select {
-		case voucher := <-pm.nitro.ReceivedVouchers():
+		case voucher, ok := <-vouchers.Updates():
+			if !ok {
+				if !errors.Is(vouchers.Err(), notifier.ErrSubscriptionOverflow) {
+					slog.Info("voucher subscription closed, stopping voucher subscription loop...", "err", vouchers.Err())
+					return
+				}
+				slog.Error("payments manager fell behind and missed vouchers, resubscribing", "err", vouchers.Err())
+				vouchers = pm.subscribe()
+				continue
+			}
+
 			payer, err := pm.getChannelCounterparty(voucher.ChannelId)
 			if err != nil {
 				// TODO: Handle
@@ -15,6 +15,7 @@ import (
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/channel/state/outcome"
+	"github.com/statechannels/go-nitro/crypto"
 	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
 	"github.com/statechannels/go-nitro/payments"
 	"github.com/statechannels/go-nitro/protocols"
@@ -61,10 +62,12 @@ type Objective struct {
 	IsCheckpoint                   bool
 	checkpointTransactionSubmitted bool
 
+	// SpawnedAtBlock is the number of the block in which a challenge registered by someone else spawned the objective, or zero if it was requested.
+	// Should a reorg remove that block, the objective is undone.
+	SpawnedAtBlock uint64
+
 	FundedChannels            map[types.Destination]*channel.Channel
 	GetVoucherIfAmountPresent func(channelId types.Destination) (*payments.VoucherInfo, bool) `json:"-"`
-
-	droppedEvent protocols.DroppedEventInfo
 }
 
 // isInConsensusOrFinalState returns true if the channel has a final state or latest state that is supported
@@ -265,7 +268,7 @@ func (o *Objective) Update(p protocols.ObjectivePayload) (protocols.Objective, e
 }
 
 // Crank inspects the extended state and declares a list of Effects to be executed
-func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) Crank(signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	updated := o.clone()
 
 	sideEffects := protocols.SideEffects{}
@@ -276,14 +279,14 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 
 	// Direct defund with challenge
 	if updated.IsChallenge || updated.IsCheckpoint || updated.C.OnChain.ChannelMode != channel.Open {
-		return o.crankWithChallenge(updated, sideEffects, secretKey)
+		return o.crankWithChallenge(updated, sideEffects, signer)
 	}
 
 	// Direct defund without challenge
-	return o.crank(updated, sideEffects, secretKey)
+	return o.crank(updated, sideEffects, signer)
 }
 
-func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.SideEffects, secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.SideEffects, signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	// Alice loops over funded targets and call challenge on each channel serially
 	if updated.IsChallenge && len(updated.FundedChannels) != 0 && !updated.virtualChannelChallengeSubmitted {
 		// TODO: Refactor to seperate method
@@ -326,16 +329,16 @@ func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.
 
 				vp := state.VariablePart{Outcome: outcome.Exit{newOutcome}, TurnNum: latestSupportedState.State().TurnNum + 1, AppData: dataEncoded, IsFinal: false}
 				newState := state.StateFromFixedAndVariablePart(latestSupportedState.State().FixedPart(), vp)
-				latestSignedState, _ := virtualChannel.SignAndAddState(newState, secretKey)
+				latestSignedState, _ := virtualChannel.SignAndAddState(newState, signer)
 
 				// Bob calls challenge method on virtual channel
-				virtualChallengerSig, _ := NitroAdjudicator.SignChallengeMessage(latestSignedState.State(), *secretKey)
+				virtualChallengerSig, _ := NitroAdjudicator.SignChallengeMessageWith(latestSignedState.State(), signer)
 				virtualChallengeTx := protocols.NewChallengeTransaction(virtualChannel.Id, latestSignedState, []state.SignedState{signedPostFundState}, virtualChallengerSig)
 				sideEffects.TransactionsToSubmit = append(sideEffects.TransactionsToSubmit, virtualChallengeTx)
 			} else {
 				// Call challenge without proof if voucher doesn't exist
 				latestSupportedSignedState, _ := virtualChannel.LatestSupportedSignedState()
-				challengerSig, _ := NitroAdjudicator.SignChallengeMessage(latestSupportedSignedState.State(), *secretKey)
+				challengerSig, _ := NitroAdjudicator.SignChallengeMessageWith(latestSupportedSignedState.State(), signer)
 				challengeTx := protocols.NewChallengeTransaction(updated.C.Id, latestSupportedSignedState, make([]state.SignedState, 0), challengerSig)
 				sideEffects.TransactionsToSubmit = append(sideEffects.TransactionsToSubmit, challengeTx)
 			}
@@ -366,7 +369,7 @@ func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.
 			return &updated, sideEffects, WaitingForNothing, err
 		}
 
-		challengerSig, _ := NitroAdjudicator.SignChallengeMessage(latestSupportedSignedState.State(), *secretKey)
+		challengerSig, _ := NitroAdjudicator.SignChallengeMessageWith(latestSupportedSignedState.State(), signer)
 		challengeTx := protocols.NewChallengeTransaction(updated.C.Id, latestSupportedSignedState, make([]state.SignedState, 0), challengerSig)
 		sideEffects.TransactionsToSubmit = append(sideEffects.TransactionsToSubmit, challengeTx)
 		updated.challengeTransactionSubmitted = true
@@ -490,7 +493,7 @@ func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.
 	return &updated, sideEffects, WaitingForNothing, fmt.Errorf("objective %s in invalid state", string(updated.Id()))
 }
 
-func (o *Objective) crank(updated Objective, sideEffects protocols.SideEffects, secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) crank(updated Objective, sideEffects protocols.SideEffects, signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	latestSignedState, err := updated.C.LatestSignedState()
 	if err != nil {
 		return &updated, sideEffects, WaitingForNothing, errors.New("the channel must contain at least one signed state to crank the defund objective")
@@ -503,7 +506,7 @@ func (o *Objective) crank(updated Objective, sideEffects protocols.SideEffects,
 			stateToSign.TurnNum += 1
 			stateToSign.IsFinal = true
 		}
-		ss, err := updated.C.SignAndAddState(stateToSign, secretKey)
+		ss, err := updated.C.SignAndAddState(stateToSign, signer)
 		if err != nil {
 			return &updated, protocols.SideEffects{}, WaitingForFinalization, fmt.Errorf("could not sign final state %w", err)
 		}
@@ -529,8 +532,6 @@ func (o *Objective) crank(updated Objective, sideEffects protocols.SideEffects,
 			withdrawAll := protocols.NewWithdrawAllTransaction(updated.C.Id, latestSignedState)
 			sideEffects.TransactionsToSubmit = append(sideEffects.TransactionsToSubmit, withdrawAll)
 			updated.withdrawTransactionSubmitted = true
-			// Reset dropped event info as new tx is submitted
-			updated.droppedEvent = protocols.DroppedEventInfo{}
 		}
 		// Every participant waits for all channel funds to be distributed, even if the participant has no funds in the channel
 		return &updated, sideEffects, WaitingForWithdraw, nil
@@ -581,21 +582,13 @@ func (o *Objective) clone() Objective {
 	clone.checkpointTransactionSubmitted = o.checkpointTransactionSubmitted
 	clone.virtualChannelChallengeSubmitted = o.virtualChannelChallengeSubmitted
 	clone.reclaimTransactionSubmitted = o.reclaimTransactionSubmitted
+	clone.SpawnedAtBlock = o.SpawnedAtBlock
 	clone.GetVoucherIfAmountPresent = o.GetVoucherIfAmountPresent
 	clone.FundedChannels = o.FundedChannels
-	clone.droppedEvent = o.droppedEvent
 
 	return clone
 }
 
-func (o *Objective) SetDroppedEvent(droppedEventFromChain protocols.DroppedEventInfo) {
-	o.droppedEvent = droppedEventFromChain
-}
-
-func (o *Objective) GetDroppedEvent() protocols.DroppedEventInfo {
-	return o.droppedEvent
-}
-
 // ObjectiveRequest represents a request to create a new direct defund objective.
 type ObjectiveRequest struct {
 	ChannelId        types.Destination
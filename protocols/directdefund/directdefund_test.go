@@ -136,7 +136,7 @@ func TestCrankAlice(t *testing.T) {
 	o, _ := newTestObjective()
 
 	// The first crank. Alice is expected to create and sign a final state
-	updated, se, wf, err := o.Crank(&alice.PrivateKey)
+	updated, se, wf, err := o.Crank(alice.Signer())
 	if err != nil {
 		t.Error(err)
 	}
@@ -172,7 +172,7 @@ func TestCrankAlice(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	updated, se, wf, err = updated.Crank(&alice.PrivateKey)
+	updated, se, wf, err = updated.Crank(alice.Signer())
 	if err != nil {
 		t.Error(err)
 	}
@@ -192,7 +192,7 @@ func TestCrankAlice(t *testing.T) {
 
 	// The third crank. Alice is expected to enter the terminal state of the defunding protocol.
 	updated.(*Objective).C.OnChain.Holdings = types.Funds{}
-	_, se, wf, err = updated.Crank(&alice.PrivateKey)
+	_, se, wf, err = updated.Crank(alice.Signer())
 	if err != nil {
 		t.Error(err)
 	}
@@ -229,7 +229,7 @@ func TestCrankBob(t *testing.T) {
 	}
 
 	// The first crank. Bob is expected to create and sign a final state
-	updated, se, wf, err := updated.Crank(&bob.PrivateKey)
+	updated, se, wf, err := updated.Crank(bob.Signer())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -254,7 +254,7 @@ func TestCrankBob(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	updated, se, wf, err = updated.Crank(&bob.PrivateKey)
+	updated, se, wf, err = updated.Crank(bob.Signer())
 	if err != nil {
 		t.Error(err)
 	}
@@ -279,7 +279,7 @@ func TestCrankBob(t *testing.T) {
 		t.Error(err)
 	}
 
-	_, se, wf, err = updated.Crank(&bob.PrivateKey)
+	_, se, wf, err = updated.Crank(bob.Signer())
 	if err != nil {
 		t.Error(err)
 	}
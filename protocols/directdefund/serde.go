@@ -23,7 +23,7 @@ type jsonObjective struct {
 	VirtualChannelChallengeSubmitted bool
 	ReclaimTransactionSubmitted      bool
 	FundedChannels                   map[types.Destination]*channel.Channel
-	DroppedEvent                     protocols.DroppedEventInfo
+	SpawnedAtBlock                   uint64
 }
 
 // MarshalJSON returns a JSON representation of the DirectDefundObjective
@@ -43,7 +43,7 @@ func (o *Objective) MarshalJSON() ([]byte, error) {
 		o.virtualChannelChallengeSubmitted,
 		o.reclaimTransactionSubmitted,
 		o.FundedChannels,
-		o.droppedEvent,
+		o.SpawnedAtBlock,
 	}
 
 	return json.Marshal(jsonDDFO)
@@ -77,6 +77,6 @@ func (o *Objective) UnmarshalJSON(data []byte) error {
 	o.virtualChannelChallengeSubmitted = jsonDDFO.VirtualChannelChallengeSubmitted
 	o.reclaimTransactionSubmitted = jsonDDFO.ReclaimTransactionSubmitted
 	o.FundedChannels = jsonDDFO.FundedChannels
-	o.droppedEvent = jsonDDFO.DroppedEvent
+	o.SpawnedAtBlock = jsonDDFO.SpawnedAtBlock
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/channel/state/outcome"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
 )
@@ -45,7 +46,6 @@ type Objective struct {
 	myDepositTarget          types.Funds // I want to get the on chain holdings up to this much
 	fullyFundedThreshold     types.Funds // if the on chain holdings are equal
 	transactionSubmitted     bool        // whether a transition for the objective has been submitted or not
-	droppedEvent             protocols.DroppedEventInfo
 }
 
 // GetChannelByIdFunction specifies a function that can be used to retrieve channels from a store.
@@ -288,7 +288,7 @@ func (o *Objective) otherParticipants() []types.Address {
 // Crank inspects the extended state and declares a list of Effects to be executed
 // It's like a state machine transition function where the finite / enumerable state is returned (computed from the extended state)
 // rather than being independent of the extended state; and where there is only one type of event ("the crank") with no data on it at all
-func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) Crank(signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	updated := o.clone()
 
 	sideEffects := protocols.SideEffects{}
@@ -299,7 +299,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 
 	// Prefunding
 	if !updated.C.PreFundSignedByMe() {
-		ss, err := updated.C.SignAndAddPrefund(secretKey)
+		ss, err := updated.C.SignAndAddPrefund(signer)
 		if err != nil {
 			return &updated, protocols.SideEffects{}, WaitingForCompletePrefund, fmt.Errorf("could not sign prefund %w", err)
 		}
@@ -326,8 +326,6 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	if !fundingComplete && safeToDeposit && amountToDeposit.IsNonZero() && !updated.transactionSubmitted {
 		deposit := protocols.NewDepositTransaction(updated.C.Id, amountToDeposit)
 		updated.transactionSubmitted = true
-		// Reset dropped event info as new tx is submitted
-		updated.droppedEvent = protocols.DroppedEventInfo{}
 		sideEffects.TransactionsToSubmit = append(sideEffects.TransactionsToSubmit, deposit)
 	}
 
@@ -338,7 +336,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	// Postfunding
 	if !updated.C.PostFundSignedByMe() {
 
-		ss, err := updated.C.SignAndAddPostfund(secretKey)
+		ss, err := updated.C.SignAndAddPostfund(signer)
 		if err != nil {
 			return &updated, protocols.SideEffects{}, WaitingForCompletePostFund, fmt.Errorf("could not sign postfund %w", err)
 		}
@@ -426,7 +424,6 @@ func (o *Objective) clone() Objective {
 	clone.myDepositTarget = o.myDepositTarget.Clone()
 	clone.fullyFundedThreshold = o.fullyFundedThreshold.Clone()
 	clone.transactionSubmitted = o.transactionSubmitted
-	clone.droppedEvent = o.droppedEvent
 	return clone
 }
 
@@ -439,14 +436,6 @@ func (o *Objective) ResetTxSubmitted() {
 	o.transactionSubmitted = false
 }
 
-func (o *Objective) SetDroppedEvent(droppedEventFromChain protocols.DroppedEventInfo) {
-	o.droppedEvent = droppedEventFromChain
-}
-
-func (o *Objective) GetDroppedEvent() protocols.DroppedEventInfo {
-	return o.droppedEvent
-}
-
 // ObjectiveRequest represents a request to create a new direct funding objective.
 type ObjectiveRequest struct {
 	CounterParty      types.Address
@@ -210,7 +210,7 @@ func TestCrank(t *testing.T) {
 	// END test data preparation
 
 	// Assert that cranking an unapproved objective returns an error
-	if _, _, _, err := s.Crank(&alice.PrivateKey); err == nil {
+	if _, _, _, err := s.Crank(alice.Signer()); err == nil {
 		t.Error(`Expected error when cranking unapproved objective, but got nil`)
 	}
 
@@ -223,7 +223,7 @@ func TestCrank(t *testing.T) {
 	//  - what side effects are declared.
 
 	// Initial Crank
-	_, sideEffects, waitingFor, err := o.Crank(&alice.PrivateKey)
+	_, sideEffects, waitingFor, err := o.Crank(alice.Signer())
 	if err != nil {
 		t.Error(err)
 	}
@@ -240,7 +240,7 @@ func TestCrank(t *testing.T) {
 	o.C.AddStateWithSignature(o.C.PreFundState(), correctSignatureByBobOnPreFund)
 
 	// Cranking should move us to the next waiting point
-	_, _, waitingFor, err = o.Crank(&alice.PrivateKey)
+	_, _, waitingFor, err = o.Crank(alice.Signer())
 	if err != nil {
 		t.Error(err)
 	}
@@ -250,7 +250,7 @@ func TestCrank(t *testing.T) {
 
 	// Manually make the first "deposit"
 	o.C.OnChain.Holdings[testState.Outcome[0].Asset] = testState.Outcome[0].Allocations[0].Amount
-	updated, sideEffects, waitingFor, err := o.Crank(&alice.PrivateKey)
+	updated, sideEffects, waitingFor, err := o.Crank(alice.Signer())
 
 	if !updated.(*Objective).transactionSubmitted {
 		t.Fatalf("Expected transactionSubmitted flag to be set to true")
@@ -269,7 +269,7 @@ func TestCrank(t *testing.T) {
 	// Manually make the second "deposit"
 	totalAmountAllocated := testState.Outcome[0].TotalAllocated()
 	o.C.OnChain.Holdings[testState.Outcome[0].Asset] = totalAmountAllocated
-	_, sideEffects, waitingFor, err = o.Crank(&alice.PrivateKey)
+	_, sideEffects, waitingFor, err = o.Crank(alice.Signer())
 	if err != nil {
 		t.Error(err)
 	}
@@ -286,7 +286,7 @@ func TestCrank(t *testing.T) {
 
 	// This should be the final crank
 	o.C.OnChain.Holdings[testState.Outcome[0].Asset] = totalAmountAllocated
-	_, _, waitingFor, err = o.Crank(&alice.PrivateKey)
+	_, _, waitingFor, err = o.Crank(alice.Signer())
 	if err != nil {
 		t.Error(err)
 	}
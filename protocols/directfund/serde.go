@@ -19,7 +19,6 @@ type jsonObjective struct {
 	MyDepositTarget          types.Funds
 	FullyFundedThreshold     types.Funds
 	TransactionSumbmitted    bool
-	DroppedEvent             protocols.DroppedEventInfo
 }
 
 // MarshalJSON returns a JSON representation of the DirectFundObjective
@@ -34,7 +33,6 @@ func (o Objective) MarshalJSON() ([]byte, error) {
 		o.myDepositTarget,
 		o.fullyFundedThreshold,
 		o.transactionSubmitted,
-		o.droppedEvent,
 	}
 	return json.Marshal(jsonDFO)
 }
@@ -62,7 +60,6 @@ func (o *Objective) UnmarshalJSON(data []byte) error {
 	o.myDepositTarget = jsonDFO.MyDepositTarget
 	o.myDepositSafetyThreshold = jsonDFO.MyDepositSafetyThreshold
 	o.transactionSubmitted = jsonDFO.TransactionSumbmitted
-	o.droppedEvent = jsonDFO.DroppedEvent
 
 	return nil
 }
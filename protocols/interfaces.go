@@ -5,7 +5,6 @@ import (
 	"errors"
 	"math/big"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/crypto"
@@ -161,12 +160,6 @@ type Storable interface {
 	json.Unmarshaler
 }
 
-type DroppedEventInfo struct {
-	TxHash    common.Hash
-	ChannelId types.Destination
-	EventName string
-}
-
 // Objective is the interface for off-chain protocols.
 // The lifecycle of an objective is as follows:
 //   - It is initialized by a single client (passing in various parameters). It is implicitly approved by that client. It is communicated to the other clients.
@@ -177,10 +170,10 @@ type DroppedEventInfo struct {
 type Objective interface {
 	Id() ObjectiveId
 
-	Approve() Objective                                                  // returns an updated Objective (a copy, no mutation allowed), does not declare effects
-	Reject() (Objective, SideEffects)                                    // returns an updated Objective (a copy, no mutation allowed), does not declare effects
-	Update(payload ObjectivePayload) (Objective, error)                  // returns an updated Objective (a copy, no mutation allowed), does not declare effects
-	Crank(secretKey *[]byte) (Objective, SideEffects, WaitingFor, error) // does *not* accept an event, but *does* accept a pointer to a signing key; declare side effects; return an updated Objective
+	Approve() Objective                                                     // returns an updated Objective (a copy, no mutation allowed), does not declare effects
+	Reject() (Objective, SideEffects)                                       // returns an updated Objective (a copy, no mutation allowed), does not declare effects
+	Update(payload ObjectivePayload) (Objective, error)                     // returns an updated Objective (a copy, no mutation allowed), does not declare effects
+	Crank(signer crypto.Signer) (Objective, SideEffects, WaitingFor, error) // does *not* accept an event, but *does* accept a signer; declare side effects; return an updated Objective
 
 	// Related returns a slice of related objects that need to be stored along with the objective
 	Related() []Storable
@@ -212,6 +205,36 @@ const (
 	Completed
 )
 
+// FailureCode classifies why an objective failed or was rejected.
+type FailureCode string
+
+const (
+	// PolicyRejection means the objective was rejected by a policy maker or an operator
+	PolicyRejection FailureCode = "PolicyRejection"
+	// InsufficientLedgerFunds means a ledger channel could not fund or defund the objective's channel
+	InsufficientLedgerFunds FailureCode = "InsufficientLedgerFunds"
+	// InvalidSignature means a peer sent a state or proposal for the objective which was not correctly signed
+	InvalidSignature FailureCode = "InvalidSignature"
+	// Timeout means the objective missed its deadline
+	Timeout FailureCode = "Timeout"
+	// Cancellation means the objective was cancelled through the API
+	Cancellation FailureCode = "Cancellation"
+	// OtherFailure is used for failures which do not fall into any other category
+	OtherFailure FailureCode = "OtherFailure"
+)
+
+// FailureReason describes why an objective failed or was rejected.
+type FailureReason struct {
+	Code    FailureCode
+	Message string
+}
+
+// FailedObjective identifies an objective which failed or was rejected, and why.
+type FailedObjective struct {
+	ObjectiveId ObjectiveId
+	Reason      FailureReason
+}
+
 // ObjectiveRequest is a request to create a new objective.
 type ObjectiveRequest interface {
 	Id(types.Address, *big.Int) ObjectiveId
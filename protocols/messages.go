@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/payments"
@@ -49,6 +50,13 @@ type Message struct {
 	Payments []payments.Voucher
 	// RejectedObjectives is a collection of objectives that have been rejected.
 	RejectedObjectives []ObjectiveId
+	// RejectionReasons holds why objectives in RejectedObjectives were rejected, if the sender gave a reason.
+	RejectionReasons map[ObjectiveId]FailureReason `json:",omitempty"`
+	// TraceContext carries the trace of the operation which sent the message, so that handling the message continues that trace.
+	TraceContext map[string]string `json:",omitempty"`
+	// DeliveryId identifies the delivery of the message to the message service which received it, so that the delivery
+	// can be acknowledged once the message is handled. It is not sent to peers.
+	DeliveryId string `json:"-"`
 }
 
 // Serialize serializes the message into a string.
@@ -125,6 +133,19 @@ func CreateRejectionNoticeMessage(oId ObjectiveId, recipients ...types.Address)
 	return messages
 }
 
+// AddRejectionReason records the reason on each message which notifies its recipient that the objective was rejected.
+func AddRejectionReason(messages []Message, oId ObjectiveId, reason FailureReason) {
+	for i, message := range messages {
+		if !slices.Contains(message.RejectedObjectives, oId) {
+			continue
+		}
+		if message.RejectionReasons == nil {
+			messages[i].RejectionReasons = make(map[ObjectiveId]FailureReason)
+		}
+		messages[i].RejectionReasons[oId] = reason
+	}
+}
+
 // CreateSignedProposalMessage returns a signed proposal message addressed to the counterparty in the given ledger channel.
 // The proposals MUST be sorted by turnNum
 // since the ledger protocol relies on the message receipient processing the proposals in that order. See ADR 4.
@@ -101,3 +101,27 @@ func TestMessage(t *testing.T) {
 		}
 	})
 }
+
+func TestRejectionReasons(t *testing.T) {
+	reason := FailureReason{Code: PolicyRejection, Message: "not today"}
+	messages := CreateRejectionNoticeMessage("say-hello-to-my-little-friend", common.Address{'a'}, common.Address{'b'})
+	messages = append(messages, CreateVoucherMessage(payments.Voucher{}, common.Address{'c'})...)
+	AddRejectionReason(messages, "say-hello-to-my-little-friend", reason)
+
+	for _, msg := range messages[:2] {
+		serialized, err := msg.Serialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := DeserializeMessage(serialized)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.RejectionReasons["say-hello-to-my-little-friend"] != reason {
+			t.Fatalf("expected rejection reason %+v, got %+v", reason, got.RejectionReasons)
+		}
+	}
+	if messages[2].RejectionReasons != nil {
+		t.Fatalf("expected no rejection reasons on a message which rejects nothing, got %+v", messages[2].RejectionReasons)
+	}
+}
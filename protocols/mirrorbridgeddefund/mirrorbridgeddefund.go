@@ -10,6 +10,7 @@ import (
 	"github.com/statechannels/go-nitro/channel"
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/crypto"
 	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
@@ -102,7 +103,7 @@ func (o *Objective) Id() protocols.ObjectiveId {
 // Crank inspects the extended state and declares a list of Effects to be executed
 // It's like a state machine transition function where the finite / enumerable state is returned (computed from the extended state)
 // rather than being independent of the extended state; and where there is only one type of event ("the crank") with no data on it at all
-func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) Crank(signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	updated := o.clone()
 	sideEffects := protocols.SideEffects{}
 
@@ -111,13 +112,13 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	}
 
 	if updated.IsChallenge || updated.IsCheckPoint || updated.C.OnChain.ChannelMode != channel.Open {
-		return o.crankWithChallenge(updated, sideEffects, secretKey)
+		return o.crankWithChallenge(updated, sideEffects, signer)
 	}
 
-	return o.crank(updated, sideEffects, secretKey)
+	return o.crank(updated, sideEffects, signer)
 }
 
-func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.SideEffects, secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.SideEffects, signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	if updated.IsChallenge && !updated.ChallengeTransactionSubmitted {
 		// Update L1 state using L2 state to ensure off-chain balance reflects on-chain balance
 		updatedL1State, err := o.CreateL1StateBasedOnL2()
@@ -126,13 +127,13 @@ func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.
 		}
 
 		// Sign the updated L1 state
-		_, err = updated.C.SignAndAddState(updatedL1State, secretKey)
+		_, err = updated.C.SignAndAddState(updatedL1State, signer)
 		if err != nil {
 			return &updated, protocols.SideEffects{}, WaitingForChallenge, err
 		}
 
 		// Challenge L2 signed state to finalize the state
-		challengerSig, _ := NitroAdjudicator.SignChallengeMessage(updated.L2SignedState.State(), *secretKey)
+		challengerSig, _ := NitroAdjudicator.SignChallengeMessageWith(updated.L2SignedState.State(), signer)
 		challengeTx := protocols.NewChallengeTransaction(updated.C.Id, updated.L2SignedState, make([]state.SignedState, 0), challengerSig)
 		sideEffects.TransactionsToSubmit = append(sideEffects.TransactionsToSubmit, challengeTx)
 		updated.ChallengeTransactionSubmitted = true
@@ -175,7 +176,7 @@ func (o *Objective) crankWithChallenge(updated Objective, sideEffects protocols.
 	return &updated, sideEffects, WaitingForNothing, nil
 }
 
-func (o *Objective) crank(updated Objective, sideEffects protocols.SideEffects, secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) crank(updated Objective, sideEffects protocols.SideEffects, signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	latestL1SignedState, err := updated.C.LatestSignedState()
 	if err != nil {
 		return &updated, protocols.SideEffects{}, WaitingForFinalization, err
@@ -199,7 +200,7 @@ func (o *Objective) crank(updated Objective, sideEffects protocols.SideEffects,
 	// Executed for both Alice and Bob
 	if !latestL1SignedState.HasSignatureForParticipant(updated.C.MyIndex) {
 		// Sign the latest L1 signed state
-		l1SignedState, err := updated.C.SignAndAddState(latestL1State, secretKey)
+		l1SignedState, err := updated.C.SignAndAddState(latestL1State, signer)
 		if err != nil {
 			return &updated, protocols.SideEffects{}, WaitingForFinalization, fmt.Errorf("could not sign final state %w", err)
 		}
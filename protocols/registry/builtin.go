@@ -0,0 +1,376 @@
+package registry
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/statechannels/go-nitro/channel"
+	"github.com/statechannels/go-nitro/channel/consensus_channel"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/protocols/bridgeddefund"
+	"github.com/statechannels/go-nitro/protocols/bridgedfund"
+	"github.com/statechannels/go-nitro/protocols/directdefund"
+	"github.com/statechannels/go-nitro/protocols/directfund"
+	"github.com/statechannels/go-nitro/protocols/mirrorbridgeddefund"
+	"github.com/statechannels/go-nitro/protocols/swap"
+	"github.com/statechannels/go-nitro/protocols/swapdefund"
+	"github.com/statechannels/go-nitro/protocols/swapfund"
+	"github.com/statechannels/go-nitro/protocols/virtualdefund"
+	"github.com/statechannels/go-nitro/protocols/virtualfund"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// builtinTypes returns the objective types implemented by the protocol packages of this module, keyed by name
+func builtinTypes() map[string]ObjectiveType {
+	builtin := []ObjectiveType{
+		{
+			Prefix: directfund.ObjectivePrefix,
+			RequestScope: func(r protocols.ObjectiveRequest) (Scope, bool) {
+				request, ok := r.(directfund.ObjectiveRequest)
+				return Scope{Peers: []types.Address{request.CounterParty}}, ok
+			},
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				dfo, err := directfund.ConstructFromPayload(false, p, deps.Address)
+				return &dfo, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				dfo := directfund.Objective{}
+				err := dfo.UnmarshalJSON(data)
+				return &dfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				dfo := o.(*directfund.Objective)
+				ch, err := data.GetChannel(dfo.C.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving channel data for objective %s: %w", o.Id(), err)
+				}
+				dfo.C = &ch
+				return nil
+			},
+			Policy: Policy{
+				FundedChannel: func(o protocols.Objective) (*channel.Channel, bool) {
+					return o.(*directfund.Objective).C, true
+				},
+			},
+		},
+		{
+			Prefix: directdefund.ObjectivePrefix,
+			RequestScope: func(r protocols.ObjectiveRequest) (Scope, bool) {
+				request, ok := r.(directdefund.ObjectiveRequest)
+				return Scope{Channel: request.ChannelId}, ok
+			},
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				ddfo, err := directdefund.ConstructObjectiveFromPayload(p, false, deps.GetConsensusChannelById, deps.GetChannelById, deps.Vouchers.GetVoucherIfAmountPresent)
+				return &ddfo, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				ddfo := directdefund.Objective{}
+				err := ddfo.UnmarshalJSON(data)
+				return &ddfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				ddfo := o.(*directdefund.Objective)
+				ch, err := data.GetChannel(ddfo.C.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving channel data for objective %s: %w", o.Id(), err)
+				}
+				ddfo.C = &ch
+
+				// Populate virtual channels if present
+				for virtualChannelId := range ddfo.FundedChannels {
+					updatedVirtualChannel, _ := data.GetChannel(virtualChannelId)
+					ddfo.FundedChannels[virtualChannelId] = &updatedVirtualChannel
+				}
+				return nil
+			},
+			Policy: Policy{
+				Challenge: func(o protocols.Objective) (protocols.Objective, bool) {
+					ddfo := o.(*directdefund.Objective)
+					if ddfo.IsChallenge {
+						return ddfo, false
+					}
+					ddfo.IsChallenge = true
+					return ddfo, true
+				},
+			},
+		},
+		{
+			Prefix: virtualfund.ObjectivePrefix,
+			RequestScope: func(r protocols.ObjectiveRequest) (Scope, bool) {
+				request, ok := r.(virtualfund.ObjectiveRequest)
+				return Scope{Peers: append(request.Intermediaries, request.CounterParty)}, ok
+			},
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				vfo, err := virtualfund.ConstructObjectiveFromPayload(p, false, deps.Address, deps.GetConsensusChannel)
+				if err != nil {
+					return nil, err
+				}
+				err = vfo.RegisterPaymentChannel(deps.Vouchers)
+				if err != nil {
+					return nil, fmt.Errorf("could not register channel with payment/receipt manager: %w", err)
+				}
+				return &vfo, nil
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				vfo := virtualfund.Objective{}
+				err := vfo.UnmarshalJSON(data)
+				return &vfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				vfo := o.(*virtualfund.Objective)
+				v, err := data.GetChannel(vfo.V.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving virtual channel data for objective %s: %w", o.Id(), err)
+				}
+				vfo.V = &channel.VirtualChannel{Channel: v}
+
+				var left, right **consensus_channel.ConsensusChannel
+				if vfo.ToMyLeft != nil {
+					left = &vfo.ToMyLeft.Channel
+				}
+				if vfo.ToMyRight != nil {
+					right = &vfo.ToMyRight.Channel
+				}
+				return populateLedgers(o.Id(), left, right, data)
+			},
+			LedgerProposal:      consensus_channel.AddProposal,
+			ProposalChannelType: types.Virtual,
+			Policy: Policy{
+				FundedChannel: func(o protocols.Objective) (*channel.Channel, bool) {
+					return &o.(*virtualfund.Objective).V.Channel, false
+				},
+			},
+		},
+		{
+			Prefix: virtualdefund.ObjectivePrefix,
+			RequestScope: func(r protocols.ObjectiveRequest) (Scope, bool) {
+				request, ok := r.(virtualdefund.ObjectiveRequest)
+				return Scope{Channel: request.ChannelId}, ok
+			},
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				vId, err := virtualdefund.GetVirtualChannelFromObjectiveId(p.ObjectiveId)
+				if err != nil {
+					return nil, fmt.Errorf("could not determine virtual channel id from objective %s: %w", p.ObjectiveId, err)
+				}
+				minAmount := big.NewInt(0)
+				if deps.Vouchers.ChannelRegistered(vId) {
+					paid, err := deps.Vouchers.Paid(vId)
+					if err != nil {
+						return nil, fmt.Errorf("could not determine amount paid in virtual channel %s: %w", vId, err)
+					}
+					minAmount = paid
+				}
+
+				vdfo, err := virtualdefund.ConstructObjectiveFromPayload(p, false, deps.Address, deps.GetChannelById, deps.GetConsensusChannel, minAmount)
+				return &vdfo, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				vdfo := virtualdefund.Objective{}
+				err := vdfo.UnmarshalJSON(data)
+				return &vdfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				vdfo := o.(*virtualdefund.Objective)
+				v, err := data.GetChannel(vdfo.V.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving virtual channel data for objective %s: %w", o.Id(), err)
+				}
+				vdfo.V = &channel.VirtualChannel{Channel: v}
+				return populateLedgers(o.Id(), &vdfo.ToMyLeft, &vdfo.ToMyRight, data)
+			},
+			LedgerProposal:      consensus_channel.RemoveProposal,
+			ProposalChannelType: types.Virtual,
+		},
+		{
+			Prefix: swapfund.ObjectivePrefix,
+			RequestScope: func(r protocols.ObjectiveRequest) (Scope, bool) {
+				request, ok := r.(swapfund.ObjectiveRequest)
+				return Scope{Peers: append(request.Intermediaries, request.CounterParty)}, ok
+			},
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				sfo, err := swapfund.ConstructObjectiveFromPayload(p, false, deps.Address, deps.GetConsensusChannel)
+				return &sfo, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				sfo := swapfund.Objective{}
+				err := sfo.UnmarshalJSON(data)
+				return &sfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				sfo := o.(*swapfund.Objective)
+				s, err := data.GetChannel(sfo.S.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving swap channel data for objective %s: %w", o.Id(), err)
+				}
+				sfo.S = &channel.SwapChannel{Channel: s}
+
+				var left, right **consensus_channel.ConsensusChannel
+				if sfo.ToMyLeft != nil {
+					left = &sfo.ToMyLeft.Channel
+				}
+				if sfo.ToMyRight != nil {
+					right = &sfo.ToMyRight.Channel
+				}
+				return populateLedgers(o.Id(), left, right, data)
+			},
+			LedgerProposal:      consensus_channel.AddProposal,
+			ProposalChannelType: types.Swap,
+			Policy: Policy{
+				FundedChannel: func(o protocols.Objective) (*channel.Channel, bool) {
+					return &o.(*swapfund.Objective).S.Channel, false
+				},
+			},
+		},
+		{
+			Prefix: swapdefund.ObjectivePrefix,
+			RequestScope: func(r protocols.ObjectiveRequest) (Scope, bool) {
+				request, ok := r.(swapdefund.ObjectiveRequest)
+				return Scope{Channel: request.ChannelId}, ok
+			},
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				sdfo, err := swapdefund.ConstructObjectiveFromPayload(p, false, deps.Address, deps.GetChannelById, deps.GetConsensusChannel)
+				return &sdfo, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				sdfo := swapdefund.Objective{}
+				err := sdfo.UnmarshalJSON(data)
+				return &sdfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				sdfo := o.(*swapdefund.Objective)
+				s, err := data.GetChannel(sdfo.S.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving swap channel data for objective %s: %w", o.Id(), err)
+				}
+				sdfo.S = &channel.SwapChannel{Channel: s}
+				return populateLedgers(o.Id(), &sdfo.ToMyLeft, &sdfo.ToMyRight, data)
+			},
+			LedgerProposal:      consensus_channel.RemoveProposal,
+			ProposalChannelType: types.Swap,
+		},
+		{
+			Prefix: swap.ObjectivePrefix,
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				so, err := swap.ConstructObjectiveFromPayload(p, false, deps.GetChannelById, deps.Address)
+				return &so, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				so := swap.Objective{}
+				err := so.UnmarshalJSON(data)
+				return &so, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				so := o.(*swap.Objective)
+				ch, err := data.GetChannel(so.C.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving channel data for objective %s: %w", o.Id(), err)
+				}
+				s, err := data.GetSwap(so.Swap.Id)
+				if err != nil {
+					return fmt.Errorf("error getting swap by id: %w", err)
+				}
+				so.Swap = s
+				so.C = &channel.SwapChannel{Channel: ch}
+				return nil
+			},
+		},
+		{
+			Prefix: bridgedfund.ObjectivePrefix,
+			RequestScope: func(r protocols.ObjectiveRequest) (Scope, bool) {
+				request, ok := r.(bridgedfund.ObjectiveRequest)
+				return Scope{Peers: []types.Address{request.CounterParty}}, ok
+			},
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				bfo, err := bridgedfund.ConstructFromPayload(false, p, deps.Address)
+				return &bfo, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				bfo := bridgedfund.Objective{}
+				err := bfo.UnmarshalJSON(data)
+				return &bfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				bfo := o.(*bridgedfund.Objective)
+				ch, err := data.GetChannel(bfo.C.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving channel data for objective %s: %w", o.Id(), err)
+				}
+				bfo.C = &ch
+				return nil
+			},
+		},
+		{
+			Prefix: bridgeddefund.ObjectivePrefix,
+			RequestScope: func(r protocols.ObjectiveRequest) (Scope, bool) {
+				request, ok := r.(bridgeddefund.ObjectiveRequest)
+				return Scope{Channel: request.ChannelId}, ok
+			},
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				bdfo, err := bridgeddefund.ConstructObjectiveFromPayload(p, false, deps.GetConsensusChannelById)
+				return &bdfo, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				bdfo := bridgeddefund.Objective{}
+				err := bdfo.UnmarshalJSON(data)
+				return &bdfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				bdfo := o.(*bridgeddefund.Objective)
+				ch, err := data.GetChannel(bdfo.C.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving channel data for objective %s: %w", o.Id(), err)
+				}
+				bdfo.C = &ch
+				return nil
+			},
+		},
+		{
+			Prefix: mirrorbridgeddefund.ObjectivePrefix,
+			ConstructFromPayload: func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error) {
+				mbdfo, err := mirrorbridgeddefund.ConstructObjectiveFromPayload(p, false, deps.GetConsensusChannelById)
+				return &mbdfo, err
+			},
+			Decode: func(data []byte) (protocols.Objective, error) {
+				mbdfo := mirrorbridgeddefund.Objective{}
+				err := mbdfo.UnmarshalJSON(data)
+				return &mbdfo, err
+			},
+			PopulateChannelData: func(o protocols.Objective, data ChannelData) error {
+				mbdfo := o.(*mirrorbridgeddefund.Objective)
+				ch, err := data.GetChannel(mbdfo.C.Id)
+				if err != nil {
+					return fmt.Errorf("error retrieving channel data for objective %s: %w", o.Id(), err)
+				}
+				mbdfo.C = &ch
+				return nil
+			},
+		},
+	}
+
+	objectiveTypes := make(map[string]ObjectiveType, len(builtin))
+	for _, t := range builtin {
+		objectiveTypes[t.Name()] = t
+	}
+	return objectiveTypes
+}
+
+// populateLedgers replaces the ledger channels to the left and right of an objective with the stored ledger channels.
+// Nil and zero-id ledger channels are left alone.
+func populateLedgers(id protocols.ObjectiveId, left, right **consensus_channel.ConsensusChannel, data ChannelData) error {
+	sides := []struct {
+		name   string
+		ledger **consensus_channel.ConsensusChannel
+	}{{"left", left}, {"right", right}}
+	for _, side := range sides {
+		ledger := side.ledger
+		if ledger == nil || *ledger == nil || (*ledger).Id == (types.Destination{}) {
+			continue
+		}
+		stored, err := data.GetConsensusChannel((*ledger).Id)
+		if err != nil {
+			return fmt.Errorf("error retrieving %s ledger channel data for objective %s: %w", side.name, id, err)
+		}
+		*ledger = stored
+	}
+	return nil
+}
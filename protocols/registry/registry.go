@@ -0,0 +1,176 @@
+// Package registry holds the objective types known to a go-nitro node. The engine and the stores look objective types up here,
+// so that a new protocol can be added by registering its objective type rather than by editing them.
+package registry // import "github.com/statechannels/go-nitro/protocols/registry"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/statechannels/go-nitro/channel"
+	"github.com/statechannels/go-nitro/channel/consensus_channel"
+	"github.com/statechannels/go-nitro/payments"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// Dependencies are the node services available to an objective type when it constructs an objective from a payload.
+type Dependencies struct {
+	Address                 types.Address
+	GetChannelById          func(id types.Destination) (channel *channel.Channel, ok bool)
+	GetConsensusChannel     func(counterparty types.Address) (ledger *consensus_channel.ConsensusChannel, ok bool)
+	GetConsensusChannelById func(id types.Destination) (ledger *consensus_channel.ConsensusChannel, err error)
+	Vouchers                *payments.VoucherManager
+}
+
+// ChannelData gives an objective type access to the channel data held by a store, which decoded objectives only reference by id.
+type ChannelData struct {
+	GetChannel          func(id types.Destination) (channel.Channel, error)
+	GetConsensusChannel func(id types.Destination) (*consensus_channel.ConsensusChannel, error)
+	GetSwap             func(id types.Destination) (payments.Swap, error)
+}
+
+// Policy describes objectives of a type to policy makers.
+type Policy struct {
+	// FundedChannel returns the channel an objective of this type funds, and whether it is funded directly by on-chain deposits rather than by guarantees in ledger channels.
+	// It is nil if objectives of this type do not fund a channel.
+	FundedChannel func(o protocols.Objective) (c *channel.Channel, direct bool)
+	// Challenge escalates a stalled objective of this type to an on-chain challenge, and returns false if the objective is already challenging.
+	// It is nil if objectives of this type cannot be escalated.
+	Challenge func(o protocols.Objective) (protocols.Objective, bool)
+}
+
+// Scope is what an objective request touches: the peers whose ledger channels fund the channel it opens, or the channel it closes.
+type Scope struct {
+	Peers   []types.Address
+	Channel types.Destination
+}
+
+// ObjectiveType is a kind of objective, implemented by a protocol package.
+type ObjectiveType struct {
+	// Prefix is the prefix of the ids of objectives of this type, e.g. "DirectFunding-"
+	Prefix string
+	// ConstructFromPayload constructs an objective of this type from a payload sent by a peer.
+	ConstructFromPayload func(p protocols.ObjectivePayload, deps Dependencies) (protocols.Objective, error)
+	// Decode decodes an objective of this type from its stored JSON. The decoded objective has no channel data other than channel ids.
+	Decode func(data []byte) (protocols.Objective, error)
+	// PopulateChannelData attaches stored channel data to a decoded objective of this type, in place of its existing channel pointers.
+	PopulateChannelData func(o protocols.Objective, data ChannelData) error
+	// LedgerProposal is the type of ledger proposal made by objectives of this type, if any, and ProposalChannelType the type of channel those proposals target.
+	// Ledger proposals received from peers are routed to the objective of the matching type for the targeted channel.
+	LedgerProposal      consensus_channel.ProposalType
+	ProposalChannelType types.ChannelType
+	// RequestScope returns the scope of an objective request, and false if the request is not for an objective of this type.
+	// The engine handles requests with disjoint scopes concurrently. It is nil if requests for objectives of this type are handled one at a time.
+	RequestScope func(r protocols.ObjectiveRequest) (Scope, bool)
+	Policy       Policy
+}
+
+// Name returns the name of the objective type, e.g. "DirectFunding"
+func (t ObjectiveType) Name() string {
+	return strings.TrimSuffix(t.Prefix, "-")
+}
+
+var (
+	mu             sync.RWMutex
+	objectiveTypes = builtinTypes() // keyed by name
+)
+
+// Register adds an objective type to the registry. It returns an error if the type is incomplete, or clashes with a registered type.
+func Register(t ObjectiveType) error {
+	name := t.Name()
+	if name == "" || strings.Contains(name, "-") || !strings.HasSuffix(t.Prefix, "-") {
+		return fmt.Errorf("objective prefix %q must be a name followed by a single -", t.Prefix)
+	}
+	if t.ConstructFromPayload == nil || t.Decode == nil || t.PopulateChannelData == nil {
+		return fmt.Errorf("%s objective type must be able to construct, decode and populate objectives", name)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := objectiveTypes[name]; ok {
+		return fmt.Errorf("%s objective type is already registered", name)
+	}
+	if t.LedgerProposal != "" {
+		for _, other := range objectiveTypes {
+			if other.LedgerProposal == t.LedgerProposal && other.ProposalChannelType == t.ProposalChannelType {
+				return fmt.Errorf("%s objectives already make %s ledger proposals for this type of channel", other.Name(), t.LedgerProposal)
+			}
+		}
+	}
+	objectiveTypes[name] = t
+	return nil
+}
+
+// Lookup returns the type of the objective with the given id, and false if no such type is registered.
+func Lookup(id protocols.ObjectiveId) (ObjectiveType, bool) {
+	name, _, _ := strings.Cut(string(id), "-")
+	return LookupName(name)
+}
+
+// LookupName returns the objective type with the given name, e.g. "DirectFunding", and false if no such type is registered.
+func LookupName(name string) (ObjectiveType, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	t, ok := objectiveTypes[name]
+	return t, ok
+}
+
+// Names returns the names of the registered objective types in alphabetical order.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(objectiveTypes))
+	for name := range objectiveTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Decode decodes the stored JSON of the objective with the given id. The decoded objective has no channel data other than channel ids.
+func Decode(id protocols.ObjectiveId, data []byte) (protocols.Objective, error) {
+	t, ok := Lookup(id)
+	if !ok {
+		return nil, fmt.Errorf("objective id %s does not correspond to a known Objective type", id)
+	}
+	return t.Decode(data)
+}
+
+// PopulateChannelData attaches stored channel data to a decoded objective.
+func PopulateChannelData(o protocols.Objective, data ChannelData) error {
+	t, ok := Lookup(o.Id())
+	if !ok {
+		return fmt.Errorf("objective %s did not correctly represent a known Objective type", o.Id())
+	}
+	return t.PopulateChannelData(o, data)
+}
+
+// RequestScope returns the scope of an objective request, and false if no registered objective type supplies it.
+func RequestScope(r protocols.ObjectiveRequest) (Scope, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, t := range objectiveTypes {
+		if t.RequestScope == nil {
+			continue
+		}
+		if scope, ok := t.RequestScope(r); ok {
+			return scope, true
+		}
+	}
+	return Scope{}, false
+}
+
+// ProposalObjectiveId returns the id of the objective which makes ledger proposals of the given type targeting the given channel,
+// and false if no registered objective type makes such proposals.
+func ProposalObjectiveId(proposalType consensus_channel.ProposalType, target types.Destination, channelType types.ChannelType) (protocols.ObjectiveId, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, t := range objectiveTypes {
+		if t.LedgerProposal == proposalType && t.ProposalChannelType == channelType {
+			return protocols.ObjectiveId(t.Prefix + target.String()), true
+		}
+	}
+	return "", false
+}
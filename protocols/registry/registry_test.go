@@ -0,0 +1,134 @@
+package registry_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/statechannels/go-nitro/channel/consensus_channel"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/protocols/directdefund"
+	"github.com/statechannels/go-nitro/protocols/registry"
+	"github.com/statechannels/go-nitro/protocols/swap"
+	"github.com/statechannels/go-nitro/protocols/swapdefund"
+	"github.com/statechannels/go-nitro/protocols/virtualfund"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// testObjective is a minimal objective of a type registered by a test, standing in for a protocol implemented outside this module
+type testObjective struct {
+	protocols.Objective
+	ObjectiveId protocols.ObjectiveId
+}
+
+func (o *testObjective) Id() protocols.ObjectiveId { return o.ObjectiveId }
+
+func testObjectiveType(prefix string) registry.ObjectiveType {
+	return registry.ObjectiveType{
+		Prefix: prefix,
+		ConstructFromPayload: func(p protocols.ObjectivePayload, deps registry.Dependencies) (protocols.Objective, error) {
+			return &testObjective{ObjectiveId: p.ObjectiveId}, nil
+		},
+		Decode: func(data []byte) (protocols.Objective, error) {
+			var stored struct{ ObjectiveId protocols.ObjectiveId }
+			err := json.Unmarshal(data, &stored)
+			return &testObjective{ObjectiveId: stored.ObjectiveId}, err
+		},
+		PopulateChannelData: func(o protocols.Objective, data registry.ChannelData) error { return nil },
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := registry.Register(testObjectiveType("Test-")); err != nil {
+		t.Fatal(err)
+	}
+
+	objectiveType, ok := registry.Lookup("Test-0x01")
+	if !ok || objectiveType.Name() != "Test" {
+		t.Fatalf("expected to look up the registered type, got %+v, %t", objectiveType, ok)
+	}
+	o, err := registry.Decode("Test-0x01", []byte(`{"ObjectiveId":"Test-0x01"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Id() != "Test-0x01" {
+		t.Fatalf("expected to decode objective Test-0x01, got %s", o.Id())
+	}
+
+	invalid := map[string]registry.ObjectiveType{
+		"duplicate":       testObjectiveType("Test-"),
+		"builtin":         testObjectiveType(directdefund.ObjectivePrefix),
+		"no separator":    testObjectiveType("Other"),
+		"two separators":  testObjectiveType("Other-Type-"),
+		"missing decoder": {Prefix: "Other-", ConstructFromPayload: testObjectiveType("Other-").ConstructFromPayload},
+		"clashing proposals": func() registry.ObjectiveType {
+			t := testObjectiveType("Other-")
+			t.LedgerProposal, t.ProposalChannelType = consensus_channel.AddProposal, types.Virtual
+			return t
+		}(),
+	}
+	for name, objectiveType := range invalid {
+		if err := registry.Register(objectiveType); err == nil {
+			t.Fatalf("expected an error registering a %s objective type", name)
+		}
+	}
+	if _, ok := registry.Lookup("Other-0x01"); ok {
+		t.Fatal("expected no invalid objective type to be registered")
+	}
+}
+
+func TestProposalObjectiveId(t *testing.T) {
+	target := types.Destination{1}
+	testCases := []struct {
+		proposalType consensus_channel.ProposalType
+		channelType  types.ChannelType
+		want         protocols.ObjectiveId
+		wantOk       bool
+	}{
+		{consensus_channel.AddProposal, types.Virtual, protocols.ObjectiveId(virtualfund.ObjectivePrefix + target.String()), true},
+		{consensus_channel.RemoveProposal, types.Swap, protocols.ObjectiveId(swapdefund.ObjectivePrefix + target.String()), true},
+		{consensus_channel.AddProposal, types.Ledger, "", false},
+	}
+
+	for _, tc := range testCases {
+		id, ok := registry.ProposalObjectiveId(tc.proposalType, target, tc.channelType)
+		if id != tc.want || ok != tc.wantOk {
+			t.Fatalf("expected %s, %t for a %s proposal, got %s, %t", tc.want, tc.wantOk, tc.proposalType, id, ok)
+		}
+	}
+}
+
+func TestRequestScope(t *testing.T) {
+	alice, bob, irene := types.Address{1}, types.Address{2}, types.Address{3}
+	channelId := types.Destination{4}
+
+	scope, ok := registry.RequestScope(virtualfund.ObjectiveRequest{Intermediaries: []types.Address{irene}, CounterParty: bob})
+	if !ok || !reflect.DeepEqual(scope.Peers, []types.Address{irene, bob}) || !scope.Channel.IsZero() {
+		t.Fatalf("expected a virtualfund request to be scoped to its intermediary and counterparty, got %+v, %t", scope, ok)
+	}
+	scope, ok = registry.RequestScope(directdefund.NewObjectiveRequest(channelId, false))
+	if !ok || scope.Channel != channelId || len(scope.Peers) != 0 {
+		t.Fatalf("expected a directdefund request to be scoped to its channel, got %+v, %t", scope, ok)
+	}
+	if _, ok := registry.RequestScope(swap.ObjectiveRequest{}); ok {
+		t.Fatal("expected a swap request to have no scope")
+	}
+
+	scoped := testObjectiveType("Scoped-")
+	scoped.RequestScope = func(r protocols.ObjectiveRequest) (registry.Scope, bool) {
+		_, ok := r.(testRequest)
+		return registry.Scope{Peers: []types.Address{alice}}, ok
+	}
+	if err := registry.Register(scoped); err != nil {
+		t.Fatal(err)
+	}
+	scope, ok = registry.RequestScope(testRequest{})
+	if !ok || !reflect.DeepEqual(scope.Peers, []types.Address{alice}) {
+		t.Fatalf("expected a request of a registered type to be scoped by that type, got %+v, %t", scope, ok)
+	}
+}
+
+// testRequest is a request for an objective of a type registered by a test
+type testRequest struct {
+	protocols.ObjectiveRequest
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/channel/state/outcome"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
 )
@@ -304,7 +305,7 @@ func (o *Objective) hasFinalStateFromAlice() bool {
 }
 
 // Crank inspects the extended state and declares a list of Effects to be executed.
-func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) Crank(signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	updated := o.clone()
 	sideEffects := protocols.SideEffects{}
 	// Input validation
@@ -336,7 +337,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 			s = updated.finalState()
 		}
 		// Sign and store:
-		ss, err := updated.S.SignAndAddState(s, secretKey)
+		ss, err := updated.S.SignAndAddState(s, signer)
 		if err != nil {
 			return &updated, sideEffects, WaitingForNothing, fmt.Errorf("could not sign final state: %w", err)
 		}
@@ -354,7 +355,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	}
 
 	if !updated.isAlice() && !updated.leftHasDefunded() {
-		ledgerSideEffects, err := updated.updateLedgerToRemoveGuarantee(updated.ToMyLeft, secretKey)
+		ledgerSideEffects, err := updated.updateLedgerToRemoveGuarantee(updated.ToMyLeft, signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, fmt.Errorf("error updating ledger funding: %w", err)
 		}
@@ -362,7 +363,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	}
 
 	if !updated.isBob() && !updated.rightHasDefunded() {
-		ledgerSideEffects, err := updated.updateLedgerToRemoveGuarantee(updated.ToMyRight, secretKey)
+		ledgerSideEffects, err := updated.updateLedgerToRemoveGuarantee(updated.ToMyRight, signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, fmt.Errorf("error updating ledger funding: %w", err)
 		}
@@ -424,7 +425,7 @@ func (o *Objective) ledgerProposal(ledger *consensus_channel.ConsensusChannel) [
 }
 
 // updateLedgerToRemoveGuarantee updates the ledger channel to remove the guarantee that funds V.
-func (o *Objective) updateLedgerToRemoveGuarantee(ledger *consensus_channel.ConsensusChannel, sk *[]byte) (protocols.SideEffects, error) {
+func (o *Objective) updateLedgerToRemoveGuarantee(ledger *consensus_channel.ConsensusChannel, signer crypto.Signer) (protocols.SideEffects, error) {
 	var sideEffects protocols.SideEffects
 
 	proposals := o.ledgerProposal(ledger)
@@ -445,7 +446,7 @@ func (o *Objective) updateLedgerToRemoveGuarantee(ledger *consensus_channel.Cons
 		}
 
 		for _, p := range proposals {
-			_, err := ledger.Propose(p, *sk)
+			_, err := ledger.Propose(p, signer)
 			if err != nil {
 				return protocols.SideEffects{}, fmt.Errorf("error proposing ledger update: %w", err)
 			}
@@ -465,7 +466,7 @@ func (o *Objective) updateLedgerToRemoveGuarantee(ledger *consensus_channel.Cons
 			proposedNext := ledger.HasRemovalBeenProposedNext(o.VId(), p.ToRemove.AssetAddress)
 
 			if proposedNext {
-				sp, err := ledger.SignNextProposal(p, *sk)
+				sp, err := ledger.SignNextProposal(p, signer)
 				if err != nil {
 					return protocols.SideEffects{}, fmt.Errorf("could not sign proposal: %w", err)
 				}
@@ -13,6 +13,7 @@ import (
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/channel/state/outcome"
+	"github.com/statechannels/go-nitro/crypto"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
 )
@@ -90,6 +91,9 @@ func (c *Connection) handleProposal(sp consensus_channel.SignedProposal) error {
 		if errors.Is(err, consensus_channel.ErrInvalidTurnNum) {
 			return nil
 		}
+		if errors.Is(err, consensus_channel.ErrInvalidProposalSignature) || errors.Is(err, consensus_channel.ErrWrongSigner) {
+			return err
+		}
 	}
 
 	return nil
@@ -416,7 +420,7 @@ func (o *Objective) Update(raw protocols.ObjectivePayload) (protocols.Objective,
 // Crank inspects the extended state and declares a list of Effects to be executed
 // It's like a state machine transition function where the finite / enumerable state is returned (computed from the extended state)
 // rather than being independent of the extended state; and where there is only one type of event ("the crank") with no data on it at all.
-func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) Crank(signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	updated := o.clone()
 
 	sideEffects := protocols.SideEffects{}
@@ -428,7 +432,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	// Prefunding
 
 	if !updated.S.PreFundSignedByMe() {
-		ss, err := updated.S.SignAndAddPrefund(secretKey)
+		ss, err := updated.S.SignAndAddPrefund(signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, err
 		}
@@ -449,7 +453,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 
 	if !updated.isAlice() && !updated.ToMyLeft.IsFundingTheTarget() {
 
-		ledgerSideEffects, err := updated.updateLedgerWithGuarantee(*updated.ToMyLeft, secretKey)
+		ledgerSideEffects, err := updated.updateLedgerWithGuarantee(*updated.ToMyLeft, signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, fmt.Errorf("%w: %w", ErrUpdatingLedgerFunding, err)
 		}
@@ -457,7 +461,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	}
 
 	if !updated.isBob() && !updated.ToMyRight.IsFundingTheTarget() {
-		ledgerSideEffects, err := updated.updateLedgerWithGuarantee(*updated.ToMyRight, secretKey)
+		ledgerSideEffects, err := updated.updateLedgerWithGuarantee(*updated.ToMyRight, signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, fmt.Errorf("%w: %w", ErrUpdatingLedgerFunding, err)
 		}
@@ -470,7 +474,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 
 	// Postfunding
 	if !updated.S.PostFundSignedByMe() {
-		ss, err := updated.S.SignAndAddPostfund(secretKey)
+		ss, err := updated.S.SignAndAddPostfund(signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, err
 		}
@@ -667,8 +671,20 @@ func (c *Connection) expectedProposal() map[common.Address]consensus_channel.Pro
 	return proposals
 }
 
+// RollbackProposals returns the proposals which remove the connection's guarantees from the ledger, returning the deposits to the ledger balances.
+func (c *Connection) RollbackProposals() []consensus_channel.Proposal {
+	proposals := []consensus_channel.Proposal{}
+	g := c.getExpectedGuarantee()
+
+	for asset, amount := range c.GuaranteeInfo.LeftAmount {
+		proposals = append(proposals, consensus_channel.NewRemoveProposal(c.Channel.Id, g[asset].Target(), amount, asset))
+	}
+
+	return proposals
+}
+
 // proposeLedgerUpdate will propose a ledger update to the channel by crafting a new state
-func (o *Objective) proposeLedgerUpdate(connection Connection, sk *[]byte) (protocols.SideEffects, error) {
+func (o *Objective) proposeLedgerUpdate(connection Connection, signer crypto.Signer) (protocols.SideEffects, error) {
 	ledger := connection.Channel
 
 	if !ledger.IsLeader() {
@@ -681,7 +697,7 @@ func (o *Objective) proposeLedgerUpdate(connection Connection, sk *[]byte) (prot
 	proposals := connection.expectedProposal()
 
 	for _, p := range proposals {
-		_, err := ledger.Propose(p, *sk)
+		_, err := ledger.Propose(p, signer)
 		if err != nil {
 			return protocols.SideEffects{}, err
 		}
@@ -699,13 +715,13 @@ func (o *Objective) proposeLedgerUpdate(connection Connection, sk *[]byte) (prot
 }
 
 // acceptLedgerUpdate checks for a ledger state proposal and accepts that proposal if it satisfies the expected guarantee.
-func (o *Objective) acceptLedgerUpdate(c Connection, sk *[]byte, a common.Address) (protocols.SideEffects, error) {
+func (o *Objective) acceptLedgerUpdate(c Connection, signer crypto.Signer, a common.Address) (protocols.SideEffects, error) {
 	ledger := c.Channel
 	sideEffects := protocols.SideEffects{}
 	expectedProposals := c.expectedProposal()
 
 	p := expectedProposals[a]
-	sp, err := ledger.SignNextProposal(p, *sk)
+	sp, err := ledger.SignNextProposal(p, signer)
 	if err != nil {
 		return protocols.SideEffects{}, fmt.Errorf("no proposed state found for ledger channel %w", err)
 	}
@@ -725,7 +741,7 @@ func (o *Objective) acceptLedgerUpdate(c Connection, sk *[]byte, a common.Addres
 // updateLedgerWithGuarantee updates the ledger channel funding to include the guarantee.
 // If the user is the proposer a new ledger state will be created and signed.
 // If the user is the follower then they will sign a ledger state proposal if it satisfies their expected guarantees.
-func (o *Objective) updateLedgerWithGuarantee(ledgerConnection Connection, sk *[]byte) (protocols.SideEffects, error) {
+func (o *Objective) updateLedgerWithGuarantee(ledgerConnection Connection, signer crypto.Signer) (protocols.SideEffects, error) {
 	ledger := ledgerConnection.Channel
 
 	var sideEffects protocols.SideEffects
@@ -748,7 +764,7 @@ func (o *Objective) updateLedgerWithGuarantee(ledgerConnection Connection, sk *[
 		if proposed {
 			return protocols.SideEffects{}, nil
 		}
-		se, err := o.proposeLedgerUpdate(ledgerConnection, sk)
+		se, err := o.proposeLedgerUpdate(ledgerConnection, signer)
 		if err != nil {
 			return protocols.SideEffects{}, fmt.Errorf("error proposing ledger update: %w", err)
 		}
@@ -762,7 +778,7 @@ func (o *Objective) updateLedgerWithGuarantee(ledgerConnection Connection, sk *[
 			}
 
 			if proposedNext {
-				se, err := o.acceptLedgerUpdate(ledgerConnection, sk, a)
+				se, err := o.acceptLedgerUpdate(ledgerConnection, signer, a)
 				if err != nil {
 					return protocols.SideEffects{}, fmt.Errorf("error proposing ledger update: %w", err)
 				}
@@ -0,0 +1,124 @@
+package protocols
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/statechannels/go-nitro/types"
+)
+
+// chainTransactionJSON is the JSON encoding of a ChainTransaction, which records the type of the transaction alongside it
+type chainTransactionJSON struct {
+	Type      string
+	ChannelId types.Destination
+	Data      json.RawMessage
+}
+
+// ChainTransactionType returns the name of the type of the transaction, e.g. "DepositTransaction"
+func ChainTransactionType(tx ChainTransaction) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", tx), "protocols.")
+}
+
+// MarshalChainTransaction encodes a transaction as JSON, so that it can be persisted and decoded by UnmarshalChainTransaction
+func MarshalChainTransaction(tx ChainTransaction) ([]byte, error) {
+	// The embedded ChainTransaction only holds the channel id, which is encoded separately
+	var data interface{}
+	switch tx := tx.(type) {
+	case DepositTransaction:
+		tx.ChainTransaction = nil
+		data = tx
+	case WithdrawAllTransaction:
+		tx.ChainTransaction = nil
+		data = tx
+	case MirrorWithdrawAllTransaction:
+		tx.ChainTransaction = nil
+		data = tx
+	case ChallengeTransaction:
+		tx.ChainTransaction = nil
+		data = tx
+	case TransferAllTransaction:
+		tx.ChainTransaction = nil
+		data = tx
+	case MirrorTransferAllTransaction:
+		tx.ChainTransaction = nil
+		data = tx
+	case CheckpointTransaction:
+		tx.ChainTransaction = nil
+		data = tx
+	case ReclaimTransaction:
+		tx.ChainTransaction = nil
+		data = tx
+	case SetL2ToL1Transaction:
+		tx.ChainTransaction = nil
+		data = tx
+	default:
+		return nil, fmt.Errorf("unexpected chain transaction type %T", tx)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(chainTransactionJSON{Type: ChainTransactionType(tx), ChannelId: tx.ChannelId(), Data: raw})
+}
+
+// UnmarshalChainTransaction decodes a transaction encoded by MarshalChainTransaction
+func UnmarshalChainTransaction(b []byte) (ChainTransaction, error) {
+	var j chainTransactionJSON
+	err := json.Unmarshal(b, &j)
+	if err != nil {
+		return nil, err
+	}
+	base := ChainTransactionBase{channelId: j.ChannelId}
+
+	switch j.Type {
+	case "DepositTransaction":
+		tx := DepositTransaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	case "WithdrawAllTransaction":
+		tx := WithdrawAllTransaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	case "MirrorWithdrawAllTransaction":
+		tx := MirrorWithdrawAllTransaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	case "ChallengeTransaction":
+		tx := ChallengeTransaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	case "TransferAllTransaction":
+		tx := TransferAllTransaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	case "MirrorTransferAllTransaction":
+		tx := MirrorTransferAllTransaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	case "CheckpointTransaction":
+		tx := CheckpointTransaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	case "ReclaimTransaction":
+		tx := ReclaimTransaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	case "SetL2ToL1Transaction":
+		tx := SetL2ToL1Transaction{}
+		err = json.Unmarshal(j.Data, &tx)
+		tx.ChainTransaction = base
+		return tx, err
+	default:
+		return nil, fmt.Errorf("unexpected chain transaction type %s", j.Type)
+	}
+}
@@ -0,0 +1,47 @@
+package protocols
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/types"
+)
+
+func TestChainTransactionJSON(t *testing.T) {
+	signedState := state.NewSignedState(state.TestState)
+	channelId := state.TestState.ChannelId()
+
+	txs := []ChainTransaction{
+		NewDepositTransaction(channelId, types.Funds{common.Address{}: big.NewInt(3), common.HexToAddress("0x1234"): big.NewInt(1)}),
+		NewWithdrawAllTransaction(channelId, signedState),
+		NewChallengeTransaction(channelId, signedState, []state.SignedState{signedState}, state.Signature{R: common.Hash{1}.Bytes(), S: common.Hash{2}.Bytes(), V: 27}),
+		NewCheckpointTransaction(channelId, signedState, []state.SignedState{}),
+		NewTransferAllTransaction(channelId, signedState),
+		NewMirrorTransferAllTransaction(channelId, signedState),
+		NewMirrorWithdrawAllTransaction(channelId, signedState),
+		NewSetL2ToL1Transaction(channelId, types.Destination{1}),
+	}
+
+	for _, tx := range txs {
+		t.Run(ChainTransactionType(tx), func(t *testing.T) {
+			b, err := MarshalChainTransaction(tx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := UnmarshalChainTransaction(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tx, got, cmp.AllowUnexported(ChainTransactionBase{}, state.SignedState{}, big.Int{})); diff != "" {
+				t.Fatalf("decoded transaction did not match (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := UnmarshalChainTransaction([]byte(`{"Type":"UnknownTransaction"}`)); err == nil {
+		t.Fatal("expected a transaction of unknown type to be rejected")
+	}
+}
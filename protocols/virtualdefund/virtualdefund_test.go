@@ -114,7 +114,7 @@ func testCrankAs(my ta.Actor) func(t *testing.T) {
 		virtualDefund, err := NewObjective(request, true, my.Address(), ourPaymentAmount, getChannel, getConsensusChannel)
 		testhelpers.Ok(t, err)
 
-		updatedObj, se, waitingFor, err := virtualDefund.Crank(&my.PrivateKey)
+		updatedObj, se, waitingFor, err := virtualDefund.Crank(my.Signer())
 		testhelpers.Ok(t, err)
 		updated := updatedObj.(*Objective)
 
@@ -130,7 +130,7 @@ func testCrankAs(my ta.Actor) func(t *testing.T) {
 			err = ss.AddSignature(aliceSig)
 			testhelpers.Ok(t, err)
 			updated.V.AddSignedState(ss)
-			updatedObj, se, waitingFor, err = updated.Crank(&my.PrivateKey)
+			updatedObj, se, waitingFor, err = updated.Crank(my.Signer())
 			testhelpers.Ok(t, err)
 			updated = updatedObj.(*Objective)
 		}
@@ -152,7 +152,7 @@ func testCrankAs(my ta.Actor) func(t *testing.T) {
 		}
 		updated.V.AddSignedState(ss)
 
-		updatedObj, se, waitingFor, err = updated.Crank(&my.PrivateKey)
+		updatedObj, se, waitingFor, err = updated.Crank(my.Signer())
 		updated = updatedObj.(*Objective)
 		testhelpers.Ok(t, err)
 
@@ -173,7 +173,7 @@ func testCrankAs(my ta.Actor) func(t *testing.T) {
 			updated = updatedObj.(*Objective)
 		}
 
-		updatedObj, se, waitingFor, err = updated.Crank(&my.PrivateKey)
+		updatedObj, se, waitingFor, err = updated.Crank(my.Signer())
 		updated = updatedObj.(*Objective)
 		testhelpers.Ok(t, err)
 
@@ -15,6 +15,8 @@ import (
 	"github.com/statechannels/go-nitro/channel/consensus_channel"
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/channel/state/outcome"
+	"github.com/statechannels/go-nitro/crypto"
+	"github.com/statechannels/go-nitro/payments"
 	"github.com/statechannels/go-nitro/protocols"
 	"github.com/statechannels/go-nitro/types"
 )
@@ -89,6 +91,9 @@ func (c *Connection) handleProposal(sp consensus_channel.SignedProposal) error {
 		if errors.Is(err, consensus_channel.ErrInvalidTurnNum) {
 			return nil
 		}
+		if errors.Is(err, consensus_channel.ErrInvalidProposalSignature) || errors.Is(err, consensus_channel.ErrWrongSigner) {
+			return err
+		}
 	}
 
 	return nil
@@ -344,6 +349,16 @@ func (o *Objective) GetStatus() protocols.ObjectiveStatus {
 	return o.Status
 }
 
+// RegisterPaymentChannel registers the channel being funded with the voucher manager, so that payments made with it can be tracked.
+func (o *Objective) RegisterPaymentChannel(vm *payments.VoucherManager) error {
+	postfund := o.V.PostFundState()
+	startingBalance := big.NewInt(0)
+	// TODO: Assumes one asset for now
+	startingBalance.Set(postfund.Outcome[0].Allocations[0].Amount)
+
+	return vm.Register(o.V.Id, payments.GetPayer(postfund.Participants), payments.GetPayee(postfund.Participants), startingBalance)
+}
+
 func (o *Objective) otherParticipants() []types.Address {
 	otherParticipants := make([]types.Address, 0)
 	for i, p := range o.V.Participants {
@@ -427,7 +442,7 @@ func (o *Objective) Update(raw protocols.ObjectivePayload) (protocols.Objective,
 // Crank inspects the extended state and declares a list of Effects to be executed
 // It's like a state machine transition function where the finite / enumerable state is returned (computed from the extended state)
 // rather than being independent of the extended state; and where there is only one type of event ("the crank") with no data on it at all.
-func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
+func (o *Objective) Crank(signer crypto.Signer) (protocols.Objective, protocols.SideEffects, protocols.WaitingFor, error) {
 	updated := o.clone()
 
 	sideEffects := protocols.SideEffects{}
@@ -439,7 +454,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	// Prefunding
 
 	if !updated.V.PreFundSignedByMe() {
-		ss, err := updated.V.SignAndAddPrefund(secretKey)
+		ss, err := updated.V.SignAndAddPrefund(signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, err
 		}
@@ -460,7 +475,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 
 	if !updated.isAlice() && !updated.ToMyLeft.IsFundingTheTarget() {
 
-		ledgerSideEffects, err := updated.updateLedgerWithGuarantee(*updated.ToMyLeft, secretKey)
+		ledgerSideEffects, err := updated.updateLedgerWithGuarantee(*updated.ToMyLeft, signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, fmt.Errorf("%w: %w", ErrUpdatingLedgerFunding, err)
 		}
@@ -468,7 +483,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 	}
 
 	if !updated.isBob() && !updated.ToMyRight.IsFundingTheTarget() {
-		ledgerSideEffects, err := updated.updateLedgerWithGuarantee(*updated.ToMyRight, secretKey)
+		ledgerSideEffects, err := updated.updateLedgerWithGuarantee(*updated.ToMyRight, signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, fmt.Errorf("%w: %w", ErrUpdatingLedgerFunding, err)
 		}
@@ -481,7 +496,7 @@ func (o *Objective) Crank(secretKey *[]byte) (protocols.Objective, protocols.Sid
 
 	// Postfunding
 	if !updated.V.PostFundSignedByMe() {
-		ss, err := updated.V.SignAndAddPostfund(secretKey)
+		ss, err := updated.V.SignAndAddPostfund(signer)
 		if err != nil {
 			return o, protocols.SideEffects{}, WaitingForNothing, err
 		}
@@ -678,8 +693,14 @@ func (c *Connection) expectedProposal() consensus_channel.Proposal {
 	return proposal
 }
 
+// RollbackProposals returns the proposals which remove the connection's guarantee from the ledger, returning the deposits to the ledger balances.
+func (c *Connection) RollbackProposals() []consensus_channel.Proposal {
+	add := c.expectedProposal().ToAdd
+	return []consensus_channel.Proposal{consensus_channel.NewRemoveProposal(c.Channel.Id, add.Target(), add.LeftDeposit, add.AssetAddress)}
+}
+
 // proposeLedgerUpdate will propose a ledger update to the channel by crafting a new state
-func (o *Objective) proposeLedgerUpdate(connection Connection, sk *[]byte) (protocols.SideEffects, error) {
+func (o *Objective) proposeLedgerUpdate(connection Connection, signer crypto.Signer) (protocols.SideEffects, error) {
 	ledger := connection.Channel
 
 	if !ledger.IsLeader() {
@@ -688,7 +709,7 @@ func (o *Objective) proposeLedgerUpdate(connection Connection, sk *[]byte) (prot
 
 	sideEffects := protocols.SideEffects{}
 
-	_, err := ledger.Propose(connection.expectedProposal(), *sk)
+	_, err := ledger.Propose(connection.expectedProposal(), signer)
 	if err != nil {
 		return protocols.SideEffects{}, err
 	}
@@ -705,9 +726,9 @@ func (o *Objective) proposeLedgerUpdate(connection Connection, sk *[]byte) (prot
 }
 
 // acceptLedgerUpdate checks for a ledger state proposal and accepts that proposal if it satisfies the expected guarantee.
-func (o *Objective) acceptLedgerUpdate(c Connection, sk *[]byte) (protocols.SideEffects, error) {
+func (o *Objective) acceptLedgerUpdate(c Connection, signer crypto.Signer) (protocols.SideEffects, error) {
 	ledger := c.Channel
-	sp, err := ledger.SignNextProposal(c.expectedProposal(), *sk)
+	sp, err := ledger.SignNextProposal(c.expectedProposal(), signer)
 	if err != nil {
 		return protocols.SideEffects{}, fmt.Errorf("no proposed state found for ledger channel %w", err)
 	}
@@ -730,7 +751,7 @@ func (o *Objective) acceptLedgerUpdate(c Connection, sk *[]byte) (protocols.Side
 // updateLedgerWithGuarantee updates the ledger channel funding to include the guarantee.
 // If the user is the proposer a new ledger state will be created and signed.
 // If the user is the follower then they will sign a ledger state proposal if it satisfies their expected guarantees.
-func (o *Objective) updateLedgerWithGuarantee(ledgerConnection Connection, sk *[]byte) (protocols.SideEffects, error) {
+func (o *Objective) updateLedgerWithGuarantee(ledgerConnection Connection, signer crypto.Signer) (protocols.SideEffects, error) {
 	ledger := ledgerConnection.Channel
 
 	var sideEffects protocols.SideEffects
@@ -746,7 +767,7 @@ func (o *Objective) updateLedgerWithGuarantee(ledgerConnection Connection, sk *[
 		if proposed {
 			return protocols.SideEffects{}, nil
 		}
-		se, err := o.proposeLedgerUpdate(ledgerConnection, sk)
+		se, err := o.proposeLedgerUpdate(ledgerConnection, signer)
 		if err != nil {
 			return protocols.SideEffects{}, fmt.Errorf("error proposing ledger update: %w", err)
 		}
@@ -759,7 +780,7 @@ func (o *Objective) updateLedgerWithGuarantee(ledgerConnection Connection, sk *[
 		proposedNext, _ := ledger.IsProposedNext(g, a)
 		if proposedNext {
 
-			se, err := o.acceptLedgerUpdate(ledgerConnection, sk)
+			se, err := o.acceptLedgerUpdate(ledgerConnection, signer)
 			if err != nil {
 				return protocols.SideEffects{}, fmt.Errorf("error proposing ledger update: %w", err)
 			}
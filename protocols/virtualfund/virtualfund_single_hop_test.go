@@ -225,7 +225,7 @@ func TestCrankAsAlice(t *testing.T) {
 		s, _     = constructFromState(false, vPreFund, my.Address(), ledgers[my.Destination()].left, ledgers[my.Destination()].right)
 	)
 	// Assert that cranking an unapproved objective returns an error
-	_, _, _, err := s.Crank(&my.PrivateKey)
+	_, _, _, err := s.Crank(my.Signer())
 	Assert(t, err != nil, `Expected error when cranking unapproved objective, but got nil`)
 
 	// Approve the objective, so that the rest of the test cases can run.
@@ -237,7 +237,7 @@ func TestCrankAsAlice(t *testing.T) {
 	// need to remember to convert the result back to a virtualfund.Objective struct
 
 	// Initial Crank
-	oObj, effects, waitingFor, err := o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err := o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	expectedSignedState := state.NewSignedState(o.V.PreFundState())
@@ -262,7 +262,7 @@ func TestCrankAsAlice(t *testing.T) {
 
 	// Cranking should move us to the next waiting point, update the ledger channel, and alter the extended state to reflect that
 	// TODO: Check that ledger channel is updated as expected
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	oGuarantee, _ := o.ToMyRight.getExpectedGuaranteeAndAsset()
@@ -275,7 +275,7 @@ func TestCrankAsAlice(t *testing.T) {
 
 	// Check idempotency
 	emptySideEffects := protocols.SideEffects{}
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 	Ok(t, err)
 	Equals(t, effects, emptySideEffects)
@@ -289,7 +289,7 @@ func TestCrankAsAlice(t *testing.T) {
 	o = oObj.(*Objective)
 	Ok(t, err)
 
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	postFS := state.NewSignedState(o.V.PostFundState())
@@ -311,7 +311,7 @@ func TestCrankAsBob(t *testing.T) {
 		s, _     = constructFromState(false, vPreFund, my.Address(), ledgers[my.Destination()].left, ledgers[my.Destination()].right)
 	)
 	// Assert that cranking an unapproved objective returns an error
-	_, _, _, err := s.Crank(&my.PrivateKey)
+	_, _, _, err := s.Crank(my.Signer())
 	Assert(t, err != nil, `Expected error when cranking unapproved objective, but got nil`)
 
 	// Approve the objective, so that the rest of the test cases can run.
@@ -323,7 +323,7 @@ func TestCrankAsBob(t *testing.T) {
 	// need to remember to convert the result back to a virtualfund.Objective struct
 
 	// Initial Crank
-	oObj, effects, waitingFor, err := o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err := o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	expectedSignedState := state.NewSignedState(o.V.PreFundState())
@@ -349,7 +349,7 @@ func TestCrankAsBob(t *testing.T) {
 
 	// Cranking should move us to the next waiting point, update the ledger channel, and alter the extended state to reflect that
 	// TODO: Check that ledger channel is updated as expected
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	emptySideEffects := protocols.SideEffects{}
@@ -358,7 +358,7 @@ func TestCrankAsBob(t *testing.T) {
 	Equals(t, waitingFor, WaitingForCompleteFunding)
 
 	// Check idempotency
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 	Ok(t, err)
 	Equals(t, effects, emptySideEffects)
@@ -374,7 +374,7 @@ func TestCrankAsBob(t *testing.T) {
 	o = oObj.(*Objective)
 	Ok(t, err)
 
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	postFS := state.NewSignedState(o.V.PostFundState())
@@ -400,7 +400,7 @@ func TestCrankAsP1(t *testing.T) {
 		s, _     = constructFromState(false, vPreFund, my.Address(), left, right)
 	)
 	// Assert that cranking an unapproved objective returns an error
-	_, _, _, err := s.Crank(&my.PrivateKey)
+	_, _, _, err := s.Crank(my.Signer())
 	Assert(t, err != nil, `Expected error when cranking unapproved objective, but got nil`)
 
 	// Approve the objective, so that the rest of the test cases can run.
@@ -412,7 +412,7 @@ func TestCrankAsP1(t *testing.T) {
 	// need to remember to convert the result back to a virtualfund.Objective struct
 
 	// Initial Crank
-	oObj, effects, waitingFor, err := o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err := o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	expectedSignedState := state.NewSignedState(o.V.PreFundState())
@@ -435,7 +435,7 @@ func TestCrankAsP1(t *testing.T) {
 	assertSupportedPrefund(o, t)
 
 	// Cranking should move us to the next waiting point, update the ledger channel, and alter the extended state to reflect that
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	oGuarantee, _ := o.ToMyLeft.getExpectedGuaranteeAndAsset()
@@ -447,7 +447,7 @@ func TestCrankAsP1(t *testing.T) {
 
 	// Check idempotency
 	emptySideEffects := protocols.SideEffects{}
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 	Ok(t, err)
 	Equals(t, effects, emptySideEffects)
@@ -462,7 +462,7 @@ func TestCrankAsP1(t *testing.T) {
 	o = oObj.(*Objective)
 	Ok(t, err)
 
-	oObj, effects, waitingFor, err = o.Crank(&my.PrivateKey)
+	oObj, effects, waitingFor, err = o.Crank(my.Signer())
 	o = oObj.(*Objective)
 
 	postFS := state.NewSignedState(o.V.PostFundState())
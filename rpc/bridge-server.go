@@ -125,16 +125,6 @@ func (brs *BridgeRpcServer) registerHandlers() (err error) {
 
 				return brs.bridge.MirrorBridgedDefund(req.ChannelId, l2SignedState, req.IsChallenge)
 			})
-		case serde.RetryObjectiveTxMethod:
-			return processRequest(brs.BaseRpcServer, permSign, requestData, func(req serde.RetryObjectiveTxRequest) (protocols.ObjectiveId, error) {
-				err := brs.bridge.RetryObjectiveTx(req.ObjectiveId)
-				return req.ObjectiveId, err
-			})
-		case serde.RetryTxMethod:
-			return processRequest(brs.BaseRpcServer, permSign, requestData, func(req serde.RetryTxRequest) (string, error) {
-				err := brs.bridge.RetryTx(req.TxHash)
-				return req.TxHash.String(), err
-			})
 		case serde.GetObjectiveMethod:
 			return processRequest(brs.BaseRpcServer, permSign, requestData, func(req serde.GetObjectiveRequest) (string, error) {
 				objective, err := brs.bridge.GetObjectiveById(req.ObjectiveId, req.L2)
@@ -165,7 +155,7 @@ func (brs *BridgeRpcServer) registerHandlers() (err error) {
 			})
 		case serde.GetPendingBridgeTxsMethod:
 			return processRequest(brs.BaseRpcServer, permSign, requestData, func(req serde.GetPendingBridgeTxsRequest) (string, error) {
-				pendingBridgeTxs := brs.bridge.GetPendingBridgeTxs(req.ChannelId)
+				pendingBridgeTxs, err := brs.bridge.GetPendingBridgeTxs(req.ChannelId)
 				if err != nil {
 					return "", err
 				}
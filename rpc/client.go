@@ -86,6 +86,9 @@ type RpcClientApi interface {
 	CloseBridgeChannel(id types.Destination) (protocols.ObjectiveId, error)
 
 	CreatedMirrorChannel() <-chan types.Destination
+
+	// FailedObjectives returns a channel that receives an objective id and the reason whenever that objective has failed
+	FailedObjectives() <-chan protocols.FailedObjective
 }
 
 // rpcClient is the implementation
@@ -93,6 +96,7 @@ type rpcClient struct {
 	transport             transport.Requester
 	completedObjectives   *safesync.Map[chan struct{}]
 	createdMirrorChannels chan types.Destination
+	failedObjectives      chan protocols.FailedObjective
 	ledgerChannelUpdates  *safesync.Map[chan query.LedgerChannelInfo]
 	paymentChannelUpdates *safesync.Map[chan query.PaymentChannelInfo]
 	cancel                context.CancelFunc
@@ -114,6 +118,7 @@ func NewRpcClient(trans transport.Requester) (RpcClientApi, error) {
 	c := &rpcClient{
 		transport:             trans,
 		completedObjectives:   &safesync.Map[chan struct{}]{},
+		failedObjectives:      make(chan protocols.FailedObjective, 100),
 		ledgerChannelUpdates:  &safesync.Map[chan query.LedgerChannelInfo]{},
 		paymentChannelUpdates: &safesync.Map[chan query.PaymentChannelInfo]{},
 		cancel:                cancel,
@@ -316,6 +321,19 @@ func (rc *rpcClient) subscribeToNotifications(ctx context.Context, notificationC
 				case rc.createdMirrorChannels <- rpcRequest.Params.Payload:
 				default:
 				}
+			case serde.ObjectiveFailed:
+				rpcRequest := serde.JsonRpcSpecificRequest[protocols.FailedObjective]{}
+				err := json.Unmarshal(data, &rpcRequest)
+				rc.logger.Debug("Received notification", "method", method, "data", rpcRequest)
+				if err != nil {
+					panic(err)
+				}
+
+				// use a nonblocking send in case no one is listening
+				select {
+				case rc.failedObjectives <- rpcRequest.Params.Payload:
+				default:
+				}
 			}
 		}
 	}
@@ -420,3 +438,8 @@ func getNotificationMethod(raw []byte) (serde.NotificationMethod, error) {
 func (rc *rpcClient) CreatedMirrorChannel() <-chan types.Destination {
 	return rc.createdMirrorChannels
 }
+
+// FailedObjectives returns a chan that receives an objective id and the reason whenever that objective has failed
+func (rc *rpcClient) FailedObjectives() <-chan protocols.FailedObjective {
+	return rc.failedObjectives
+}
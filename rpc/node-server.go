@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"math/big"
 
 	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/internal/logging"
+	"github.com/statechannels/go-nitro/internal/tracing"
 	nitro "github.com/statechannels/go-nitro/node"
+	"github.com/statechannels/go-nitro/node/engine/store"
+	"github.com/statechannels/go-nitro/node/notifier"
 	"github.com/statechannels/go-nitro/node/query"
 	"github.com/statechannels/go-nitro/payments"
 	"github.com/statechannels/go-nitro/paymentsmanager"
@@ -69,14 +73,18 @@ func NewNodeRpcServer(nitroNode *nitro.Node, paymentManager paymentsmanager.Paym
 	nrs.cancel = cancel
 	nrs.wg.Add(1)
 
-	// The update channels are initialized syncronously.
-	// If these channels are initialized in another go routine,
-	// the server can send an update before the channels are initialized.
-	completedObjChan := nrs.node.CompletedObjectives()
-	ledgerUpdateChan := nrs.node.LedgerUpdates()
-	paymentUpdateChan := nrs.node.PaymentUpdates()
+	// The subscriptions are made syncronously.
+	// If they are made in another go routine,
+	// the server can send an update before the subscriptions exist.
+	// Should a transport fall behind, the oldest notifications are dropped rather than holding up the node.
+	opts := notifier.SubscriptionOpts{Overflow: notifier.DropOldest}
+	completedObjectives := nrs.node.SubscribeCompletedObjectives(opts)
+	ledgerUpdates := nrs.node.SubscribeLedgerUpdates(opts)
+	paymentUpdates := nrs.node.SubscribePaymentUpdates(opts)
+	challengeResponses := nrs.node.SubscribeChallengeResponses(opts)
+	failedObjectives := nrs.node.SubscribeFailedObjectives(opts)
 
-	go nrs.sendNotifications(ctx, completedObjChan, ledgerUpdateChan, paymentUpdateChan)
+	go nrs.sendNotifications(ctx, completedObjectives, ledgerUpdates, paymentUpdates, challengeResponses, failedObjectives)
 
 	err := nrs.registerHandlers()
 	if err != nil {
@@ -112,6 +120,11 @@ func (nrs *NodeRpcServer) registerHandlers() (err error) {
 			return errRes
 		}
 
+		// Objectives requested by the call are traced from here
+		ctx, span := tracing.Tracer.Start(context.Background(), "rpc "+jsonrpcReq.Method)
+		defer span.End()
+		node := nrs.node.WithContext(ctx)
+
 		switch serde.RequestMethod(jsonrpcReq.Method) {
 		case serde.GetAuthTokenMethod:
 			return processRequest(nrs.BaseRpcServer, permNone, requestData, func(req serde.AuthRequest) (string, error) {
@@ -135,18 +148,18 @@ func (nrs *NodeRpcServer) registerHandlers() (err error) {
 			})
 		case serde.CreateLedgerChannelRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req directfund.ObjectiveRequest) (directfund.ObjectiveResponse, error) {
-				return nrs.node.CreateLedgerChannel(req.CounterParty, req.ChallengeDuration, req.Outcome)
+				return node.CreateLedgerChannel(req.CounterParty, req.ChallengeDuration, req.Outcome)
 			})
 		case serde.CloseLedgerChannelRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req directdefund.ObjectiveRequest) (protocols.ObjectiveId, error) {
-				return nrs.node.CloseLedgerChannel(req.ChannelId, req.IsChallenge)
+				return node.CloseLedgerChannel(req.ChannelId, req.IsChallenge)
 			})
 		case serde.CloseBridgeChannelRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req bridgeddefund.ObjectiveRequest) (protocols.ObjectiveId, error) {
 				if DISABLE_BRIDGE_DEFUND {
 					return protocols.ObjectiveId(bridgeddefund.ObjectivePrefix + req.ChannelId.String()), fmt.Errorf("bridged defund is currently disabled")
 				}
-				return nrs.node.CloseBridgeChannel(req.ChannelId)
+				return node.CloseBridgeChannel(req.ChannelId)
 			})
 		case serde.MirrorBridgedDefundRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req serde.MirrorBridgedDefundRequest) (protocols.ObjectiveId, error) {
@@ -160,23 +173,23 @@ func (nrs *NodeRpcServer) registerHandlers() (err error) {
 					return "", err
 				}
 
-				return nrs.node.MirrorBridgedDefund(req.ChannelId, l2SignedState, req.IsChallenge)
+				return node.MirrorBridgedDefund(req.ChannelId, l2SignedState, req.IsChallenge)
 			})
 		case serde.CreateSwapChannelRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req swapfund.ObjectiveRequest) (swapfund.ObjectiveResponse, error) {
-				return nrs.node.CreateSwapChannel(req.Intermediaries, req.CounterParty, req.ChallengeDuration, req.Outcome)
+				return node.CreateSwapChannel(req.Intermediaries, req.CounterParty, req.ChallengeDuration, req.Outcome)
 			})
 		case serde.CloseSwapChannelRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req swapdefund.ObjectiveRequest) (protocols.ObjectiveId, error) {
-				return nrs.node.CloseSwapChannel(req.ChannelId)
+				return node.CloseSwapChannel(req.ChannelId)
 			})
 		case serde.CreatePaymentChannelRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req virtualfund.ObjectiveRequest) (virtualfund.ObjectiveResponse, error) {
-				return nrs.node.CreatePaymentChannel(req.Intermediaries, req.CounterParty, req.ChallengeDuration, req.Outcome)
+				return node.CreatePaymentChannel(req.Intermediaries, req.CounterParty, req.ChallengeDuration, req.Outcome)
 			})
 		case serde.ClosePaymentChannelRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req virtualdefund.ObjectiveRequest) (protocols.ObjectiveId, error) {
-				return nrs.node.ClosePaymentChannel(req.ChannelId)
+				return node.ClosePaymentChannel(req.ChannelId)
 			})
 		case serde.GetNodeInfoRequestMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req serde.NoPayloadRequest) (types.NodeInfo, error) {
@@ -225,7 +238,7 @@ func (nrs *NodeRpcServer) registerHandlers() (err error) {
 					return serde.SwapInitiateRequest{}, err
 				}
 
-				_, err := nrs.node.SwapAssets(req.Channel, req.SwapAssetsData.TokenIn, req.SwapAssetsData.TokenOut, big.NewInt(int64(req.SwapAssetsData.AmountIn)), big.NewInt(int64(req.SwapAssetsData.AmountOut)))
+				_, err := node.SwapAssets(req.Channel, req.SwapAssetsData.TokenIn, req.SwapAssetsData.TokenOut, big.NewInt(int64(req.SwapAssetsData.AmountIn)), big.NewInt(int64(req.SwapAssetsData.AmountOut)))
 				return req, err
 			})
 		case serde.ConfirmSwapRequestMethod:
@@ -320,11 +333,6 @@ func (nrs *NodeRpcServer) registerHandlers() (err error) {
 
 				return string(marshalledState), nil
 			})
-		case serde.RetryObjectiveTxMethod:
-			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req serde.RetryObjectiveTxRequest) (protocols.ObjectiveId, error) {
-				nrs.node.RetryObjectiveTx(req.ObjectiveId)
-				return req.ObjectiveId, nil
-			})
 		case serde.GetObjectiveMethod:
 			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req serde.GetObjectiveRequest) (string, error) {
 				objective, err := nrs.node.GetObjectiveById(req.ObjectiveId)
@@ -337,8 +345,113 @@ func (nrs *NodeRpcServer) registerHandlers() (err error) {
 					return "", err
 				}
 
+				reason, failed, err := nrs.node.GetFailureReason(req.ObjectiveId)
+				if err != nil || !failed {
+					return string(marshalledObjective), err
+				}
+
+				// Failed objectives are returned with the reason they failed alongside their own fields
+				fields := map[string]json.RawMessage{}
+				err = json.Unmarshal(marshalledObjective, &fields)
+				if err != nil {
+					return "", err
+				}
+				fields["FailureReason"], err = json.Marshal(reason)
+				if err != nil {
+					return "", err
+				}
+				marshalledObjective, err = json.Marshal(fields)
+				if err != nil {
+					return "", err
+				}
+
 				return string(marshalledObjective), nil
 			})
+		case serde.GetPendingApprovalsMethod:
+			return processRequest(nrs.BaseRpcServer, permRead, requestData, func(req serde.NoPayloadRequest) (string, error) {
+				pending, err := nrs.node.GetPendingApprovals()
+				if err != nil {
+					return "", err
+				}
+
+				marshalledPending, err := json.Marshal(pending)
+				if err != nil {
+					return "", err
+				}
+
+				return string(marshalledPending), nil
+			})
+		case serde.GetOutboxDepthsMethod:
+			return processRequest(nrs.BaseRpcServer, permRead, requestData, func(req serde.NoPayloadRequest) (string, error) {
+				depths, err := nrs.node.GetOutboxDepths()
+				if err != nil {
+					return "", err
+				}
+
+				marshalledDepths, err := json.Marshal(depths)
+				if err != nil {
+					return "", err
+				}
+
+				return string(marshalledDepths), nil
+			})
+		case serde.ApproveObjectiveMethod:
+			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req serde.ObjectiveDecisionRequest) (protocols.ObjectiveId, error) {
+				return req.ObjectiveId, nrs.node.ApproveObjective(req.ObjectiveId)
+			})
+		case serde.RejectObjectiveMethod:
+			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req serde.ObjectiveDecisionRequest) (protocols.ObjectiveId, error) {
+				return req.ObjectiveId, nrs.node.RejectObjective(req.ObjectiveId)
+			})
+		case serde.CancelObjectiveMethod:
+			return processRequest(nrs.BaseRpcServer, permSign, requestData, func(req serde.CancelObjectiveRequest) (protocols.ObjectiveId, error) {
+				return req.ObjectiveId, nrs.node.CancelObjective(req.ObjectiveId)
+			})
+		case serde.GetObjectivesMethod:
+			return processRequest(nrs.BaseRpcServer, permRead, requestData, func(req serde.GetObjectivesRequest) (string, error) {
+				objectives, err := nrs.node.GetObjectives(store.ObjectiveFilter{Statuses: req.Statuses, Type: req.Type, ChannelId: req.ChannelId})
+				if err != nil {
+					return "", err
+				}
+
+				marshalledObjectives, err := json.Marshal(objectives)
+				if err != nil {
+					return "", err
+				}
+
+				return string(marshalledObjectives), nil
+			})
+		case serde.GetObjectiveProgressMethod:
+			return processRequest(nrs.BaseRpcServer, permRead, requestData, func(req serde.GetObjectiveProgressRequest) (string, error) {
+				progress, err := nrs.node.GetObjectiveProgress(req.ObjectiveId)
+				if err != nil {
+					return "", err
+				}
+
+				marshalledProgress, err := json.Marshal(progress)
+				if err != nil {
+					return "", err
+				}
+
+				return string(marshalledProgress), nil
+			})
+		case serde.GetPolicyDecisionMethod:
+			return processRequest(nrs.BaseRpcServer, permRead, requestData, func(req serde.GetPolicyDecisionRequest) (string, error) {
+				decision, ok, err := nrs.node.GetPolicyDecision(req.ObjectiveId)
+				if err != nil {
+					return "", err
+				}
+				if !ok {
+					return "", fmt.Errorf("no policy decision recorded for objective %s", req.ObjectiveId)
+				}
+
+				marshalledDecision, err := json.Marshal(decision)
+				if err != nil {
+					return "", err
+				}
+
+				return string(marshalledDecision), nil
+			})
 		default:
 			errRes := serde.NewJsonRpcErrorResponse(jsonrpcReq.Id, serde.MethodNotFoundError)
 			return marshalResponse(errRes)
@@ -350,37 +463,43 @@ func (nrs *NodeRpcServer) registerHandlers() (err error) {
 }
 
 func (rs *NodeRpcServer) sendNotifications(ctx context.Context,
-	completedObjChan <-chan protocols.ObjectiveId,
-	ledgerUpdatesChan <-chan query.LedgerChannelInfo,
-	paymentUpdatesChan <-chan query.PaymentChannelInfo,
+	completedObjectives *notifier.Subscription[protocols.ObjectiveId],
+	ledgerUpdates *notifier.Subscription[query.LedgerChannelInfo],
+	paymentUpdates *notifier.Subscription[query.PaymentChannelInfo],
+	challengeResponses *notifier.Subscription[types.ChallengeResponse],
+	failedObjectives *notifier.Subscription[protocols.FailedObjective],
 ) {
 	defer rs.wg.Done()
+	for _, s := range []io.Closer{completedObjectives, ledgerUpdates, paymentUpdates, challengeResponses, failedObjectives} {
+		defer s.Close()
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 
-		case completedObjective, ok := <-completedObjChan:
+		case completedObjective, ok := <-completedObjectives.Updates():
 			if !ok {
-				rs.logger.Warn("CompletedObjectives channel closed, exiting sendNotifications")
+				rs.logger.Warn("CompletedObjectives subscription closed, exiting sendNotifications", "err", completedObjectives.Err())
 				return
 			}
 			err := sendNotification(rs.BaseRpcServer, serde.ObjectiveCompleted, completedObjective)
 			if err != nil {
 				panic(err)
 			}
-		case ledgerInfo, ok := <-ledgerUpdatesChan:
+		case ledgerInfo, ok := <-ledgerUpdates.Updates():
 			if !ok {
-				rs.logger.Warn("LedgerUpdates channel closed, exiting sendNotifications")
+				rs.logger.Warn("LedgerUpdates subscription closed, exiting sendNotifications", "err", ledgerUpdates.Err())
 				return
 			}
 			err := sendNotification(rs.BaseRpcServer, serde.LedgerChannelUpdated, ledgerInfo)
 			if err != nil {
 				panic(err)
 			}
-		case paymentInfo, ok := <-paymentUpdatesChan:
+		case paymentInfo, ok := <-paymentUpdates.Updates():
 			if !ok {
-				rs.logger.Warn("PaymentUpdates channel closed, exiting sendNotifications")
+				rs.logger.Warn("PaymentUpdates subscription closed, exiting sendNotifications", "err", paymentUpdates.Err())
 				return
 			}
 
@@ -389,6 +508,24 @@ func (rs *NodeRpcServer) sendNotifications(ctx context.Context,
 			if err != nil {
 				panic(err)
 			}
+		case challengeResponse, ok := <-challengeResponses.Updates():
+			if !ok {
+				rs.logger.Warn("ChallengeResponses subscription closed, exiting sendNotifications", "err", challengeResponses.Err())
+				return
+			}
+			err := sendNotification(rs.BaseRpcServer, serde.ChallengeResponded, challengeResponse)
+			if err != nil {
+				panic(err)
+			}
+		case failedObjective, ok := <-failedObjectives.Updates():
+			if !ok {
+				rs.logger.Warn("FailedObjectives subscription closed, exiting sendNotifications", "err", failedObjectives.Err())
+				return
+			}
+			err := sendNotification(rs.BaseRpcServer, serde.ObjectiveFailed, failedObjective)
+			if err != nil {
+				panic(err)
+			}
 		}
 	}
 }
@@ -3,6 +3,7 @@ package serde
 import (
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/statechannels/go-nitro/channel/state"
 	"github.com/statechannels/go-nitro/node/query"
 	"github.com/statechannels/go-nitro/payments"
 	"github.com/statechannels/go-nitro/protocols"
@@ -55,10 +56,26 @@ const (
 
 	GetSignedStateMethod RequestMethod = "get_signed_state"
 
-	// Chain reorgs workaround methods
-	GetObjectiveMethod     RequestMethod = "get_objective"
-	RetryObjectiveTxMethod RequestMethod = "retry_objective_tx"
-	RetryTxMethod          RequestMethod = "retry_tx"
+	// Operator approval methods
+	GetPendingApprovalsMethod RequestMethod = "get_pending_approvals"
+	ApproveObjectiveMethod    RequestMethod = "approve_objective"
+	RejectObjectiveMethod     RequestMethod = "reject_objective"
+
+	// Objective cancellation methods
+	CancelObjectiveMethod RequestMethod = "cancel_objective"
+
+	// Objective introspection methods
+	GetObjectiveMethod         RequestMethod = "get_objective"
+	GetObjectivesMethod        RequestMethod = "get_objectives"
+	GetObjectiveProgressMethod RequestMethod = "get_objective_progress"
+	GetPolicyDecisionMethod    RequestMethod = "get_policy_decision"
+
+	// Message service methods
+	GetOutboxDepthsMethod RequestMethod = "get_outbox_depths"
+
+	// Watchtower methods
+	WatchStateMethod      RequestMethod = "watch_state"
+	GetWatchedStateMethod RequestMethod = "get_watched_state"
 )
 
 type NotificationMethod string
@@ -68,6 +85,8 @@ const (
 	LedgerChannelUpdated  NotificationMethod = "ledger_channel_updated"
 	PaymentChannelUpdated NotificationMethod = "payment_channel_updated"
 	MirrorChannelCreated  NotificationMethod = "mirror_channel_created"
+	ChallengeResponded    NotificationMethod = "challenge_responded"
+	ObjectiveFailed       NotificationMethod = "objective_failed"
 )
 
 type NotificationOrRequest interface {
@@ -140,17 +159,33 @@ type ValidateVoucherRequest struct {
 	Signer      common.Address
 	Value       uint64
 }
-type RetryObjectiveTxRequest struct {
+type GetObjectiveRequest struct {
 	ObjectiveId protocols.ObjectiveId
+	L2          bool
 }
 
-type RetryTxRequest struct {
-	TxHash common.Hash
+type ObjectiveDecisionRequest struct {
+	ObjectiveId protocols.ObjectiveId
 }
 
-type GetObjectiveRequest struct {
+type CancelObjectiveRequest struct {
+	ObjectiveId protocols.ObjectiveId
+}
+
+// GetObjectivesRequest filters the objectives returned by get_objectives. Empty fields match every objective.
+type GetObjectivesRequest struct {
+	Statuses []protocols.ObjectiveStatus
+	// Type is the prefix of the objective ids, e.g. "VirtualDefund"
+	Type      string
+	ChannelId types.Destination
+}
+
+type GetObjectiveProgressRequest struct {
+	ObjectiveId protocols.ObjectiveId
+}
+
+type GetPolicyDecisionRequest struct {
 	ObjectiveId protocols.ObjectiveId
-	L2          bool
 }
 
 type GetL2ObjectiveFromL1Request struct {
@@ -169,6 +204,11 @@ type GetSignedStateRequest struct {
 	Id types.Destination
 }
 
+type WatchStateRequest struct {
+	StringifiedSignedState string
+	ChallengerSignature    state.Signature
+}
+
 type RequestPayload interface {
 	directfund.ObjectiveRequest |
 		directdefund.ObjectiveRequest |
@@ -187,15 +227,19 @@ type RequestPayload interface {
 		GetPaymentChannelsByLedgerRequest |
 		GetSwapChannelsByLedgerRequest |
 		GetSignedStateRequest |
+		WatchStateRequest |
 		GetVoucherRequest |
 		NoPayloadRequest |
 		payments.Voucher |
 		CounterChallengeRequest |
 		ValidateVoucherRequest |
 		bridgeddefund.ObjectiveRequest |
-		RetryObjectiveTxRequest |
-		RetryTxRequest |
 		GetObjectiveRequest |
+		ObjectiveDecisionRequest |
+		CancelObjectiveRequest |
+		GetObjectivesRequest |
+		GetObjectiveProgressRequest |
+		GetPolicyDecisionRequest |
 		GetL2ObjectiveFromL1Request |
 		GetPendingBridgeTxsRequest
 }
@@ -205,7 +249,9 @@ type NotificationPayload interface {
 		query.PaymentChannelInfo |
 		query.LedgerChannelInfo |
 		query.SwapInfo |
-		types.Destination
+		types.Destination |
+		types.ChallengeResponse |
+		protocols.FailedObjective
 }
 
 type Params[T RequestPayload | NotificationPayload] struct {
@@ -0,0 +1,88 @@
+package rpc
+
+import (
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/rpc/serde"
+	"github.com/statechannels/go-nitro/rpc/transport"
+	"github.com/statechannels/go-nitro/rpc/transport/http"
+	"github.com/statechannels/go-nitro/types"
+	"github.com/statechannels/go-nitro/watchtower"
+)
+
+// WatchtowerClient uploads signed states to a watchtower RPC server
+type WatchtowerClient struct {
+	transport      transport.Requester
+	routineTracker *sync.WaitGroup
+	logger         *slog.Logger
+	authToken      string
+}
+
+// NewWatchtowerClient creates a new WatchtowerClient
+func NewWatchtowerClient(trans transport.Requester) (*WatchtowerClient, error) {
+	wc := &WatchtowerClient{
+		transport:      trans,
+		routineTracker: &sync.WaitGroup{},
+		logger:         slog.Default(),
+	}
+
+	authToken, err := waitForWatchtowerRequest[serde.NoPayloadRequest, string](wc, serde.GetAuthTokenMethod, serde.NoPayloadRequest{})
+	wc.authToken = authToken
+
+	return wc, err
+}
+
+// NewHttpWatchtowerClient creates a new WatchtowerClient using an http transport
+func NewHttpWatchtowerClient(rpcServerUrl string, isSecure bool) (*WatchtowerClient, error) {
+	transport, err := http.NewHttpTransportAsClient(rpcServerUrl, isSecure, 10*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	return NewWatchtowerClient(transport)
+}
+
+// WatchState uploads ss for the watchtower to defend. If challengerSig is not empty the watchtower will counter challenge with ss rather than checkpoint it.
+func (wc *WatchtowerClient) WatchState(ss state.SignedState, challengerSig state.Signature) (types.Destination, error) {
+	marshalledState, err := json.Marshal(ss)
+	if err != nil {
+		return types.Destination{}, err
+	}
+
+	req := serde.WatchStateRequest{StringifiedSignedState: string(marshalledState), ChallengerSignature: challengerSig}
+	return waitForWatchtowerRequest[serde.WatchStateRequest, types.Destination](wc, serde.WatchStateMethod, req)
+}
+
+// GetWatchedState returns the state the watchtower is defending for the given channel
+func (wc *WatchtowerClient) GetWatchedState(id types.Destination) (watchtower.WatchedState, error) {
+	res, err := waitForWatchtowerRequest[serde.GetSignedStateRequest, string](wc, serde.GetWatchedStateMethod, serde.GetSignedStateRequest{Id: id})
+	if err != nil {
+		return watchtower.WatchedState{}, err
+	}
+
+	var ws watchtower.WatchedState
+	err = json.Unmarshal([]byte(res), &ws)
+	return ws, err
+}
+
+// Close waits for outstanding requests and closes the underlying transport
+func (wc *WatchtowerClient) Close() error {
+	wc.routineTracker.Wait()
+	return wc.transport.Close()
+}
+
+func waitForWatchtowerRequest[T serde.RequestPayload, U serde.ResponsePayload](wc *WatchtowerClient, method serde.RequestMethod, requestData T) (U, error) {
+	wc.routineTracker.Add(1)
+	defer wc.routineTracker.Done()
+
+	res, err := sendRequest[T, U](wc.transport, method, requestData, wc.authToken, wc.logger, wc.routineTracker)
+	if err != nil {
+		var zero U
+		return zero, err
+	}
+
+	return res.Payload, res.Error
+}
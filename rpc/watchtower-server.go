@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/rpc/serde"
+	"github.com/statechannels/go-nitro/rpc/transport"
+	"github.com/statechannels/go-nitro/types"
+	"github.com/statechannels/go-nitro/watchtower"
+)
+
+type WatchtowerRpcServer struct {
+	*BaseRpcServer
+	watchtower *watchtower.Watchtower
+}
+
+func NewWatchtowerRpcServer(watchtower *watchtower.Watchtower, trans transport.Responder) (*WatchtowerRpcServer, error) {
+	baseRpcServer := NewBaseRpcServer(trans)
+
+	wrs := &WatchtowerRpcServer{
+		baseRpcServer,
+		watchtower,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wrs.cancel = cancel
+	wrs.wg.Add(1)
+
+	responses := wrs.watchtower.Responses()
+	go wrs.sendNotifications(ctx, responses)
+
+	err := wrs.registerHandlers()
+	if err != nil {
+		return nil, err
+	}
+
+	return wrs, nil
+}
+
+func (wrs *WatchtowerRpcServer) Close() error {
+	wrs.cancel()
+	wrs.wg.Wait()
+
+	err := wrs.BaseRpcServer.Close()
+	if err != nil {
+		return err
+	}
+
+	return wrs.watchtower.Close()
+}
+
+func (wrs *WatchtowerRpcServer) registerHandlers() (err error) {
+	handlerV1 := func(requestData []byte) []byte {
+		if !json.Valid(requestData) {
+			wrs.logger.Error("request is not valid json")
+			errRes := serde.NewJsonRpcErrorResponse(0, serde.ParseError)
+			return marshalResponse(errRes)
+		}
+
+		jsonrpcReq, errRes := validateJsonrpcRequest(requestData)
+		wrs.logger.Debug("Rpc server received request", "request", jsonrpcReq)
+		if errRes != nil {
+			wrs.logger.Error("could not validate jsonrpc request")
+
+			return errRes
+		}
+
+		switch serde.RequestMethod(jsonrpcReq.Method) {
+		case serde.GetAuthTokenMethod:
+			return processRequest(wrs.BaseRpcServer, permNone, requestData, func(req serde.AuthRequest) (string, error) {
+				return generateAuthToken(req.Id, allPermissions)
+			})
+		case serde.WatchStateMethod:
+			return processRequest(wrs.BaseRpcServer, permSign, requestData, func(req serde.WatchStateRequest) (types.Destination, error) {
+				var signedState state.SignedState
+				err := json.Unmarshal([]byte(req.StringifiedSignedState), &signedState)
+				if err != nil {
+					return types.Destination{}, fmt.Errorf("error in unmarshalling signed state payload %w", err)
+				}
+
+				err = wrs.watchtower.Watch(watchtower.WatchedState{SignedState: signedState, ChallengerSignature: req.ChallengerSignature})
+				return signedState.ChannelId(), err
+			})
+		case serde.GetWatchedStateMethod:
+			return processRequest(wrs.BaseRpcServer, permRead, requestData, func(req serde.GetSignedStateRequest) (string, error) {
+				if err := serde.ValidateGetSignedStateRequest(req); err != nil {
+					return "", err
+				}
+
+				watchedState, err := wrs.watchtower.GetWatchedState(req.Id)
+				if err != nil {
+					return "", err
+				}
+
+				marshalledState, err := json.Marshal(watchedState)
+				if err != nil {
+					return "", err
+				}
+
+				return string(marshalledState), nil
+			})
+		default:
+			errRes := serde.NewJsonRpcErrorResponse(jsonrpcReq.Id, serde.MethodNotFoundError)
+			return marshalResponse(errRes)
+		}
+	}
+
+	err = wrs.transport.RegisterRequestHandler("v1", handlerV1)
+	return err
+}
+
+func (wrs *WatchtowerRpcServer) sendNotifications(ctx context.Context,
+	responsesChan <-chan types.ChallengeResponse,
+) {
+	defer wrs.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case response, ok := <-responsesChan:
+			if !ok {
+				wrs.logger.Warn("ChallengeResponses channel closed, exiting sendNotifications")
+				return
+			}
+			err := sendNotification(wrs.BaseRpcServer, serde.ChallengeResponded, response)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
@@ -41,8 +41,35 @@ const (
 	Challenge
 )
 
-type RetryObjectiveTxRequest struct {
-	ObjectiveId string
+func (a CounterChallengeAction) String() string {
+	switch a {
+	case Checkpoint:
+		return "checkpoint"
+	case Challenge:
+		return "challenge"
+	default:
+		return "unknown"
+	}
+}
+
+// ChallengeResponse describes a checkpoint or counter challenge submitted without operator involvement,
+// in response to a challenge registered against a stale state.
+type ChallengeResponse struct {
+	ChannelId         Destination
+	Action            CounterChallengeAction
+	ChallengedTurnNum uint64
+	ResponseTurnNum   uint64
+	FinalizesAt       uint64
+}
+
+// TransactionReplacement describes a chain transaction which stayed pending for too long, and was resubmitted with higher fees
+type TransactionReplacement struct {
+	ChannelId      Destination
+	ReplacedTxHash common.Hash
+	TxHash         common.Hash
+	Nonce          uint64
+	GasTipCap      *big.Int
+	GasFeeCap      *big.Int
 }
 
 type SwapStatus int8
@@ -0,0 +1,149 @@
+package watchtower
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	"github.com/statechannels/go-nitro/types"
+	"github.com/tidwall/buntdb"
+)
+
+const WATCHTOWER_DURABLE_STORE_SUB_DIR = "watchtower"
+
+// ErrNoWatchedState is returned when no state has been uploaded for a channel
+var ErrNoWatchedState = errors.New("no state is being watched for channel")
+
+// DurableStore holds the watched states, and is the outbox of the transactions submitted in response to challenges
+type DurableStore struct {
+	watchedStates *buntdb.DB
+	txOutbox      *buntdb.DB
+	folder        string // the folder where the store's data is stored
+}
+
+func NewDurableStore(folder string, config buntdb.Config) (*DurableStore, error) {
+	dataFolder := filepath.Join(folder, WATCHTOWER_DURABLE_STORE_SUB_DIR)
+	ds := DurableStore{}
+
+	err := os.MkdirAll(dataFolder, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	ds.folder = dataFolder
+
+	ds.watchedStates, err = ds.openDB("watched_states", config)
+	if err != nil {
+		return nil, err
+	}
+	ds.txOutbox, err = ds.openDB("tx_outbox", config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ds, nil
+}
+
+func (ds *DurableStore) openDB(name string, config buntdb.Config) (*buntdb.DB, error) {
+	db, err := buntdb.Open(fmt.Sprintf("%s/%s.db", ds.folder, name))
+	if err != nil {
+		return nil, err
+	}
+	err = db.SetConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// SetWatchedState stores ws against the channel of its signed state, replacing any state previously stored for that channel
+func (ds *DurableStore) SetWatchedState(ws WatchedState) error {
+	wsJson, err := json.Marshal(ws)
+	if err != nil {
+		return err
+	}
+
+	err = ds.watchedStates.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(ws.SignedState.ChannelId().String(), string(wsJson), nil)
+		return err
+	})
+	return err
+}
+
+// GetWatchedState returns the state stored for the given channel, or ErrNoWatchedState if there is none
+func (ds *DurableStore) GetWatchedState(channelId types.Destination) (ws WatchedState, err error) {
+	var wsJson string
+
+	err = ds.watchedStates.View(func(tx *buntdb.Tx) error {
+		var err error
+		wsJson, err = tx.Get(channelId.String())
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return ws, fmt.Errorf("%w %s", ErrNoWatchedState, channelId)
+	}
+	if err != nil {
+		return ws, err
+	}
+
+	err = json.Unmarshal([]byte(wsJson), &ws)
+	if err != nil {
+		return ws, err
+	}
+
+	return ws, nil
+}
+
+func (ds *DurableStore) SetOutboxTx(o chainservice.OutboxTx) error {
+	oJSON, err := json.Marshal(o)
+	if err != nil {
+		return fmt.Errorf("error setting outbox transaction %s: %w", o.Id, err)
+	}
+	return ds.txOutbox.Update(func(tx *buntdb.Tx) error {
+		_, _, err := tx.Set(o.Id, string(oJSON), nil)
+		return err
+	})
+}
+
+func (ds *DurableStore) GetOutboxTxs() ([]chainservice.OutboxTx, error) {
+	txs := []chainservice.OutboxTx{}
+
+	var decodeErr error
+	err := ds.txOutbox.View(func(tx *buntdb.Tx) error {
+		return tx.Ascend("", func(key, value string) bool {
+			var o chainservice.OutboxTx
+			err := json.Unmarshal([]byte(value), &o)
+			if err != nil {
+				decodeErr = fmt.Errorf("error decoding outbox transaction %s: %w", key, err)
+				return false
+			}
+			txs = append(txs, o)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return txs, nil
+}
+
+func (ds *DurableStore) RemoveOutboxTx(id string) error {
+	err := ds.txOutbox.Update(func(tx *buntdb.Tx) error {
+		_, err := tx.Delete(id)
+		return err
+	})
+	if errors.Is(err, buntdb.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (ds *DurableStore) Close() error {
+	return errors.Join(ds.watchedStates.Close(), ds.txOutbox.Close())
+}
@@ -0,0 +1,240 @@
+// Package watchtower contains a service which responds to challenges on behalf of nodes which are not always online.
+//
+// The watchtower holds no funds and signs no states. Nodes upload the latest supported state of each of their channels,
+// and when a stale state is challenged on chain the watchtower submits the uploaded state in a checkpoint.
+// If the node also uploaded a challenge signature for the state, the watchtower counter challenges instead.
+package watchtower // import "github.com/statechannels/go-nitro/watchtower"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sync"
+
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+)
+
+// ResponseObjectivePrefix prefixes the ids under which the watchtower's responses are held in the transaction outbox.
+// The watchtower runs no objectives, so each response is given an id of its own.
+const ResponseObjectivePrefix = "WatchtowerResponse-"
+
+// WatchedState is a state uploaded by a node for the watchtower to defend.
+type WatchedState struct {
+	// SignedState is the latest supported state of the channel, signed by every participant
+	SignedState state.SignedState
+	// ChallengerSignature is an optional challenge signature for SignedState from one of the participants.
+	// If it is present the watchtower counter challenges with SignedState, otherwise it checkpoints SignedState.
+	ChallengerSignature state.Signature
+}
+
+// Watchtower watches the adjudicator for challenges registered against states older than the states it has been given.
+type Watchtower struct {
+	store          *DurableStore
+	chain          chainservice.ChainService
+	responseMargin uint64
+	responses      chan types.ChallengeResponse
+
+	uploadMu sync.Mutex // ensures an uploaded state is never replaced by an older one
+	cancel   context.CancelFunc
+	wg       *sync.WaitGroup
+}
+
+// New constructs a Watchtower and starts watching the events of the supplied chain service.
+// A response is only submitted if more than responseMargin seconds remain before the challenge finalizes.
+// Responses are submitted through the chain service's outbox, which should be the store, so that they survive restarts and reorgs.
+func New(chain chainservice.ChainService, store *DurableStore, responseMargin uint64) *Watchtower {
+	w := &Watchtower{
+		store:          store,
+		chain:          chain,
+		responseMargin: responseMargin,
+		responses:      make(chan types.ChallengeResponse, 100),
+		wg:             &sync.WaitGroup{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	w.wg.Add(1)
+	go w.run(ctx)
+
+	return w
+}
+
+// Watch validates ws and starts defending it, replacing any older state previously uploaded for the same channel.
+func (w *Watchtower) Watch(ws WatchedState) error {
+	err := validateWatchedState(ws)
+	if err != nil {
+		return err
+	}
+
+	w.uploadMu.Lock()
+	defer w.uploadMu.Unlock()
+
+	channelId := ws.SignedState.ChannelId()
+	existing, err := w.store.GetWatchedState(channelId)
+	if err != nil && !errors.Is(err, ErrNoWatchedState) {
+		return err
+	}
+	if err == nil && existing.SignedState.State().TurnNum > ws.SignedState.State().TurnNum {
+		return fmt.Errorf("already watching turn %d of channel %s, which is newer than turn %d", existing.SignedState.State().TurnNum, channelId, ws.SignedState.State().TurnNum)
+	}
+
+	slog.Info("Watching state", "channel", channelId, "turnNum", ws.SignedState.State().TurnNum)
+	return w.store.SetWatchedState(ws)
+}
+
+// GetWatchedState returns the state being watched for the given channel.
+func (w *Watchtower) GetWatchedState(channelId types.Destination) (WatchedState, error) {
+	return w.store.GetWatchedState(channelId)
+}
+
+// Responses returns a chan that receives a challenge response whenever the watchtower checkpoints or counter challenges.
+// Not suitable for multiple subscribers.
+func (w *Watchtower) Responses() <-chan types.ChallengeResponse {
+	return w.responses
+}
+
+func (w *Watchtower) Close() error {
+	w.cancel()
+	w.wg.Wait()
+
+	// The chain service is closed first, since it records the transactions it submits in the store
+	err := w.chain.Close()
+	if err != nil {
+		return err
+	}
+
+	return w.store.Close()
+}
+
+func (w *Watchtower) run(ctx context.Context) {
+	defer w.wg.Done()
+
+	for {
+		select {
+		case event := <-w.chain.EventEngineFeed():
+			err := w.handleChainEvent(event)
+			if err != nil {
+				slog.Error("error handling chain event", "event", event, "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handleChainEvent submits a checkpoint or counter challenge if the event is a challenge registered against a state older than the watched state.
+func (w *Watchtower) handleChainEvent(event chainservice.Event) error {
+	challenge, ok := event.(chainservice.ChallengeRegisteredEvent)
+	if !ok || challenge.IsInitiatedByMe {
+		return nil
+	}
+
+	ws, err := w.store.GetWatchedState(challenge.ChannelID())
+	if errors.Is(err, ErrNoWatchedState) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	watchedTurnNum := ws.SignedState.State().TurnNum
+	if watchedTurnNum <= challenge.TurnNum() {
+		return nil
+	}
+
+	latestBlockTime := w.latestBlockTime(challenge.Block())
+	deadline := new(big.Int).SetUint64(latestBlockTime + w.responseMargin)
+	if challenge.FinalizesAt.Cmp(deadline) <= 0 {
+		slog.Warn("Challenge finalizes too soon to respond", "channel", challenge.ChannelID(), "finalizesAt", challenge.FinalizesAt, "latestBlockTime", latestBlockTime)
+		return nil
+	}
+
+	action := types.Checkpoint
+	var tx protocols.ChainTransaction = protocols.NewCheckpointTransaction(challenge.ChannelID(), ws.SignedState, make([]state.SignedState, 0))
+	if !ws.ChallengerSignature.IsEmpty() {
+		action = types.Challenge
+		tx = protocols.NewChallengeTransaction(challenge.ChannelID(), ws.SignedState, make([]state.SignedState, 0), ws.ChallengerSignature)
+	}
+
+	slog.Info("Responding to challenge registered against a stale state", "channel", challenge.ChannelID(), "action", action, "challengedTurnNum", challenge.TurnNum(), "watchedTurnNum", watchedTurnNum)
+	err = w.chain.SubmitTransaction(ResponseObjectiveId(challenge), tx)
+	if err != nil {
+		return err
+	}
+
+	response := types.ChallengeResponse{
+		ChannelId:         challenge.ChannelID(),
+		Action:            action,
+		ChallengedTurnNum: challenge.TurnNum(),
+		ResponseTurnNum:   watchedTurnNum,
+		FinalizesAt:       challenge.FinalizesAt.Uint64(),
+	}
+	select {
+	case w.responses <- response:
+	default:
+		slog.Warn("Dropping challenge response notification, no listener is keeping up", "channel", response.ChannelId)
+	}
+
+	return nil
+}
+
+// ResponseObjectiveId returns the id under which the response to a challenge is held in the transaction outbox.
+// A channel is never challenged twice with the same turn once the challenge has been responded to, so the challenged turn identifies the challenge.
+func ResponseObjectiveId(challenge chainservice.ChallengeRegisteredEvent) protocols.ObjectiveId {
+	return protocols.ObjectiveId(fmt.Sprintf("%s%s-%d", ResponseObjectivePrefix, challenge.ChannelID(), challenge.TurnNum()))
+}
+
+// latestBlockTime returns the timestamp of the latest confirmed block, falling back to the timestamp of the supplied block.
+func (w *Watchtower) latestBlockTime(block chainservice.Block) uint64 {
+	lastConfirmedBlockNum := w.chain.GetLastConfirmedBlockNum()
+	if lastConfirmedBlockNum <= block.BlockNum {
+		return block.Timestamp
+	}
+
+	latestBlock, err := w.chain.GetBlockByNumber(new(big.Int).SetUint64(lastConfirmedBlockNum))
+	if err != nil || latestBlock == nil {
+		return block.Timestamp
+	}
+	return max(latestBlock.Time(), block.Timestamp)
+}
+
+// validateWatchedState checks that the state is signed by every participant, and that any challenge signature is from a participant.
+func validateWatchedState(ws WatchedState) error {
+	s := ws.SignedState.State()
+
+	// Signatures are not checked when a signed state is unmarshalled, so we add them to a fresh signed state to verify them
+	verified := state.NewSignedState(s)
+	for _, sig := range ws.SignedState.Signatures() {
+		if sig.IsEmpty() {
+			continue
+		}
+		err := verified.AddSignature(sig)
+		if err != nil {
+			return fmt.Errorf("invalid signature on state: %w", err)
+		}
+	}
+	if len(s.Participants) == 0 || !verified.HasAllSignatures() {
+		return fmt.Errorf("state for channel %s is not signed by every participant", s.ChannelId())
+	}
+
+	if ws.ChallengerSignature.IsEmpty() {
+		return nil
+	}
+	challenger, err := NitroAdjudicator.RecoverChallengeMessageSigner(s, ws.ChallengerSignature)
+	if err != nil {
+		return fmt.Errorf("invalid challenger signature: %w", err)
+	}
+	for _, p := range s.Participants {
+		if p == challenger {
+			return nil
+		}
+	}
+	return fmt.Errorf("challenger signature is not from a participant of channel %s", s.ChannelId())
+}
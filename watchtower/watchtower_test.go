@@ -0,0 +1,205 @@
+package watchtower_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/statechannels/go-nitro/channel/state"
+	"github.com/statechannels/go-nitro/internal/testactors"
+	"github.com/statechannels/go-nitro/internal/testdata"
+	"github.com/statechannels/go-nitro/internal/testhelpers"
+	"github.com/statechannels/go-nitro/node/engine/chainservice"
+	NitroAdjudicator "github.com/statechannels/go-nitro/node/engine/chainservice/adjudicator"
+	"github.com/statechannels/go-nitro/protocols"
+	"github.com/statechannels/go-nitro/types"
+	"github.com/statechannels/go-nitro/watchtower"
+	"github.com/tidwall/buntdb"
+)
+
+// fakeChain emits the events it is given, and records the transactions submitted to it in its outbox as a chain service does
+type fakeChain struct {
+	chainservice.ChainService
+	outbox chainservice.TxOutbox
+	events chan chainservice.Event
+	txs    chan protocols.ChainTransaction
+}
+
+func newFakeChain(outbox chainservice.TxOutbox) *fakeChain {
+	return &fakeChain{outbox: outbox, events: make(chan chainservice.Event), txs: make(chan protocols.ChainTransaction, 10)}
+}
+
+func (fc *fakeChain) EventEngineFeed() <-chan chainservice.Event { return fc.events }
+
+func (fc *fakeChain) SubmitTransaction(objectiveId protocols.ObjectiveId, tx protocols.ChainTransaction) error {
+	err := fc.outbox.SetOutboxTx(chainservice.OutboxTx{Id: fmt.Sprint(len(fc.txs)), ObjectiveId: objectiveId, Tx: tx, Status: chainservice.TxSubmitted})
+	if err != nil {
+		return err
+	}
+	fc.txs <- tx
+	return nil
+}
+
+func (fc *fakeChain) GetLastConfirmedBlockNum() uint64 { return 0 }
+
+func (fc *fakeChain) Close() error { return nil }
+
+func newTestWatchtower(t *testing.T, responseMargin uint64) (*watchtower.Watchtower, *fakeChain, *watchtower.DurableStore) {
+	t.Helper()
+	dataFolder, cleanup := testhelpers.GenerateTempStoreFolder()
+	t.Cleanup(cleanup)
+
+	store, err := watchtower.NewDurableStore(dataFolder, buntdb.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := newFakeChain(store)
+	w := watchtower.New(chain, store, responseMargin)
+	t.Cleanup(func() { _ = w.Close() })
+	return w, chain, store
+}
+
+func testState(turnNum uint64) state.State {
+	return state.State{
+		Participants:      []types.Address{testactors.Alice.Address(), testactors.Bob.Address()},
+		ChannelNonce:      37140676580,
+		ChallengeDuration: 60,
+		AppData:           []byte{},
+		Outcome:           testdata.Outcomes.Create(testactors.Alice.Address(), testactors.Bob.Address(), 5, 5, common.Address{}),
+		TurnNum:           turnNum,
+	}
+}
+
+func signedState(t *testing.T, turnNum uint64, signers ...testactors.Actor) state.SignedState {
+	t.Helper()
+	s := testState(turnNum)
+	ss := state.NewSignedState(s)
+	for _, signer := range signers {
+		sig, err := s.Sign(signer.PrivateKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ss.AddSignature(sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ss
+}
+
+func TestWatch(t *testing.T) {
+	w, _, _ := newTestWatchtower(t, 0)
+
+	if err := w.Watch(watchtower.WatchedState{SignedState: signedState(t, 5, testactors.Alice)}); err == nil {
+		t.Fatal("expected a state missing a signature to be rejected")
+	}
+
+	notParticipantSig, err := NitroAdjudicator.SignChallengeMessage(testState(5), testactors.Irene.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = w.Watch(watchtower.WatchedState{SignedState: signedState(t, 5, testactors.Alice, testactors.Bob), ChallengerSignature: notParticipantSig})
+	if err == nil {
+		t.Fatal("expected a challenger signature from a non participant to be rejected")
+	}
+
+	newer := signedState(t, 6, testactors.Alice, testactors.Bob)
+	if err := w.Watch(watchtower.WatchedState{SignedState: newer}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Watch(watchtower.WatchedState{SignedState: signedState(t, 5, testactors.Alice, testactors.Bob)}); err == nil {
+		t.Fatal("expected an older state to be rejected")
+	}
+
+	got, err := w.GetWatchedState(newer.ChannelId())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SignedState.State().TurnNum != 6 {
+		t.Fatalf("expected turn 6 to be watched, got %d", got.SignedState.State().TurnNum)
+	}
+}
+
+func TestRespondsToStaleChallenge(t *testing.T) {
+	testCases := []struct {
+		name              string
+		withChallengerSig bool
+		challengedTurnNum uint64
+		finalizesAt       int64
+		wantAction        types.CounterChallengeAction
+		wantResponse      bool
+	}{
+		{"checkpoints a stale challenge", false, 5, 1000, types.Checkpoint, true},
+		{"counter challenges a stale challenge", true, 5, 1000, types.Challenge, true},
+		{"ignores a challenge with the latest state", false, 6, 1000, types.Checkpoint, false},
+		{"ignores a challenge finalizing within the margin", false, 5, 150, types.Checkpoint, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w, chain, store := newTestWatchtower(t, 60)
+
+			ws := watchtower.WatchedState{SignedState: signedState(t, 6, testactors.Alice, testactors.Bob)}
+			if tc.withChallengerSig {
+				sig, err := NitroAdjudicator.SignChallengeMessage(ws.SignedState.State(), testactors.Alice.PrivateKey)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ws.ChallengerSignature = sig
+			}
+			if err := w.Watch(ws); err != nil {
+				t.Fatal(err)
+			}
+
+			challenged := signedState(t, tc.challengedTurnNum, testactors.Alice, testactors.Bob)
+			challenge := chainservice.NewChallengeRegisteredEvent(
+				ws.SignedState.ChannelId(),
+				chainservice.Block{BlockNum: 1, Timestamp: 100},
+				0,
+				challenged.State().VariablePart(),
+				challenged.Signatures(),
+				big.NewInt(tc.finalizesAt),
+				false,
+				common.Hash{},
+			)
+			chain.events <- challenge
+
+			select {
+			case tx := <-chain.txs:
+				if !tc.wantResponse {
+					t.Fatalf("expected no response, got %T", tx)
+				}
+				switch tc.wantAction {
+				case types.Checkpoint:
+					if _, ok := tx.(protocols.CheckpointTransaction); !ok {
+						t.Fatalf("expected a checkpoint transaction, got %T", tx)
+					}
+				case types.Challenge:
+					if _, ok := tx.(protocols.ChallengeTransaction); !ok {
+						t.Fatalf("expected a challenge transaction, got %T", tx)
+					}
+				}
+				response := <-w.Responses()
+				if response.Action != tc.wantAction || response.ResponseTurnNum != 6 || response.ChallengedTurnNum != tc.challengedTurnNum {
+					t.Fatalf("unexpected challenge response %+v", response)
+				}
+
+				// The response is held in the outbox, so that it is resubmitted should it be lost to a restart or a reorg
+				txs, err := store.GetOutboxTxs()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(txs) != 1 || txs[0].ObjectiveId != watchtower.ResponseObjectiveId(challenge) || txs[0].Tx.ChannelId() != challenge.ChannelID() {
+					t.Fatalf("expected the response to be recorded in the outbox, got %+v", txs)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tc.wantResponse {
+					t.Fatal("expected a response to the challenge")
+				}
+			}
+		})
+	}
+}